@@ -69,3 +69,8 @@ func AppendBigIntToBytesSlice(commonBytes []byte, appended *big.Int) []byte {
 	resultBytes = append(resultBytes, appended.Bytes()...)
 	return resultBytes
 }
+
+// IsInSignedInterval returns true when -bound <= b <= bound
+func IsInSignedInterval(b *big.Int, bound *big.Int) bool {
+	return b.CmpAbs(bound) <= 0
+}
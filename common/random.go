@@ -50,6 +50,17 @@ func GetRandomPositiveInt(rand io.Reader, lessThan *big.Int) *big.Int {
 	return try
 }
 
+// GetRandomSignedInt returns a uniformly random integer in the interval [-bound, bound]
+func GetRandomSignedInt(rand io.Reader, bound *big.Int) *big.Int {
+	if bound == nil || zero.Cmp(bound) != -1 {
+		return nil
+	}
+	width := new(big.Int).Lsh(bound, 1)
+	width.Add(width, one)
+	try := GetRandomPositiveInt(rand, width)
+	return try.Sub(try, bound)
+}
+
 func GetRandomPrimeInt(rand io.Reader, bits int) *big.Int {
 	if bits <= 0 {
 		return nil
@@ -46,3 +46,12 @@ func TestGetRandomPrimeInt(t *testing.T) {
 	assert.NotZero(t, prime, "rand prime should not be zero")
 	assert.True(t, prime.ProbablyPrime(50), "rand prime should be prime")
 }
+
+func TestGetRandomSignedInt(t *testing.T) {
+	bound := common.MustGetRandomInt(rand.Reader, randomIntBitLen)
+	for i := 0; i < 16; i++ {
+		rnd := common.GetRandomSignedInt(rand.Reader, bound)
+		assert.True(t, common.IsInSignedInterval(rnd, bound), "rand int should be within the bound")
+		assert.Equal(t, 0, rnd.Cmp(common.SignedBytesToBigInt(common.SignedBigIntToBytes(rnd))))
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// The encodings below convert the output of the signing protocols to the formats that chains and libraries expect.
+// R and S of an ecdsa signature are big-endian; R and S of an eddsa signature are the integers whose little-endian
+// encodings form the RFC 8032 signature. SignatureRecovery holds the recovery id of an ecdsa signature, 0 to 3.
+
+const (
+	compactSigLen     = 65
+	compactSigMagic   = 27
+	compactCompressed = 4
+
+	ethereumV      = 27
+	ethereumEIP155 = 35
+)
+
+type ecdsaSignatureASN1 struct {
+	R, S *big.Int
+}
+
+// ToDER returns the ASN.1 DER encoding of an ecdsa signature, SEQUENCE { r INTEGER, s INTEGER }
+func (x *SignatureData) ToDER() ([]byte, error) {
+	r, s, err := x.rs()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(ecdsaSignatureASN1{R: r, S: s})
+}
+
+// ToCompact returns the 65-byte compact encoding of a secp256k1 signature used by Bitcoin signed messages:
+// a header byte of 27 + recovery id, plus 4 if the key is compressed, followed by R and S.
+func (x *SignatureData) ToCompact(compressedKey bool) ([]byte, error) {
+	recid, err := x.recoveryID()
+	if err != nil {
+		return nil, err
+	}
+	r, s, err := x.rs()
+	if err != nil {
+		return nil, err
+	}
+	header := byte(compactSigMagic + recid)
+	if compressedKey {
+		header += compactCompressed
+	}
+	sig := make([]byte, 1, compactSigLen)
+	sig[0] = header
+	sig = append(sig, r.FillBytes(make([]byte, 32))...)
+	return append(sig, s.FillBytes(make([]byte, 32))...), nil
+}
+
+// ToEthereum returns the r, s and v of a secp256k1 signature for Ethereum. v is 27 + recovery id if chainID is nil,
+// or chainID * 2 + 35 + recovery id as defined by EIP-155. Ethereum only accepts signatures with a low s.
+func (x *SignatureData) ToEthereum(chainID *big.Int) (r, s []byte, v *big.Int, err error) {
+	recid, err := x.recoveryID()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if recid > 1 {
+		return nil, nil, nil, errors.New("ToEthereum: the recovery id must be 0 or 1")
+	}
+	rInt, sInt, err := x.rs()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if sInt.Cmp(new(big.Int).Rsh(btcec.S256().Params().N, 1)) > 0 {
+		return nil, nil, nil, errors.New("ToEthereum: s is not in the lower half of the order")
+	}
+	if chainID == nil {
+		v = big.NewInt(int64(ethereumV + recid))
+	} else {
+		v = new(big.Int).Lsh(chainID, 1)
+		v.Add(v, big.NewInt(int64(ethereumEIP155+recid)))
+	}
+	return rInt.FillBytes(make([]byte, 32)), sInt.FillBytes(make([]byte, 32)), v, nil
+}
+
+// ToEd25519 returns the 64-byte RFC 8032 encoding of an eddsa signature
+func (x *SignatureData) ToEd25519() ([]byte, error) {
+	if len(x.GetR()) > 32 || len(x.GetS()) > 32 {
+		return nil, errors.New("ToEd25519: invalid signature length")
+	}
+	sig := make([]byte, 0, ed25519.SignatureSize)
+	sig = append(sig, littleEndian32(x.GetR())...)
+	return append(sig, littleEndian32(x.GetS())...), nil
+}
+
+// Verify verifies the signature of M against pubKey, which is an *ecdsa.PublicKey, a *btcec.PublicKey, an
+// ed25519.PublicKey or an *edwards.PublicKey
+func (x *SignatureData) Verify(pubKey crypto.PublicKey) bool {
+	switch pub := pubKey.(type) {
+	case *ecdsa.PublicKey:
+		r, s, err := x.rs()
+		return err == nil && ecdsa.Verify(pub, x.GetM(), r, s)
+	case *btcec.PublicKey:
+		r, s, err := x.rs()
+		return err == nil && ecdsa.Verify(pub.ToECDSA(), x.GetM(), r, s)
+	case ed25519.PublicKey:
+		sig, err := x.ToEd25519()
+		return err == nil && len(pub) == ed25519.PublicKeySize && ed25519.Verify(pub, x.GetM(), sig)
+	case *edwards.PublicKey:
+		return x.Verify(ed25519.PublicKey(pub.Serialize()))
+	}
+	return false
+}
+
+// ----- //
+
+// ParseDERSignature parses an ecdsa signature of m in strict ASN.1 DER. The result has no recovery id.
+func ParseDERSignature(der, m []byte) (*SignatureData, error) {
+	var sig ecdsaSignatureASN1
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("ParseDERSignature: trailing data")
+	}
+	if canonical, err := asn1.Marshal(sig); err != nil || !bytes.Equal(canonical, der) {
+		return nil, errors.New("ParseDERSignature: not in DER")
+	}
+	return newECDSASignatureData(sig.R, sig.S, nil, m)
+}
+
+// ParseCompactSignature parses a 65-byte compact secp256k1 signature of m, and returns whether it was made with a
+// compressed key
+func ParseCompactSignature(sig, m []byte) (*SignatureData, bool, error) {
+	if len(sig) != compactSigLen {
+		return nil, false, errors.New("ParseCompactSignature: invalid signature length")
+	}
+	header := int(sig[0]) - compactSigMagic
+	if header < 0 || header > 7 {
+		return nil, false, fmt.Errorf("ParseCompactSignature: invalid header byte %d", sig[0])
+	}
+	compressed := header&compactCompressed != 0
+	recid := byte(header &^ compactCompressed)
+	data, err := newECDSASignatureData(new(big.Int).SetBytes(sig[1:33]), new(big.Int).SetBytes(sig[33:]), &recid, m)
+	return data, compressed, err
+}
+
+// ParseEthereumSignature parses the r, s and v of a secp256k1 signature of m. v is the raw recovery id, 27 or 28,
+// or an EIP-155 value, in which case the chain id is returned as well.
+func ParseEthereumSignature(r, s []byte, v *big.Int, m []byte) (*SignatureData, *big.Int, error) {
+	if len(r) > 32 || len(s) > 32 {
+		return nil, nil, errors.New("ParseEthereumSignature: invalid signature length")
+	}
+	if v == nil || v.Sign() < 0 {
+		return nil, nil, errors.New("ParseEthereumSignature: invalid v")
+	}
+	var chainID *big.Int
+	var recid byte
+	switch {
+	case v.Cmp(big.NewInt(1)) <= 0:
+		recid = byte(v.Uint64())
+	case v.Cmp(big.NewInt(ethereumV)) == 0 || v.Cmp(big.NewInt(ethereumV+1)) == 0:
+		recid = byte(v.Uint64() - ethereumV)
+	case v.Cmp(big.NewInt(ethereumEIP155)) >= 0:
+		rem := new(big.Int).Sub(v, big.NewInt(ethereumEIP155))
+		recid = byte(rem.Bit(0))
+		chainID = rem.Rsh(rem, 1)
+	default:
+		return nil, nil, fmt.Errorf("ParseEthereumSignature: invalid v %s", v)
+	}
+	data, err := newECDSASignatureData(new(big.Int).SetBytes(r), new(big.Int).SetBytes(s), &recid, m)
+	return data, chainID, err
+}
+
+// ParseEd25519Signature parses a 64-byte RFC 8032 signature of m
+func ParseEd25519Signature(sig, m []byte) (*SignatureData, error) {
+	if len(sig) != ed25519.SignatureSize {
+		return nil, errors.New("ParseEd25519Signature: invalid signature length")
+	}
+	// a canonical s is reduced modulo the group order L; s + L would be another valid signature of m
+	s := new(big.Int).SetBytes(littleEndian32(sig[32:]))
+	if s.Cmp(edwards.Edwards().Params().N) >= 0 {
+		return nil, errors.New("ParseEd25519Signature: non-canonical s")
+	}
+	return &SignatureData{
+		Signature: append([]byte{}, sig...),
+		R:         new(big.Int).SetBytes(littleEndian32(sig[:32])).Bytes(),
+		S:         s.Bytes(),
+		M:         append([]byte{}, m...),
+	}, nil
+}
+
+// ----- //
+
+func newECDSASignatureData(r, s *big.Int, recid *byte, m []byte) (*SignatureData, error) {
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.BitLen() > 256 || s.BitLen() > 256 {
+		return nil, errors.New("invalid signature: r and s must be positive 256-bit integers")
+	}
+	rBz, sBz := r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))
+	data := &SignatureData{
+		Signature: append(append([]byte{}, rBz...), sBz...),
+		R:         rBz,
+		S:         sBz,
+		M:         append([]byte{}, m...),
+	}
+	if recid != nil {
+		data.SignatureRecovery = []byte{*recid}
+	}
+	return data, nil
+}
+
+// rs returns R and S of an ecdsa signature, which may have been decoded from anywhere, so that they fit in 32 bytes
+func (x *SignatureData) rs() (r, s *big.Int, err error) {
+	if len(x.GetR()) == 0 || len(x.GetS()) == 0 {
+		return nil, nil, errors.New("the signature has no R or S")
+	}
+	r, s = new(big.Int).SetBytes(x.GetR()), new(big.Int).SetBytes(x.GetS())
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.BitLen() > 256 || s.BitLen() > 256 {
+		return nil, nil, errors.New("invalid signature: r and s must be positive 256-bit integers")
+	}
+	return r, s, nil
+}
+
+func (x *SignatureData) recoveryID() (byte, error) {
+	if len(x.GetSignatureRecovery()) == 0 {
+		return 0, errors.New("the signature has no recovery id")
+	}
+	recid := x.GetSignatureRecovery()[0]
+	if recid > 3 {
+		return 0, fmt.Errorf("invalid recovery id %d", recid)
+	}
+	return recid, nil
+}
+
+// littleEndian32 returns the reverse of bz left-padded to 32 bytes
+func littleEndian32(bz []byte) []byte {
+	out := make([]byte, 32)
+	for i := range bz {
+		out[i] = bz[len(bz)-1-i]
+	}
+	return out
+}
@@ -0,0 +1,188 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/bnb-chain/tss-lib/v2/common"
+)
+
+func TestCompactAndDERSignatureEncoding(t *testing.T) {
+	priv, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("tss-lib"))
+
+	compact, err := btcecdsa.SignCompact(priv, hash[:], true)
+	assert.NoError(t, err)
+	data, compressed, err := ParseCompactSignature(compact, hash[:])
+	assert.NoError(t, err)
+	assert.True(t, compressed)
+	assert.True(t, data.Verify(priv.PubKey()))
+	assert.True(t, data.Verify(priv.PubKey().ToECDSA()))
+
+	bz, err := data.ToCompact(true)
+	assert.NoError(t, err)
+	assert.Equal(t, compact, bz)
+
+	// DER matches btcec
+	der, err := data.ToDER()
+	assert.NoError(t, err)
+	btcSig, err := btcecdsa.ParseDERSignature(der)
+	assert.NoError(t, err)
+	assert.True(t, btcSig.Verify(hash[:], priv.PubKey()))
+	assert.Equal(t, btcSig.Serialize(), der)
+
+	parsed, err := ParseDERSignature(der, hash[:])
+	assert.NoError(t, err)
+	assert.Equal(t, data.GetR(), parsed.GetR())
+	assert.Equal(t, data.GetS(), parsed.GetS())
+	assert.True(t, parsed.Verify(priv.PubKey()))
+	_, err = ParseDERSignature(append(der, 0), hash[:])
+	assert.Error(t, err)
+
+	// a tampered message does not verify
+	data.M = hash[1:]
+	assert.False(t, data.Verify(priv.PubKey()))
+
+	_, _, err = ParseCompactSignature(compact[1:], hash[:])
+	assert.Error(t, err)
+}
+
+func TestEthereumSignatureEncoding(t *testing.T) {
+	priv, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("tss-lib"))
+	compact, err := btcecdsa.SignCompact(priv, hash[:], false)
+	assert.NoError(t, err)
+	data, _, err := ParseCompactSignature(compact, hash[:])
+	assert.NoError(t, err)
+	recid := int64(data.GetSignatureRecovery()[0])
+
+	r, s, v, err := data.ToEthereum(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(27+recid), v)
+
+	chainID := big.NewInt(56)
+	r, s, v, err = data.ToEthereum(chainID)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(56*2+35+recid), v)
+
+	parsed, parsedChainID, err := ParseEthereumSignature(r, s, v, hash[:])
+	assert.NoError(t, err)
+	assert.Equal(t, chainID, parsedChainID)
+	assert.Equal(t, data.GetSignatureRecovery(), parsed.GetSignatureRecovery())
+	assert.True(t, parsed.Verify(priv.PubKey()))
+
+	parsed, parsedChainID, err = ParseEthereumSignature(r, s, big.NewInt(recid), hash[:])
+	assert.NoError(t, err)
+	assert.Nil(t, parsedChainID)
+	assert.Equal(t, data.GetSignatureRecovery(), parsed.GetSignatureRecovery())
+
+	_, _, err = ParseEthereumSignature(r, s, big.NewInt(30), hash[:])
+	assert.Error(t, err)
+
+	// high s is rejected
+	highS := new(big.Int).Sub(btcec.S256().Params().N, new(big.Int).SetBytes(s))
+	data.S = highS.Bytes()
+	_, _, _, err = data.ToEthereum(chainID)
+	assert.Error(t, err)
+}
+
+func TestSignatureEncodingRejectsOversizedRS(t *testing.T) {
+	priv, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("tss-lib"))
+	compact, err := btcecdsa.SignCompact(priv, hash[:], false)
+	assert.NoError(t, err)
+
+	for _, oversized := range []func(data *SignatureData){
+		func(data *SignatureData) { data.R = append([]byte{1}, data.R...) },
+		func(data *SignatureData) { data.S = append([]byte{1}, data.S...) },
+	} {
+		data, _, err := ParseCompactSignature(compact, hash[:])
+		assert.NoError(t, err)
+		oversized(data)
+		assert.Len(t, append(data.R, data.S...), 65, "R or S has 33 significant bytes")
+
+		_, err = data.ToCompact(false)
+		assert.Error(t, err)
+		_, _, _, err = data.ToEthereum(nil)
+		assert.Error(t, err)
+		_, err = data.ToDER()
+		assert.Error(t, err)
+		assert.False(t, data.Verify(priv.PubKey()))
+	}
+
+	// a leading zero byte does not make R wider
+	data, _, err := ParseCompactSignature(compact, hash[:])
+	assert.NoError(t, err)
+	data.R = append([]byte{0}, data.R...)
+	bz, err := data.ToCompact(false)
+	assert.NoError(t, err)
+	assert.Equal(t, compact, bz)
+}
+
+func TestEd25519SignatureEncoding(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	msg := []byte("tss-lib")
+	sig := ed25519.Sign(priv, msg)
+
+	data, err := ParseEd25519Signature(sig, msg)
+	assert.NoError(t, err)
+	assert.True(t, data.Verify(pub))
+	edPub, err := edwards.ParsePubKey(pub)
+	assert.NoError(t, err)
+	assert.True(t, data.Verify(edPub))
+	assert.True(t, edwards.Verify(edPub, msg, new(big.Int).SetBytes(data.GetR()), new(big.Int).SetBytes(data.GetS())))
+
+	bz, err := data.ToEd25519()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(sig), bz)
+
+	// a public key of another kind does not verify
+	assert.False(t, data.Verify("not a key"))
+
+	sig[63] |= 0x80
+	_, err = ParseEd25519Signature(sig, msg)
+	assert.Error(t, err)
+}
+
+func TestEd25519SignatureRejectsNonCanonicalS(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	msg := []byte("tss-lib")
+	sig := ed25519.Sign(priv, msg)
+	L := edwards.Edwards().Params().N
+
+	// s + L passes the check of the top three bits, but is the same signature in another encoding
+	s := new(big.Int).SetBytes(reverse(sig[32:]))
+	for _, nonCanonical := range []*big.Int{L, new(big.Int).Add(s, L)} {
+		malleated := append(append([]byte{}, sig[:32]...), reverse(nonCanonical.FillBytes(make([]byte, 32)))...)
+		assert.Zero(t, malleated[63]&0xe0)
+		_, err = ParseEd25519Signature(malleated, msg)
+		assert.Error(t, err)
+	}
+}
+
+func reverse(bz []byte) []byte {
+	out := make([]byte, len(bz))
+	for i := range bz {
+		out[i] = bz[len(bz)-1-i]
+	}
+	return out
+}
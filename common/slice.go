@@ -62,3 +62,25 @@ func PadToLengthBytesInPlace(src []byte, length int) []byte {
 	}
 	return src
 }
+
+// SignedBigIntToBytes encodes a possibly negative *big.Int as a sign byte followed by its absolute value
+func SignedBigIntToBytes(i *big.Int) []byte {
+	abs := i.Bytes()
+	bz := make([]byte, 1, len(abs)+1)
+	if i.Sign() < 0 {
+		bz[0] = 1
+	}
+	return append(bz, abs...)
+}
+
+// SignedBytesToBigInt decodes a *big.Int encoded with SignedBigIntToBytes
+func SignedBytesToBigInt(bz []byte) *big.Int {
+	if len(bz) == 0 {
+		return new(big.Int)
+	}
+	i := new(big.Int).SetBytes(bz[1:])
+	if bz[0] == 1 {
+		i.Neg(i)
+	}
+	return i
+}
@@ -0,0 +1,40 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common
+
+import (
+	"math/big"
+)
+
+// WipeBigInt overwrites the words of x with zeros, including the unused capacity that may still hold the words of a
+// previous value, and sets x to 0. Setting a variable to another *big.Int only rebinds it, so a secret stays in the
+// heap until it is collected; x must not be shared with code that still needs its value. It is a no-op for nil.
+func WipeBigInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	words := x.Bits()
+	words = words[:cap(words)]
+	for i := range words {
+		words[i] = 0
+	}
+	x.SetInt64(0)
+}
+
+// WipeBigInts calls WipeBigInt on each of xs
+func WipeBigInts(xs ...*big.Int) {
+	for _, x := range xs {
+		WipeBigInt(x)
+	}
+}
+
+// WipeBytes overwrites b with zeros
+func WipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package common_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+func TestWipeBigInt(t *testing.T) {
+	x := common.MustGetRandomInt(rand.Reader, randomIntBitLen)
+	words := x.Bits()
+	// a smaller value leaves the higher words of the previous one in the capacity
+	x.Rsh(x, randomIntBitLen/2)
+	common.WipeBigInt(x)
+	assert.Equal(t, 0, x.Sign())
+	for _, w := range words[:cap(words)] {
+		assert.Zero(t, w)
+	}
+	common.WipeBigInt(nil)
+
+	y, z := big.NewInt(7), new(big.Int).Lsh(big.NewInt(1), 300)
+	common.WipeBigInts(y, nil, z)
+	assert.Equal(t, 0, y.Sign())
+	assert.Equal(t, 0, z.Sign())
+}
+
+func TestWipeBytes(t *testing.T) {
+	b := []byte{1, 2, 3}
+	common.WipeBytes(b)
+	assert.Equal(t, []byte{0, 0, 0}, b)
+}
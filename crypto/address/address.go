@@ -0,0 +1,135 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package address recovers the public key of a signature produced by ecdsa signing, and derives the addresses of
+// chains from the public keys of keygen and key derivation.
+package address
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+// RecoverPublicKey recovers the secp256k1 public key of a signature of M from R, S and the recovery id
+func RecoverPublicKey(sig *common.SignatureData) (*crypto.ECPoint, error) {
+	if sig == nil {
+		return nil, errors.New("RecoverPublicKey: nil signature")
+	}
+	compact, err := sig.ToCompact(true)
+	if err != nil {
+		return nil, fmt.Errorf("RecoverPublicKey: %w", err)
+	}
+	pub, _, err := ecdsa.RecoverCompact(compact, sig.GetM())
+	if err != nil {
+		return nil, fmt.Errorf("RecoverPublicKey: %w", err)
+	}
+	return crypto.NewECPoint(group.Secp256k1(), pub.X(), pub.Y())
+}
+
+// BitcoinP2PKH returns the pay-to-pubkey-hash address of the compressed key pub
+func BitcoinP2PKH(pub *crypto.ECPoint, net *chaincfg.Params) (string, error) {
+	key, err := secp256k1Key(pub)
+	if err != nil {
+		return "", err
+	}
+	addr, err := btcutil.NewAddressPubKeyHash(btcutil.Hash160(key.SerializeCompressed()), net)
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+// BitcoinP2WPKH returns the native segwit v0 pay-to-witness-pubkey-hash address of pub
+func BitcoinP2WPKH(pub *crypto.ECPoint, net *chaincfg.Params) (string, error) {
+	key, err := secp256k1Key(pub)
+	if err != nil {
+		return "", err
+	}
+	addr, err := btcutil.NewAddressWitnessPubKeyHash(btcutil.Hash160(key.SerializeCompressed()), net)
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+// BitcoinP2TR returns the taproot address of the internal key pub tweaked with merkleRoot, which is empty for a
+// key-path only output. The signatures of the output key are produced by schnorr signing with a tap tweak.
+func BitcoinP2TR(pub *crypto.ECPoint, merkleRoot []byte, net *chaincfg.Params) (string, error) {
+	key, err := secp256k1Key(pub)
+	if err != nil {
+		return "", err
+	}
+	outputKey := txscript.ComputeTaprootOutputKey(key, merkleRoot)
+	addr, err := btcutil.NewAddressTaproot(schnorr.SerializePubKey(outputKey), net)
+	if err != nil {
+		return "", err
+	}
+	return addr.EncodeAddress(), nil
+}
+
+// EthereumAddress returns the EIP-55 checksummed address of pub
+func EthereumAddress(pub *crypto.ECPoint) (string, error) {
+	key, err := secp256k1Key(pub)
+	if err != nil {
+		return "", err
+	}
+	h := sha3.NewLegacyKeccak256()
+	h.Write(key.SerializeUncompressed()[1:])
+	addr := hex.EncodeToString(h.Sum(nil)[12:])
+
+	// EIP-55: upper-case the letters whose nibble in the hash of the lower-case address is 8 or more
+	h = sha3.NewLegacyKeccak256()
+	h.Write([]byte(addr))
+	checksum := h.Sum(nil)
+	var sb strings.Builder
+	sb.WriteString("0x")
+	for i, c := range addr {
+		nibble := checksum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = checksum[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			c -= 'a' - 'A'
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String(), nil
+}
+
+// SolanaAddress returns the base58 encoding of the Ed25519 key pub
+func SolanaAddress(pub *crypto.ECPoint) (string, error) {
+	if pub == nil || pub.Group() != group.Ed25519() || !pub.ValidateBasic() {
+		return "", errors.New("SolanaAddress: the key must be an edwards25519 point")
+	}
+	key := edwards.PublicKey{Curve: pub.Group().Curve(), X: pub.X(), Y: pub.Y()}
+	return base58.Encode(key.Serialize()), nil
+}
+
+func secp256k1Key(pub *crypto.ECPoint) (*btcec.PublicKey, error) {
+	if pub == nil || pub.Group() != group.Secp256k1() || !pub.ValidateBasic() {
+		return nil, errors.New("the key must be a secp256k1 point")
+	}
+	var x, y btcec.FieldVal
+	x.SetByteSlice(pub.X().Bytes())
+	y.SetByteSlice(pub.Y().Bytes())
+	return btcec.NewPublicKey(&x, &y), nil
+}
@@ -0,0 +1,104 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package address_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/address"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+func TestRecoverPublicKey(t *testing.T) {
+	priv, err := btcec.NewPrivateKey()
+	assert.NoError(t, err)
+	hash := sha256.Sum256([]byte("tss-lib"))
+	compact, err := btcecdsa.SignCompact(priv, hash[:], true)
+	assert.NoError(t, err)
+	sig, _, err := common.ParseCompactSignature(compact, hash[:])
+	assert.NoError(t, err)
+
+	pub, err := RecoverPublicKey(sig)
+	assert.NoError(t, err)
+	assert.Equal(t, priv.PubKey().X(), pub.X())
+	assert.Equal(t, priv.PubKey().Y(), pub.Y())
+
+	// another recovery id recovers another key
+	sig.SignatureRecovery = []byte{sig.SignatureRecovery[0] ^ 1}
+	pub, err = RecoverPublicKey(sig)
+	if err == nil {
+		assert.NotEqual(t, priv.PubKey().X(), pub.X())
+	}
+
+	sig.SignatureRecovery = nil
+	_, err = RecoverPublicKey(sig)
+	assert.Error(t, err)
+}
+
+func TestBitcoinAndEthereumAddresses(t *testing.T) {
+	// the key of the private key 1 is the generator
+	pub := crypto.ScalarBaseMult(group.Secp256k1(), big.NewInt(1))
+
+	addr, err := BitcoinP2PKH(pub, &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+	assert.Equal(t, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", addr)
+
+	// BIP-173
+	addr, err = BitcoinP2WPKH(pub, &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+	assert.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", addr)
+
+	addr, err = EthereumAddress(pub)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x7E5F4552091A69125d5DfCb7b8C2659029395Bdf", addr)
+
+	// BIP-86, the first receiving address of m/86'/0'/0'
+	internalKey, err := hex.DecodeString("cc8a4bc64d897bddc5fbc2f670f7a8ba0b386779106cf1223c6fc5d7cd6fc115")
+	assert.NoError(t, err)
+	key, err := schnorr.ParsePubKey(internalKey)
+	assert.NoError(t, err)
+	pub, err = crypto.NewECPoint(group.Secp256k1(), key.X(), key.Y())
+	assert.NoError(t, err)
+	addr, err = BitcoinP2TR(pub, nil, &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+	assert.Equal(t, "bc1p5cyxnuxmeuwuvkwfem96lqzszd02n6xdcjrs20cac6yqjjwudpxqkedrcr", addr)
+
+	// the curve must be secp256k1
+	_, err = EthereumAddress(crypto.ScalarBaseMult(group.Ed25519(), big.NewInt(1)))
+	assert.Error(t, err)
+}
+
+func TestSolanaAddress(t *testing.T) {
+	edPub, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	key, err := edwards.ParsePubKey(edPub)
+	assert.NoError(t, err)
+	pub, err := crypto.NewECPoint(group.Ed25519(), key.X, key.Y)
+	assert.NoError(t, err)
+
+	addr, err := SolanaAddress(pub)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(edPub), base58.Decode(addr))
+
+	_, err = SolanaAddress(crypto.ScalarBaseMult(group.Secp256k1(), big.NewInt(1)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,250 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package affgproof
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofAffGBytesParts = 14
+)
+
+type (
+	// ProofAffG is a proof that D = C^x * enc0(y) and Y = enc1(y) with X = g^x for x in ±2^l and y in ±2^l',
+	// "Paillier Affine Operation with Group Commitment in Range" (CGGMP21 Fig. 15)
+	ProofAffG struct {
+		S, T, A *big.Int
+		Bx      *crypto.ECPoint
+		By, E, F,
+		Z1, Z2, Z3, Z4, W, Wy *big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements proofaffg.
+// pk0 is the verifier's Paillier key under which C and D are encrypted; pk1 is the prover's Paillier key under which Y is encrypted.
+// x, y are the multiplicative and additive plaintexts, rho the pk0 randomness of the added term in D and rhoY the pk1 randomness of Y.
+func NewProof(Session []byte, g group.AffineGroup, pk0, pk1 *paillier.PublicKey, NCap, s, t, C, D, Y *big.Int, X *crypto.ECPoint,
+	x, y, rho, rhoY *big.Int, rand io.Reader) (*ProofAffG, error) {
+	if g == nil || pk0 == nil || pk1 == nil || NCap == nil || s == nil || t == nil || C == nil || D == nil || Y == nil ||
+		X == nil || x == nil || y == nil || rho == nil || rhoY == nil {
+		return nil, errors.New("ProveAffG constructor received nil value(s)")
+	}
+
+	q := g.Order()
+	l := q.BitLen()
+	twoL := new(big.Int).Lsh(one, uint(l))
+	twoLEps := new(big.Int).Lsh(one, uint(3*l))
+	twoLPrmEps := new(big.Int).Lsh(one, uint(7*l))
+	twoLNCap := new(big.Int).Mul(twoL, NCap)
+	twoLEpsNCap := new(big.Int).Mul(twoLEps, NCap)
+
+	// Fig 15.1 sample
+	alpha := common.GetRandomSignedInt(rand, twoLEps)
+	beta := common.GetRandomSignedInt(rand, twoLPrmEps)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk0.N)
+	ry := common.GetRandomPositiveRelativelyPrimeInt(rand, pk1.N)
+	gamma := common.GetRandomSignedInt(rand, twoLEpsNCap)
+	m := common.GetRandomSignedInt(rand, twoLNCap)
+	delta := common.GetRandomSignedInt(rand, twoLEpsNCap)
+	mu := common.GetRandomSignedInt(rand, twoLNCap)
+
+	// Fig 15.1 compute
+	modNCap := common.ModInt(NCap)
+	modN02 := common.ModInt(pk0.NSquare())
+	A, err := pk0.EncryptWithRandomness(new(big.Int).Mod(beta, pk0.N), r)
+	if err != nil {
+		return nil, err
+	}
+	A = modN02.Mul(modN02.Exp(C, alpha), A)
+	Bx := crypto.ScalarBaseMult(g, new(big.Int).Mod(alpha, q))
+	By, err := pk1.EncryptWithRandomness(new(big.Int).Mod(beta, pk1.N), ry)
+	if err != nil {
+		return nil, err
+	}
+	E := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, m))
+	F := modNCap.Mul(modNCap.Exp(s, beta), modNCap.Exp(t, delta))
+	T := modNCap.Mul(modNCap.Exp(s, y), modNCap.Exp(t, mu))
+
+	// Fig 15.2 e
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, append(append(pk0.AsInts(), pk1.AsInts()...),
+			NCap, s, t, C, D, Y, X.X(), X.Y(), S, T, A, Bx.X(), Bx.Y(), By, E, F)...)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 15.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	z2 := new(big.Int).Add(beta, new(big.Int).Mul(e, y))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, m))
+	z4 := new(big.Int).Add(delta, new(big.Int).Mul(e, mu))
+	modN0, modN1 := common.ModInt(pk0.N), common.ModInt(pk1.N)
+	w := modN0.Mul(r, modN0.Exp(rho, e))
+	wy := modN1.Mul(ry, modN1.Exp(rhoY, e))
+
+	return &ProofAffG{S: S, T: T, A: A, Bx: Bx, By: By, E: E, F: F, Z1: z1, Z2: z2, Z3: z3, Z4: z4, W: w, Wy: wy}, nil
+}
+
+func NewProofFromBytes(g group.AffineGroup, bzs [][]byte) (*ProofAffG, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofAffGBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofAffG", ProofAffGBytesParts)
+	}
+	Bx, err := crypto.NewECPoint(g, new(big.Int).SetBytes(bzs[3]), new(big.Int).SetBytes(bzs[4]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofAffG{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		T:  new(big.Int).SetBytes(bzs[1]),
+		A:  new(big.Int).SetBytes(bzs[2]),
+		Bx: Bx,
+		By: new(big.Int).SetBytes(bzs[5]),
+		E:  new(big.Int).SetBytes(bzs[6]),
+		F:  new(big.Int).SetBytes(bzs[7]),
+		Z1: common.SignedBytesToBigInt(bzs[8]),
+		Z2: common.SignedBytesToBigInt(bzs[9]),
+		Z3: common.SignedBytesToBigInt(bzs[10]),
+		Z4: common.SignedBytesToBigInt(bzs[11]),
+		W:  new(big.Int).SetBytes(bzs[12]),
+		Wy: new(big.Int).SetBytes(bzs[13]),
+	}, nil
+}
+
+func (pf *ProofAffG) Verify(Session []byte, g group.AffineGroup, pk0, pk1 *paillier.PublicKey, NCap, s, t, C, D, Y *big.Int, X *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || g == nil || pk0 == nil || pk1 == nil || NCap == nil || s == nil || t == nil ||
+		C == nil || D == nil || Y == nil || X == nil {
+		return false
+	}
+
+	q := g.Order()
+	l := q.BitLen()
+	twoLEps := new(big.Int).Lsh(one, uint(3*l))
+	twoLPrmEps := new(big.Int).Lsh(one, uint(7*l))
+
+	// Fig 15. Range Check
+	if !common.IsInSignedInterval(pf.Z1, twoLEps) {
+		return false
+	}
+	if !common.IsInSignedInterval(pf.Z2, twoLPrmEps) {
+		return false
+	}
+	if !common.IsNumberInMultiplicativeGroup(pk0.N, pf.W) || !common.IsNumberInMultiplicativeGroup(pk1.N, pf.Wy) {
+		return false
+	}
+	if !common.IsNumberInMultiplicativeGroup(pk0.NSquare(), C) {
+		return false
+	}
+
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, append(append(pk0.AsInts(), pk1.AsInts()...),
+			NCap, s, t, C, D, Y, X.X(), X.Y(), pf.S, pf.T, pf.A, pf.Bx.X(), pf.Bx.Y(), pf.By, pf.E, pf.F)...)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 15. Equality Check
+	{
+		modN02 := common.ModInt(pk0.NSquare())
+		LHS, err := pk0.EncryptWithRandomness(new(big.Int).Mod(pf.Z2, pk0.N), pf.W)
+		if err != nil {
+			return false
+		}
+		LHS = modN02.Mul(modN02.Exp(C, pf.Z1), LHS)
+		RHS := modN02.Mul(pf.A, modN02.Exp(D, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		LHS := crypto.ScalarBaseMult(g, new(big.Int).Mod(pf.Z1, q))
+		RHS, err := pf.Bx.Add(X.ScalarMult(e))
+		if err != nil || !LHS.Equals(RHS) {
+			return false
+		}
+	}
+
+	{
+		modN12 := common.ModInt(pk1.NSquare())
+		LHS, err := pk1.EncryptWithRandomness(new(big.Int).Mod(pf.Z2, pk1.N), pf.Wy)
+		if err != nil {
+			return false
+		}
+		RHS := modN12.Mul(pf.By, modN12.Exp(Y, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	modNCap := common.ModInt(NCap)
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.E, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z2), modNCap.Exp(t, pf.Z4))
+		RHS := modNCap.Mul(pf.F, modNCap.Exp(pf.T, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (pf *ProofAffG) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.T != nil &&
+		pf.A != nil &&
+		pf.Bx != nil &&
+		pf.By != nil &&
+		pf.E != nil &&
+		pf.F != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil &&
+		pf.Z4 != nil &&
+		pf.W != nil &&
+		pf.Wy != nil
+}
+
+func (pf *ProofAffG) Bytes() [ProofAffGBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.T.Bytes(),
+		pf.A.Bytes(),
+		pf.Bx.X().Bytes(),
+		pf.Bx.Y().Bytes(),
+		pf.By.Bytes(),
+		pf.E.Bytes(),
+		pf.F.Bytes(),
+		common.SignedBigIntToBytes(pf.Z1),
+		common.SignedBigIntToBytes(pf.Z2),
+		common.SignedBigIntToBytes(pf.Z3),
+		common.SignedBigIntToBytes(pf.Z4),
+		pf.W.Bytes(),
+		pf.Wy.Bytes(),
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package affgproof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/affgproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testSafePrimeBits = 1024
+)
+
+var Session = []byte("session")
+
+func TestAffG(test *testing.T) {
+	ec := group.Secp256k1()
+	q := ec.Order()
+
+	pk0 := &paillier.PublicKey{N: new(big.Int).Mul(
+		common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits))}
+	pk1 := &paillier.PublicKey{N: new(big.Int).Mul(
+		common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits))}
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+
+	// the verifier's ciphertext
+	k := common.GetRandomPositiveInt(rand.Reader, q)
+	C, err := pk0.Encrypt(rand.Reader, k)
+	assert.NoError(test, err)
+
+	// the prover's affine operation D = C^x * enc0(y), Y = enc1(y)
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	X := crypto.ScalarBaseMult(ec, x)
+	y := common.GetRandomSignedInt(rand.Reader, new(big.Int).Lsh(big.NewInt(1), uint(5*q.BitLen())))
+	D, rho, err := pk0.EncryptAndReturnRandomness(rand.Reader, new(big.Int).Mod(y, pk0.N))
+	assert.NoError(test, err)
+	Cx, err := pk0.HomoMult(x, C)
+	assert.NoError(test, err)
+	D, err = pk0.HomoAdd(Cx, D)
+	assert.NoError(test, err)
+	Y, rhoY, err := pk1.EncryptAndReturnRandomness(rand.Reader, new(big.Int).Mod(y, pk1.N))
+	assert.NoError(test, err)
+
+	proof, err := NewProof(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, X, x, y, rho, rhoY, rand.Reader)
+	assert.NoError(test, err)
+	ok := proof.Verify(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, X)
+	assert.True(test, ok, "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(ec, bzs[:])
+	assert.NoError(test, err)
+	ok = proof2.Verify(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, X)
+	assert.True(test, ok, "proof must verify after a round trip through bytes")
+
+	// the proof must not verify for another group commitment
+	X2 := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	ok = proof.Verify(Session, ec, pk0, pk1, NCap, s, t, C, D, Y, X2)
+	assert.False(test, ok, "proof must not verify for a different X")
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcutil/base58"
 	"golang.org/x/crypto/ripemd160"
@@ -33,7 +34,7 @@ type ExtendedKey struct {
 // For more information about child key derivation see https://github.com/binance-chain/tss-lib/issues/104
 // https://github.com/bitcoin/bips/blob/master/bip-0032.mediawiki .
 // The functions below do not implement the full BIP-32 specification. As mentioned in the Jira ticket above,
-// we only use non-hardened derived keys.
+// we only use non-hardened derived keys. See hardened.go for the threshold-friendly hardened derivation.
 
 const (
 
@@ -79,7 +80,7 @@ func (k *ExtendedKey) String() string {
 }
 
 // NewExtendedKeyFromString returns a new extended key from a base58-encoded extended key
-func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, error) {
+func NewExtendedKeyFromString(key string, g group.AffineGroup) (*ExtendedKey, error) {
 	// version(4) || depth(1) || parentFP (4) || childinde(4) || chaincode (32) || key(33) || checksum(4)
 
 	decoded := base58.Decode(key)
@@ -105,7 +106,7 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 
 	var pubKey ecdsa.PublicKey
 
-	if c, ok := curve.(*btcec.KoblitzCurve); ok {
+	if c, ok := g.Curve().(*btcec.KoblitzCurve); ok {
 		pk, err := btcec.ParsePubKey(keyData)
 		if err != nil {
 			return nil, err
@@ -116,9 +117,12 @@ func NewExtendedKeyFromString(key string, curve elliptic.Curve) (*ExtendedKey, e
 			Y:     pk.Y(),
 		}
 	} else {
-		px, py := elliptic.Unmarshal(curve, keyData)
+		px, py := elliptic.UnmarshalCompressed(g.Curve(), keyData)
+		if px == nil {
+			return nil, errors.New("invalid extended key")
+		}
 		pubKey = ecdsa.PublicKey{
-			Curve: curve,
+			Curve: g.Curve(),
 			X:     px,
 			Y:     py,
 		}
@@ -180,7 +184,7 @@ func serializeCompressed(publicKeyX *big.Int, publicKeyY *big.Int) []byte {
 	return paddedAppend(b, 32, publicKeyX.Bytes())
 }
 
-func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod *big.Int, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
+func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod *big.Int, g group.AffineGroup) (*big.Int, *ExtendedKey, error) {
 	var k = pk
 	var err error
 	var childKey *ExtendedKey
@@ -188,7 +192,7 @@ func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod
 	ilNum := big.NewInt(0)
 	for index := range indicesHierarchy {
 		ilNumOld := ilNum
-		ilNum, childKey, err = DeriveChildKey(indicesHierarchy[index], k, curve)
+		ilNum, childKey, err = DeriveChildKey(indicesHierarchy[index], k, g)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -200,7 +204,7 @@ func DeriveChildKeyFromHierarchy(indicesHierarchy []uint32, pk *ExtendedKey, mod
 
 // DeriveChildKey Derive a child key from the given parent key. The function returns "IL" ("I left"), per BIP-32 spec. It also
 // returns the derived child key.
-func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.Int, *ExtendedKey, error) {
+func DeriveChildKey(index uint32, pk *ExtendedKey, g group.AffineGroup) (*big.Int, *ExtendedKey, error) {
 	if index >= HardenedKeyStart {
 		return nil, nil, errors.New("the index must be non-hardened")
 	}
@@ -208,7 +212,7 @@ func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.I
 		return nil, nil, errors.New("cannot derive key beyond max depth")
 	}
 
-	cryptoPk, err := crypto.NewECPoint(curve, pk.X, pk.Y)
+	cryptoPk, err := crypto.NewECPoint(g, pk.X, pk.Y)
 	if err != nil {
 		common.Logger.Error("error getting pubkey from extendedkey")
 		return nil, nil, err
@@ -228,14 +232,14 @@ func DeriveChildKey(index uint32, pk *ExtendedKey, curve elliptic.Curve) (*big.I
 	childChainCode := ilr[32:]
 	ilNum := new(big.Int).SetBytes(il)
 
-	if ilNum.Cmp(curve.Params().N) >= 0 || ilNum.Sign() == 0 {
+	if ilNum.Cmp(g.Order()) >= 0 || ilNum.Sign() == 0 {
 		// falling outside of the valid range for curve private keys
 		err = errors.New("invalid derived key")
 		common.Logger.Error("error deriving child key")
 		return nil, nil, err
 	}
 
-	deltaG := crypto.ScalarBaseMult(curve, ilNum)
+	deltaG := crypto.ScalarBaseMult(g, ilNum)
 	if deltaG.X().Sign() == 0 || deltaG.Y().Sign() == 0 {
 		err = errors.New("invalid child")
 		common.Logger.Error("error invalid child")
@@ -10,7 +10,7 @@ import (
 	"testing"
 
 	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
-	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 )
 
 func TestPublicDerivation(t *testing.T) {
@@ -104,7 +104,7 @@ func TestPublicDerivation(t *testing.T) {
 
 tests:
 	for i, test := range tests {
-		extKey, err := NewExtendedKeyFromString(test.master, btcec.S256())
+		extKey, err := NewExtendedKeyFromString(test.master, group.Secp256k1())
 		if err != nil {
 			t.Errorf("NewKeyFromString #%d (%s): unexpected error "+
 				"creating extended key: %v", i, test.name,
@@ -114,7 +114,7 @@ tests:
 
 		for _, childNum := range test.path {
 			var err error
-			_, extKey, err = DeriveChildKey(childNum, extKey, btcec.S256())
+			_, extKey, err = DeriveChildKey(childNum, extKey, group.Secp256k1())
 			if err != nil {
 				t.Errorf("err: %v", err)
 				continue tests
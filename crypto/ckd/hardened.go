@@ -0,0 +1,153 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+// BIP-32 hardened derivation computes HMAC-SHA512 over the parent private key, which a threshold committee cannot
+// do without a generic MPC. The functions below implement a threshold-friendly alternative instead. A hardened
+// step keys the HMAC with ser_P(x * H) || ser_32(i), where x is the parent private key and H is a point with
+// unknown discrete log derived from the master public key. The committee computes x * H in one round, and only
+// holders of x * H can derive hardened children, so a child private key and the parent xpub do not reveal the
+// parent private key. Non-hardened steps follow BIP-32. Hardened keys derived this way are NOT compatible with
+// BIP-32 wallets.
+
+const hardenedBaseDomain = "tss-lib hardened derivation base"
+
+// HardenedSecret is x * Base for a private key x, which is needed to derive hardened children of the key x * G.
+// It must be kept as secret as a chain code together with its key shares.
+type HardenedSecret struct {
+	Base, Point *crypto.ECPoint
+}
+
+// HardenedBase returns the point H of the hardened derivation of masterPub. Its discrete log is unknown.
+func HardenedBase(g group.AffineGroup, masterPub *crypto.ECPoint) (*crypto.ECPoint, error) {
+	if masterPub == nil || !masterPub.ValidateBasic() {
+		return nil, errors.New("invalid master public key")
+	}
+	seed := append([]byte(hardenedBaseDomain), serializeCompressed(masterPub.X(), masterPub.Y())...)
+	for ctr := uint32(0); ctr < 256; ctr++ {
+		var ctrBz [4]byte
+		binary.BigEndian.PutUint32(ctrBz[:], ctr)
+		x := sha256.Sum256(append(seed, ctrBz[:]...))
+		if p := decompress(g, append([]byte{pubKeyCompressed}, x[:]...)); p != nil {
+			return p, nil
+		}
+	}
+	return nil, errors.New("could not hash to a curve point")
+}
+
+// DeriveHardenedChildKey derives the child of pk at a hardened index, given the HardenedSecret of pk.
+// Like DeriveChildKey, it returns IL and the derived child key.
+func DeriveHardenedChildKey(index uint32, pk *ExtendedKey, secret *HardenedSecret, g group.AffineGroup) (*big.Int, *ExtendedKey, error) {
+	if index < HardenedKeyStart {
+		return nil, nil, errors.New("the index must be hardened")
+	}
+	if pk.Depth == maxDepth {
+		return nil, nil, errors.New("cannot derive key beyond max depth")
+	}
+	if secret == nil || secret.Point == nil || !secret.Point.ValidateBasic() {
+		return nil, nil, errors.New("invalid hardened secret")
+	}
+	cryptoPk, err := crypto.NewECPoint(g, pk.X, pk.Y)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data := make([]byte, 37)
+	copy(data, serializeCompressed(secret.Point.X(), secret.Point.Y()))
+	binary.BigEndian.PutUint32(data[33:], index)
+
+	// I = HMAC-SHA512(Key = chainCode, Data = ser_P(x * H) || ser_32(i))
+	hmac512 := hmac.New(sha512.New, pk.ChainCode)
+	hmac512.Write(data)
+	ilr := hmac512.Sum(nil)
+	ilNum := new(big.Int).SetBytes(ilr[:32])
+	if ilNum.Cmp(g.Order()) >= 0 || ilNum.Sign() == 0 {
+		return nil, nil, errors.New("invalid derived key")
+	}
+	childCryptoPk, err := cryptoPk.Add(crypto.ScalarBaseMult(g, ilNum))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	childPk := &ExtendedKey{
+		PublicKey:  *childCryptoPk.ToECDSAPubKey(),
+		Depth:      pk.Depth + 1,
+		ChildIndex: index,
+		ChainCode:  ilr[32:],
+		ParentFP:   hash160(serializeCompressed(pk.X, pk.Y))[:4],
+		Version:    pk.Version,
+	}
+	return ilNum, childPk, nil
+}
+
+// DeriveChildKeyFromHierarchyWithSecret is DeriveChildKeyFromHierarchy for paths that may contain hardened
+// indices, given the HardenedSecret of pk. The secret of each child is x * H + IL * H.
+func DeriveChildKeyFromHierarchyWithSecret(indicesHierarchy []uint32, pk *ExtendedKey, secret *HardenedSecret, mod *big.Int, g group.AffineGroup) (*big.Int, *ExtendedKey, error) {
+	k := pk
+	mod_ := common.ModInt(mod)
+	delta := big.NewInt(0)
+	for _, index := range indicesHierarchy {
+		var ilNum *big.Int
+		var err error
+		if index >= HardenedKeyStart {
+			// the secret of the current key is x * H + delta * H
+			current := secret
+			if delta.Sign() != 0 {
+				point, err := secret.Point.Add(secret.Base.ScalarMult(delta))
+				if err != nil {
+					return nil, nil, err
+				}
+				current = &HardenedSecret{Base: secret.Base, Point: point}
+			}
+			ilNum, k, err = DeriveHardenedChildKey(index, k, current, g)
+		} else {
+			ilNum, k, err = DeriveChildKey(index, k, g)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		delta = mod_.Add(delta, ilNum)
+	}
+	return delta, k, nil
+}
+
+// decompress returns the point encoded in compressed form by bz, or nil if there is none
+func decompress(g group.AffineGroup, bz []byte) *crypto.ECPoint {
+	var x, y *big.Int
+	if _, ok := g.Curve().(*btcec.KoblitzCurve); ok {
+		pk, err := btcec.ParsePubKey(bz)
+		if err != nil {
+			return nil
+		}
+		x, y = pk.X(), pk.Y()
+	} else {
+		if x, y = elliptic.UnmarshalCompressed(g.Curve(), bz); x == nil {
+			return nil
+		}
+	}
+	p, err := crypto.NewECPoint(g, x, y)
+	if err != nil {
+		return nil
+	}
+	return p
+}
@@ -0,0 +1,76 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package ckd_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+func TestHardenedDerivation(t *testing.T) {
+	ec := group.Secp256k1()
+	q := ec.Order()
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	pub := crypto.ScalarBaseMult(ec, x)
+	chainCode := make([]byte, 32)
+	_, _ = rand.Read(chainCode)
+	master := &ExtendedKey{
+		PublicKey: *pub.ToECDSAPubKey(),
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+		Version:   []byte{0x04, 0x88, 0xb2, 0x1e},
+	}
+
+	base, err := HardenedBase(ec, pub)
+	if !assert.NoError(t, err) {
+		return
+	}
+	secret := &HardenedSecret{Base: base, Point: base.ScalarMult(x)}
+
+	// m/44'/60'/0'/0/7
+	path := []uint32{HardenedKeyStart + 44, HardenedKeyStart + 60, HardenedKeyStart, 0, 7}
+	delta, child, err := DeriveChildKeyFromHierarchyWithSecret(path, master, secret, q, ec)
+	if !assert.NoError(t, err) {
+		return
+	}
+	childX := common.ModInt(q).Add(x, delta)
+	assert.True(t, crypto.ScalarBaseMult(ec, childX).Equals(mustPoint(t, child)), "the child key must be x + delta")
+	assert.Equal(t, uint8(len(path)), child.Depth)
+
+	// deriving the path in two parts with the secret of the intermediate key gives the same child
+	delta1, parent, err := DeriveChildKeyFromHierarchyWithSecret(path[:2], master, secret, q, ec)
+	assert.NoError(t, err)
+	parentSecret := &HardenedSecret{Base: base, Point: base.ScalarMult(common.ModInt(q).Add(x, delta1))}
+	delta2, child2, err := DeriveChildKeyFromHierarchyWithSecret(path[2:], parent, parentSecret, q, ec)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, common.ModInt(q).Add(delta1, delta2).Cmp(delta))
+	assert.Equal(t, child.String(), child2.String())
+
+	// the hardened child depends on the secret, unlike a non-hardened one
+	wrongSecret := &HardenedSecret{Base: base, Point: base.ScalarMult(common.GetRandomPositiveInt(rand.Reader, q))}
+	_, child3, err := DeriveChildKeyFromHierarchyWithSecret(path, master, wrongSecret, q, ec)
+	assert.NoError(t, err)
+	assert.NotEqual(t, child.String(), child3.String())
+
+	_, _, err = DeriveChildKey(HardenedKeyStart, master, ec)
+	assert.Error(t, err, "DeriveChildKey must reject a hardened index")
+	_, _, err = DeriveHardenedChildKey(0, master, secret, ec)
+	assert.Error(t, err, "DeriveHardenedChildKey must reject a non-hardened index")
+}
+
+func mustPoint(t *testing.T, k *ExtendedKey) *crypto.ECPoint {
+	p, err := crypto.NewECPoint(group.Secp256k1(), k.X, k.Y)
+	assert.NoError(t, err)
+	return p
+}
@@ -53,6 +53,15 @@ func NewHashDeCommitmentFromBytes(marshalled [][]byte) HashDeCommitment {
 	return common.MultiBytesToBigInts(marshalled)
 }
 
+// WipeDeCommitment wipes the randomness of a decommitment once it is no longer needed. The committed values are left
+// alone: they may be shared with other data, e.g. the coordinates of the points given to crypto.FlattenECPoints.
+func WipeDeCommitment(D HashDeCommitment) {
+	if len(D) == 0 {
+		return
+	}
+	common.WipeBigInt(D[0])
+}
+
 func (cmt *HashCommitDecommit) Verify() bool {
 	C, D := cmt.C, cmt.D
 	if C == nil || D == nil {
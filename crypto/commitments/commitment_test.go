@@ -37,3 +37,16 @@ func TestDeCommit(t *testing.T) {
 
 	assert.NotZero(t, len(secrets), "len(secrets) must be non-zero")
 }
+
+func TestWipeDeCommitment(t *testing.T) {
+	one := big.NewInt(1)
+	two := big.NewInt(2)
+
+	commitment := NewHashCommitment(rand.Reader, one, two)
+	WipeDeCommitment(commitment.D)
+
+	assert.Equal(t, 0, commitment.D[0].Sign(), "the randomness must be wiped")
+	assert.Equal(t, int64(1), one.Int64(), "the committed values must be left alone")
+	assert.False(t, commitment.Verify())
+	WipeDeCommitment(nil)
+}
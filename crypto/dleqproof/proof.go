@@ -0,0 +1,103 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package dleqproof
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+const (
+	ProofDLEQBytesParts = 5
+)
+
+type (
+	// ProofDLEQ is a Chaum-Pedersen proof that X = x*G and Y = x*H for the same secret x
+	ProofDLEQ struct {
+		A1, A2 *crypto.ECPoint
+		Z      *big.Int
+	}
+)
+
+// NewProof proves that X = x*G and Y = x*H
+func NewProof(Session []byte, x *big.Int, G, X, H, Y *crypto.ECPoint, rand io.Reader) (*ProofDLEQ, error) {
+	if x == nil || G == nil || X == nil || H == nil || Y == nil {
+		return nil, errors.New("ProveDLEQ constructor received nil value(s)")
+	}
+	q := G.Group().Order()
+
+	a := common.GetRandomPositiveInt(rand, q)
+	A1, A2 := G.ScalarMult(a), H.ScalarMult(a)
+
+	c := challenge(Session, q, G, X, H, Y, A1, A2)
+	z := common.ModInt(q).Add(a, new(big.Int).Mul(c, x))
+	return &ProofDLEQ{A1: A1, A2: A2, Z: z}, nil
+}
+
+func NewProofFromBytes(g group.AffineGroup, bzs [][]byte) (*ProofDLEQ, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofDLEQBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofDLEQ", ProofDLEQBytesParts)
+	}
+	A1, err := crypto.NewECPoint(g, new(big.Int).SetBytes(bzs[0]), new(big.Int).SetBytes(bzs[1]))
+	if err != nil {
+		return nil, err
+	}
+	A2, err := crypto.NewECPoint(g, new(big.Int).SetBytes(bzs[2]), new(big.Int).SetBytes(bzs[3]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofDLEQ{A1: A1, A2: A2, Z: new(big.Int).SetBytes(bzs[4])}, nil
+}
+
+func (pf *ProofDLEQ) Verify(Session []byte, G, X, H, Y *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || G == nil || X == nil || H == nil || Y == nil {
+		return false
+	}
+	q := G.Group().Order()
+	if pf.Z.Cmp(q) >= 0 {
+		return false
+	}
+	c := challenge(Session, q, G, X, H, Y, pf.A1, pf.A2)
+
+	// z*G = A1 + c*X and z*H = A2 + c*Y
+	right1, err := pf.A1.Add(X.ScalarMult(c))
+	if err != nil || !G.ScalarMult(pf.Z).Equals(right1) {
+		return false
+	}
+	right2, err := pf.A2.Add(Y.ScalarMult(c))
+	if err != nil || !H.ScalarMult(pf.Z).Equals(right2) {
+		return false
+	}
+	return true
+}
+
+func (pf *ProofDLEQ) ValidateBasic() bool {
+	return pf.A1 != nil &&
+		pf.A2 != nil &&
+		pf.Z != nil
+}
+
+func (pf *ProofDLEQ) Bytes() [ProofDLEQBytesParts][]byte {
+	return [...][]byte{
+		pf.A1.X().Bytes(),
+		pf.A1.Y().Bytes(),
+		pf.A2.X().Bytes(),
+		pf.A2.Y().Bytes(),
+		pf.Z.Bytes(),
+	}
+}
+
+func challenge(Session []byte, q *big.Int, G, X, H, Y, A1, A2 *crypto.ECPoint) *big.Int {
+	cHash := common.SHA512_256i_TAGGED(Session, G.X(), G.Y(), X.X(), X.Y(), H.X(), H.Y(), Y.X(), Y.Y(), A1.X(), A1.Y(), A2.X(), A2.Y())
+	return common.RejectionSample(q, cHash)
+}
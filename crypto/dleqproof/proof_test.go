@@ -0,0 +1,50 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package dleqproof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/dleqproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+var Session = []byte("session")
+
+func TestDLEQ(test *testing.T) {
+	ec := group.Secp256k1()
+	q := ec.Order()
+
+	G := crypto.ScalarBaseMult(ec, big.NewInt(1))
+	H := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	X, Y := G.ScalarMult(x), H.ScalarMult(x)
+
+	proof, err := NewProof(Session, x, G, X, H, Y, rand.Reader)
+	assert.NoError(test, err)
+	assert.True(test, proof.Verify(Session, G, X, H, Y), "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(ec, bzs[:])
+	assert.NoError(test, err)
+	assert.True(test, proof2.Verify(Session, G, X, H, Y), "proof must verify after a round trip through bytes")
+
+	assert.False(test, proof.Verify([]byte("other session"), G, X, H, Y), "proof must not verify in another session")
+
+	// Y for a different secret
+	Y2 := H.ScalarMult(new(big.Int).Add(x, big.NewInt(1)))
+	assert.False(test, proof.Verify(Session, G, X, H, Y2), "proof must not verify for unequal discrete logs")
+	forged, err := NewProof(Session, x, G, X, H, Y2, rand.Reader)
+	assert.NoError(test, err)
+	assert.False(test, forged.Verify(Session, G, X, H, Y2), "a proof for unequal discrete logs must not verify")
+}
@@ -9,41 +9,50 @@ package crypto
 import (
 	"bytes"
 	"crypto/ecdsa"
-	"crypto/elliptic"
 	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 
-	"github.com/decred/dcrd/dcrec/edwards/v2"
-
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
-// ECPoint convenience helper
+// ECPoint is a point of one of the groups of elliptic curves, other than the identity, along with its affine
+// coordinates. The arithmetic is done in the group.
 type ECPoint struct {
-	curve  elliptic.Curve
+	group  group.AffineGroup
+	point  group.Point
 	coords [2]*big.Int
 }
 
-var (
-	eight    = big.NewInt(8)
-	eightInv = new(big.Int).ModInverse(eight, edwards.Edwards().Params().N)
-)
+// Creates a new ECPoint and checks that the given coordinates are those of a point of the group.
+func NewECPoint(g group.AffineGroup, X, Y *big.Int) (*ECPoint, error) {
+	point, err := g.NewPointFromAffine(X, Y)
+	if err != nil {
+		return nil, fmt.Errorf("NewECPoint: %v", err)
+	}
+	return &ECPoint{g, point, [2]*big.Int{new(big.Int).Set(X), new(big.Int).Set(Y)}}, nil
+}
 
-// Creates a new ECPoint and checks that the given coordinates are on the elliptic curve.
-func NewECPoint(curve elliptic.Curve, X, Y *big.Int) (*ECPoint, error) {
-	if !isOnCurve(curve, X, Y) {
-		return nil, fmt.Errorf("NewECPoint: the given point is not on the elliptic curve")
+// NewECPointFromGroupPoint returns the ECPoint of a point of one of the groups of elliptic curves. The identity
+// cannot be represented by an ECPoint.
+func NewECPointFromGroupPoint(p group.Point) (*ECPoint, error) {
+	g, ok := p.Group().(group.AffineGroup)
+	if !ok {
+		return nil, fmt.Errorf("NewECPointFromGroupPoint: the group %s is not a group of curve points", p.Group().Name())
 	}
-	return &ECPoint{curve, [2]*big.Int{X, Y}}, nil
+	x, y := g.Affine(p)
+	if x == nil {
+		return nil, errors.New("NewECPointFromGroupPoint: the point is the identity")
+	}
+	return &ECPoint{g, g.NewPoint().Set(p), [2]*big.Int{x, y}}, nil
 }
 
-// Creates a new ECPoint without checking that the coordinates are on the elliptic curve.
-// Only use this function when you are completely sure that the point is already on the curve.
-func NewECPointNoCurveCheck(curve elliptic.Curve, X, Y *big.Int) *ECPoint {
-	return &ECPoint{curve, [2]*big.Int{X, Y}}
+// Generator returns the standard generator of g
+func Generator(g group.AffineGroup) *ECPoint {
+	return mustECPoint(g.Generator())
 }
 
 func (p *ECPoint) X() *big.Int {
@@ -54,34 +63,39 @@ func (p *ECPoint) Y() *big.Int {
 	return new(big.Int).Set(p.coords[1])
 }
 
+// Add returns p + p1, or an error if the points are of different groups or the sum is the identity
 func (p *ECPoint) Add(p1 *ECPoint) (*ECPoint, error) {
-	x, y := p.curve.Add(p.X(), p.Y(), p1.X(), p1.Y())
-	return NewECPoint(p.curve, x, y)
+	if !p.ValidateBasic() || !p1.ValidateBasic() || p.group != p1.group {
+		return nil, errors.New("ECPoint.Add: the points are not of the same group")
+	}
+	return NewECPointFromGroupPoint(p.group.NewPoint().Add(p.point, p1.point))
 }
 
+// ScalarMult returns k * p. It panics if k is a multiple of the order of the group.
 func (p *ECPoint) ScalarMult(k *big.Int) *ECPoint {
-	x, y := p.curve.ScalarMult(p.X(), p.Y(), k.Bytes())
-	newP, err := NewECPoint(p.curve, x, y) // it must be on the curve, no need to check.
-	if err != nil {
-		panic(fmt.Errorf("scalar mult to an ecpoint %s", err.Error()))
-	}
-	return newP
+	return mustECPoint(p.group.NewPoint().ScalarMult(p.group.NewScalar().SetBigInt(k), p.point))
 }
 
 func (p *ECPoint) ToECDSAPubKey() *ecdsa.PublicKey {
 	return &ecdsa.PublicKey{
-		Curve: p.curve,
+		Curve: p.group.Curve(),
 		X:     p.X(),
 		Y:     p.Y(),
 	}
 }
 
 func (p *ECPoint) IsOnCurve() bool {
-	return isOnCurve(p.curve, p.coords[0], p.coords[1])
+	return p.point != nil
 }
 
-func (p *ECPoint) Curve() elliptic.Curve {
-	return p.curve
+// Group returns the group of the point
+func (p *ECPoint) Group() group.AffineGroup {
+	return p.group
+}
+
+// Point returns a copy of p as an element of its group
+func (p *ECPoint) Point() group.Point {
+	return p.group.NewPoint().Set(p.point)
 }
 
 func (p *ECPoint) Equals(p2 *ECPoint) bool {
@@ -91,35 +105,23 @@ func (p *ECPoint) Equals(p2 *ECPoint) bool {
 	return p.X().Cmp(p2.X()) == 0 && p.Y().Cmp(p2.Y()) == 0
 }
 
-func (p *ECPoint) SetCurve(curve elliptic.Curve) *ECPoint {
-	p.curve = curve
-	return p
-}
-
 func (p *ECPoint) ValidateBasic() bool {
 	return p != nil && p.coords[0] != nil && p.coords[1] != nil && p.IsOnCurve()
 }
 
-func (p *ECPoint) EightInvEight() *ECPoint {
-	return p.ScalarMult(eight).ScalarMult(eightInv)
+// ScalarBaseMult returns k times the generator of g. It panics if k is a multiple of the order of the group.
+func ScalarBaseMult(g group.AffineGroup, k *big.Int) *ECPoint {
+	return mustECPoint(g.NewPoint().ScalarBaseMult(g.NewScalar().SetBigInt(k)))
 }
 
-func ScalarBaseMult(curve elliptic.Curve, k *big.Int) *ECPoint {
-	x, y := curve.ScalarBaseMult(k.Bytes())
-	p, err := NewECPoint(curve, x, y) // it must be on the curve, no need to check.
+func mustECPoint(point group.Point) *ECPoint {
+	p, err := NewECPointFromGroupPoint(point)
 	if err != nil {
 		panic(fmt.Errorf("scalar mult to an ecpoint %s", err.Error()))
 	}
 	return p
 }
 
-func isOnCurve(c elliptic.Curve, x, y *big.Int) bool {
-	if x == nil || y == nil {
-		return false
-	}
-	return c.IsOnCurve(x, y)
-}
-
 // ----- //
 
 func FlattenECPoints(in []*ECPoint) ([]*big.Int, error) {
@@ -137,20 +139,16 @@ func FlattenECPoints(in []*ECPoint) ([]*big.Int, error) {
 	return flat, nil
 }
 
-func UnFlattenECPoints(curve elliptic.Curve, in []*big.Int, noCurveCheck ...bool) ([]*ECPoint, error) {
+func UnFlattenECPoints(g group.AffineGroup, in []*big.Int) ([]*ECPoint, error) {
 	if in == nil || len(in)%2 != 0 {
 		return nil, errors.New("UnFlattenECPoints expected an in len divisible by 2")
 	}
 	var err error
 	unFlat := make([]*ECPoint, len(in)/2)
 	for i, j := 0, 0; i < len(in); i, j = i+2, j+1 {
-		if len(noCurveCheck) == 0 || !noCurveCheck[0] {
-			unFlat[j], err = NewECPoint(curve, in[i], in[i+1])
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			unFlat[j] = NewECPointNoCurveCheck(curve, in[i], in[i+1])
+		unFlat[j], err = NewECPoint(g, in[i], in[i+1])
+		if err != nil {
+			return nil, err
 		}
 	}
 	for _, point := range unFlat {
@@ -217,11 +215,15 @@ func (p *ECPoint) GobDecode(buf []byte) error {
 	if err := Y.GobDecode(y); err != nil {
 		return err
 	}
-	p.curve = tss.EC()
-	p.coords = [2]*big.Int{X, Y}
-	if !p.IsOnCurve() {
-		return errors.New("ECPoint.UnmarshalJSON: the point is not on the elliptic curve")
+	g, ok := group.FromCurve(tss.EC())
+	if !ok {
+		return fmt.Errorf("ECPoint.GobDecode: no group for the curve %s", tss.EC().Params().Name)
+	}
+	newP, err := NewECPoint(g, X, Y)
+	if err != nil {
+		return fmt.Errorf("ECPoint.GobDecode: %v", err)
 	}
+	*p = *newP
 	return nil
 }
 
@@ -229,9 +231,9 @@ func (p *ECPoint) GobDecode(buf []byte) error {
 
 // crypto.ECPoint is not inherently json marshal-able
 func (p *ECPoint) MarshalJSON() ([]byte, error) {
-	ecName, ok := tss.GetCurveName(p.curve)
+	ecName, ok := tss.GetGroupName(p.group)
 	if !ok {
-		return nil, fmt.Errorf("cannot find %T name in curve registry, please call tss.RegisterCurve(name, curve) to register it first", p.curve)
+		return nil, fmt.Errorf("cannot find %T name in curve registry, please call tss.RegisterCurve(name, curve) to register it first", p.group.Curve())
 	}
 
 	return json.Marshal(&struct {
@@ -251,22 +253,22 @@ func (p *ECPoint) UnmarshalJSON(payload []byte) error {
 	if err := json.Unmarshal(payload, &aux); err != nil {
 		return err
 	}
-	p.coords = [2]*big.Int{aux.Coords[0], aux.Coords[1]}
-
+	curve := tss.EC() // forward compatible, use global ec as default value
 	if len(aux.Curve) > 0 {
 		ec, ok := tss.GetCurveByName(tss.CurveName(aux.Curve))
 		if !ok {
 			return fmt.Errorf("cannot find curve named with %s in curve registry, please call tss.RegisterCurve(name, curve) to register it first", aux.Curve)
 		}
-		p.curve = ec
-	} else {
-		// forward compatible, use global ec as default value
-		p.curve = tss.EC()
+		curve = ec
 	}
-
-	if !p.IsOnCurve() {
-		return fmt.Errorf("ECPoint.UnmarshalJSON: the point is not on the elliptic curve (%T) ", p.curve)
+	g, ok := group.FromCurve(curve)
+	if !ok {
+		return fmt.Errorf("ECPoint.UnmarshalJSON: no group for the curve %s", curve.Params().Name)
 	}
-
+	newP, err := NewECPoint(g, aux.Coords[0], aux.Coords[1])
+	if err != nil {
+		return fmt.Errorf("ECPoint.UnmarshalJSON: %v", err)
+	}
+	*p = *newP
 	return nil
 }
@@ -18,10 +18,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	. "github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func TestFlattenECPoints(t *testing.T) {
+	p1, p2 := ScalarBaseMult(group.Secp256k1(), big.NewInt(1)), ScalarBaseMult(group.Secp256k1(), big.NewInt(2))
 	type args struct {
 		in []*ECPoint
 	}
@@ -32,26 +34,16 @@ func TestFlattenECPoints(t *testing.T) {
 		wantErr bool
 	}{{
 		name: "flatten with 2 points (happy)",
-		args: args{[]*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(3), big.NewInt(4)),
-		}},
-		want: []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)},
+		args: args{[]*ECPoint{p1, p2}},
+		want: []*big.Int{p1.X(), p1.Y(), p2.X(), p2.Y()},
 	}, {
-		name: "flatten with nil point (expects err)",
-		args: args{[]*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
-			nil,
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(3), big.NewInt(4))},
-		},
+		name:    "flatten with nil point (expects err)",
+		args:    args{[]*ECPoint{p1, nil, p2}},
 		want:    nil,
 		wantErr: true,
 	}, {
-		name: "flatten with nil coordinate (expects err)",
-		args: args{[]*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
-			NewECPointNoCurveCheck(tss.EC(), nil, big.NewInt(4))},
-		},
+		name:    "flatten with nil coordinate (expects err)",
+		args:    args{[]*ECPoint{p1, new(ECPoint)}},
 		want:    nil,
 		wantErr: true,
 	}, {
@@ -75,6 +67,7 @@ func TestFlattenECPoints(t *testing.T) {
 }
 
 func TestUnFlattenECPoints(t *testing.T) {
+	p1, p2 := ScalarBaseMult(group.Secp256k1(), big.NewInt(1)), ScalarBaseMult(group.Secp256k1(), big.NewInt(2))
 	type args struct {
 		in []*big.Int
 	}
@@ -85,11 +78,13 @@ func TestUnFlattenECPoints(t *testing.T) {
 		wantErr bool
 	}{{
 		name: "un-flatten 2 points (happy)",
-		args: args{[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4)}},
-		want: []*ECPoint{
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(1), big.NewInt(2)),
-			NewECPointNoCurveCheck(tss.EC(), big.NewInt(3), big.NewInt(4)),
-		},
+		args: args{[]*big.Int{p1.X(), p1.Y(), p2.X(), p2.Y()}},
+		want: []*ECPoint{p1, p2},
+	}, {
+		name:    "un-flatten a point that is not on the curve (expects err)",
+		args:    args{[]*big.Int{p1.X(), p1.Y(), big.NewInt(3), big.NewInt(4)}},
+		want:    nil,
+		wantErr: true,
 	}, {
 		name:    "un-flatten uneven len(points) (expects err)",
 		args:    args{[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3)}},
@@ -97,7 +92,7 @@ func TestUnFlattenECPoints(t *testing.T) {
 		wantErr: true,
 	}, {
 		name:    "un-flatten with nil coordinate (expects err)",
-		args:    args{[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(3), nil}},
+		args:    args{[]*big.Int{p1.X(), p1.Y(), p2.X(), nil}},
 		want:    nil,
 		wantErr: true,
 	}, {
@@ -108,13 +103,19 @@ func TestUnFlattenECPoints(t *testing.T) {
 	}}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := UnFlattenECPoints(tss.EC(), tt.args.in, true)
+			got, err := UnFlattenECPoints(group.Secp256k1(), tt.args.in)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UnFlattenECPoints() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
-			if !reflect.DeepEqual(got, tt.want) {
+			if len(got) != len(tt.want) {
 				t.Errorf("UnFlattenECPoints() = %v, want %v", got, tt.want)
+				return
+			}
+			for i := range got {
+				if !got[i].Equals(tt.want[i]) {
+					t.Errorf("UnFlattenECPoints() = %v, want %v", got, tt.want)
+				}
 			}
 		})
 	}
@@ -129,7 +130,7 @@ func TestS256EcpointJsonSerialization(t *testing.T) {
 	pbk, err := btcec.ParsePubKey(pubKeyBytes)
 	assert.NoError(t, err)
 
-	point, err := NewECPoint(ec, pbk.X(), pbk.Y())
+	point, err := NewECPoint(group.Secp256k1(), pbk.X(), pbk.Y())
 	assert.NoError(t, err)
 	bz, err := json.Marshal(point)
 	assert.NoError(t, err)
@@ -140,7 +141,7 @@ func TestS256EcpointJsonSerialization(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.True(t, point.Equals(&umpoint))
-	assert.True(t, reflect.TypeOf(point.Curve()) == reflect.TypeOf(umpoint.Curve()))
+	assert.Equal(t, point.Group(), umpoint.Group())
 }
 
 func TestEdwardsEcpointJsonSerialization(t *testing.T) {
@@ -152,7 +153,7 @@ func TestEdwardsEcpointJsonSerialization(t *testing.T) {
 	pbk, err := edwards.ParsePubKey(pubKeyBytes)
 	assert.NoError(t, err)
 
-	point, err := NewECPoint(ec, pbk.X, pbk.Y)
+	point, err := NewECPoint(group.Ed25519(), pbk.X, pbk.Y)
 	assert.NoError(t, err)
 	bz, err := json.Marshal(point)
 	assert.NoError(t, err)
@@ -163,5 +164,30 @@ func TestEdwardsEcpointJsonSerialization(t *testing.T) {
 	assert.NoError(t, err)
 
 	assert.True(t, point.Equals(&umpoint))
-	assert.True(t, reflect.TypeOf(point.Curve()) == reflect.TypeOf(umpoint.Curve()))
+	assert.Equal(t, point.Group(), umpoint.Group())
+}
+
+func TestECPointGroupPointRoundTrip(t *testing.T) {
+	for _, g := range []group.AffineGroup{group.Secp256k1(), group.P256(), group.Ed25519()} {
+		point := ScalarBaseMult(g, big.NewInt(12345))
+		gp := point.Point()
+		assert.True(t, gp.Equal(g.NewPoint().ScalarBaseMult(g.NewScalar().SetBigInt(big.NewInt(12345)))), g.Name())
+		back, err := NewECPointFromGroupPoint(gp)
+		assert.NoError(t, err)
+		assert.True(t, point.Equals(back))
+
+		_, err = NewECPointFromGroupPoint(g.NewPoint())
+		assert.Error(t, err, "the identity has no ECPoint")
+		_, err = point.Add(point.ScalarMult(new(big.Int).Sub(g.Order(), big.NewInt(1))))
+		assert.Error(t, err, "the sum is the identity")
+	}
+}
+
+func TestEdwardsECPointRejectsSmallOrderComponent(t *testing.T) {
+	// (0, -1) has order 2, so that P + (0, -1) is on the curve but not in the group of prime order
+	P := ScalarBaseMult(group.Ed25519(), big.NewInt(12345))
+	x, y := edwards.Edwards().Add(P.X(), P.Y(), big.NewInt(0), new(big.Int).Sub(edwards.Edwards().Params().P, big.NewInt(1)))
+	assert.True(t, edwards.Edwards().IsOnCurve(x, y))
+	_, err := NewECPoint(group.Ed25519(), x, y)
+	assert.Error(t, err)
 }
@@ -0,0 +1,163 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package encproof
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofEncBytesParts = 6
+)
+
+type (
+	// ProofEnc is a proof that the plaintext of a Paillier ciphertext K lies in the range ±2^l (CGGMP21 Fig. 14)
+	ProofEnc struct {
+		S, A, C, Z1, Z2, Z3 *big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements proofenc; k is the plaintext and rho the Paillier randomness used to produce K
+func NewProof(Session []byte, g group.AffineGroup, pk *paillier.PublicKey, K, NCap, s, t, k, rho *big.Int, rand io.Reader) (*ProofEnc, error) {
+	if g == nil || pk == nil || K == nil || NCap == nil || s == nil || t == nil || k == nil || rho == nil {
+		return nil, errors.New("ProveEnc constructor received nil value(s)")
+	}
+
+	q := g.Order()
+	l := q.BitLen()
+	twoL := new(big.Int).Lsh(one, uint(l))
+	twoLEps := new(big.Int).Lsh(one, uint(3*l))
+	twoLNCap := new(big.Int).Mul(twoL, NCap)
+	twoLEpsNCap := new(big.Int).Mul(twoLEps, NCap)
+
+	// Fig 14.1 sample
+	alpha := common.GetRandomSignedInt(rand, twoLEps)
+	mu := common.GetRandomSignedInt(rand, twoLNCap)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk.N)
+	gamma := common.GetRandomSignedInt(rand, twoLEpsNCap)
+
+	// Fig 14.1 compute
+	modNCap := common.ModInt(NCap)
+	S := modNCap.Mul(modNCap.Exp(s, k), modNCap.Exp(t, mu))
+	A, err := pk.EncryptWithRandomness(new(big.Int).Mod(alpha, pk.N), r)
+	if err != nil {
+		return nil, err
+	}
+	C := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+
+	// Fig 14.2 e
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), K, NCap, s, t, S, A, C)...)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 14.3
+	z1 := new(big.Int).Mul(e, k)
+	z1 = new(big.Int).Add(z1, alpha)
+
+	modN := common.ModInt(pk.N)
+	z2 := modN.Mul(r, modN.Exp(rho, e))
+
+	z3 := new(big.Int).Mul(e, mu)
+	z3 = new(big.Int).Add(z3, gamma)
+
+	return &ProofEnc{S: S, A: A, C: C, Z1: z1, Z2: z2, Z3: z3}, nil
+}
+
+func NewProofFromBytes(bzs [][]byte) (*ProofEnc, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofEncBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofEnc", ProofEncBytesParts)
+	}
+	return &ProofEnc{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		A:  new(big.Int).SetBytes(bzs[1]),
+		C:  new(big.Int).SetBytes(bzs[2]),
+		Z1: common.SignedBytesToBigInt(bzs[3]),
+		Z2: new(big.Int).SetBytes(bzs[4]),
+		Z3: common.SignedBytesToBigInt(bzs[5]),
+	}, nil
+}
+
+func (pf *ProofEnc) Verify(Session []byte, g group.AffineGroup, pk *paillier.PublicKey, NCap, s, t, K *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || g == nil || pk == nil || NCap == nil || s == nil || t == nil || K == nil {
+		return false
+	}
+
+	q := g.Order()
+	l := q.BitLen()
+	twoLEps := new(big.Int).Lsh(one, uint(3*l))
+
+	// Fig 14. Range Check
+	if !common.IsInSignedInterval(pf.Z1, twoLEps) {
+		return false
+	}
+	if !common.IsNumberInMultiplicativeGroup(pk.N, pf.Z2) {
+		return false
+	}
+
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), K, NCap, s, t, pf.S, pf.A, pf.C)...)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 14. Equality Check
+	{
+		modN2 := common.ModInt(pk.NSquare())
+		LHS, err := pk.EncryptWithRandomness(new(big.Int).Mod(pf.Z1, pk.N), pf.Z2)
+		if err != nil {
+			return false
+		}
+		RHS := modN2.Mul(pf.A, modN2.Exp(K, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		modNCap := common.ModInt(NCap)
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.C, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (pf *ProofEnc) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.A != nil &&
+		pf.C != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil
+}
+
+func (pf *ProofEnc) Bytes() [ProofEncBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.A.Bytes(),
+		pf.C.Bytes(),
+		common.SignedBigIntToBytes(pf.Z1),
+		pf.Z2.Bytes(),
+		common.SignedBigIntToBytes(pf.Z3),
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package encproof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/encproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testSafePrimeBits = 1024
+)
+
+var Session = []byte("session")
+
+func TestEnc(test *testing.T) {
+	ec := group.Secp256k1()
+	q := ec.Order()
+
+	p, pq := common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)
+	pk := &paillier.PublicKey{N: new(big.Int).Mul(p, pq)}
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+
+	k := common.GetRandomPositiveInt(rand.Reader, q)
+	K, rho, err := pk.EncryptAndReturnRandomness(rand.Reader, k)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(Session, ec, pk, K, NCap, s, t, k, rho, rand.Reader)
+	assert.NoError(test, err)
+	ok := proof.Verify(Session, ec, pk, NCap, s, t, K)
+	assert.True(test, ok, "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(bzs[:])
+	assert.NoError(test, err)
+	ok = proof2.Verify(Session, ec, pk, NCap, s, t, K)
+	assert.True(test, ok, "proof must verify after a round trip through bytes")
+
+	ok = proof.Verify([]byte("another session"), ec, pk, NCap, s, t, K)
+	assert.False(test, ok, "proof must not verify in another session")
+
+	// a plaintext outside of the range must not be provable
+	kBig := new(big.Int).Lsh(q, 1024)
+	KBig, rhoBig, err := pk.EncryptAndReturnRandomness(rand.Reader, kBig)
+	assert.NoError(test, err)
+	proof, err = NewProof(Session, ec, pk, KBig, NCap, s, t, kBig, rhoBig, rand.Reader)
+	assert.NoError(test, err)
+	ok = proof.Verify(Session, ec, pk, NCap, s, t, KBig)
+	assert.False(test, ok, "proof must not verify for a plaintext out of range")
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/facproof"
-	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 )
 
 // Using a modulus length of 2048 is recommended in the GG18 spec
@@ -27,7 +27,7 @@ const (
 var Session = []byte("session")
 
 func TestFac(test *testing.T) {
-	ec := tss.EC()
+	ec := group.Secp256k1()
 
 	N0p := common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)
 	N0q := common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)
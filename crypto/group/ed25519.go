@@ -0,0 +1,254 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package group
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/agl/ed25519/edwards25519"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// The points of Ed25519 are encoded as in RFC 8032, and decoding rejects non-canonical encodings and the points
+// outside of the subgroup of prime order. The scalars are encoded in 32 bytes, little-endian. The multiplication of
+// a variable point by a scalar is not constant time.
+
+type (
+	ed25519Group struct{}
+
+	ed25519Point struct {
+		p edwards25519.ExtendedGroupElement
+	}
+)
+
+var theEd25519 = &ed25519Group{}
+
+// Ed25519 returns the group of prime order of the points of edwards25519
+func Ed25519() AffineGroup {
+	return theEd25519
+}
+
+func (g *ed25519Group) Name() string {
+	return "ed25519"
+}
+
+func (g *ed25519Group) Order() *big.Int {
+	return new(big.Int).Set(edwards.Edwards().Params().N)
+}
+
+func (g *ed25519Group) NewScalar() Scalar {
+	return newModScalar(g)
+}
+
+func (g *ed25519Group) NewPoint() Point {
+	p := new(ed25519Point)
+	p.p.Zero()
+	return p
+}
+
+func (g *ed25519Group) Generator() Point {
+	p := new(ed25519Point)
+	var one [32]byte
+	one[0] = 1
+	edwards25519.GeScalarMultBase(&p.p, &one)
+	return p
+}
+
+func (g *ed25519Group) HashToScalar(dst []byte, msgs ...[]byte) Scalar {
+	return hashToScalar(g, dst, msgs...)
+}
+
+func (g *ed25519Group) ScalarLen() int {
+	return 32
+}
+
+func (g *ed25519Group) PointLen() int {
+	return 32
+}
+
+func (g *ed25519Group) Curve() elliptic.Curve {
+	return edwards.Edwards()
+}
+
+func (g *ed25519Group) NewPointFromAffine(x, y *big.Int) (Point, error) {
+	if x == nil || y == nil || !edwards.Edwards().IsOnCurve(x, y) {
+		return nil, errors.New("group: the point is not on edwards25519")
+	}
+	var fx, fy edwards25519.FieldElement
+	edwards25519.FeFromBytes(&fx, bigIntToLittleEndian(x))
+	edwards25519.FeFromBytes(&fy, bigIntToLittleEndian(y))
+	p := new(ed25519Point)
+	edwards25519.FeCopy(&p.p.X, &fx)
+	edwards25519.FeCopy(&p.p.Y, &fy)
+	edwards25519.FeOne(&p.p.Z)
+	edwards25519.FeMul(&p.p.T, &fx, &fy)
+	if !inPrimeOrderSubgroup(&p.p) {
+		return nil, errors.New("group: the point is not in the subgroup of prime order")
+	}
+	return p, nil
+}
+
+func (g *ed25519Group) Affine(p Point) (x, y *big.Int) {
+	q := castEd25519Point(p)
+	if q.IsIdentity() {
+		return nil, nil
+	}
+	var zInv, fx, fy edwards25519.FieldElement
+	edwards25519.FeInvert(&zInv, &q.p.Z)
+	edwards25519.FeMul(&fx, &q.p.X, &zInv)
+	edwards25519.FeMul(&fy, &q.p.Y, &zInv)
+	return feToBigInt(&fx), feToBigInt(&fy)
+}
+
+// ----- //
+
+func castEd25519Point(a Point) *ed25519Point {
+	p, ok := a.(*ed25519Point)
+	if !ok {
+		mismatch()
+	}
+	return p
+}
+
+func (p *ed25519Point) Group() Group {
+	return theEd25519
+}
+
+func (p *ed25519Point) Set(a Point) Point {
+	p.p = castEd25519Point(a).p
+	return p
+}
+
+func (p *ed25519Point) Add(a, b Point) Point {
+	geAdd(&p.p, &castEd25519Point(a).p, &castEd25519Point(b).p)
+	return p
+}
+
+func (p *ed25519Point) Sub(a, b Point) Point {
+	var negB edwards25519.ExtendedGroupElement
+	geNeg(&negB, &castEd25519Point(b).p)
+	geAdd(&p.p, &castEd25519Point(a).p, &negB)
+	return p
+}
+
+func (p *ed25519Point) Negate(a Point) Point {
+	geNeg(&p.p, &castEd25519Point(a).p)
+	return p
+}
+
+func (p *ed25519Point) ScalarMult(s Scalar, a Point) Point {
+	geScalarMult(&p.p, edwardsScalarBytes(theEd25519, s), &castEd25519Point(a).p)
+	return p
+}
+
+func (p *ed25519Point) ScalarBaseMult(s Scalar) Point {
+	edwards25519.GeScalarMultBase(&p.p, edwardsScalarBytes(theEd25519, s))
+	return p
+}
+
+func (p *ed25519Point) Equal(b Point) bool {
+	var e1, e2 [32]byte
+	p.p.ToBytes(&e1)
+	castEd25519Point(b).p.ToBytes(&e2)
+	return e1 == e2
+}
+
+func (p *ed25519Point) IsIdentity() bool {
+	var identity ed25519Point
+	identity.p.Zero()
+	return p.Equal(&identity)
+}
+
+func (p *ed25519Point) MarshalBinary() ([]byte, error) {
+	var bz [32]byte
+	p.p.ToBytes(&bz)
+	return bz[:], nil
+}
+
+func (p *ed25519Point) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return errors.New("group: invalid point length")
+	}
+	var bz, check [32]byte
+	copy(bz[:], data)
+	var q edwards25519.ExtendedGroupElement
+	if !q.FromBytes(&bz) {
+		return errors.New("group: invalid point encoding")
+	}
+	if q.ToBytes(&check); check != bz {
+		return errors.New("group: non-canonical point encoding")
+	}
+	if !inPrimeOrderSubgroup(&q) {
+		return errors.New("group: the point is not in the subgroup of prime order")
+	}
+	p.p = q
+	return nil
+}
+
+// ----- //
+// the arithmetic of the extended points, shared with ristretto255
+
+func geAdd(r, a, b *edwards25519.ExtendedGroupElement) {
+	var bCached edwards25519.CachedGroupElement
+	var sum edwards25519.CompletedGroupElement
+	b.ToCached(&bCached)
+	edwards25519.GeAdd(&sum, a, &bCached)
+	sum.ToExtended(r)
+}
+
+func geNeg(r, a *edwards25519.ExtendedGroupElement) {
+	edwards25519.FeNeg(&r.X, &a.X)
+	edwards25519.FeCopy(&r.Y, &a.Y)
+	edwards25519.FeCopy(&r.Z, &a.Z)
+	edwards25519.FeNeg(&r.T, &a.T)
+}
+
+// geScalarMult sets r to k * a, in variable time
+func geScalarMult(r *edwards25519.ExtendedGroupElement, k *[32]byte, a *edwards25519.ExtendedGroupElement) {
+	var zero [32]byte
+	var proj edwards25519.ProjectiveGroupElement
+	edwards25519.GeDoubleScalarMultVartime(&proj, k, a, &zero)
+	// (X : Y : Z) is (XZ : YZ : Z^2 : XY) in extended coordinates
+	edwards25519.FeMul(&r.X, &proj.X, &proj.Z)
+	edwards25519.FeMul(&r.Y, &proj.Y, &proj.Z)
+	edwards25519.FeSquare(&r.Z, &proj.Z)
+	edwards25519.FeMul(&r.T, &proj.X, &proj.Y)
+}
+
+// inPrimeOrderSubgroup returns true if l * a is the identity, where l is the order of the subgroup
+func inPrimeOrderSubgroup(a *edwards25519.ExtendedGroupElement) bool {
+	var la edwards25519.ExtendedGroupElement
+	geScalarMult(&la, bigIntToLittleEndian(edwards.Edwards().Params().N), a)
+	// the identity is (0 : Z : Z); (0 : -Z : Z) is the point of order 2
+	var yMinusZ edwards25519.FieldElement
+	edwards25519.FeSub(&yMinusZ, &la.Y, &la.Z)
+	return edwards25519.FeIsNonZero(&la.X) == 0 && edwards25519.FeIsNonZero(&yMinusZ) == 0
+}
+
+func edwardsScalarBytes(g Group, s Scalar) *[32]byte {
+	sc, ok := s.(*modScalar)
+	if !ok || sc.g != g {
+		mismatch()
+	}
+	return bigIntToLittleEndian(sc.v)
+}
+
+func bigIntToLittleEndian(a *big.Int) *[32]byte {
+	var bz [32]byte
+	a.FillBytes(bz[:])
+	reverse(bz[:])
+	return &bz
+}
+
+func feToBigInt(f *edwards25519.FieldElement) *big.Int {
+	var bz [32]byte
+	edwards25519.FeToBytes(&bz, f)
+	reverse(bz[:])
+	return new(big.Int).SetBytes(bz[:])
+}
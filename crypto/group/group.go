@@ -0,0 +1,118 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package group abstracts the prime-order groups the protocols of tss-lib run in. A Group is the source of its
+// Points and Scalars; the arithmetic methods set and return their receiver, and panic when given elements of
+// another group. The encodings returned by MarshalBinary are canonical and have a fixed length, and
+// UnmarshalBinary rejects anything else, including points outside of the prime-order group.
+package group
+
+import (
+	"crypto/elliptic"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+type (
+	Group interface {
+		// Name identifies the group, e.g. "secp256k1"
+		Name() string
+		// Order is the prime order of the group
+		Order() *big.Int
+		// NewScalar returns the scalar zero
+		NewScalar() Scalar
+		// NewPoint returns the identity
+		NewPoint() Point
+		// Generator returns the standard generator of the group
+		Generator() Point
+		// HashToScalar hashes msgs to a scalar that is close to uniform, separating the domains of the callers by dst
+		HashToScalar(dst []byte, msgs ...[]byte) Scalar
+		// ScalarLen and PointLen are the lengths of the encodings of the scalars and the points
+		ScalarLen() int
+		PointLen() int
+	}
+
+	Scalar interface {
+		Group() Group
+		Set(a Scalar) Scalar
+		// SetBigInt sets the scalar to a reduced modulo the order of the group
+		SetBigInt(a *big.Int) Scalar
+		BigInt() *big.Int
+		Add(a, b Scalar) Scalar
+		Sub(a, b Scalar) Scalar
+		Mul(a, b Scalar) Scalar
+		Negate(a Scalar) Scalar
+		// Invert sets the scalar to the inverse of a, or to zero if a is zero
+		Invert(a Scalar) Scalar
+		Equal(b Scalar) bool
+		IsZero() bool
+		MarshalBinary() ([]byte, error)
+		UnmarshalBinary(data []byte) error
+	}
+
+	Point interface {
+		Group() Group
+		Set(a Point) Point
+		Add(a, b Point) Point
+		Sub(a, b Point) Point
+		Negate(a Point) Point
+		ScalarMult(s Scalar, p Point) Point
+		ScalarBaseMult(s Scalar) Point
+		Equal(b Point) bool
+		IsIdentity() bool
+		MarshalBinary() ([]byte, error)
+		UnmarshalBinary(data []byte) error
+	}
+
+	// AffineGroup is implemented by the groups of the points of an elliptic.Curve, which are represented elsewhere
+	// in tss-lib by their affine coordinates
+	AffineGroup interface {
+		Group
+		Curve() elliptic.Curve
+		// NewPointFromAffine returns the point (x, y), or an error if it is not on the curve or not in the group
+		NewPointFromAffine(x, y *big.Int) (Point, error)
+		// Affine returns the affine coordinates of p, or nil for the identity
+		Affine(p Point) (x, y *big.Int)
+	}
+)
+
+// FromCurve returns the group of the points of curve, if it is one of the curves of this package
+func FromCurve(curve elliptic.Curve) (AffineGroup, bool) {
+	switch curve.(type) {
+	case *btcec.KoblitzCurve:
+		return Secp256k1(), true
+	case *edwards.TwistedEdwardsCurve:
+		return Ed25519(), true
+	}
+	if curve.Params().Name == elliptic.P256().Params().Name {
+		return P256(), true
+	}
+	return nil, false
+}
+
+// hashToScalar reduces 64 bytes of SHA-512 output modulo the order of g, which is at most 2^256, so that the
+// bias of the result is negligible. dst and each of the msgs are prefixed by their length.
+func hashToScalar(g Group, dst []byte, msgs ...[]byte) Scalar {
+	h := sha512.New()
+	var lenBz [8]byte
+	binary.BigEndian.PutUint64(lenBz[:], uint64(len(dst)))
+	h.Write(lenBz[:])
+	h.Write(dst)
+	for _, msg := range msgs {
+		binary.BigEndian.PutUint64(lenBz[:], uint64(len(msg)))
+		h.Write(lenBz[:])
+		h.Write(msg)
+	}
+	return g.NewScalar().SetBigInt(new(big.Int).SetBytes(h.Sum(nil)))
+}
+
+func mismatch() {
+	panic("group: elements of different groups")
+}
@@ -0,0 +1,184 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package group_test
+
+import (
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+var groups = []Group{Secp256k1(), P256(), Ed25519(), Ristretto255()}
+
+func randomScalar(g Group) Scalar {
+	return g.NewScalar().SetBigInt(common.GetRandomPositiveInt(rand.Reader, g.Order()))
+}
+
+func TestGroupArithmetic(t *testing.T) {
+	for _, g := range groups {
+		t.Run(g.Name(), func(t *testing.T) {
+			a, b := randomScalar(g), randomScalar(g)
+			sum := g.NewScalar().Add(a, b)
+			G := g.Generator()
+
+			// (a + b) * G == a * G + b * G
+			aG, bG := g.NewPoint().ScalarBaseMult(a), g.NewPoint().ScalarMult(b, G)
+			assert.True(t, g.NewPoint().ScalarBaseMult(sum).Equal(g.NewPoint().Add(aG, bG)))
+			assert.True(t, g.NewPoint().Sub(g.NewPoint().Add(aG, bG), bG).Equal(aG))
+
+			// a * (b * G) == (a * b) * G
+			assert.True(t, g.NewPoint().ScalarMult(a, bG).Equal(g.NewPoint().ScalarBaseMult(g.NewScalar().Mul(a, b))))
+
+			// P - P is the identity, and the identity is neutral
+			assert.True(t, g.NewPoint().Add(aG, g.NewPoint().Negate(aG)).IsIdentity())
+			assert.True(t, g.NewPoint().Add(aG, g.NewPoint()).Equal(aG))
+			assert.False(t, aG.IsIdentity())
+			assert.True(t, g.NewPoint().ScalarBaseMult(g.NewScalar()).IsIdentity())
+
+			// scalars
+			assert.True(t, g.NewScalar().Sub(sum, b).Equal(a))
+			assert.True(t, g.NewScalar().Add(a, g.NewScalar().Negate(a)).IsZero())
+			one := g.NewScalar().SetBigInt(big.NewInt(1))
+			assert.True(t, g.NewScalar().Mul(a, g.NewScalar().Invert(a)).Equal(one))
+			assert.True(t, g.NewScalar().Invert(g.NewScalar()).IsZero())
+			assert.Equal(t, new(big.Int).Mod(new(big.Int).Add(a.BigInt(), b.BigInt()), g.Order()), sum.BigInt())
+		})
+	}
+}
+
+func TestGroupEncoding(t *testing.T) {
+	for _, g := range groups {
+		t.Run(g.Name(), func(t *testing.T) {
+			a := randomScalar(g)
+			bz, err := a.MarshalBinary()
+			assert.NoError(t, err)
+			assert.Len(t, bz, g.ScalarLen())
+			a2 := g.NewScalar()
+			assert.NoError(t, a2.UnmarshalBinary(bz))
+			assert.True(t, a.Equal(a2))
+
+			// the order itself is not a canonical scalar
+			order := g.NewScalar()
+			orderBz := g.Order().FillBytes(make([]byte, g.ScalarLen()))
+			if g == Ed25519() || g == Ristretto255() {
+				for i, j := 0, len(orderBz)-1; i < j; i, j = i+1, j-1 {
+					orderBz[i], orderBz[j] = orderBz[j], orderBz[i]
+				}
+			}
+			assert.Error(t, order.UnmarshalBinary(orderBz))
+			assert.Error(t, order.UnmarshalBinary(bz[1:]))
+
+			for _, P := range []Point{g.NewPoint().ScalarBaseMult(a), g.NewPoint()} {
+				bz, err = P.MarshalBinary()
+				assert.NoError(t, err)
+				assert.Len(t, bz, g.PointLen())
+				P2 := g.NewPoint()
+				assert.NoError(t, P2.UnmarshalBinary(bz))
+				assert.True(t, P.Equal(P2))
+			}
+			assert.Error(t, g.NewPoint().UnmarshalBinary(bz[1:]))
+		})
+	}
+}
+
+func TestGroupHashToScalar(t *testing.T) {
+	for _, g := range groups {
+		t.Run(g.Name(), func(t *testing.T) {
+			s1 := g.HashToScalar([]byte("dst"), []byte("a"), []byte("b"))
+			assert.True(t, s1.Equal(g.HashToScalar([]byte("dst"), []byte("a"), []byte("b"))))
+			assert.False(t, s1.Equal(g.HashToScalar([]byte("dst"), []byte("ab"))))
+			assert.False(t, s1.Equal(g.HashToScalar([]byte("dst2"), []byte("a"), []byte("b"))))
+			assert.True(t, s1.BigInt().Cmp(g.Order()) < 0)
+		})
+	}
+}
+
+func TestGroupMismatchPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		Secp256k1().NewPoint().Add(Secp256k1().Generator(), P256().Generator())
+	})
+	assert.Panics(t, func() {
+		Ed25519().NewPoint().ScalarBaseMult(Ristretto255().NewScalar())
+	})
+}
+
+func TestFromCurve(t *testing.T) {
+	for _, curve := range []elliptic.Curve{btcec.S256(), elliptic.P256(), edwards.Edwards()} {
+		g, ok := FromCurve(curve)
+		if !assert.True(t, ok) {
+			continue
+		}
+		k := common.GetRandomPositiveInt(rand.Reader, curve.Params().N)
+		x, y := curve.ScalarBaseMult(k.Bytes())
+		P, err := g.NewPointFromAffine(x, y)
+		assert.NoError(t, err)
+		assert.True(t, P.Equal(g.NewPoint().ScalarBaseMult(g.NewScalar().SetBigInt(k))))
+		x2, y2 := g.Affine(P)
+		assert.Equal(t, 0, x.Cmp(x2))
+		assert.Equal(t, 0, y.Cmp(y2))
+
+		_, err = g.NewPointFromAffine(x, new(big.Int).Add(y, big.NewInt(1)))
+		assert.Error(t, err)
+	}
+	_, ok := FromCurve(elliptic.P384())
+	assert.False(t, ok)
+}
+
+func TestEd25519RejectsSmallOrderPoints(t *testing.T) {
+	// a point of order 8
+	bz, _ := hex.DecodeString("c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a")
+	assert.Error(t, Ed25519().NewPoint().UnmarshalBinary(bz))
+
+	// G + (0, -1) = (-x, -y) is on the curve but has a component of order 2
+	g := Ed25519()
+	x, y := g.Affine(g.Generator())
+	P := g.Curve().Params().P
+	_, err := g.NewPointFromAffine(new(big.Int).Sub(P, x), new(big.Int).Sub(P, y))
+	assert.Error(t, err)
+}
+
+// the multiples of the generator from RFC 9496, appendix A.1
+func TestRistretto255Vectors(t *testing.T) {
+	vectors := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76",
+		"6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919",
+		"94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259",
+		"da80862773358b466ffadfe0b3293ab3d9fd53c5ea6c955358f568322daf6a57",
+	}
+	g := Ristretto255()
+	for i, vector := range vectors {
+		P := g.NewPoint().ScalarBaseMult(g.NewScalar().SetBigInt(big.NewInt(int64(i))))
+		bz, err := P.MarshalBinary()
+		assert.NoError(t, err)
+		assert.Equal(t, vector, hex.EncodeToString(bz), "%d * B", i)
+
+		expected, _ := hex.DecodeString(vector)
+		P2 := g.NewPoint()
+		assert.NoError(t, P2.UnmarshalBinary(expected))
+		assert.True(t, P.Equal(P2))
+	}
+
+	// non-canonical and negative field elements from RFC 9496, appendix A.2
+	for _, bad := range []string{
+		"edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"26948d35ca62e643e26a83177332e6b6afeb9d08e4268b650f1f5bbd8d81d371",
+	} {
+		bz, _ := hex.DecodeString(bad)
+		assert.Error(t, g.NewPoint().UnmarshalBinary(bz), bad)
+	}
+}
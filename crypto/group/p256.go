@@ -0,0 +1,180 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package group
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+)
+
+// The points of P-256 are encoded in SEC 1 compressed form, and the identity as 33 zero bytes. The scalars are
+// encoded in 32 bytes, big-endian.
+
+const p256PointLen = 33
+
+type (
+	p256Group struct{}
+
+	// p256Point holds the affine coordinates of a point as crypto/elliptic does, with (0, 0) for the identity
+	p256Point struct {
+		x, y *big.Int
+	}
+)
+
+var theP256 = &p256Group{}
+
+// P256 returns the group of the points of NIST P-256
+func P256() AffineGroup {
+	return theP256
+}
+
+func (g *p256Group) Name() string {
+	return "P-256"
+}
+
+func (g *p256Group) Order() *big.Int {
+	return new(big.Int).Set(elliptic.P256().Params().N)
+}
+
+func (g *p256Group) NewScalar() Scalar {
+	return newModScalar(g)
+}
+
+func (g *p256Group) NewPoint() Point {
+	return &p256Point{x: new(big.Int), y: new(big.Int)}
+}
+
+func (g *p256Group) Generator() Point {
+	params := elliptic.P256().Params()
+	return &p256Point{x: new(big.Int).Set(params.Gx), y: new(big.Int).Set(params.Gy)}
+}
+
+func (g *p256Group) HashToScalar(dst []byte, msgs ...[]byte) Scalar {
+	return hashToScalar(g, dst, msgs...)
+}
+
+func (g *p256Group) ScalarLen() int {
+	return 32
+}
+
+func (g *p256Group) PointLen() int {
+	return p256PointLen
+}
+
+func (g *p256Group) Curve() elliptic.Curve {
+	return elliptic.P256()
+}
+
+func (g *p256Group) NewPointFromAffine(x, y *big.Int) (Point, error) {
+	if x == nil || y == nil || !elliptic.P256().IsOnCurve(x, y) {
+		return nil, errors.New("group: the point is not on P-256")
+	}
+	return &p256Point{x: new(big.Int).Set(x), y: new(big.Int).Set(y)}, nil
+}
+
+func (g *p256Group) Affine(p Point) (x, y *big.Int) {
+	q := castP256Point(p)
+	if q.IsIdentity() {
+		return nil, nil
+	}
+	return new(big.Int).Set(q.x), new(big.Int).Set(q.y)
+}
+
+// ----- //
+
+func castP256Point(a Point) *p256Point {
+	p, ok := a.(*p256Point)
+	if !ok {
+		mismatch()
+	}
+	return p
+}
+
+func p256ScalarBytes(s Scalar) []byte {
+	if sc, ok := s.(*modScalar); !ok || sc.g != theP256 {
+		mismatch()
+	}
+	bz, _ := s.MarshalBinary()
+	return bz
+}
+
+func (p *p256Point) Group() Group {
+	return theP256
+}
+
+func (p *p256Point) Set(a Point) Point {
+	q := castP256Point(a)
+	p.x, p.y = new(big.Int).Set(q.x), new(big.Int).Set(q.y)
+	return p
+}
+
+func (p *p256Point) Add(a, b Point) Point {
+	q, r := castP256Point(a), castP256Point(b)
+	p.x, p.y = elliptic.P256().Add(q.x, q.y, r.x, r.y)
+	return p
+}
+
+func (p *p256Point) Sub(a, b Point) Point {
+	var negB p256Point
+	negB.Negate(b)
+	return p.Add(a, &negB)
+}
+
+func (p *p256Point) Negate(a Point) Point {
+	q := castP256Point(a)
+	x := new(big.Int).Set(q.x)
+	y := new(big.Int)
+	if q.y.Sign() != 0 {
+		y.Sub(elliptic.P256().Params().P, q.y)
+	}
+	p.x, p.y = x, y
+	return p
+}
+
+func (p *p256Point) ScalarMult(s Scalar, a Point) Point {
+	q := castP256Point(a)
+	p.x, p.y = elliptic.P256().ScalarMult(q.x, q.y, p256ScalarBytes(s))
+	return p
+}
+
+func (p *p256Point) ScalarBaseMult(s Scalar) Point {
+	p.x, p.y = elliptic.P256().ScalarBaseMult(p256ScalarBytes(s))
+	return p
+}
+
+func (p *p256Point) Equal(b Point) bool {
+	q := castP256Point(b)
+	return p.x.Cmp(q.x) == 0 && p.y.Cmp(q.y) == 0
+}
+
+func (p *p256Point) IsIdentity() bool {
+	return p.x.Sign() == 0 && p.y.Sign() == 0
+}
+
+func (p *p256Point) MarshalBinary() ([]byte, error) {
+	if p.IsIdentity() {
+		return make([]byte, p256PointLen), nil
+	}
+	return elliptic.MarshalCompressed(elliptic.P256(), p.x, p.y), nil
+}
+
+func (p *p256Point) UnmarshalBinary(data []byte) error {
+	if len(data) != p256PointLen {
+		return errors.New("group: invalid point length")
+	}
+	if isZeros(data) {
+		p.x, p.y = new(big.Int), new(big.Int)
+		return nil
+	}
+	x, y := elliptic.UnmarshalCompressed(elliptic.P256(), data)
+	if x == nil {
+		return errors.New("group: invalid point encoding")
+	}
+	p.x, p.y = x, y
+	return nil
+}
@@ -0,0 +1,335 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package group
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/agl/ed25519/edwards25519"
+	"github.com/decred/dcrd/dcrec/edwards/v2"
+)
+
+// ristretto255 is the group of prime order built from edwards25519 in RFC 9496. Its points are represented by any
+// point of edwards25519 of their class, and they are encoded and decoded as in the RFC. The scalars are those of
+// Ed25519.
+
+type (
+	ristretto255Group struct{}
+
+	ristretto255Point struct {
+		p edwards25519.ExtendedGroupElement
+	}
+)
+
+var (
+	theRistretto255 = &ristretto255Group{}
+
+	feD, feSqrtM1, feInvSqrtAMinusD edwards25519.FieldElement
+)
+
+func init() {
+	for _, c := range []struct {
+		fe  *edwards25519.FieldElement
+		dec string
+	}{
+		{&feD, "37095705934669439343138083508754565189542113879843219016388785533085940283555"},
+		{&feSqrtM1, "19681161376707505956807079304988542015446066515923890162744021073123829784752"},
+		{&feInvSqrtAMinusD, "54469307008909316920995813868745141605393597292927456921205312896311721017578"},
+	} {
+		v, _ := new(big.Int).SetString(c.dec, 10)
+		edwards25519.FeFromBytes(c.fe, bigIntToLittleEndian(v))
+	}
+}
+
+// Ristretto255 returns the ristretto255 group
+func Ristretto255() Group {
+	return theRistretto255
+}
+
+func (g *ristretto255Group) Name() string {
+	return "ristretto255"
+}
+
+func (g *ristretto255Group) Order() *big.Int {
+	return new(big.Int).Set(edwards.Edwards().Params().N)
+}
+
+func (g *ristretto255Group) NewScalar() Scalar {
+	return newModScalar(g)
+}
+
+func (g *ristretto255Group) NewPoint() Point {
+	p := new(ristretto255Point)
+	p.p.Zero()
+	return p
+}
+
+func (g *ristretto255Group) Generator() Point {
+	p := new(ristretto255Point)
+	p.p = castEd25519Point(theEd25519.Generator()).p
+	return p
+}
+
+func (g *ristretto255Group) HashToScalar(dst []byte, msgs ...[]byte) Scalar {
+	return hashToScalar(g, dst, msgs...)
+}
+
+func (g *ristretto255Group) ScalarLen() int {
+	return 32
+}
+
+func (g *ristretto255Group) PointLen() int {
+	return 32
+}
+
+// ----- //
+
+func castRistretto255Point(a Point) *ristretto255Point {
+	p, ok := a.(*ristretto255Point)
+	if !ok {
+		mismatch()
+	}
+	return p
+}
+
+func (p *ristretto255Point) Group() Group {
+	return theRistretto255
+}
+
+func (p *ristretto255Point) Set(a Point) Point {
+	p.p = castRistretto255Point(a).p
+	return p
+}
+
+func (p *ristretto255Point) Add(a, b Point) Point {
+	geAdd(&p.p, &castRistretto255Point(a).p, &castRistretto255Point(b).p)
+	return p
+}
+
+func (p *ristretto255Point) Sub(a, b Point) Point {
+	var negB edwards25519.ExtendedGroupElement
+	geNeg(&negB, &castRistretto255Point(b).p)
+	geAdd(&p.p, &castRistretto255Point(a).p, &negB)
+	return p
+}
+
+func (p *ristretto255Point) Negate(a Point) Point {
+	geNeg(&p.p, &castRistretto255Point(a).p)
+	return p
+}
+
+func (p *ristretto255Point) ScalarMult(s Scalar, a Point) Point {
+	geScalarMult(&p.p, edwardsScalarBytes(theRistretto255, s), &castRistretto255Point(a).p)
+	return p
+}
+
+func (p *ristretto255Point) ScalarBaseMult(s Scalar) Point {
+	edwards25519.GeScalarMultBase(&p.p, edwardsScalarBytes(theRistretto255, s))
+	return p
+}
+
+// Equal compares the classes of the points: X1 * Y2 == Y1 * X2 or Y1 * Y2 == X1 * X2
+func (p *ristretto255Point) Equal(b Point) bool {
+	q := castRistretto255Point(b)
+	var x1y2, y1x2, y1y2, x1x2 edwards25519.FieldElement
+	edwards25519.FeMul(&x1y2, &p.p.X, &q.p.Y)
+	edwards25519.FeMul(&y1x2, &p.p.Y, &q.p.X)
+	edwards25519.FeMul(&y1y2, &p.p.Y, &q.p.Y)
+	edwards25519.FeMul(&x1x2, &p.p.X, &q.p.X)
+	return feEqual(&x1y2, &y1x2)|feEqual(&y1y2, &x1x2) == 1
+}
+
+func (p *ristretto255Point) IsIdentity() bool {
+	return p.Equal(theRistretto255.NewPoint())
+}
+
+func (p *ristretto255Point) MarshalBinary() ([]byte, error) {
+	var u1, u2, zMinusY, tmp edwards25519.FieldElement
+	var one edwards25519.FieldElement
+	edwards25519.FeOne(&one)
+	x0, y0, z0, t0 := &p.p.X, &p.p.Y, &p.p.Z, &p.p.T
+
+	// u1 = (Z0 + Y0) * (Z0 - Y0), u2 = X0 * Y0
+	edwards25519.FeAdd(&tmp, z0, y0)
+	edwards25519.FeSub(&zMinusY, z0, y0)
+	edwards25519.FeMul(&u1, &tmp, &zMinusY)
+	edwards25519.FeMul(&u2, x0, y0)
+
+	// invsqrt = SQRT_RATIO_M1(1, u1 * u2^2)
+	var invSqrt edwards25519.FieldElement
+	edwards25519.FeSquare(&tmp, &u2)
+	edwards25519.FeMul(&tmp, &u1, &tmp)
+	sqrtRatioM1(&invSqrt, &one, &tmp)
+
+	var den1, den2, zInv edwards25519.FieldElement
+	edwards25519.FeMul(&den1, &invSqrt, &u1)
+	edwards25519.FeMul(&den2, &invSqrt, &u2)
+	edwards25519.FeMul(&zInv, &den1, &den2)
+	edwards25519.FeMul(&zInv, &zInv, t0)
+
+	var ix0, iy0, enchantedDenominator edwards25519.FieldElement
+	edwards25519.FeMul(&ix0, x0, &feSqrtM1)
+	edwards25519.FeMul(&iy0, y0, &feSqrtM1)
+	edwards25519.FeMul(&enchantedDenominator, &den1, &feInvSqrtAMinusD)
+
+	edwards25519.FeMul(&tmp, t0, &zInv)
+	rotate := int32(edwards25519.FeIsNegative(&tmp))
+
+	var x, y, denInv edwards25519.FieldElement
+	edwards25519.FeCopy(&x, x0)
+	edwards25519.FeCopy(&y, y0)
+	edwards25519.FeCopy(&denInv, &den2)
+	edwards25519.FeCMove(&x, &iy0, rotate)
+	edwards25519.FeCMove(&y, &ix0, rotate)
+	edwards25519.FeCMove(&denInv, &enchantedDenominator, rotate)
+
+	edwards25519.FeMul(&tmp, &x, &zInv)
+	feCondNeg(&y, int32(edwards25519.FeIsNegative(&tmp)))
+
+	// s = |den_inv * (z - y)|
+	var s edwards25519.FieldElement
+	edwards25519.FeSub(&tmp, z0, &y)
+	edwards25519.FeMul(&s, &denInv, &tmp)
+	feAbs(&s)
+
+	var bz [32]byte
+	edwards25519.FeToBytes(&bz, &s)
+	return bz[:], nil
+}
+
+func (p *ristretto255Point) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return errors.New("group: invalid point length")
+	}
+	var bz, check [32]byte
+	copy(bz[:], data)
+	var s edwards25519.FieldElement
+	edwards25519.FeFromBytes(&s, &bz)
+	if edwards25519.FeToBytes(&check, &s); check != bz || edwards25519.FeIsNegative(&s) == 1 {
+		return errors.New("group: non-canonical point encoding")
+	}
+
+	var one, ss, u1, u2, u2Sqr, v, tmp edwards25519.FieldElement
+	edwards25519.FeOne(&one)
+	edwards25519.FeSquare(&ss, &s)
+	edwards25519.FeSub(&u1, &one, &ss)
+	edwards25519.FeAdd(&u2, &one, &ss)
+	edwards25519.FeSquare(&u2Sqr, &u2)
+
+	// v = -(D * u1^2) - u2_sqr
+	edwards25519.FeSquare(&tmp, &u1)
+	edwards25519.FeMul(&tmp, &feD, &tmp)
+	edwards25519.FeNeg(&tmp, &tmp)
+	edwards25519.FeSub(&v, &tmp, &u2Sqr)
+
+	var invSqrt edwards25519.FieldElement
+	edwards25519.FeMul(&tmp, &v, &u2Sqr)
+	wasSquare := sqrtRatioM1(&invSqrt, &one, &tmp)
+
+	var denX, denY edwards25519.FieldElement
+	edwards25519.FeMul(&denX, &invSqrt, &u2)
+	edwards25519.FeMul(&denY, &invSqrt, &denX)
+	edwards25519.FeMul(&denY, &denY, &v)
+
+	var q edwards25519.ExtendedGroupElement
+	edwards25519.FeAdd(&tmp, &s, &s)
+	edwards25519.FeMul(&q.X, &tmp, &denX)
+	feAbs(&q.X)
+	edwards25519.FeMul(&q.Y, &u1, &denY)
+	edwards25519.FeOne(&q.Z)
+	edwards25519.FeMul(&q.T, &q.X, &q.Y)
+
+	if wasSquare == 0 || edwards25519.FeIsNegative(&q.T) == 1 || edwards25519.FeIsNonZero(&q.Y) == 0 {
+		return errors.New("group: invalid point encoding")
+	}
+	p.p = q
+	return nil
+}
+
+// ----- //
+// the field arithmetic of RFC 9496 that edwards25519 does not export
+
+// sqrtRatioM1 sets r to the non-negative square root of u / v, or of SQRT_M1 * u / v if u / v is not a square.
+// It returns 1 if u / v is a square.
+func sqrtRatioM1(r, u, v *edwards25519.FieldElement) int32 {
+	var v3, v7, uv3, uv7, check, negU, negUi, rPrime edwards25519.FieldElement
+	edwards25519.FeSquare(&v3, v)
+	edwards25519.FeMul(&v3, &v3, v)
+	edwards25519.FeSquare(&v7, &v3)
+	edwards25519.FeMul(&v7, &v7, v)
+	edwards25519.FeMul(&uv3, u, &v3)
+	edwards25519.FeMul(&uv7, u, &v7)
+
+	// r = (u * v^3) * (u * v^7)^((p-5)/8)
+	fePow22523(r, &uv7)
+	edwards25519.FeMul(r, &uv3, r)
+
+	edwards25519.FeSquare(&check, r)
+	edwards25519.FeMul(&check, v, &check)
+
+	edwards25519.FeNeg(&negU, u)
+	edwards25519.FeMul(&negUi, &negU, &feSqrtM1)
+	correctSignSqrt := feEqual(&check, u)
+	flippedSignSqrt := feEqual(&check, &negU)
+	flippedSignSqrtI := feEqual(&check, &negUi)
+
+	edwards25519.FeMul(&rPrime, &feSqrtM1, r)
+	edwards25519.FeCMove(r, &rPrime, flippedSignSqrt|flippedSignSqrtI)
+	feAbs(r)
+	return correctSignSqrt | flippedSignSqrt
+}
+
+// fePow22523 sets out to z^((p-5)/8) = z^(2^252-3)
+func fePow22523(out, z *edwards25519.FieldElement) {
+	var t0, t1, t2 edwards25519.FieldElement
+	square := func(dst, src *edwards25519.FieldElement, n int) {
+		edwards25519.FeSquare(dst, src)
+		for i := 1; i < n; i++ {
+			edwards25519.FeSquare(dst, dst)
+		}
+	}
+	square(&t0, z, 1)
+	square(&t1, &t0, 2)
+	edwards25519.FeMul(&t1, z, &t1)
+	edwards25519.FeMul(&t0, &t0, &t1)
+	square(&t0, &t0, 1)
+	edwards25519.FeMul(&t0, &t1, &t0)
+	square(&t1, &t0, 5)
+	edwards25519.FeMul(&t0, &t1, &t0)
+	square(&t1, &t0, 10)
+	edwards25519.FeMul(&t1, &t1, &t0)
+	square(&t2, &t1, 20)
+	edwards25519.FeMul(&t1, &t2, &t1)
+	square(&t1, &t1, 10)
+	edwards25519.FeMul(&t0, &t1, &t0)
+	square(&t1, &t0, 50)
+	edwards25519.FeMul(&t1, &t1, &t0)
+	square(&t2, &t1, 100)
+	edwards25519.FeMul(&t1, &t2, &t1)
+	square(&t1, &t1, 50)
+	edwards25519.FeMul(&t0, &t1, &t0)
+	square(&t0, &t0, 2)
+	edwards25519.FeMul(out, &t0, z)
+}
+
+// feEqual returns 1 if a == b
+func feEqual(a, b *edwards25519.FieldElement) int32 {
+	var diff edwards25519.FieldElement
+	edwards25519.FeSub(&diff, a, b)
+	return 1 - edwards25519.FeIsNonZero(&diff)
+}
+
+func feCondNeg(f *edwards25519.FieldElement, b int32) {
+	var neg edwards25519.FieldElement
+	edwards25519.FeNeg(&neg, f)
+	edwards25519.FeCMove(f, &neg, b)
+}
+
+func feAbs(f *edwards25519.FieldElement) {
+	feCondNeg(f, int32(edwards25519.FeIsNegative(f)))
+}
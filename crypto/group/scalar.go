@@ -0,0 +1,132 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package group
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+)
+
+// modScalar is a scalar of the groups without a dedicated scalar type. It is encoded in 32 bytes, big-endian or,
+// for the Edwards groups, little-endian.
+type modScalar struct {
+	g Group
+	v *big.Int
+}
+
+func newModScalar(g Group) *modScalar {
+	return &modScalar{g: g, v: new(big.Int)}
+}
+
+func (s *modScalar) cast(a Scalar) *modScalar {
+	other, ok := a.(*modScalar)
+	if !ok || other.g != s.g {
+		mismatch()
+	}
+	return other
+}
+
+func (s *modScalar) littleEndian() bool {
+	switch s.g.(type) {
+	case *ed25519Group, *ristretto255Group:
+		return true
+	}
+	return false
+}
+
+func (s *modScalar) Group() Group {
+	return s.g
+}
+
+func (s *modScalar) Set(a Scalar) Scalar {
+	s.v.Set(s.cast(a).v)
+	return s
+}
+
+func (s *modScalar) SetBigInt(a *big.Int) Scalar {
+	s.v.Mod(a, s.g.Order())
+	return s
+}
+
+func (s *modScalar) BigInt() *big.Int {
+	return new(big.Int).Set(s.v)
+}
+
+func (s *modScalar) Add(a, b Scalar) Scalar {
+	mod := common.ModInt(s.g.Order())
+	s.v = mod.Add(s.cast(a).v, s.cast(b).v)
+	return s
+}
+
+func (s *modScalar) Sub(a, b Scalar) Scalar {
+	mod := common.ModInt(s.g.Order())
+	s.v = mod.Sub(s.cast(a).v, s.cast(b).v)
+	return s
+}
+
+func (s *modScalar) Mul(a, b Scalar) Scalar {
+	mod := common.ModInt(s.g.Order())
+	s.v = mod.Mul(s.cast(a).v, s.cast(b).v)
+	return s
+}
+
+func (s *modScalar) Negate(a Scalar) Scalar {
+	mod := common.ModInt(s.g.Order())
+	s.v = mod.Sub(big.NewInt(0), s.cast(a).v)
+	return s
+}
+
+func (s *modScalar) Invert(a Scalar) Scalar {
+	av := s.cast(a).v
+	if av.Sign() == 0 {
+		s.v = new(big.Int)
+		return s
+	}
+	mod := common.ModInt(s.g.Order())
+	s.v = mod.ModInverse(av)
+	return s
+}
+
+func (s *modScalar) Equal(b Scalar) bool {
+	return s.v.Cmp(s.cast(b).v) == 0
+}
+
+func (s *modScalar) IsZero() bool {
+	return s.v.Sign() == 0
+}
+
+func (s *modScalar) MarshalBinary() ([]byte, error) {
+	bz := s.v.FillBytes(make([]byte, s.g.ScalarLen()))
+	if s.littleEndian() {
+		reverse(bz)
+	}
+	return bz, nil
+}
+
+func (s *modScalar) UnmarshalBinary(data []byte) error {
+	if len(data) != s.g.ScalarLen() {
+		return errors.New("group: invalid scalar length")
+	}
+	bz := append([]byte{}, data...)
+	if s.littleEndian() {
+		reverse(bz)
+	}
+	v := new(big.Int).SetBytes(bz)
+	if v.Cmp(s.g.Order()) >= 0 {
+		return errors.New("group: non-canonical scalar")
+	}
+	s.v = v
+	return nil
+}
+
+func reverse(bz []byte) {
+	for i, j := 0, len(bz)-1; i < j; i, j = i+1, j-1 {
+		bz[i], bz[j] = bz[j], bz[i]
+	}
+}
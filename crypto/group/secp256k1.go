@@ -0,0 +1,290 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package group
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// The points of secp256k1 are encoded in SEC 1 compressed form, and the identity as 33 zero bytes. The scalars are
+// encoded in 32 bytes, big-endian. The point arithmetic of btcec is not constant time.
+
+const secp256k1PointLen = 33
+
+type (
+	secp256k1Group struct{}
+
+	secp256k1Scalar struct {
+		s btcec.ModNScalar
+	}
+
+	secp256k1Point struct {
+		p btcec.JacobianPoint
+	}
+)
+
+var theSecp256k1 = &secp256k1Group{}
+
+// Secp256k1 returns the group of the points of secp256k1
+func Secp256k1() AffineGroup {
+	return theSecp256k1
+}
+
+func (g *secp256k1Group) Name() string {
+	return "secp256k1"
+}
+
+func (g *secp256k1Group) Order() *big.Int {
+	return new(big.Int).Set(btcec.S256().Params().N)
+}
+
+func (g *secp256k1Group) NewScalar() Scalar {
+	return new(secp256k1Scalar)
+}
+
+func (g *secp256k1Group) NewPoint() Point {
+	return new(secp256k1Point)
+}
+
+func (g *secp256k1Group) Generator() Point {
+	p := new(secp256k1Point)
+	btcec.GeneratorJacobian(&p.p)
+	return p
+}
+
+func (g *secp256k1Group) HashToScalar(dst []byte, msgs ...[]byte) Scalar {
+	return hashToScalar(g, dst, msgs...)
+}
+
+func (g *secp256k1Group) ScalarLen() int {
+	return 32
+}
+
+func (g *secp256k1Group) PointLen() int {
+	return secp256k1PointLen
+}
+
+func (g *secp256k1Group) Curve() elliptic.Curve {
+	return btcec.S256()
+}
+
+func (g *secp256k1Group) NewPointFromAffine(x, y *big.Int) (Point, error) {
+	if x == nil || y == nil || !btcec.S256().IsOnCurve(x, y) {
+		return nil, errors.New("group: the point is not on secp256k1")
+	}
+	p := new(secp256k1Point)
+	p.p.X.SetByteSlice(x.Bytes())
+	p.p.Y.SetByteSlice(y.Bytes())
+	p.p.Z.SetInt(1)
+	return p, nil
+}
+
+func (g *secp256k1Group) Affine(p Point) (x, y *big.Int) {
+	q := castSecp256k1Point(p).p
+	if isInfinity(&q) {
+		return nil, nil
+	}
+	q.ToAffine()
+	x, y = new(big.Int).SetBytes(q.X.Bytes()[:]), new(big.Int).SetBytes(q.Y.Bytes()[:])
+	return
+}
+
+// ----- //
+
+func castSecp256k1Scalar(a Scalar) *secp256k1Scalar {
+	s, ok := a.(*secp256k1Scalar)
+	if !ok {
+		mismatch()
+	}
+	return s
+}
+
+func (s *secp256k1Scalar) Group() Group {
+	return theSecp256k1
+}
+
+func (s *secp256k1Scalar) Set(a Scalar) Scalar {
+	s.s.Set(&castSecp256k1Scalar(a).s)
+	return s
+}
+
+func (s *secp256k1Scalar) SetBigInt(a *big.Int) Scalar {
+	v := new(big.Int).Mod(a, btcec.S256().Params().N)
+	s.s.SetByteSlice(v.Bytes())
+	return s
+}
+
+func (s *secp256k1Scalar) BigInt() *big.Int {
+	bz := s.s.Bytes()
+	return new(big.Int).SetBytes(bz[:])
+}
+
+func (s *secp256k1Scalar) Add(a, b Scalar) Scalar {
+	s.s.Add2(&castSecp256k1Scalar(a).s, &castSecp256k1Scalar(b).s)
+	return s
+}
+
+func (s *secp256k1Scalar) Sub(a, b Scalar) Scalar {
+	var negB btcec.ModNScalar
+	negB.NegateVal(&castSecp256k1Scalar(b).s)
+	s.s.Add2(&castSecp256k1Scalar(a).s, &negB)
+	return s
+}
+
+func (s *secp256k1Scalar) Mul(a, b Scalar) Scalar {
+	s.s.Mul2(&castSecp256k1Scalar(a).s, &castSecp256k1Scalar(b).s)
+	return s
+}
+
+func (s *secp256k1Scalar) Negate(a Scalar) Scalar {
+	s.s.NegateVal(&castSecp256k1Scalar(a).s)
+	return s
+}
+
+func (s *secp256k1Scalar) Invert(a Scalar) Scalar {
+	s.s.InverseValNonConst(&castSecp256k1Scalar(a).s)
+	return s
+}
+
+func (s *secp256k1Scalar) Equal(b Scalar) bool {
+	return s.s.Equals(&castSecp256k1Scalar(b).s)
+}
+
+func (s *secp256k1Scalar) IsZero() bool {
+	return s.s.IsZero()
+}
+
+func (s *secp256k1Scalar) MarshalBinary() ([]byte, error) {
+	bz := s.s.Bytes()
+	return bz[:], nil
+}
+
+func (s *secp256k1Scalar) UnmarshalBinary(data []byte) error {
+	if len(data) != 32 {
+		return errors.New("group: invalid scalar length")
+	}
+	var v btcec.ModNScalar
+	if overflow := v.SetByteSlice(data); overflow {
+		return errors.New("group: non-canonical scalar")
+	}
+	s.s = v
+	return nil
+}
+
+// ----- //
+
+func castSecp256k1Point(a Point) *secp256k1Point {
+	p, ok := a.(*secp256k1Point)
+	if !ok {
+		mismatch()
+	}
+	return p
+}
+
+func (p *secp256k1Point) Group() Group {
+	return theSecp256k1
+}
+
+func (p *secp256k1Point) Set(a Point) Point {
+	p.p.Set(&castSecp256k1Point(a).p)
+	return p
+}
+
+func (p *secp256k1Point) Add(a, b Point) Point {
+	var r btcec.JacobianPoint
+	btcec.AddNonConst(&castSecp256k1Point(a).p, &castSecp256k1Point(b).p, &r)
+	p.p = r
+	return p
+}
+
+func (p *secp256k1Point) Sub(a, b Point) Point {
+	var negB secp256k1Point
+	negB.Negate(b)
+	return p.Add(a, &negB)
+}
+
+func (p *secp256k1Point) Negate(a Point) Point {
+	p.p.Set(&castSecp256k1Point(a).p)
+	p.p.Y.Normalize().Negate(1).Normalize()
+	return p
+}
+
+func (p *secp256k1Point) ScalarMult(s Scalar, a Point) Point {
+	var r btcec.JacobianPoint
+	btcec.ScalarMultNonConst(&castSecp256k1Scalar(s).s, &castSecp256k1Point(a).p, &r)
+	p.p = r
+	return p
+}
+
+func (p *secp256k1Point) ScalarBaseMult(s Scalar) Point {
+	var r btcec.JacobianPoint
+	btcec.ScalarBaseMultNonConst(&castSecp256k1Scalar(s).s, &r)
+	p.p = r
+	return p
+}
+
+func (p *secp256k1Point) Equal(b Point) bool {
+	x1, y1 := theSecp256k1.Affine(p)
+	x2, y2 := theSecp256k1.Affine(b)
+	if x1 == nil || x2 == nil {
+		return x1 == nil && x2 == nil
+	}
+	return x1.Cmp(x2) == 0 && y1.Cmp(y2) == 0
+}
+
+func (p *secp256k1Point) IsIdentity() bool {
+	q := p.p
+	return isInfinity(&q)
+}
+
+// isInfinity normalizes the coordinates of q, which btcec sets to zero for the point at infinity
+func isInfinity(q *btcec.JacobianPoint) bool {
+	q.X.Normalize()
+	q.Y.Normalize()
+	q.Z.Normalize()
+	return (q.X.IsZero() && q.Y.IsZero()) || q.Z.IsZero()
+}
+
+func (p *secp256k1Point) MarshalBinary() ([]byte, error) {
+	if p.IsIdentity() {
+		return make([]byte, secp256k1PointLen), nil
+	}
+	q := p.p
+	q.ToAffine()
+	return btcec.NewPublicKey(&q.X, &q.Y).SerializeCompressed(), nil
+}
+
+func (p *secp256k1Point) UnmarshalBinary(data []byte) error {
+	if len(data) != secp256k1PointLen {
+		return errors.New("group: invalid point length")
+	}
+	if isZeros(data) {
+		p.p = btcec.JacobianPoint{}
+		return nil
+	}
+	if data[0] != 0x02 && data[0] != 0x03 {
+		return errors.New("group: invalid point encoding")
+	}
+	pk, err := btcec.ParsePubKey(data)
+	if err != nil {
+		return err
+	}
+	pk.AsJacobian(&p.p)
+	return nil
+}
+
+func isZeros(bz []byte) bool {
+	var acc byte
+	for _, b := range bz {
+		acc |= b
+	}
+	return acc == 0
+}
@@ -0,0 +1,180 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package logstarproof
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+const (
+	ProofLogStarBytesParts = 8
+)
+
+type (
+	// ProofLogStar is a proof that the plaintext x of a Paillier ciphertext C lies in the range ±2^l and that X = x*G,
+	// "Knowledge of Exponent vs Paillier Encryption" (CGGMP21 Fig. 25)
+	ProofLogStar struct {
+		S, A          *big.Int
+		Y             *crypto.ECPoint
+		D, Z1, Z2, Z3 *big.Int
+	}
+)
+
+var (
+	one = big.NewInt(1)
+)
+
+// NewProof implements prooflogstar; G is the base point of the discrete log, x the plaintext and rho the Paillier randomness of C
+func NewProof(Session []byte, g group.AffineGroup, pk *paillier.PublicKey, C *big.Int, X, G *crypto.ECPoint, NCap, s, t, x, rho *big.Int, rand io.Reader) (*ProofLogStar, error) {
+	if g == nil || pk == nil || C == nil || X == nil || G == nil || NCap == nil || s == nil || t == nil || x == nil || rho == nil {
+		return nil, errors.New("ProveLogStar constructor received nil value(s)")
+	}
+
+	q := g.Order()
+	l := q.BitLen()
+	twoL := new(big.Int).Lsh(one, uint(l))
+	twoLEps := new(big.Int).Lsh(one, uint(3*l))
+	twoLNCap := new(big.Int).Mul(twoL, NCap)
+	twoLEpsNCap := new(big.Int).Mul(twoLEps, NCap)
+
+	// Fig 25.1 sample
+	alpha := common.GetRandomSignedInt(rand, twoLEps)
+	mu := common.GetRandomSignedInt(rand, twoLNCap)
+	r := common.GetRandomPositiveRelativelyPrimeInt(rand, pk.N)
+	gamma := common.GetRandomSignedInt(rand, twoLEpsNCap)
+
+	// Fig 25.1 compute
+	modNCap := common.ModInt(NCap)
+	S := modNCap.Mul(modNCap.Exp(s, x), modNCap.Exp(t, mu))
+	A, err := pk.EncryptWithRandomness(new(big.Int).Mod(alpha, pk.N), r)
+	if err != nil {
+		return nil, err
+	}
+	Y := G.ScalarMult(new(big.Int).Mod(alpha, q))
+	D := modNCap.Mul(modNCap.Exp(s, alpha), modNCap.Exp(t, gamma))
+
+	// Fig 25.2 e
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), C, X.X(), X.Y(), G.X(), G.Y(), NCap, s, t, S, A, Y.X(), Y.Y(), D)...)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 25.3
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, x))
+	modN := common.ModInt(pk.N)
+	z2 := modN.Mul(r, modN.Exp(rho, e))
+	z3 := new(big.Int).Add(gamma, new(big.Int).Mul(e, mu))
+
+	return &ProofLogStar{S: S, A: A, Y: Y, D: D, Z1: z1, Z2: z2, Z3: z3}, nil
+}
+
+func NewProofFromBytes(g group.AffineGroup, bzs [][]byte) (*ProofLogStar, error) {
+	if !common.NonEmptyMultiBytes(bzs, ProofLogStarBytesParts) {
+		return nil, fmt.Errorf("expected %d byte parts to construct ProofLogStar", ProofLogStarBytesParts)
+	}
+	Y, err := crypto.NewECPoint(g, new(big.Int).SetBytes(bzs[2]), new(big.Int).SetBytes(bzs[3]))
+	if err != nil {
+		return nil, err
+	}
+	return &ProofLogStar{
+		S:  new(big.Int).SetBytes(bzs[0]),
+		A:  new(big.Int).SetBytes(bzs[1]),
+		Y:  Y,
+		D:  new(big.Int).SetBytes(bzs[4]),
+		Z1: common.SignedBytesToBigInt(bzs[5]),
+		Z2: new(big.Int).SetBytes(bzs[6]),
+		Z3: common.SignedBytesToBigInt(bzs[7]),
+	}, nil
+}
+
+func (pf *ProofLogStar) Verify(Session []byte, g group.AffineGroup, pk *paillier.PublicKey, C *big.Int, X, G *crypto.ECPoint, NCap, s, t *big.Int) bool {
+	if pf == nil || !pf.ValidateBasic() || g == nil || pk == nil || C == nil || X == nil || G == nil || NCap == nil || s == nil || t == nil {
+		return false
+	}
+
+	q := g.Order()
+	l := q.BitLen()
+	twoLEps := new(big.Int).Lsh(one, uint(3*l))
+
+	// Fig 25. Range Check
+	if !common.IsInSignedInterval(pf.Z1, twoLEps) {
+		return false
+	}
+	if !common.IsNumberInMultiplicativeGroup(pk.N, pf.Z2) {
+		return false
+	}
+
+	var e *big.Int
+	{
+		eHash := common.SHA512_256i_TAGGED(Session, append(pk.AsInts(), C, X.X(), X.Y(), G.X(), G.Y(), NCap, s, t, pf.S, pf.A, pf.Y.X(), pf.Y.Y(), pf.D)...)
+		e = common.RejectionSample(q, eHash)
+	}
+
+	// Fig 25. Equality Check
+	{
+		modN2 := common.ModInt(pk.NSquare())
+		LHS, err := pk.EncryptWithRandomness(new(big.Int).Mod(pf.Z1, pk.N), pf.Z2)
+		if err != nil {
+			return false
+		}
+		RHS := modN2.Mul(pf.A, modN2.Exp(C, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	{
+		LHS := G.ScalarMult(new(big.Int).Mod(pf.Z1, q))
+		RHS, err := pf.Y.Add(X.ScalarMult(e))
+		if err != nil || !LHS.Equals(RHS) {
+			return false
+		}
+	}
+
+	{
+		modNCap := common.ModInt(NCap)
+		LHS := modNCap.Mul(modNCap.Exp(s, pf.Z1), modNCap.Exp(t, pf.Z3))
+		RHS := modNCap.Mul(pf.D, modNCap.Exp(pf.S, e))
+		if LHS.Cmp(RHS) != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (pf *ProofLogStar) ValidateBasic() bool {
+	return pf.S != nil &&
+		pf.A != nil &&
+		pf.Y != nil &&
+		pf.D != nil &&
+		pf.Z1 != nil &&
+		pf.Z2 != nil &&
+		pf.Z3 != nil
+}
+
+func (pf *ProofLogStar) Bytes() [ProofLogStarBytesParts][]byte {
+	return [...][]byte{
+		pf.S.Bytes(),
+		pf.A.Bytes(),
+		pf.Y.X().Bytes(),
+		pf.Y.Y().Bytes(),
+		pf.D.Bytes(),
+		common.SignedBigIntToBytes(pf.Z1),
+		pf.Z2.Bytes(),
+		common.SignedBigIntToBytes(pf.Z3),
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package logstarproof_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	. "github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+)
+
+// Using a modulus length of 2048 is recommended in the GG18 spec
+const (
+	testSafePrimeBits = 1024
+)
+
+var Session = []byte("session")
+
+func TestLogStar(test *testing.T) {
+	ec := group.Secp256k1()
+	q := ec.Order()
+
+	pk := &paillier.PublicKey{N: new(big.Int).Mul(
+		common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits))}
+
+	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
+	NCap, s, t, err := crypto.GenerateNTildei(rand.Reader, primes)
+	assert.NoError(test, err)
+
+	// use a base point other than the generator
+	G := crypto.ScalarBaseMult(ec, common.GetRandomPositiveInt(rand.Reader, q))
+	x := common.GetRandomPositiveInt(rand.Reader, q)
+	X := G.ScalarMult(x)
+	C, rho, err := pk.EncryptAndReturnRandomness(rand.Reader, x)
+	assert.NoError(test, err)
+
+	proof, err := NewProof(Session, ec, pk, C, X, G, NCap, s, t, x, rho, rand.Reader)
+	assert.NoError(test, err)
+	ok := proof.Verify(Session, ec, pk, C, X, G, NCap, s, t)
+	assert.True(test, ok, "proof must verify")
+
+	bzs := proof.Bytes()
+	proof2, err := NewProofFromBytes(ec, bzs[:])
+	assert.NoError(test, err)
+	ok = proof2.Verify(Session, ec, pk, C, X, G, NCap, s, t)
+	assert.True(test, ok, "proof must verify after a round trip through bytes")
+
+	// the proof must not verify against the generator
+	g := crypto.ScalarBaseMult(ec, big.NewInt(1))
+	ok = proof.Verify(Session, ec, pk, C, X, g, NCap, s, t)
+	assert.False(test, ok, "proof must not verify for a different base point")
+}
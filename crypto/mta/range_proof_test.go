@@ -18,8 +18,8 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
-	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 // Using a modulus length of 2048 is recommended in the GG18 spec
@@ -28,7 +28,7 @@ const (
 )
 
 func TestProveRangeAlice(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
@@ -43,15 +43,15 @@ func TestProveRangeAlice(t *testing.T) {
 	primes := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
 	NTildei, h1i, h2i, err := crypto.GenerateNTildei(rand.Reader, primes)
 	assert.NoError(t, err)
-	proof, err := ProveRangeAlice(tss.EC(), pk, c, NTildei, h1i, h2i, m, r, rand.Reader)
+	proof, err := ProveRangeAlice(group.Secp256k1(), pk, c, NTildei, h1i, h2i, m, r, rand.Reader)
 	assert.NoError(t, err)
 
-	ok := proof.Verify(tss.EC(), pk, NTildei, h1i, h2i, c)
+	ok := proof.Verify(group.Secp256k1(), pk, NTildei, h1i, h2i, c)
 	assert.True(t, ok, "proof must verify")
 }
 
 func TestProveRangeAliceBypassed(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
@@ -66,10 +66,10 @@ func TestProveRangeAliceBypassed(t *testing.T) {
 	primes0 := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
 	Ntildei0, h1i0, h2i0, err := crypto.GenerateNTildei(rand.Reader, primes0)
 	assert.NoError(t, err)
-	proof0, err := ProveRangeAlice(tss.EC(), pk0, c0, Ntildei0, h1i0, h2i0, m0, r0, rand.Reader)
+	proof0, err := ProveRangeAlice(group.Secp256k1(), pk0, c0, Ntildei0, h1i0, h2i0, m0, r0, rand.Reader)
 	assert.NoError(t, err)
 
-	ok0 := proof0.Verify(tss.EC(), pk0, Ntildei0, h1i0, h2i0, c0)
+	ok0 := proof0.Verify(group.Secp256k1(), pk0, Ntildei0, h1i0, h2i0, c0)
 	assert.True(t, ok0, "proof must verify")
 
 	// proof 2
@@ -83,16 +83,16 @@ func TestProveRangeAliceBypassed(t *testing.T) {
 	primes1 := [2]*big.Int{common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits), common.GetRandomPrimeInt(rand.Reader, testSafePrimeBits)}
 	Ntildei1, h1i1, h2i1, err := crypto.GenerateNTildei(rand.Reader, primes1)
 	assert.NoError(t, err)
-	proof1, err := ProveRangeAlice(tss.EC(), pk1, c1, Ntildei1, h1i1, h2i1, m1, r1, rand.Reader)
+	proof1, err := ProveRangeAlice(group.Secp256k1(), pk1, c1, Ntildei1, h1i1, h2i1, m1, r1, rand.Reader)
 	assert.NoError(t, err)
 
-	ok1 := proof1.Verify(tss.EC(), pk1, Ntildei1, h1i1, h2i1, c1)
+	ok1 := proof1.Verify(group.Secp256k1(), pk1, Ntildei1, h1i1, h2i1, c1)
 	assert.True(t, ok1, "proof must verify")
 
-	cross0 := proof0.Verify(tss.EC(), pk1, Ntildei1, h1i1, h2i1, c1)
+	cross0 := proof0.Verify(group.Secp256k1(), pk1, Ntildei1, h1i1, h2i1, c1)
 	assert.False(t, cross0, "proof must not verify")
 
-	cross1 := proof1.Verify(tss.EC(), pk0, Ntildei0, h1i0, h2i0, c0)
+	cross1 := proof1.Verify(group.Secp256k1(), pk0, Ntildei0, h1i0, h2i0, c0)
 	assert.False(t, cross1, "proof must not verify")
 
 	fmt.Println("Did verify proof 0 with data from 0?", ok0)
@@ -112,10 +112,10 @@ func TestProveRangeAliceBypassed(t *testing.T) {
 	}
 
 	cBogus := big.NewInt(1)
-	proofBogus, _ := ProveRangeAlice(tss.EC(), pk1, cBogus, Ntildei1, h1i1, h2i1, m1, r1, rand.Reader)
+	proofBogus, _ := ProveRangeAlice(group.Secp256k1(), pk1, cBogus, Ntildei1, h1i1, h2i1, m1, r1, rand.Reader)
 
-	ok2 := proofBogus.Verify(tss.EC(), pk1, Ntildei1, h1i1, h2i1, cBogus)
-	bypassresult3 := bypassedproofNew.Verify(tss.EC(), pk1, Ntildei1, h1i1, h2i1, cBogus)
+	ok2 := proofBogus.Verify(group.Secp256k1(), pk1, Ntildei1, h1i1, h2i1, cBogus)
+	bypassresult3 := bypassedproofNew.Verify(group.Secp256k1(), pk1, Ntildei1, h1i1, h2i1, cBogus)
 
 	// c = 1 is not valid, even though we can find a range proof for it that passes!
 	// this also means that the homo mul and add needs to be checked with this!
@@ -7,18 +7,18 @@
 package mta
 
 import (
-	"crypto/elliptic"
 	"errors"
 	"io"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 )
 
 func AliceInit(
-	ec elliptic.Curve,
+	g group.AffineGroup,
 	pkA *paillier.PublicKey,
 	a, NTildeB, h1B, h2B *big.Int,
 	rand io.Reader,
@@ -27,23 +27,23 @@ func AliceInit(
 	if err != nil {
 		return nil, nil, err
 	}
-	pf, err = ProveRangeAlice(ec, pkA, cA, NTildeB, h1B, h2B, a, rA, rand)
+	pf, err = ProveRangeAlice(g, pkA, cA, NTildeB, h1B, h2B, a, rA, rand)
 	return cA, pf, err
 }
 
 func BobMid(
 	Session []byte,
-	ec elliptic.Curve,
+	g group.AffineGroup,
 	pkA *paillier.PublicKey,
 	pf *RangeProofAlice,
 	b, cA, NTildeA, h1A, h2A, NTildeB, h1B, h2B *big.Int,
 	rand io.Reader,
 ) (beta, cB, betaPrm *big.Int, piB *ProofBob, err error) {
-	if !pf.Verify(ec, pkA, NTildeB, h1B, h2B, cA) {
+	if !pf.Verify(g, pkA, NTildeB, h1B, h2B, cA) {
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
-	q := ec.Params().N
+	q := g.Order()
 	q5 := new(big.Int).Mul(q, q)  // q^2
 	q5 = new(big.Int).Mul(q5, q5) // q^4
 	q5 = new(big.Int).Mul(q5, q)  // q^5
@@ -61,24 +61,24 @@ func BobMid(
 		return
 	}
 	beta = common.ModInt(q).Sub(zero, betaPrm)
-	piB, err = ProveBob(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, rand)
+	piB, err = ProveBob(Session, g, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, rand)
 	return
 }
 
 func BobMidWC(
 	Session []byte,
-	ec elliptic.Curve,
+	g group.AffineGroup,
 	pkA *paillier.PublicKey,
 	pf *RangeProofAlice,
 	b, cA, NTildeA, h1A, h2A, NTildeB, h1B, h2B *big.Int,
 	B *crypto.ECPoint,
 	rand io.Reader,
 ) (beta, cB, betaPrm *big.Int, piB *ProofBobWC, err error) {
-	if !pf.Verify(ec, pkA, NTildeB, h1B, h2B, cA) {
+	if !pf.Verify(g, pkA, NTildeB, h1B, h2B, cA) {
 		err = errors.New("RangeProofAlice.Verify() returned false")
 		return
 	}
-	q := ec.Params().N
+	q := g.Order()
 	q5 := new(big.Int).Mul(q, q)  // q^2
 	q5 = new(big.Int).Mul(q5, q5) // q^4
 	q5 = new(big.Int).Mul(q5, q)  // q^5
@@ -96,45 +96,45 @@ func BobMidWC(
 		return
 	}
 	beta = common.ModInt(q).Sub(zero, betaPrm)
-	piB, err = ProveBobWC(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, B, rand)
+	piB, err = ProveBobWC(Session, g, pkA, NTildeA, h1A, h2A, cA, cB, b, betaPrm, cRand, B, rand)
 	return
 }
 
 func AliceEnd(
 	Session []byte,
-	ec elliptic.Curve,
+	g group.AffineGroup,
 	pkA *paillier.PublicKey,
 	pf *ProofBob,
 	h1A, h2A, cA, cB, NTildeA *big.Int,
 	sk *paillier.PrivateKey,
 ) (*big.Int, error) {
-	if !pf.Verify(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB) {
+	if !pf.Verify(Session, g, pkA, NTildeA, h1A, h2A, cA, cB) {
 		return nil, errors.New("ProofBob.Verify() returned false")
 	}
 	alphaPrm, err := sk.Decrypt(cB)
 	if err != nil {
 		return nil, err
 	}
-	q := ec.Params().N
+	q := g.Order()
 	return new(big.Int).Mod(alphaPrm, q), nil
 }
 
 func AliceEndWC(
 	Session []byte,
-	ec elliptic.Curve,
+	g group.AffineGroup,
 	pkA *paillier.PublicKey,
 	pf *ProofBobWC,
 	B *crypto.ECPoint,
 	cA, cB, NTildeA, h1A, h2A *big.Int,
 	sk *paillier.PrivateKey,
 ) (*big.Int, error) {
-	if !pf.Verify(Session, ec, pkA, NTildeA, h1A, h2A, cA, cB, B) {
+	if !pf.Verify(Session, g, pkA, NTildeA, h1A, h2A, cA, cB, B) {
 		return nil, errors.New("ProofBobWC.Verify() returned false")
 	}
 	alphaPrm, err := sk.Decrypt(cB)
 	if err != nil {
 		return nil, err
 	}
-	q := ec.Params().N
+	q := g.Order()
 	return new(big.Int).Mod(alphaPrm, q), nil
 }
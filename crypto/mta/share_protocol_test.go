@@ -17,9 +17,9 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
-	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 // Using a modulus length of 2048 is recommended in the GG18 spec
@@ -30,7 +30,7 @@ const (
 var Session = []byte("session")
 
 func TestShareProtocol(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
@@ -46,13 +46,13 @@ func TestShareProtocol(t *testing.T) {
 	NTildej, h1j, h2j, err := keygen.LoadNTildeH1H2FromTestFixture(1)
 	assert.NoError(t, err)
 
-	cA, pf, err := AliceInit(tss.EC(), pk, a, NTildej, h1j, h2j, rand.Reader)
+	cA, pf, err := AliceInit(group.Secp256k1(), pk, a, NTildej, h1j, h2j, rand.Reader)
 	assert.NoError(t, err)
 
-	_, cB, betaPrm, pfB, err := BobMid(Session, tss.EC(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, rand.Reader)
+	_, cB, betaPrm, pfB, err := BobMid(Session, group.Secp256k1(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, rand.Reader)
 	assert.NoError(t, err)
 
-	alpha, err := AliceEnd(Session, tss.EC(), pk, pfB, h1i, h2i, cA, cB, NTildei, sk)
+	alpha, err := AliceEnd(Session, group.Secp256k1(), pk, pfB, h1i, h2i, cA, cB, NTildei, sk)
 	assert.NoError(t, err)
 
 	// expect: alpha = ab + betaPrm
@@ -63,7 +63,7 @@ func TestShareProtocol(t *testing.T) {
 }
 
 func TestShareProtocolWC(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
@@ -73,22 +73,20 @@ func TestShareProtocolWC(t *testing.T) {
 
 	a := common.GetRandomPositiveInt(rand.Reader, q)
 	b := common.GetRandomPositiveInt(rand.Reader, q)
-	gBX, gBY := tss.EC().ScalarBaseMult(b.Bytes())
 
 	NTildei, h1i, h2i, err := keygen.LoadNTildeH1H2FromTestFixture(0)
 	assert.NoError(t, err)
 	NTildej, h1j, h2j, err := keygen.LoadNTildeH1H2FromTestFixture(1)
 	assert.NoError(t, err)
 
-	cA, pf, err := AliceInit(tss.EC(), pk, a, NTildej, h1j, h2j, rand.Reader)
+	cA, pf, err := AliceInit(group.Secp256k1(), pk, a, NTildej, h1j, h2j, rand.Reader)
 	assert.NoError(t, err)
 
-	gBPoint, err := crypto.NewECPoint(tss.EC(), gBX, gBY)
-	assert.NoError(t, err)
-	_, cB, betaPrm, pfB, err := BobMidWC(Session, tss.EC(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint, rand.Reader)
+	gBPoint := crypto.ScalarBaseMult(group.Secp256k1(), b)
+	_, cB, betaPrm, pfB, err := BobMidWC(Session, group.Secp256k1(), pk, pf, b, cA, NTildei, h1i, h2i, NTildej, h1j, h2j, gBPoint, rand.Reader)
 	assert.NoError(t, err)
 
-	alpha, err := AliceEndWC(Session, tss.EC(), pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk)
+	alpha, err := AliceEndWC(Session, group.Secp256k1(), pk, pfB, gBPoint, cA, cB, NTildei, h1i, h2i, sk)
 	assert.NoError(t, err)
 
 	// expect: alpha = ab + betaPrm
@@ -116,6 +116,18 @@ func (publicKey *PublicKey) EncryptAndReturnRandomness(rand io.Reader, m *big.In
 		return nil, nil, ErrMessageTooLong
 	}
 	x = common.GetRandomPositiveRelativelyPrimeInt(rand, publicKey.N)
+	c, err = publicKey.EncryptWithRandomness(m, x)
+	return
+}
+
+// EncryptWithRandomness encrypts m using the caller-supplied randomness x, which must be in Z*_N
+func (publicKey *PublicKey) EncryptWithRandomness(m, x *big.Int) (c *big.Int, err error) {
+	if m.Cmp(zero) == -1 || m.Cmp(publicKey.N) != -1 { // m < 0 || m >= N ?
+		return nil, ErrMessageTooLong
+	}
+	if !common.IsNumberInMultiplicativeGroup(publicKey.N, x) {
+		return nil, ErrMessageMalFormed
+	}
 	N2 := publicKey.NSquare()
 	// 1. gamma^m mod N2
 	Gm := new(big.Int).Exp(publicKey.Gamma(), m, N2)
@@ -190,6 +202,21 @@ func (privateKey *PrivateKey) Decrypt(c *big.Int) (m *big.Int, err error) {
 	return
 }
 
+// DecryptAndRecoverRandomness decrypts c and also returns the randomness x such that c = Enc(m; x),
+// which lets the holder of the private key open a ciphertext to a third party
+func (privateKey *PrivateKey) DecryptAndRecoverRandomness(c *big.Int) (m, x *big.Int, err error) {
+	if m, err = privateKey.Decrypt(c); err != nil {
+		return nil, nil, err
+	}
+	// c = gamma^m * x^N mod N2, so c mod N = x^N mod N and x = c^(N^-1 mod phiN) mod N
+	NInv := new(big.Int).ModInverse(privateKey.N, privateKey.PhiN)
+	if NInv == nil {
+		return nil, nil, ErrMessageMalFormed
+	}
+	x = new(big.Int).Exp(new(big.Int).Mod(c, privateKey.N), NInv, privateKey.N)
+	return m, x, nil
+}
+
 // ----- //
 
 // Proof is an implementation of Gennaro, R., Micciancio, D., Rabin, T.:
@@ -17,8 +17,8 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/paillier"
-	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 // Using a modulus length of 2048 is recommended in the GG18 spec
@@ -76,6 +76,20 @@ func TestEncryptDecrypt(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDecryptAndRecoverRandomness(t *testing.T) {
+	setUp(t)
+	exp := big.NewInt(100)
+	cypher, x, err := publicKey.EncryptAndReturnRandomness(rand.Reader, exp)
+	assert.NoError(t, err)
+	ret, xRet, err := privateKey.DecryptAndRecoverRandomness(cypher)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, exp.Cmp(ret))
+	assert.Equal(t, 0, x.Cmp(xRet))
+	reCypher, err := publicKey.EncryptWithRandomness(ret, xRet)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cypher.Cmp(reCypher))
+}
+
 func TestHomoMul(t *testing.T) {
 	setUp(t)
 	three, err := privateKey.Encrypt(rand.Reader, big.NewInt(3))
@@ -111,24 +125,24 @@ func TestHomoAdd(t *testing.T) {
 
 func TestProofVerify(t *testing.T) {
 	setUp(t)
-	ki := common.MustGetRandomInt(rand.Reader, 256)                     // index
-	ui := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N) // ECDSA private
-	yX, yY := tss.EC().ScalarBaseMult(ui.Bytes())                       // ECDSA public
-	proof := privateKey.Proof(ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
-	res, err := proof.Verify(publicKey.N, ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
+	ki := common.MustGetRandomInt(rand.Reader, 256)                           // index
+	ui := common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()) // ECDSA private
+	y := crypto.ScalarBaseMult(group.Secp256k1(), ui)                         // ECDSA public
+	proof := privateKey.Proof(ki, y)
+	res, err := proof.Verify(publicKey.N, ki, y)
 	assert.NoError(t, err)
 	assert.True(t, res, "proof verify result must be true")
 }
 
 func TestProofVerifyFail(t *testing.T) {
 	setUp(t)
-	ki := common.MustGetRandomInt(rand.Reader, 256)                     // index
-	ui := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N) // ECDSA private
-	yX, yY := tss.EC().ScalarBaseMult(ui.Bytes())                       // ECDSA public
-	proof := privateKey.Proof(ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
+	ki := common.MustGetRandomInt(rand.Reader, 256)                           // index
+	ui := common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()) // ECDSA private
+	y := crypto.ScalarBaseMult(group.Secp256k1(), ui)                         // ECDSA public
+	proof := privateKey.Proof(ki, y)
 	last := proof[len(proof)-1]
 	last.Sub(last, big.NewInt(1))
-	res, err := proof.Verify(publicKey.N, ki, crypto.NewECPointNoCurveCheck(tss.EC(), yX, yY))
+	res, err := proof.Verify(publicKey.N, ki, y)
 	assert.NoError(t, err)
 	assert.False(t, res, "proof verify result must be true")
 }
@@ -145,11 +159,10 @@ func TestComputeL(t *testing.T) {
 
 func TestGenerateXs(t *testing.T) {
 	k := common.MustGetRandomInt(rand.Reader, 256)
-	sX := common.MustGetRandomInt(rand.Reader, 256)
-	sY := common.MustGetRandomInt(rand.Reader, 256)
+	s := common.MustGetRandomInt(rand.Reader, 256)
 	N := common.GetRandomPrimeInt(rand.Reader, 2048)
 
-	xs := GenerateXs(13, k, N, crypto.NewECPointNoCurveCheck(tss.EC(), sX, sY))
+	xs := GenerateXs(13, k, N, crypto.ScalarBaseMult(group.Secp256k1(), s))
 	assert.Equal(t, 13, len(xs))
 	for _, xi := range xs {
 		assert.True(t, common.IsNumberInMultiplicativeGroup(N, xi))
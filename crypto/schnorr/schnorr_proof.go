@@ -29,20 +29,25 @@ type (
 
 // NewZKProof constructs a new Schnorr ZK proof of knowledge of the discrete logarithm (GG18Spec Fig. 16)
 func NewZKProof(Session []byte, x *big.Int, X *crypto.ECPoint, rand io.Reader) (*ZKProof, error) {
-	if x == nil || X == nil || !X.ValidateBasic() {
+	if X == nil {
 		return nil, errors.New("ZKProof constructor received nil or invalid value(s)")
 	}
-	ec := X.Curve()
-	ecParams := ec.Params()
-	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy) // already on the curve.
+	return NewZKProofWithBase(Session, x, crypto.Generator(X.Group()), X, rand)
+}
+
+// NewZKProofWithBase constructs a new Schnorr ZK proof of knowledge of the discrete logarithm of X to the base B
+func NewZKProofWithBase(Session []byte, x *big.Int, B, X *crypto.ECPoint, rand io.Reader) (*ZKProof, error) {
+	if x == nil || B == nil || X == nil || !B.ValidateBasic() || !X.ValidateBasic() {
+		return nil, errors.New("ZKProof constructor received nil or invalid value(s)")
+	}
+	q := X.Group().Order()
 
 	a := common.GetRandomPositiveInt(rand, q)
-	alpha := crypto.ScalarBaseMult(ec, a)
+	alpha := B.ScalarMult(a)
 
 	var c *big.Int
 	{
-		cHash := common.SHA512_256i_TAGGED(Session, X.X(), X.Y(), g.X(), g.Y(), alpha.X(), alpha.Y())
+		cHash := common.SHA512_256i_TAGGED(Session, X.X(), X.Y(), B.X(), B.Y(), alpha.X(), alpha.Y())
 		c = common.RejectionSample(q, cHash)
 	}
 	t := new(big.Int).Mul(c, x)
@@ -53,26 +58,35 @@ func NewZKProof(Session []byte, x *big.Int, X *crypto.ECPoint, rand io.Reader) (
 
 // NewZKProof verifies a new Schnorr ZK proof of knowledge of the discrete logarithm (GG18Spec Fig. 16)
 func (pf *ZKProof) Verify(Session []byte, X *crypto.ECPoint) bool {
-	if pf == nil || !pf.ValidateBasic() {
+	if X == nil {
+		return false
+	}
+	return pf.VerifyWithBase(Session, crypto.Generator(X.Group()), X)
+}
+
+// VerifyWithBase verifies a Schnorr ZK proof of knowledge of the discrete logarithm of X to the base B
+func (pf *ZKProof) VerifyWithBase(Session []byte, B, X *crypto.ECPoint) bool {
+	if pf == nil || !pf.ValidateBasic() || !B.ValidateBasic() || !X.ValidateBasic() || B.Group() != X.Group() {
+		return false
+	}
+	q := X.Group().Order()
+	// B^T would be the point at infinity
+	if pf.T.Sign() == 0 || pf.T.Cmp(q) >= 0 {
 		return false
 	}
-	ec := X.Curve()
-	ecParams := ec.Params()
-	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy)
 
 	var c *big.Int
 	{
-		cHash := common.SHA512_256i_TAGGED(Session, X.X(), X.Y(), g.X(), g.Y(), pf.Alpha.X(), pf.Alpha.Y())
+		cHash := common.SHA512_256i_TAGGED(Session, X.X(), X.Y(), B.X(), B.Y(), pf.Alpha.X(), pf.Alpha.Y())
 		c = common.RejectionSample(q, cHash)
 	}
-	tG := crypto.ScalarBaseMult(ec, pf.T)
+	tB := B.ScalarMult(pf.T)
 	Xc := X.ScalarMult(c)
 	aXc, err := pf.Alpha.Add(Xc)
 	if err != nil {
 		return false
 	}
-	return aXc.X().Cmp(tG.X()) == 0 && aXc.Y().Cmp(tG.Y()) == 0
+	return aXc.X().Cmp(tB.X()) == 0 && aXc.Y().Cmp(tB.Y()) == 0
 }
 
 func (pf *ZKProof) ValidateBasic() bool {
@@ -84,10 +98,9 @@ func NewZKVProof(Session []byte, V, R *crypto.ECPoint, s, l *big.Int, rand io.Re
 	if V == nil || R == nil || s == nil || l == nil || !V.ValidateBasic() || !R.ValidateBasic() {
 		return nil, errors.New("ZKVProof constructor received nil value(s)")
 	}
-	ec := V.Curve()
-	ecParams := ec.Params()
-	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy)
+	ec := V.Group()
+	q := ec.Order()
+	g := crypto.Generator(ec)
 
 	a, b := common.GetRandomPositiveInt(rand, q), common.GetRandomPositiveInt(rand, q)
 	aR := R.ScalarMult(a)
@@ -107,13 +120,12 @@ func NewZKVProof(Session []byte, V, R *crypto.ECPoint, s, l *big.Int, rand io.Re
 }
 
 func (pf *ZKVProof) Verify(Session []byte, V, R *crypto.ECPoint) bool {
-	if pf == nil || !pf.ValidateBasic() {
+	if pf == nil || !pf.ValidateBasic() || !V.ValidateBasic() || !R.ValidateBasic() {
 		return false
 	}
-	ec := V.Curve()
-	ecParams := ec.Params()
-	q := ecParams.N
-	g := crypto.NewECPointNoCurveCheck(ec, ecParams.Gx, ecParams.Gy)
+	ec := V.Group()
+	q := ec.Order()
+	g := crypto.Generator(ec)
 
 	var c *big.Int
 	{
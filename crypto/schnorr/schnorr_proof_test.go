@@ -14,16 +14,16 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
-	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 var Session = []byte("session")
 
 func TestSchnorrProof(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 	u := common.GetRandomPositiveInt(rand.Reader, q)
-	uG := crypto.ScalarBaseMult(tss.EC(), u)
+	uG := crypto.ScalarBaseMult(group.Secp256k1(), u)
 	proof, _ := NewZKProof(Session, u, uG, rand.Reader)
 
 	assert.True(t, proof.Alpha.IsOnCurve())
@@ -33,9 +33,9 @@ func TestSchnorrProof(t *testing.T) {
 }
 
 func TestSchnorrProofVerify(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 	u := common.GetRandomPositiveInt(rand.Reader, q)
-	X := crypto.ScalarBaseMult(tss.EC(), u)
+	X := crypto.ScalarBaseMult(group.Secp256k1(), u)
 
 	proof, _ := NewZKProof(Session, u, X, rand.Reader)
 	res := proof.Verify(Session, X)
@@ -44,11 +44,11 @@ func TestSchnorrProofVerify(t *testing.T) {
 }
 
 func TestSchnorrProofVerifyBadX(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 	u := common.GetRandomPositiveInt(rand.Reader, q)
 	u2 := common.GetRandomPositiveInt(rand.Reader, q)
-	X := crypto.ScalarBaseMult(tss.EC(), u)
-	X2 := crypto.ScalarBaseMult(tss.EC(), u2)
+	X := crypto.ScalarBaseMult(group.Secp256k1(), u)
+	X2 := crypto.ScalarBaseMult(group.Secp256k1(), u2)
 
 	proof, _ := NewZKProof(Session, u2, X2, rand.Reader)
 	res := proof.Verify(Session, X)
@@ -57,13 +57,13 @@ func TestSchnorrProofVerifyBadX(t *testing.T) {
 }
 
 func TestSchnorrVProofVerify(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 	k := common.GetRandomPositiveInt(rand.Reader, q)
 	s := common.GetRandomPositiveInt(rand.Reader, q)
 	l := common.GetRandomPositiveInt(rand.Reader, q)
-	R := crypto.ScalarBaseMult(tss.EC(), k) // k_-1 * G
+	R := crypto.ScalarBaseMult(group.Secp256k1(), k) // k_-1 * G
 	Rs := R.ScalarMult(s)
-	lG := crypto.ScalarBaseMult(tss.EC(), l)
+	lG := crypto.ScalarBaseMult(group.Secp256k1(), l)
 	V, _ := Rs.Add(lG)
 
 	proof, _ := NewZKVProof(Session, V, R, s, l, rand.Reader)
@@ -73,11 +73,11 @@ func TestSchnorrVProofVerify(t *testing.T) {
 }
 
 func TestSchnorrVProofVerifyBadPartialV(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 	k := common.GetRandomPositiveInt(rand.Reader, q)
 	s := common.GetRandomPositiveInt(rand.Reader, q)
 	l := common.GetRandomPositiveInt(rand.Reader, q)
-	R := crypto.ScalarBaseMult(tss.EC(), k) // k_-1 * G
+	R := crypto.ScalarBaseMult(group.Secp256k1(), k) // k_-1 * G
 	Rs := R.ScalarMult(s)
 	V := Rs
 
@@ -88,14 +88,14 @@ func TestSchnorrVProofVerifyBadPartialV(t *testing.T) {
 }
 
 func TestSchnorrVProofVerifyBadS(t *testing.T) {
-	q := tss.EC().Params().N
+	q := group.Secp256k1().Order()
 	k := common.GetRandomPositiveInt(rand.Reader, q)
 	s := common.GetRandomPositiveInt(rand.Reader, q)
 	s2 := common.GetRandomPositiveInt(rand.Reader, q)
 	l := common.GetRandomPositiveInt(rand.Reader, q)
-	R := crypto.ScalarBaseMult(tss.EC(), k) // k_-1 * G
+	R := crypto.ScalarBaseMult(group.Secp256k1(), k) // k_-1 * G
 	Rs := R.ScalarMult(s)
-	lG := crypto.ScalarBaseMult(tss.EC(), l)
+	lG := crypto.ScalarBaseMult(group.Secp256k1(), l)
 	V, _ := Rs.Add(lG)
 
 	proof, _ := NewZKVProof(Session, V, R, s2, l, rand.Reader)
@@ -103,3 +103,21 @@ func TestSchnorrVProofVerifyBadS(t *testing.T) {
 
 	assert.False(t, res, "verify result must be false")
 }
+
+func TestSchnorrProofWithBaseVerify(t *testing.T) {
+	q := group.Secp256k1().Order()
+	b := common.GetRandomPositiveInt(rand.Reader, q)
+	u := common.GetRandomPositiveInt(rand.Reader, q)
+	B := crypto.ScalarBaseMult(group.Secp256k1(), b)
+	X := B.ScalarMult(u)
+
+	proof, err := NewZKProofWithBase(Session, u, B, X, rand.Reader)
+	assert.NoError(t, err)
+	assert.True(t, proof.VerifyWithBase(Session, B, X), "verify result must be true")
+
+	// the proof is for the base B, not g
+	assert.False(t, proof.Verify(Session, X), "verify result must be false")
+	G := crypto.ScalarBaseMult(group.Secp256k1(), u)
+	proof, _ = NewZKProof(Session, u, G, rand.Reader)
+	assert.False(t, proof.VerifyWithBase(Session, B, G), "verify result must be false")
+}
@@ -11,7 +11,6 @@
 package vss
 
 import (
-	"crypto/elliptic"
 	"errors"
 	"fmt"
 	"io"
@@ -19,6 +18,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 )
 
 type (
@@ -41,10 +41,10 @@ var (
 )
 
 // Check share ids of Shamir's Secret Sharing, return error if duplicate or 0 value found
-func CheckIndexes(ec elliptic.Curve, indexes []*big.Int) ([]*big.Int, error) {
+func CheckIndexes(g group.AffineGroup, indexes []*big.Int) ([]*big.Int, error) {
 	visited := make(map[string]struct{})
 	for _, v := range indexes {
-		vMod := new(big.Int).Mod(v, ec.Params().N)
+		vMod := new(big.Int).Mod(v, g.Order())
 		if vMod.Cmp(zero) == 0 {
 			return nil, errors.New("party index should not be 0")
 		}
@@ -59,7 +59,7 @@ func CheckIndexes(ec elliptic.Curve, indexes []*big.Int) ([]*big.Int, error) {
 
 // Returns a new array of secret shares created by Shamir's Secret Sharing Algorithm,
 // requiring a minimum number of shares to recreate, of length shares, from the input secret
-func Create(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.Int, rand io.Reader) (Vs, Shares, error) {
+func Create(g group.AffineGroup, threshold int, secret *big.Int, indexes []*big.Int, rand io.Reader) (Vs, Shares, error) {
 	if secret == nil || indexes == nil {
 		return nil, nil, fmt.Errorf("vss secret or indexes == nil: %v %v", secret, indexes)
 	}
@@ -67,7 +67,7 @@ func Create(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.In
 		return nil, nil, errors.New("vss threshold < 1")
 	}
 
-	ids, err := CheckIndexes(ec, indexes)
+	ids, err := CheckIndexes(g, indexes)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -77,47 +77,118 @@ func Create(ec elliptic.Curve, threshold int, secret *big.Int, indexes []*big.In
 		return nil, nil, ErrNumSharesBelowThreshold
 	}
 
-	poly := samplePolynomial(ec, threshold, secret, rand)
+	poly := samplePolynomial(g, threshold, secret, rand)
 
 	v := make(Vs, len(poly))
 	for i, ai := range poly {
-		v[i] = crypto.ScalarBaseMult(ec, ai)
+		v[i] = crypto.ScalarBaseMult(g, ai)
 	}
 
 	shares := make(Shares, num)
 	for i := 0; i < num; i++ {
-		share := evaluatePolynomial(ec, threshold, poly, ids[i])
+		share := evaluatePolynomial(g, threshold, poly, ids[i])
 		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
 	}
+	// the secret belongs to the caller; the other coefficients would reveal it together with any share
+	common.WipeBigInts(poly[1:]...)
 	return v, shares, nil
 }
 
-func (share *Share) Verify(ec elliptic.Curve, threshold int, vs Vs) bool {
+func (share *Share) Verify(g group.AffineGroup, threshold int, vs Vs) bool {
 	if share.Threshold != threshold || vs == nil || len(vs) != threshold+1 {
 		return false
 	}
 	var err error
-	modQ := common.ModInt(ec.Params().N)
+	modQ := common.ModInt(g.Order())
 	v, t := vs[0], one // YRO : we need to have our accumulator outside of the loop
 	for j := 1; j <= threshold; j++ {
 		// t = k_i^j
 		t = modQ.Mul(t, share.ID)
 		// v = v * v_j^t
-		vjt := vs[j].SetCurve(ec).ScalarMult(t)
-		v, err = v.SetCurve(ec).Add(vjt)
+		vjt := vs[j].ScalarMult(t)
+		v, err = v.Add(vjt)
 		if err != nil {
 			return false
 		}
 	}
-	sigmaGi := crypto.ScalarBaseMult(ec, share.Share)
+	sigmaGi := crypto.ScalarBaseMult(g, share.Share)
 	return sigmaGi.Equals(v)
 }
 
-func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error) {
+// CreateZeroSharing returns a new array of shares of the secret 0, used to re-randomize existing shares.
+// The returned Vs holds the commitments v1..vt; v0 is omitted because it is the point at infinity.
+func CreateZeroSharing(g group.AffineGroup, threshold int, indexes []*big.Int, rand io.Reader) (Vs, Shares, error) {
+	if indexes == nil {
+		return nil, nil, errors.New("vss indexes == nil")
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("vss threshold < 1")
+	}
+
+	ids, err := CheckIndexes(g, indexes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	num := len(indexes)
+	if num < threshold {
+		return nil, nil, ErrNumSharesBelowThreshold
+	}
+
+	poly := samplePolynomial(g, threshold, zero, rand)
+
+	v := make(Vs, threshold)
+	for i, ai := range poly[1:] {
+		v[i] = crypto.ScalarBaseMult(g, ai)
+	}
+
+	shares := make(Shares, num)
+	for i := 0; i < num; i++ {
+		share := evaluatePolynomial(g, threshold, poly, ids[i])
+		shares[i] = &Share{Threshold: threshold, ID: ids[i], Share: share}
+	}
+	// the secret belongs to the caller; the other coefficients would reveal it together with any share
+	common.WipeBigInts(poly[1:]...)
+	return v, shares, nil
+}
+
+// EvaluateZeroSharing returns the public commitment to the share with the given id from the commitments v1..vt of a zero sharing
+func EvaluateZeroSharing(g group.AffineGroup, vs Vs, id *big.Int) (*crypto.ECPoint, error) {
+	if len(vs) == 0 {
+		return nil, errors.New("EvaluateZeroSharing: empty vs")
+	}
+	var err error
+	modQ := common.ModInt(g.Order())
+	t := new(big.Int).Mod(id, g.Order())
+	v := vs[0].ScalarMult(t)
+	for j := 1; j < len(vs); j++ {
+		// t = k_i^(j+1)
+		t = modQ.Mul(t, id)
+		v, err = v.Add(vs[j].ScalarMult(t))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+func (share *Share) VerifyZeroSharing(g group.AffineGroup, threshold int, vs Vs) bool {
+	if share.Threshold != threshold || vs == nil || len(vs) != threshold {
+		return false
+	}
+	v, err := EvaluateZeroSharing(g, vs, share.ID)
+	if err != nil {
+		return false
+	}
+	sigmaGi := crypto.ScalarBaseMult(g, share.Share)
+	return sigmaGi.Equals(v)
+}
+
+func (shares Shares) ReConstruct(g group.AffineGroup) (secret *big.Int, err error) {
 	if shares != nil && shares[0].Threshold > len(shares) {
 		return nil, ErrNumSharesBelowThreshold
 	}
-	modN := common.ModInt(ec.Params().N)
+	modN := common.ModInt(g.Order())
 
 	// x coords
 	xs := make([]*big.Int, 0)
@@ -145,8 +216,8 @@ func (shares Shares) ReConstruct(ec elliptic.Curve) (secret *big.Int, err error)
 	return secret, nil
 }
 
-func samplePolynomial(ec elliptic.Curve, threshold int, secret *big.Int, rand io.Reader) []*big.Int {
-	q := ec.Params().N
+func samplePolynomial(g group.AffineGroup, threshold int, secret *big.Int, rand io.Reader) []*big.Int {
+	q := g.Order()
 	v := make([]*big.Int, threshold+1)
 	v[0] = secret
 	for i := 1; i <= threshold; i++ {
@@ -160,8 +231,8 @@ func samplePolynomial(ec elliptic.Curve, threshold int, secret *big.Int, rand io
 // evaluatePolynomial([a, b, c, d], x):
 //
 //	returns a + bx + cx^2 + dx^3
-func evaluatePolynomial(ec elliptic.Curve, threshold int, v []*big.Int, id *big.Int) (result *big.Int) {
-	q := ec.Params().N
+func evaluatePolynomial(g group.AffineGroup, threshold int, v []*big.Int, id *big.Int) (result *big.Int) {
+	q := g.Order()
 	modQ := common.ModInt(q)
 	result = new(big.Int).Set(v[0])
 	X := big.NewInt(int64(1))
@@ -14,47 +14,47 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	. "github.com/bnb-chain/tss-lib/v2/crypto/vss"
-	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func TestCheckIndexesDup(t *testing.T) {
 	indexes := make([]*big.Int, 0)
 	for i := 0; i < 1000; i++ {
-		indexes = append(indexes, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+		indexes = append(indexes, common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()))
 	}
-	_, e := CheckIndexes(tss.EC(), indexes)
+	_, e := CheckIndexes(group.Secp256k1(), indexes)
 	assert.NoError(t, e)
 
 	indexes = append(indexes, indexes[99])
-	_, e = CheckIndexes(tss.EC(), indexes)
+	_, e = CheckIndexes(group.Secp256k1(), indexes)
 	assert.Error(t, e)
 }
 
 func TestCheckIndexesZero(t *testing.T) {
 	indexes := make([]*big.Int, 0)
 	for i := 0; i < 1000; i++ {
-		indexes = append(indexes, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+		indexes = append(indexes, common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()))
 	}
-	_, e := CheckIndexes(tss.EC(), indexes)
+	_, e := CheckIndexes(group.Secp256k1(), indexes)
 	assert.NoError(t, e)
 
-	indexes = append(indexes, tss.EC().Params().N)
-	_, e = CheckIndexes(tss.EC(), indexes)
+	indexes = append(indexes, group.Secp256k1().Order())
+	_, e = CheckIndexes(group.Secp256k1(), indexes)
 	assert.Error(t, e)
 }
 
 func TestCreate(t *testing.T) {
 	num, threshold := 5, 3
 
-	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+	secret := common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order())
 
 	ids := make([]*big.Int, 0)
 	for i := 0; i < num; i++ {
-		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()))
 	}
 
-	vs, _, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	vs, _, err := Create(group.Secp256k1(), threshold, secret, ids, rand.Reader)
 	assert.Nil(t, err)
 
 	assert.Equal(t, threshold+1, len(vs))
@@ -75,43 +75,64 @@ func TestCreate(t *testing.T) {
 func TestVerify(t *testing.T) {
 	num, threshold := 5, 3
 
-	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+	secret := common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order())
 
 	ids := make([]*big.Int, 0)
 	for i := 0; i < num; i++ {
-		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()))
 	}
 
-	vs, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	vs, shares, err := Create(group.Secp256k1(), threshold, secret, ids, rand.Reader)
 	assert.NoError(t, err)
 
 	for i := 0; i < num; i++ {
-		assert.True(t, shares[i].Verify(tss.EC(), threshold, vs))
+		assert.True(t, shares[i].Verify(group.Secp256k1(), threshold, vs))
 	}
 }
 
 func TestReconstruct(t *testing.T) {
 	num, threshold := 5, 3
 
-	secret := common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N)
+	secret := common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order())
 
 	ids := make([]*big.Int, 0)
 	for i := 0; i < num; i++ {
-		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, tss.EC().Params().N))
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()))
 	}
 
-	_, shares, err := Create(tss.EC(), threshold, secret, ids, rand.Reader)
+	_, shares, err := Create(group.Secp256k1(), threshold, secret, ids, rand.Reader)
 	assert.NoError(t, err)
 
-	secret2, err2 := shares[:threshold-1].ReConstruct(tss.EC())
+	secret2, err2 := shares[:threshold-1].ReConstruct(group.Secp256k1())
 	assert.Error(t, err2) // not enough shares to satisfy the threshold
 	assert.Nil(t, secret2)
 
-	secret3, err3 := shares[:threshold].ReConstruct(tss.EC())
+	secret3, err3 := shares[:threshold].ReConstruct(group.Secp256k1())
 	assert.NoError(t, err3)
 	assert.NotZero(t, secret3)
 
-	secret4, err4 := shares[:num].ReConstruct(tss.EC())
+	secret4, err4 := shares[:num].ReConstruct(group.Secp256k1())
 	assert.NoError(t, err4)
 	assert.NotZero(t, secret4)
 }
+
+func TestZeroSharing(t *testing.T) {
+	num, threshold := 5, 3
+
+	ids := make([]*big.Int, 0)
+	for i := 0; i < num; i++ {
+		ids = append(ids, common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order()))
+	}
+
+	vs, shares, err := CreateZeroSharing(group.Secp256k1(), threshold, ids, rand.Reader)
+	assert.NoError(t, err)
+	assert.Equal(t, threshold, len(vs))
+
+	for i := 0; i < num; i++ {
+		assert.True(t, shares[i].VerifyZeroSharing(group.Secp256k1(), threshold, vs))
+	}
+
+	secret, err := shares[:threshold+1].ReConstruct(group.Secp256k1())
+	assert.NoError(t, err)
+	assert.Zero(t, secret.Sign())
+}
@@ -0,0 +1,243 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a keygen session in a keystore container
+	SessionSnapshotKind = "ecdsa/cmp/keygen.Session"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	// LocalParty runs the CGGMP21 key generation (Canetti et al.; 2021, Fig. 5).
+	// Its output carries the key shares only: the Paillier and ring-Pedersen parameters are
+	// produced by running the refresh protocol in ecdsa/cmp/refresh before presigning.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		temp    localTempData
+		data    ecdsakeygen.LocalPartySaveData
+		journal *tss.SessionJournal
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *ecdsakeygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		kgRound1Messages,
+		kgRound2Message1s,
+		kgRound2Message2s,
+		kgRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after keygen)
+		ui            *big.Int // wiped once round 3 has proven the knowledge of it
+		rid           *big.Int
+		KGCs          []cmt.HashCommitment
+		vs            vss.Vs
+		pjVs          []vss.Vs
+		ssid          []byte
+		ssidNonce     *big.Int
+		shares        vss.Shares
+		deCommitPolyG cmt.HashDeCommitment
+	}
+)
+
+// Exported, used in `tss` client
+func NewLocalParty(
+	params *tss.Parameters,
+	out chan<- tss.Message,
+	end chan<- *ecdsakeygen.LocalPartySaveData,
+) tss.Party {
+	partyCount := params.PartyCount()
+	data := ecdsakeygen.NewLocalPartySaveData(partyCount)
+	p := &LocalParty{
+		params: params,
+		temp:   localTempData{},
+		data:   data,
+		out:    out,
+		end:    end,
+	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
+	// msgs init
+	p.temp.kgRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound2Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound2Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.kgRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.pjVs = make([]vss.Vs, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.data, &p.temp, p.out, p.end, p.journal)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() {
+		p.temp = localTempData{}
+		p.data.Xi = nil // xi is computed before the last round
+	})
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The save data of a finished party is its key share;
+// otherwise xi is wiped too.
+func (p *LocalParty) wipe(finished bool) {
+	common.WipeBigInt(p.temp.ui)
+	for _, share := range p.temp.shares {
+		if share != nil {
+			common.WipeBigInt(share.Share)
+		}
+	}
+	cmt.WipeDeCommitment(p.temp.deCommitPolyG)
+	p.temp.ui, p.temp.shares, p.temp.deCommitPolyG = nil, nil, nil
+	if !finished {
+		common.WipeBigInt(p.data.Xi)
+		p.data.Xi = nil
+	}
+	p.journal.Wipe()
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *KGRound1Message:
+		p.temp.kgRound1Messages[fromPIdx] = msg
+	case *KGRound2Message1:
+		p.temp.kgRound2Message1s[fromPIdx] = msg
+	case *KGRound2Message2:
+		p.temp.kgRound2Message2s[fromPIdx] = msg
+	case *KGRound3Message:
+		p.temp.kgRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
+	return true, nil
+}
+
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *KGRound1Message:
+		return 1
+	case *KGRound2Message1, *KGRound2Message2:
+		return 2
+	case *KGRound3Message:
+		return 3
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot, such as
+// a different round 1 commitment.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), big.NewInt(int64(p.params.Threshold())).Bytes()}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,247 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *ecdsakeygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// PHASE: keygen
+	var ended int32
+keygen:
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break keygen
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+					return
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case save := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
+				t.Logf("Done. Received save data from %d participants", ended)
+
+				// the Xi shares reconstruct the secret key behind the ECDSA public key
+				shares := make(vss.Shares, 0, len(parties))
+				for j, Pj := range parties {
+					assert.True(t, Pj.data.BigXj[j].Equals(crypto.ScalarBaseMult(group.Secp256k1(), Pj.data.Xi)), "ensure BigX_j == g^x_j")
+					assert.True(t, Pj.data.ECDSAPub.Equals(save.ECDSAPub), "everyone has the same ECDSA public key")
+					assert.Equal(t, 0, Pj.temp.rid.Cmp(parties[0].temp.rid), "everyone has the same rid")
+					assert.Equal(t, save.ChainCode, Pj.data.ChainCode, "everyone has the same chain code")
+					shares = append(shares, &vss.Share{Threshold: threshold, ID: Pj.PartyID().KeyInt(), Share: Pj.data.Xi})
+				}
+				x, err := shares[:threshold+1].ReConstruct(group.Secp256k1())
+				assert.NoError(t, err, "vss.ReConstruct should not throw error")
+				assert.True(t, crypto.ScalarBaseMult(group.Secp256k1(), x).Equals(save.ECDSAPub), "ensure x*G == y")
+
+				// the u_j are wiped by now, but their commitments u_j*G still add up to the public key
+				uG := parties[0].temp.vs[0]
+				for _, Pj := range parties[1:] {
+					uG, err = uG.Add(Pj.temp.vs[0])
+					assert.NoError(t, err)
+				}
+				assert.True(t, uG.Equals(save.ECDSAPub), "ensure sum(u_j*G) == y")
+				break keygen
+			}
+		}
+	}
+}
+
+func TestE2EOversizedRid(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+	outCh := make(chan tss.Message, 4*len(pIDs))
+	endCh := make(chan *ecdsakeygen.LocalPartySaveData, len(pIDs))
+
+	for i := range pIDs {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[i], len(pIDs), 1)
+		parties = append(parties, NewLocalParty(params, outCh, endCh).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// party 1 commits to an rid that is wider than the session identifier
+	var r1msg tss.ParsedMessage
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, pIDs[0], err.Victim())
+			assert.Equal(t, tss.ErrInvalidMessage, err.Kind(), err.Error())
+			if assert.Equal(t, 1, len(err.Culprits())) {
+				assert.Equal(t, pIDs[1], err.Culprits()[0])
+			}
+			return
+		case <-endCh:
+			t.Fatal("keygen must fail")
+		case msg := <-outCh:
+			if pMsg, ok := msg.(tss.ParsedMessage); ok && msg.GetFrom().Index == 1 && msg.IsBroadcast() {
+				switch pMsg.Content().(type) {
+				case *KGRound1Message:
+					// held back until the decommitment is known
+					r1msg = pMsg
+					continue
+				case *KGRound2Message2:
+					badR1msg, badR2msg := recommitRid(r1msg, pMsg, new(big.Int).Lsh(big.NewInt(1), ridBitLen))
+					go func() {
+						test.SharedPartyUpdater(parties[0], badR1msg, errCh)
+						test.SharedPartyUpdater(parties[0], badR2msg, errCh)
+					}()
+					continue
+				}
+			}
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		}
+	}
+}
+
+// recommitRid returns the round 1 and round 2 broadcasts of a party that committed to rid instead
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *ecdsakeygen.LocalPartySaveData, 1)
+	newParty := func(threshold int) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), threshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(testThreshold)
+	assert.Nil(t, P.Start())
+	sent := make([][]byte, 0, len(pIDs))
+	for len(outCh) > 0 {
+		bz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		sent = append(sent, bz)
+	}
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(testThreshold).Restore(snapshot, keystore.NoEncryption()))
+	for _, bz := range sent {
+		reBz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, bz, reBz)
+	}
+
+	// the snapshot cannot be restored into a session with another threshold
+	assert.NotNil(t, newParty(testThreshold+1).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(testThreshold).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+func recommitRid(r1msg, r2msg tss.ParsedMessage, rid *big.Int) (tss.ParsedMessage, tss.ParsedMessage) {
+	D := r2msg.Content().(*KGRound2Message2).UnmarshalDeCommitment()
+	D[1] = rid
+	cmt := commitments.NewHashCommitmentWithRandomness(D[0], D[1:]...)
+	r1 := proto.Clone(r1msg.Content()).(*KGRound1Message)
+	r1.Commitment = cmt.C.Bytes()
+	r2 := proto.Clone(r2msg.Content()).(*KGRound2Message2)
+	r2.DeCommitment = common.BigIntsToBytes(cmt.D)
+	meta := tss.MessageRouting{From: r1msg.GetFrom(), IsBroadcast: true}
+	return tss.NewMessage(meta, r1, tss.NewMessageWrapper(meta, r1)), tss.NewMessage(meta, r2, tss.NewMessageWrapper(meta, r2))
+}
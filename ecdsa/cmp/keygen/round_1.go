@@ -0,0 +1,124 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	ecdsakeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// ridBitLen is the bit length of the random identifier each party contributes to the session
+	ridBitLen = 256
+
+	// chainCodeTag separates the chain code of the key from the session identifier rid it is derived from
+	chainCodeTag = "tss-lib cmp keygen chain code"
+)
+
+// round 1 represents round 1 of the keygen part of the CGGMP21 ECDSA TSS spec (Canetti et al.; 2021)
+func newRound1(params *tss.Parameters, save *ecdsakeygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *ecdsakeygen.LocalPartySaveData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. calculate "partial" key share ui
+	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.Group().Order())
+
+	round.temp.ui = ui
+
+	// 2. compute the vss shares
+	ids := round.Parties().IDs().Keys()
+	vs, shares, err := vss.Create(round.Group(), round.Threshold(), ui, ids, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	round.save.Ks = ids
+
+	// 3. sample this party's contribution to the session identifier rid
+	rid := common.MustGetRandomInt(round.Rand(), ridBitLen)
+
+	// make commitment -> (C, D) over rid || poly*G
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(round.Rand(), append([]*big.Int{rid}, pGFlat...)...)
+
+	// for this P: SAVE
+	// - shareID
+	// and keep in temporary storage:
+	// - VSS Vs
+	// - our set of Shamir shares
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	round.save.ShareID = ids[i]
+	round.temp.vs = vs
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+	round.temp.shares = shares
+	round.temp.rid = rid
+	round.temp.deCommitPolyG = cmt.D
+
+	// BROADCAST commitments; round 1 message
+	{
+		msg := NewKGRound1Message(round.PartyID(), cmt.C)
+		round.temp.kgRound1Messages[i] = msg
+		if err := round.send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.kgRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// de-commitment check is in round 3
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,236 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1. calculate xi
+	xi := new(big.Int).Set(round.temp.shares[PIdx].Share)
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+		share := r2msg1.UnmarshalShare()
+		xi = new(big.Int).Add(xi, share)
+	}
+	round.save.Xi = new(big.Int).Mod(xi, round.Params().Group().Order())
+
+	Vc := make(vss.Vs, round.Threshold()+1)
+	for c := range Vc {
+		Vc[c] = round.temp.vs[c] // ours
+	}
+	round.temp.pjVs[PIdx] = round.temp.vs
+
+	// 2. verify the de-commitments and shares of the other parties
+	type vssOut struct {
+		unWrappedErr error
+		rid          *big.Int
+		pjVs         vss.Vs
+	}
+	chs := make([]chan vssOut, len(Ps))
+	for i := range chs {
+		if i == PIdx {
+			continue
+		}
+		chs[i] = make(chan vssOut)
+	}
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		go func(j int, ch chan<- vssOut) {
+			KGCj := round.temp.KGCs[j]
+			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
+			KGDj := r2msg2.UnmarshalDeCommitment()
+			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
+			ok, flat := cmtDeCmt.DeCommit()
+			if !ok || len(flat) != 1+2*(round.Threshold()+1) {
+				ch <- vssOut{errors.New("de-commitment verify failed"), nil, nil}
+				return
+			}
+			// a wider rid would not fit the session identifier and could not be encoded into the chain code
+			if flat[0].Sign() < 0 || flat[0].BitLen() > ridBitLen {
+				ch <- vssOut{tss.Errorf(tss.ErrInvalidMessage, "rid is out of range"), nil, nil}
+				return
+			}
+			PjVs, err := crypto.UnFlattenECPoints(round.Params().Group(), flat[1:])
+			if err != nil {
+				ch <- vssOut{err, nil, nil}
+				return
+			}
+			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
+			PjShare := vss.Share{
+				Threshold: round.Threshold(),
+				ID:        round.PartyID().KeyInt(),
+				Share:     r2msg1.UnmarshalShare(),
+			}
+			if ok = PjShare.Verify(round.Params().Group(), round.Threshold(), PjVs); !ok {
+				ch <- vssOut{errors.New("vss verify failed"), nil, nil}
+				return
+			}
+			ch <- vssOut{nil, flat[0], PjVs}
+		}(j, chs[j])
+	}
+
+	// consume unbuffered channels (end the goroutines)
+	vssResults := make([]vssOut, len(Ps))
+	{
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			vssResults[j] = <-chs[j]
+			// collect culprits to error out with
+			if err := vssResults[j].unWrappedErr; err != nil {
+				culprits = append(culprits, Pj)
+			}
+		}
+		var multiErr error
+		if len(culprits) > 0 {
+			for _, vssResult := range vssResults {
+				if vssResult.unWrappedErr != nil {
+					multiErr = multierror.Append(multiErr, vssResult.unWrappedErr)
+				}
+			}
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+
+	// 3. combine the commitments and the session identifier rid
+	rid := new(big.Int).Set(round.temp.rid)
+	{
+		var err error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			rid = new(big.Int).Xor(rid, vssResults[j].rid)
+			PjVs := vssResults[j].pjVs
+			round.temp.pjVs[j] = PjVs
+			for c := 0; c <= round.Threshold(); c++ {
+				Vc[c], err = Vc[c].Add(PjVs[c])
+				if err != nil {
+					culprits = append(culprits, Pj)
+				}
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
+		}
+	}
+	round.temp.rid = rid
+
+	// 4. compute Xj for each Pj
+	{
+		var err error
+		modQ := common.ModInt(round.Params().Group().Order())
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		bigXj := round.save.BigXj
+		for j := 0; j < round.PartyCount(); j++ {
+			Pj := round.Parties().IDs()[j]
+			kj := Pj.KeyInt()
+			BigXj := Vc[0]
+			z := new(big.Int).SetInt64(int64(1))
+			for c := 1; c <= round.Threshold(); c++ {
+				z = modQ.Mul(z, kj)
+				BigXj, err = BigXj.Add(Vc[c].ScalarMult(z))
+				if err != nil {
+					culprits = append(culprits, Pj)
+				}
+			}
+			bigXj[j] = BigXj
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
+		}
+		round.save.BigXj = bigXj
+	}
+
+	// 5. compute and SAVE the ECDSA public key `y`
+	ecdsaPubKey, err := crypto.NewECPoint(round.Params().Group(), Vc[0].X(), Vc[0].Y())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
+	}
+	round.save.ECDSAPub = ecdsaPubKey
+
+	// SAVE a chain code derived from rid, which no party could bias as every contribution was committed to in round 1
+	round.save.ChainCode = common.SHA512_256([]byte(chainCodeTag), rid.FillBytes(make([]byte, ridBitLen/8)))
+
+	// PRINT public key & private share
+	common.Logger.Debugf("%s public key: %x", round.PartyID(), ecdsaPubKey)
+
+	// 6. BROADCAST a proof of knowledge of ui bound to the session identifier rid
+	ContextI := common.AppendBigIntToBytesSlice(common.AppendBigIntToBytesSlice(round.temp.ssid, rid), big.NewInt(int64(PIdx)))
+	proof, err := schnorr.NewZKProof(ContextI, round.temp.ui, round.temp.vs[0], round.Rand())
+	if err != nil {
+		return round.WrapError(err, round.PartyID())
+	}
+	// security: u_i may be discarded once its knowledge is proven
+	common.WipeBigInt(round.temp.ui)
+	round.temp.ui = nil
+	r3msg := NewKGRound3Message(round.PartyID(), proof)
+	round.temp.kgRound3Messages[PIdx] = r3msg
+	if err := round.send(r3msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*KGRound3Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.kgRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// proof check is in round 4
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
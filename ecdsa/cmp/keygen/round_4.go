@@ -0,0 +1,89 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round4) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+	Ps := round.Parties().IDs()
+	ssidRid := common.AppendBigIntToBytesSlice(round.temp.ssid, round.temp.rid)
+
+	// 1. verify the proofs of knowledge of each uj (concurrent)
+	// r3 messages are assumed to be available and != nil in this function
+	chs := make([]chan bool, len(round.temp.kgRound3Messages))
+	for j := range chs {
+		chs[j] = make(chan bool)
+	}
+	for j, msg := range round.temp.kgRound3Messages {
+		if j == i {
+			continue
+		}
+		r3msg := msg.Content().(*KGRound3Message)
+		go func(j int, ch chan<- bool) {
+			proof, err := r3msg.UnmarshalZKProof(round.Group())
+			if err != nil {
+				ch <- false
+				return
+			}
+			ContextJ := common.AppendBigIntToBytesSlice(ssidRid, big.NewInt(int64(j)))
+			ch <- proof.Verify(ContextJ, round.temp.pjVs[j][0])
+		}(j, chs[j])
+	}
+
+	// consume unbuffered channels (end the goroutines)
+	for j, ch := range chs {
+		if j == i {
+			round.ok[j] = true
+			continue
+		}
+		round.ok[j] = <-ch
+	}
+	culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+	for j, ok := range round.ok {
+		if !ok {
+			culprits = append(culprits, Ps[j])
+			common.Logger.Warningf("schnorr proof verify failed for party %s", Ps[j])
+			continue
+		}
+		common.Logger.Debugf("schnorr proof verify passed for party %s", Ps[j])
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("schnorr proof verify failed"), culprits...)
+	}
+
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round4) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round4) NextRound() tss.Round {
+	return nil // finished!
+}
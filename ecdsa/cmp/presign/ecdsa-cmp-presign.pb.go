@@ -0,0 +1,461 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/ecdsa-cmp-presign.proto
+
+package presign
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a P2P message sent to each party during Round 1 of the CMP ECDSA TSS presigning protocol.
+type PreSignRound1Message1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EncProof [][]byte `protobuf:"bytes,1,rep,name=enc_proof,json=encProof,proto3" json:"enc_proof,omitempty"`
+}
+
+func (x *PreSignRound1Message1) Reset() {
+	*x = PreSignRound1Message1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound1Message1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound1Message1) ProtoMessage() {}
+
+func (x *PreSignRound1Message1) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound1Message1.ProtoReflect.Descriptor instead.
+func (*PreSignRound1Message1) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cmp_presign_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreSignRound1Message1) GetEncProof() [][]byte {
+	if x != nil {
+		return x.EncProof
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the CMP ECDSA TSS presigning protocol.
+type PreSignRound1Message2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K []byte `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	G []byte `protobuf:"bytes,2,opt,name=g,proto3" json:"g,omitempty"`
+}
+
+func (x *PreSignRound1Message2) Reset() {
+	*x = PreSignRound1Message2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound1Message2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound1Message2) ProtoMessage() {}
+
+func (x *PreSignRound1Message2) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound1Message2.ProtoReflect.Descriptor instead.
+func (*PreSignRound1Message2) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cmp_presign_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PreSignRound1Message2) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *PreSignRound1Message2) GetG() []byte {
+	if x != nil {
+		return x.G
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 2 of the CMP ECDSA TSS presigning protocol.
+type PreSignRound2Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BigGammaX    []byte   `protobuf:"bytes,1,opt,name=big_gamma_x,json=bigGammaX,proto3" json:"big_gamma_x,omitempty"`
+	BigGammaY    []byte   `protobuf:"bytes,2,opt,name=big_gamma_y,json=bigGammaY,proto3" json:"big_gamma_y,omitempty"`
+	D            []byte   `protobuf:"bytes,3,opt,name=d,proto3" json:"d,omitempty"`
+	F            []byte   `protobuf:"bytes,4,opt,name=f,proto3" json:"f,omitempty"`
+	DHat         []byte   `protobuf:"bytes,5,opt,name=d_hat,json=dHat,proto3" json:"d_hat,omitempty"`
+	FHat         []byte   `protobuf:"bytes,6,opt,name=f_hat,json=fHat,proto3" json:"f_hat,omitempty"`
+	AffgProof    [][]byte `protobuf:"bytes,7,rep,name=affg_proof,json=affgProof,proto3" json:"affg_proof,omitempty"`
+	AffgProofHat [][]byte `protobuf:"bytes,8,rep,name=affg_proof_hat,json=affgProofHat,proto3" json:"affg_proof_hat,omitempty"`
+	LogStarProof [][]byte `protobuf:"bytes,9,rep,name=log_star_proof,json=logStarProof,proto3" json:"log_star_proof,omitempty"`
+}
+
+func (x *PreSignRound2Message) Reset() {
+	*x = PreSignRound2Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound2Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound2Message) ProtoMessage() {}
+
+func (x *PreSignRound2Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound2Message.ProtoReflect.Descriptor instead.
+func (*PreSignRound2Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cmp_presign_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PreSignRound2Message) GetBigGammaX() []byte {
+	if x != nil {
+		return x.BigGammaX
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetBigGammaY() []byte {
+	if x != nil {
+		return x.BigGammaY
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetD() []byte {
+	if x != nil {
+		return x.D
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetF() []byte {
+	if x != nil {
+		return x.F
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetDHat() []byte {
+	if x != nil {
+		return x.DHat
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetFHat() []byte {
+	if x != nil {
+		return x.FHat
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetAffgProof() [][]byte {
+	if x != nil {
+		return x.AffgProof
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetAffgProofHat() [][]byte {
+	if x != nil {
+		return x.AffgProofHat
+	}
+	return nil
+}
+
+func (x *PreSignRound2Message) GetLogStarProof() [][]byte {
+	if x != nil {
+		return x.LogStarProof
+	}
+	return nil
+}
+
+// Represents a P2P message sent to each party during Round 3 of the CMP ECDSA TSS presigning protocol.
+type PreSignRound3Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Delta        []byte   `protobuf:"bytes,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	BigDeltaX    []byte   `protobuf:"bytes,2,opt,name=big_delta_x,json=bigDeltaX,proto3" json:"big_delta_x,omitempty"`
+	BigDeltaY    []byte   `protobuf:"bytes,3,opt,name=big_delta_y,json=bigDeltaY,proto3" json:"big_delta_y,omitempty"`
+	LogStarProof [][]byte `protobuf:"bytes,4,rep,name=log_star_proof,json=logStarProof,proto3" json:"log_star_proof,omitempty"`
+}
+
+func (x *PreSignRound3Message) Reset() {
+	*x = PreSignRound3Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreSignRound3Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreSignRound3Message) ProtoMessage() {}
+
+func (x *PreSignRound3Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_cmp_presign_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreSignRound3Message.ProtoReflect.Descriptor instead.
+func (*PreSignRound3Message) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_cmp_presign_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PreSignRound3Message) GetDelta() []byte {
+	if x != nil {
+		return x.Delta
+	}
+	return nil
+}
+
+func (x *PreSignRound3Message) GetBigDeltaX() []byte {
+	if x != nil {
+		return x.BigDeltaX
+	}
+	return nil
+}
+
+func (x *PreSignRound3Message) GetBigDeltaY() []byte {
+	if x != nil {
+		return x.BigDeltaY
+	}
+	return nil
+}
+
+func (x *PreSignRound3Message) GetLogStarProof() [][]byte {
+	if x != nil {
+		return x.LogStarProof
+	}
+	return nil
+}
+
+var File_protob_ecdsa_cmp_presign_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_cmp_presign_proto_rawDesc = []byte{
+	0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x63,
+	0x6d, 0x70, 0x2d, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x20, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62,
+	0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x63, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x65, 0x73, 0x69,
+	0x67, 0x6e, 0x22, 0x34, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x31, 0x12, 0x1b, 0x0a, 0x09, 0x65,
+	0x6e, 0x63, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08,
+	0x65, 0x6e, 0x63, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x33, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x53,
+	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x32, 0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b, 0x12,
+	0x0c, 0x0a, 0x01, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x67, 0x22, 0x87, 0x02,
+	0x0a, 0x14, 0x50, 0x72, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x32, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f, 0x67, 0x61,
+	0x6d, 0x6d, 0x61, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x69, 0x67,
+	0x47, 0x61, 0x6d, 0x6d, 0x61, 0x58, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f, 0x67, 0x61,
+	0x6d, 0x6d, 0x61, 0x5f, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x69, 0x67,
+	0x47, 0x61, 0x6d, 0x6d, 0x61, 0x59, 0x12, 0x0c, 0x0a, 0x01, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x01, 0x64, 0x12, 0x0c, 0x0a, 0x01, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x01, 0x66, 0x12, 0x13, 0x0a, 0x05, 0x64, 0x5f, 0x68, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x64, 0x48, 0x61, 0x74, 0x12, 0x13, 0x0a, 0x05, 0x66, 0x5f, 0x68, 0x61, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x66, 0x48, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x61, 0x66, 0x66, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x09, 0x61, 0x66, 0x66, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x24, 0x0a, 0x0e, 0x61,
+	0x66, 0x66, 0x67, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x68, 0x61, 0x74, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x61, 0x66, 0x66, 0x67, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x48, 0x61,
+	0x74, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x72, 0x5f, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c, 0x6c, 0x6f, 0x67, 0x53, 0x74,
+	0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x92, 0x01, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x53,
+	0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x33, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f, 0x64, 0x65,
+	0x6c, 0x74, 0x61, 0x5f, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x69, 0x67,
+	0x44, 0x65, 0x6c, 0x74, 0x61, 0x58, 0x12, 0x1e, 0x0a, 0x0b, 0x62, 0x69, 0x67, 0x5f, 0x64, 0x65,
+	0x6c, 0x74, 0x61, 0x5f, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x62, 0x69, 0x67,
+	0x44, 0x65, 0x6c, 0x74, 0x61, 0x59, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x6f, 0x67, 0x5f, 0x73, 0x74,
+	0x61, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0c,
+	0x6c, 0x6f, 0x67, 0x53, 0x74, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x42, 0x13, 0x5a, 0x11,
+	0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x63, 0x6d, 0x70, 0x2f, 0x70, 0x72, 0x65, 0x73, 0x69, 0x67,
+	0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_cmp_presign_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_cmp_presign_proto_rawDescData = file_protob_ecdsa_cmp_presign_proto_rawDesc
+)
+
+func file_protob_ecdsa_cmp_presign_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_cmp_presign_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_cmp_presign_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_cmp_presign_proto_rawDescData)
+	})
+	return file_protob_ecdsa_cmp_presign_proto_rawDescData
+}
+
+var file_protob_ecdsa_cmp_presign_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protob_ecdsa_cmp_presign_proto_goTypes = []interface{}{
+	(*PreSignRound1Message1)(nil), // 0: binance.tsslib.ecdsa.cmp.presign.PreSignRound1Message1
+	(*PreSignRound1Message2)(nil), // 1: binance.tsslib.ecdsa.cmp.presign.PreSignRound1Message2
+	(*PreSignRound2Message)(nil),  // 2: binance.tsslib.ecdsa.cmp.presign.PreSignRound2Message
+	(*PreSignRound3Message)(nil),  // 3: binance.tsslib.ecdsa.cmp.presign.PreSignRound3Message
+}
+var file_protob_ecdsa_cmp_presign_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_cmp_presign_proto_init() }
+func file_protob_ecdsa_cmp_presign_proto_init() {
+	if File_protob_ecdsa_cmp_presign_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_cmp_presign_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound1Message1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cmp_presign_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound1Message2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cmp_presign_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound2Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_cmp_presign_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreSignRound3Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_cmp_presign_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_cmp_presign_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_cmp_presign_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_cmp_presign_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_cmp_presign_proto = out.File
+	file_protob_ecdsa_cmp_presign_proto_rawDesc = nil
+	file_protob_ecdsa_cmp_presign_proto_goTypes = nil
+	file_protob_ecdsa_cmp_presign_proto_depIdxs = nil
+}
@@ -0,0 +1,264 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a presigning session in a keystore container
+	SessionSnapshotKind = "ecdsa/cmp/presign.Session"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	// LocalParty runs the CGGMP21 three-round presigning protocol (Canetti et al.; 2021, Fig. 7).
+	// The key must carry the auxiliary info produced by ecdsa/cmp/refresh (or ecdsa/keygen).
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys    keygen.LocalPartySaveData
+		temp    localTempData
+		data    *PreSignatureData
+		journal *tss.SessionJournal
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *PreSignatureData
+	}
+
+	localMessageStore struct {
+		presignRound1Message1s,
+		presignRound1Message2s,
+		presignRound2Messages,
+		presignRound3Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after presigning) / round 1
+		w,
+		k,
+		gamma,
+		rho, // randomness of K
+		nu *big.Int // randomness of G
+		bigWs []*crypto.ECPoint
+		Ks,
+		Gs []*big.Int
+
+		// round 2
+		pointGamma *crypto.ECPoint
+		betas,
+		betaHats []*big.Int
+
+		// round 3
+		bigGamma,
+		bigDelta *crypto.ECPoint
+		delta,
+		chi *big.Int
+
+		ssidNonce *big.Int
+		ssid      []byte
+	}
+)
+
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		params: params,
+		keys:   keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:   localTempData{},
+		data:   &PreSignatureData{},
+		out:    out,
+		end:    end,
+	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
+	// msgs init
+	p.temp.presignRound1Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound1Message2s = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.presignRound3Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.Ks = make([]*big.Int, partyCount)
+	p.temp.Gs = make([]*big.Int, partyCount)
+	p.temp.betas = make([]*big.Int, partyCount)
+	p.temp.betaHats = make([]*big.Int, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.journal)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		round1, ok := round.(*round1)
+		if !ok {
+			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+		}
+		if err := round1.prepare(); err != nil {
+			return round.WrapError(err)
+		}
+		return nil
+	})
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The ki and χi of a finished party are its presignature;
+// otherwise they are wiped too.
+func (p *LocalParty) wipe(finished bool) {
+	common.WipeBigInts(p.temp.w, p.temp.gamma, p.temp.rho, p.temp.nu)
+	common.WipeBigInts(p.temp.betas...)
+	common.WipeBigInts(p.temp.betaHats...)
+	p.temp.w, p.temp.gamma, p.temp.rho, p.temp.nu = nil, nil, nil, nil
+	p.temp.betas, p.temp.betaHats = nil, nil
+	if !finished {
+		common.WipeBigInts(p.temp.k, p.temp.chi)
+	}
+	p.temp.k, p.temp.chi = nil, nil
+	p.journal.Wipe()
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *PreSignRound1Message1:
+		p.temp.presignRound1Message1s[fromPIdx] = msg
+	case *PreSignRound1Message2:
+		p.temp.presignRound1Message2s[fromPIdx] = msg
+	case *PreSignRound2Message:
+		p.temp.presignRound2Messages[fromPIdx] = msg
+	case *PreSignRound3Message:
+		p.temp.presignRound3Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
+	return true, nil
+}
+
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *PreSignRound1Message1, *PreSignRound1Message2:
+		return 1
+	case *PreSignRound2Message:
+		return 2
+	case *PreSignRound3Message:
+		return 3
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot, such as
+// a different round 1 commitment.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey()}
+	if p.keys.ECDSAPub != nil {
+		in = append(in, p.keys.ECDSAPub.X().Bytes(), p.keys.ECDSAPub.Y().Bytes())
+	}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,167 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	// PHASE: presigning
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *PreSignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+presign:
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break presign
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received presignature data from %d participants", ended)
+
+				modN := common.ModInt(group.Secp256k1().Order())
+				k, chi := big.NewInt(0), big.NewInt(0)
+				for _, P := range parties {
+					assert.True(t, P.data.R.Equals(parties[0].data.R), "everyone has the same R")
+					k = modN.Add(k, P.data.KShare)
+					chi = modN.Add(chi, P.data.ChiShare)
+				}
+				// R = g^(k^-1)
+				assert.True(t, crypto.ScalarBaseMult(group.Secp256k1(), modN.ModInverse(k)).Equals(parties[0].data.R), "ensure R == g^(k^-1)")
+				// g^chi = y^k
+				assert.True(t, crypto.ScalarBaseMult(group.Secp256k1(), chi).Equals(keys[0].ECDSAPub.ScalarMult(k)), "ensure g^chi == y^k")
+				break presign
+			}
+		}
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, _, err := keygen.LoadKeygenTestFixtures(testThreshold + 2)
+	assert.NoError(t, err, "should load keygen fixtures")
+	outCh := make(chan tss.Message, 10*len(keys))
+	endCh := make(chan *PreSignatureData, 1)
+	// newParty returns the party of keys[0] in a session with the parties of the first n keys
+	newParty := func(n int) *LocalParty {
+		ids := make(tss.UnSortedPartyIDs, n)
+		for i, key := range keys[:n] {
+			moniker := fmt.Sprintf("%d", i+1)
+			ids[i] = tss.NewPartyID(moniker, moniker, key.ShareID)
+		}
+		signPIDs := tss.SortPartyIDs(ids)
+		params := tss.NewParameters(group.Secp256k1(), tss.NewPeerContext(signPIDs), signPIDs.FindByKey(keys[0].ShareID), n, testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, keys[0], outCh, endCh).(*LocalParty)
+	}
+	n := testThreshold + 1
+
+	P := newParty(n)
+	assert.Nil(t, P.Start())
+	sent := make([][]byte, 0, 2*n)
+	for len(outCh) > 0 {
+		bz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		sent = append(sent, bz)
+	}
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 messages
+	assert.Nil(t, newParty(n).Restore(snapshot, keystore.NoEncryption()))
+	for _, bz := range sent {
+		reBz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, bz, reBz)
+	}
+
+	// the snapshot cannot be restored into a session with another set of parties
+	assert.NotNil(t, newParty(n+1).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(n).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
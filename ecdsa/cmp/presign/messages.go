@@ -0,0 +1,217 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/affgproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/encproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cmp-presign.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that presigning messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PreSignRound1Message1)(nil),
+		(*PreSignRound1Message2)(nil),
+		(*PreSignRound2Message)(nil),
+		(*PreSignRound3Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewPreSignRound1Message1(
+	to, from *tss.PartyID,
+	proof *encproof.ProofEnc,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs := proof.Bytes()
+	content := &PreSignRound1Message1{
+		EncProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound1Message1) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetEncProof(), encproof.ProofEncBytesParts)
+}
+
+func (m *PreSignRound1Message1) UnmarshalEncProof() (*encproof.ProofEnc, error) {
+	return encproof.NewProofFromBytes(m.GetEncProof())
+}
+
+// ----- //
+
+func NewPreSignRound1Message2(
+	from *tss.PartyID,
+	K, G *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &PreSignRound1Message2{
+		K: K.Bytes(),
+		G: G.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound1Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetK()) &&
+		common.NonEmptyBytes(m.GetG())
+}
+
+func (m *PreSignRound1Message2) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+func (m *PreSignRound1Message2) UnmarshalG() *big.Int {
+	return new(big.Int).SetBytes(m.GetG())
+}
+
+// ----- //
+
+func NewPreSignRound2Message(
+	to, from *tss.PartyID,
+	bigGamma *crypto.ECPoint,
+	D, F, DHat, FHat *big.Int,
+	proof, proofHat *affgproof.ProofAffG,
+	proofLogStar *logstarproof.ProofLogStar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs, proofHatBzs, proofLogStarBzs := proof.Bytes(), proofHat.Bytes(), proofLogStar.Bytes()
+	content := &PreSignRound2Message{
+		BigGammaX:    bigGamma.X().Bytes(),
+		BigGammaY:    bigGamma.Y().Bytes(),
+		D:            D.Bytes(),
+		F:            F.Bytes(),
+		DHat:         DHat.Bytes(),
+		FHat:         FHat.Bytes(),
+		AffgProof:    proofBzs[:],
+		AffgProofHat: proofHatBzs[:],
+		LogStarProof: proofLogStarBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetBigGammaX()) &&
+		common.NonEmptyBytes(m.GetBigGammaY()) &&
+		common.NonEmptyBytes(m.GetD()) &&
+		common.NonEmptyBytes(m.GetF()) &&
+		common.NonEmptyBytes(m.GetDHat()) &&
+		common.NonEmptyBytes(m.GetFHat()) &&
+		common.NonEmptyMultiBytes(m.GetAffgProof(), affgproof.ProofAffGBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetAffgProofHat(), affgproof.ProofAffGBytesParts) &&
+		common.NonEmptyMultiBytes(m.GetLogStarProof(), logstarproof.ProofLogStarBytesParts)
+}
+
+func (m *PreSignRound2Message) UnmarshalBigGamma(g group.AffineGroup) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		g,
+		new(big.Int).SetBytes(m.GetBigGammaX()),
+		new(big.Int).SetBytes(m.GetBigGammaY()))
+}
+
+func (m *PreSignRound2Message) UnmarshalD() *big.Int {
+	return new(big.Int).SetBytes(m.GetD())
+}
+
+func (m *PreSignRound2Message) UnmarshalF() *big.Int {
+	return new(big.Int).SetBytes(m.GetF())
+}
+
+func (m *PreSignRound2Message) UnmarshalDHat() *big.Int {
+	return new(big.Int).SetBytes(m.GetDHat())
+}
+
+func (m *PreSignRound2Message) UnmarshalFHat() *big.Int {
+	return new(big.Int).SetBytes(m.GetFHat())
+}
+
+func (m *PreSignRound2Message) UnmarshalAffGProof(g group.AffineGroup) (*affgproof.ProofAffG, error) {
+	return affgproof.NewProofFromBytes(g, m.GetAffgProof())
+}
+
+func (m *PreSignRound2Message) UnmarshalAffGProofHat(g group.AffineGroup) (*affgproof.ProofAffG, error) {
+	return affgproof.NewProofFromBytes(g, m.GetAffgProofHat())
+}
+
+func (m *PreSignRound2Message) UnmarshalLogStarProof(g group.AffineGroup) (*logstarproof.ProofLogStar, error) {
+	return logstarproof.NewProofFromBytes(g, m.GetLogStarProof())
+}
+
+// ----- //
+
+func NewPreSignRound3Message(
+	to, from *tss.PartyID,
+	delta *big.Int,
+	bigDelta *crypto.ECPoint,
+	proof *logstarproof.ProofLogStar,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	proofBzs := proof.Bytes()
+	content := &PreSignRound3Message{
+		Delta:        delta.Bytes(),
+		BigDeltaX:    bigDelta.X().Bytes(),
+		BigDeltaY:    bigDelta.Y().Bytes(),
+		LogStarProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *PreSignRound3Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetDelta()) &&
+		common.NonEmptyBytes(m.GetBigDeltaX()) &&
+		common.NonEmptyBytes(m.GetBigDeltaY()) &&
+		common.NonEmptyMultiBytes(m.GetLogStarProof(), logstarproof.ProofLogStarBytesParts)
+}
+
+func (m *PreSignRound3Message) UnmarshalDelta() *big.Int {
+	return new(big.Int).SetBytes(m.GetDelta())
+}
+
+func (m *PreSignRound3Message) UnmarshalBigDelta(g group.AffineGroup) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		g,
+		new(big.Int).SetBytes(m.GetBigDeltaX()),
+		new(big.Int).SetBytes(m.GetBigDeltaY()))
+}
+
+func (m *PreSignRound3Message) UnmarshalLogStarProof(g group.AffineGroup) (*logstarproof.ProofLogStar, error) {
+	return logstarproof.NewProofFromBytes(g, m.GetLogStarProof())
+}
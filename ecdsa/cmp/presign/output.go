@@ -0,0 +1,110 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *output) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 4
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. verify the proofs that Δj = Γ^kj for each Pj (concurrent)
+	bigDeltas := make([]*crypto.ECPoint, len(Ps))
+	errs := make([]error, len(Ps))
+	wg := sync.WaitGroup{}
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			r3msg := round.temp.presignRound3Messages[j].Content().(*PreSignRound3Message)
+			ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+			bigDeltaJ, err := r3msg.UnmarshalBigDelta(round.Group())
+			if err != nil {
+				errs[j] = err
+				return
+			}
+			proof, err := r3msg.UnmarshalLogStarProof(round.Group())
+			if err != nil || !proof.Verify(ContextJ, round.Group(), round.key.PaillierPKs[j], round.temp.Ks[j], bigDeltaJ, round.temp.bigGamma,
+				round.key.NTildei, round.key.H1i, round.key.H2i) {
+				errs[j] = errors.New("log* proof verify failed")
+				return
+			}
+			bigDeltas[j] = bigDeltaJ
+		}(j)
+	}
+	wg.Wait()
+	if culprits, err := round.collectCulprits(errs); err != nil {
+		return round.WrapError(err, culprits...)
+	}
+
+	// 2. compute δ = ∑δj and check that g^δ = ∏Δj
+	modQ := common.ModInt(round.Group().Order())
+	delta := round.temp.delta
+	bigDelta := round.temp.bigDelta
+	for j := range Ps {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r3msg := round.temp.presignRound3Messages[j].Content().(*PreSignRound3Message)
+		delta = modQ.Add(delta, r3msg.UnmarshalDelta())
+		var err error
+		if bigDelta, err = bigDelta.Add(bigDeltas[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "bigDelta.Add(bigDeltaJ)"), Ps[j])
+		}
+	}
+	if delta.Sign() == 0 || !crypto.ScalarBaseMult(round.Group(), delta).Equals(bigDelta) {
+		return round.WrapError(errors.New("g^delta does not match the product of the bigDeltas"))
+	}
+
+	// 3. R = Γ^(δ^-1)
+	R := round.temp.bigGamma.ScalarMult(modQ.ModInverse(delta))
+
+	round.data.Ks = round.key.Ks
+	round.data.ShareID = round.key.ShareID
+	round.data.R = R
+	round.data.KShare = round.temp.k
+	round.data.ChiShare = round.temp.chi
+	round.data.ECDSAPub = round.key.ECDSAPub
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *output) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *output) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *output) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,32 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+type (
+	// PreSignatureData is one party's output of the presigning protocol, consumed by ecdsa/cmp/signing.
+	// It is bound to the signer set it was produced for and MUST be used to sign one message only:
+	// signing two messages with the same presignature reveals the key share.
+	PreSignatureData struct {
+		// the signing parties' original indexes, in the order of the sorted party IDs
+		Ks      []*big.Int
+		ShareID *big.Int
+
+		// R = Γ^(δ^-1), the nonce point of the signature
+		R *crypto.ECPoint
+		// ki and χi = ki * wi (additive shares of k and k * x)
+		KShare, ChiShare *big.Int
+
+		// used for signature verification
+		ECDSAPub *crypto.ECPoint
+	}
+)
@@ -0,0 +1,151 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/encproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of the presigning part of the CGGMP21 ECDSA TSS spec (Canetti et al.; 2021)
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *PreSignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *PreSignatureData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.ssid = ssid
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	// 1. sample ki, γi and encrypt them under our own Paillier key
+	k := common.GetRandomPositiveInt(round.Rand(), round.Group().Order())
+	gamma := common.GetRandomPositiveInt(round.Rand(), round.Group().Order())
+	pki := round.key.PaillierPKs[i]
+	K, rho, err := pki.EncryptAndReturnRandomness(round.Rand(), k)
+	if err != nil {
+		return round.WrapError(fmt.Errorf("failed to encrypt k: %v", err), Pi)
+	}
+	G, nu, err := pki.EncryptAndReturnRandomness(round.Rand(), gamma)
+	if err != nil {
+		return round.WrapError(fmt.Errorf("failed to encrypt gamma: %v", err), Pi)
+	}
+	round.temp.k = k
+	round.temp.gamma = gamma
+	round.temp.rho = rho
+	round.temp.nu = nu
+	round.temp.Ks[i] = K
+	round.temp.Gs[i] = G
+
+	// 2. p2p send a proof that K encrypts a value in range, using the ring-Pedersen parameters of each Pj
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		proof, err := encproof.NewProof(ContextI, round.Group(), pki, K, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], k, rho, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		r1msg1 := NewPreSignRound1Message1(Pj, Pi, proof)
+		if err := round.send(r1msg1); err != nil {
+			return err
+		}
+	}
+
+	// 3. BROADCAST K and G
+	r1msg2 := NewPreSignRound1Message2(Pi, K, G)
+	round.temp.presignRound1Message2s[i] = r1msg2
+	if err := round.send(r1msg2); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg1 := range round.temp.presignRound1Message1s {
+		if round.ok[j] {
+			continue
+		}
+		if msg1 == nil || !round.CanAccept(msg1) {
+			ret = false
+			continue
+		}
+		msg2 := round.temp.presignRound1Message2s[j]
+		if msg2 == nil || !round.CanAccept(msg2) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound1Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*PreSignRound1Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
+
+// ----- //
+
+// helper to call into PrepareForSigning()
+func (round *round1) prepare() error {
+	i := round.PartyID().Index
+
+	xi := round.key.Xi
+	ks := round.key.Ks
+	bigXs := round.key.BigXj
+
+	if round.Threshold()+1 > len(ks) {
+		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+	}
+	if round.key.PaillierSK == nil {
+		return errors.New("the key has no Paillier key; it must be refreshed before presigning")
+	}
+	for j := range ks {
+		if round.key.PaillierPKs[j] == nil || round.key.NTildej[j] == nil || round.key.H1j[j] == nil || round.key.H2j[j] == nil {
+			return errors.New("the key has no auxiliary info; it must be refreshed before presigning")
+		}
+	}
+	wi, bigWs := signing.PrepareForSigning(round.Params().Group(), i, len(ks), xi, ks, bigXs)
+
+	round.temp.w = wi
+	round.temp.bigWs = bigWs
+	return nil
+}
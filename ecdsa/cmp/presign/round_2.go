@@ -0,0 +1,161 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/affgproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	// 1. store Kj, Gj and verify the enc proofs of each Pj (concurrent)
+	errs := make([]error, len(round.Parties().IDs()))
+	wg := sync.WaitGroup{}
+	for j := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		r1msg2 := round.temp.presignRound1Message2s[j].Content().(*PreSignRound1Message2)
+		round.temp.Ks[j] = r1msg2.UnmarshalK()
+		round.temp.Gs[j] = r1msg2.UnmarshalG()
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			r1msg1 := round.temp.presignRound1Message1s[j].Content().(*PreSignRound1Message1)
+			proof, err := r1msg1.UnmarshalEncProof()
+			if err != nil {
+				errs[j] = err
+				return
+			}
+			ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+			if !proof.Verify(ContextJ, round.Group(), round.key.PaillierPKs[j], round.key.NTildei, round.key.H1i, round.key.H2i, round.temp.Ks[j]) {
+				errs[j] = errors.New("enc proof verify failed")
+			}
+		}(j)
+	}
+	wg.Wait()
+	if culprits, err := round.collectCulprits(errs); err != nil {
+		return round.WrapError(err, culprits...)
+	}
+
+	// 2. compute Γi and run the two MtA instances with each Pj as Bob
+	q := round.Group().Order()
+	betaBound := new(big.Int).Lsh(big.NewInt(1), uint(5*q.BitLen()))
+	g := crypto.Generator(round.Group())
+	pointGamma := crypto.ScalarBaseMult(round.Group(), round.temp.gamma)
+	round.temp.pointGamma = pointGamma
+	pki := round.key.PaillierPKs[i]
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	for j, Pj := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		pkj := round.key.PaillierPKs[j]
+		NTildej, H1j, H2j := round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j]
+
+		beta := common.GetRandomSignedInt(round.Rand(), betaBound)
+		D, F, proof, err := round.mtaBob(ContextI, pkj, pki, NTildej, H1j, H2j, round.temp.Ks[j], pointGamma, round.temp.gamma, beta)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		betaHat := common.GetRandomSignedInt(round.Rand(), betaBound)
+		DHat, FHat, proofHat, err := round.mtaBob(ContextI, pkj, pki, NTildej, H1j, H2j, round.temp.Ks[j], round.temp.bigWs[i], round.temp.w, betaHat)
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		proofLogStar, err := logstarproof.NewProof(ContextI, round.Group(), pki, round.temp.Gs[i], pointGamma, g, NTildej, H1j, H2j,
+			round.temp.gamma, round.temp.nu, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		round.temp.betas[j] = beta
+		round.temp.betaHats[j] = betaHat
+
+		r2msg := NewPreSignRound2Message(Pj, Pi, pointGamma, D, F, DHat, FHat, proof, proofHat, proofLogStar)
+		if err := round.send(r2msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound2Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
+
+// ----- //
+
+// mtaBob computes D = K^x * enc_j(β) and F = enc_i(β) with the proof that they are well formed, where X = g^x.
+// Alice decrypts D to obtain her additive share α = x*k + β; Bob keeps -β as his share.
+func (round *round2) mtaBob(
+	Session []byte,
+	pkj, pki *paillier.PublicKey,
+	NTildej, H1j, H2j, K *big.Int,
+	X *crypto.ECPoint,
+	x, beta *big.Int,
+) (D, F *big.Int, proof *affgproof.ProofAffG, err error) {
+	encBeta, s, err := pkj.EncryptAndReturnRandomness(round.Rand(), new(big.Int).Mod(beta, pkj.N))
+	if err != nil {
+		return nil, nil, nil, errors2.Wrapf(err, "mta: failed to encrypt beta")
+	}
+	if D, err = pkj.HomoMult(x, K); err != nil {
+		return nil, nil, nil, errors2.Wrapf(err, "mta: HomoMult failed")
+	}
+	if D, err = pkj.HomoAdd(D, encBeta); err != nil {
+		return nil, nil, nil, errors2.Wrapf(err, "mta: HomoAdd failed")
+	}
+	F, r, err := pki.EncryptAndReturnRandomness(round.Rand(), new(big.Int).Mod(beta, pki.N))
+	if err != nil {
+		return nil, nil, nil, errors2.Wrapf(err, "mta: failed to encrypt beta")
+	}
+	proof, err = affgproof.NewProof(Session, round.Group(), pkj, pki, NTildej, H1j, H2j, K, D, F, X, x, beta, s, r, round.Rand())
+	return
+}
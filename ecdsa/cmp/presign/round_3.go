@@ -0,0 +1,176 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/logstarproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	Pi := round.PartyID()
+	i := Pi.Index
+	round.ok[i] = true
+
+	// 1. verify the proofs of each Pj and decrypt the MtA shares as Alice (concurrent)
+	g := crypto.Generator(round.Group())
+	pki := round.key.PaillierPKs[i]
+	NTildei, H1i, H2i := round.key.NTildei, round.key.H1i, round.key.H2i
+	Ki := round.temp.Ks[i]
+	bigGammas := make([]*crypto.ECPoint, len(Ps))
+	alphas, alphaHats := make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	errs := make([]error, len(Ps))
+	wg := sync.WaitGroup{}
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		wg.Add(1)
+		go func(j int) {
+			defer wg.Done()
+			r2msg := round.temp.presignRound2Messages[j].Content().(*PreSignRound2Message)
+			pkj := round.key.PaillierPKs[j]
+			ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+			bigGammaJ, err := r2msg.UnmarshalBigGamma(round.Group())
+			if err != nil {
+				errs[j] = err
+				return
+			}
+			D, F, DHat, FHat := r2msg.UnmarshalD(), r2msg.UnmarshalF(), r2msg.UnmarshalDHat(), r2msg.UnmarshalFHat()
+			proof, err := r2msg.UnmarshalAffGProof(round.Group())
+			if err != nil || !proof.Verify(ContextJ, round.Group(), pki, pkj, NTildei, H1i, H2i, Ki, D, F, bigGammaJ) {
+				errs[j] = errors.New("affg proof verify failed")
+				return
+			}
+			proofHat, err := r2msg.UnmarshalAffGProofHat(round.Group())
+			if err != nil || !proofHat.Verify(ContextJ, round.Group(), pki, pkj, NTildei, H1i, H2i, Ki, DHat, FHat, round.temp.bigWs[j]) {
+				errs[j] = errors.New("affg proof (hat) verify failed")
+				return
+			}
+			proofLogStar, err := r2msg.UnmarshalLogStarProof(round.Group())
+			if err != nil || !proofLogStar.Verify(ContextJ, round.Group(), pkj, round.temp.Gs[j], bigGammaJ, g, NTildei, H1i, H2i) {
+				errs[j] = errors.New("log* proof verify failed")
+				return
+			}
+			if alphas[j], err = round.mtaAlice(D); err != nil {
+				errs[j] = err
+				return
+			}
+			if alphaHats[j], err = round.mtaAlice(DHat); err != nil {
+				errs[j] = err
+				return
+			}
+			bigGammas[j] = bigGammaJ
+		}(j)
+	}
+	wg.Wait()
+	if culprits, err := round.collectCulprits(errs); err != nil {
+		return round.WrapError(err, culprits...)
+	}
+
+	// 2. compute Γ = ∏Γj, Δi = Γ^ki, δi = γi*ki + ∑(αij + βij) and χi = wi*ki + ∑(α^ij + β^ij)
+	modQ := common.ModInt(round.Group().Order())
+	bigGamma := round.temp.pointGamma
+	delta := modQ.Mul(round.temp.gamma, round.temp.k)
+	chi := modQ.Mul(round.temp.w, round.temp.k)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		var err error
+		if bigGamma, err = bigGamma.Add(bigGammas[j]); err != nil {
+			return round.WrapError(errors2.Wrapf(err, "bigGamma.Add(bigGammaJ)"), Ps[j])
+		}
+		delta = modQ.Add(delta, modQ.Sub(alphas[j], round.temp.betas[j]))
+		chi = modQ.Add(chi, modQ.Sub(alphaHats[j], round.temp.betaHats[j]))
+	}
+	bigDelta := bigGamma.ScalarMult(round.temp.k)
+	round.temp.bigGamma = bigGamma
+	round.temp.bigDelta = bigDelta
+	round.temp.delta = delta
+	round.temp.chi = chi
+
+	// 3. p2p send δi, Δi and a proof that Δi = Γ^ki where ki is the plaintext of Ki
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		proof, err := logstarproof.NewProof(ContextI, round.Group(), pki, Ki, bigDelta, bigGamma, round.key.NTildej[j],
+			round.key.H1j[j], round.key.H2j[j], round.temp.k, round.temp.rho, round.Rand())
+		if err != nil {
+			return round.WrapError(err, Pi)
+		}
+		r3msg := NewPreSignRound3Message(Pj, Pi, delta, bigDelta, proof)
+		if err := round.send(r3msg); err != nil {
+			return err
+		}
+	}
+
+	// the MtA randomness is no longer needed
+	round.temp.betas = nil
+	round.temp.betaHats = nil
+	return nil
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.presignRound3Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreSignRound3Message); ok {
+		return !msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &output{round}
+}
+
+// ----- //
+
+// mtaAlice decrypts D with our Paillier key and maps the plaintext from [-N/2, N/2] into Zq
+func (round *round3) mtaAlice(D *big.Int) (*big.Int, error) {
+	sk := round.key.PaillierSK
+	alpha, err := sk.Decrypt(D)
+	if err != nil {
+		return nil, errors2.Wrapf(err, "mta: failed to decrypt")
+	}
+	if alpha.Cmp(new(big.Int).Rsh(sk.N, 1)) > 0 {
+		alpha = new(big.Int).Sub(alpha, sk.N)
+	}
+	return new(big.Int).Mod(alpha, round.Group().Order()), nil
+}
@@ -0,0 +1,162 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package presign
+
+import (
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	TaskName = "ecdsa-cmp-presign"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		key     *keygen.LocalPartySaveData
+		data    *PreSignatureData
+		temp    *localTempData
+		out     chan<- tss.Message
+		end     chan<- *PreSignatureData
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+		journal *tss.SessionJournal // set when session snapshots are enabled
+	}
+	round1 struct {
+		*base
+	}
+	round2 struct {
+		*round1
+	}
+	round3 struct {
+		*round2
+	}
+	output struct {
+		*round3
+	}
+)
+
+var (
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*round2)(nil)
+	_ tss.Round = (*round3)(nil)
+	_ tss.Round = (*output)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+// Rand returns the random source of the round, which is the session journal when snapshots are enabled
+func (round *base) Rand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.Parameters.Rand()
+}
+
+// send records msg in the session journal, which refuses it if a restored session diverges, then sends it
+func (round *base) send(msg tss.Message) *tss.Error {
+	if round.journal != nil {
+		if err := round.journal.Send(msg); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	round.out <- msg
+	return nil
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
+
+// collectCulprits returns the parties for which a verification returned an error along with the combined errors
+func (round *base) collectCulprits(errs []error) ([]*tss.PartyID, error) {
+	var multiErr error
+	culprits := make([]*tss.PartyID, 0, len(errs))
+	for j, err := range errs {
+		if err == nil {
+			continue
+		}
+		multiErr = multierror.Append(multiErr, err)
+		culprits = append(culprits, round.Parties().IDs()[j])
+	}
+	return culprits, multiErr
+}
+
+// get ssid from local params
+func (round *base) getSSID() ([]byte, error) {
+	ssidList := []*big.Int{round.Group().Order(), crypto.Generator(round.Group()).X(), crypto.Generator(round.Group()).Y()} // group
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                            // parties
+	BigXjList, err := crypto.FlattenECPoints(round.key.BigXj)
+	if err != nil {
+		return nil, round.WrapError(errors.New("read BigXj failed"), round.PartyID())
+	}
+	ssidList = append(ssidList, BigXjList...) // BigXj
+	for _, pk := range round.key.PaillierPKs {
+		ssidList = append(ssidList, pk.N) // Paillier N
+	}
+	ssidList = append(ssidList, round.key.NTildej...)            // NTilde
+	ssidList = append(ssidList, round.key.H1j...)                // h1
+	ssidList = append(ssidList, round.key.H2j...)                // h2
+	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
+	ssidList = append(ssidList, round.temp.ssidNonce)
+	ssid := common.SHA512_256i(ssidList...).Bytes()
+
+	return ssid, nil
+}
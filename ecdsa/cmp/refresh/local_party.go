@@ -0,0 +1,284 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a refresh session in a keystore container
+	SessionSnapshotKind = "ecdsa/cmp/refresh.Session"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	// LocalParty runs the CGGMP21 key refresh and auxiliary info protocol (Canetti et al.; 2021, Fig. 6).
+	// All parties of the original keygen re-randomize their shares with a sharing of zero, so the public key does not change,
+	// and publish fresh Paillier and ring-Pedersen parameters that replace the ones in the key.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		key     keygen.LocalPartySaveData
+		temp    localTempData
+		data    keygen.LocalPartySaveData
+		journal *tss.SessionJournal
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *keygen.LocalPartySaveData
+	}
+
+	localMessageStore struct {
+		refreshRound1Messages,
+		refreshRound2Messages,
+		refreshRound3Message1s,
+		refreshRound3Message2s []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after refresh)
+		preParams keygen.LocalPreParams
+		rid       *big.Int
+		KGCs      []cmt.HashCommitment
+		vs        vss.Vs
+		pjVs      []vss.Vs
+		ssid      []byte
+		ssidNonce *big.Int
+		shares    vss.Shares
+		deCommit  cmt.HashDeCommitment
+
+		// the pre-params were generated in round 1 rather than given to the constructor
+		ownPreParams bool
+
+		// the new auxiliary info of each Pj, applied to the key when the refresh completes
+		paillierPKs       []*paillier.PublicKey
+		NTildej, H1j, H2j []*big.Int
+	}
+)
+
+// NewLocalParty returns a party that refreshes `key`; the parties must be the same as those of the keygen.
+// When `optionalPreParams` is provided the pre-computed Paillier and ring-Pedersen parameters are used instead of generating new ones.
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *keygen.LocalPartySaveData,
+	optionalPreParams ...keygen.LocalPreParams,
+) tss.Party {
+	partyCount := params.PartyCount()
+	p := &LocalParty{
+		params: params,
+		key:    key,
+		temp:   localTempData{},
+		data:   keygen.NewLocalPartySaveData(partyCount),
+		out:    out,
+		end:    end,
+	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
+	if 0 < len(optionalPreParams) {
+		if 1 < len(optionalPreParams) {
+			panic(errors.New("refresh.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
+		}
+		if !optionalPreParams[0].ValidateWithProof() {
+			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+		}
+		p.temp.preParams = optionalPreParams[0]
+	}
+	// msgs init
+	p.temp.refreshRound1Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.refreshRound2Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.refreshRound3Message1s = make([]tss.ParsedMessage, partyCount)
+	p.temp.refreshRound3Message2s = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.KGCs = make([]cmt.HashCommitment, partyCount)
+	p.temp.pjVs = make([]vss.Vs, partyCount)
+	p.temp.paillierPKs = make([]*paillier.PublicKey, partyCount)
+	p.temp.NTildej = make([]*big.Int, partyCount)
+	p.temp.H1j, p.temp.H2j = make([]*big.Int, partyCount), make([]*big.Int, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.key, &p.data, &p.temp, p.out, p.end, p.journal)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		// the safe primes are generated concurrently, so a restored session could not generate the same ones
+		if !p.temp.preParams.ValidateWithProof() {
+			return p.WrapError(tss.Errorf(tss.ErrInvalidParameters, "session snapshots require the pre-params to be given to the constructor"))
+		}
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The key belongs to the caller and the save data of a
+// finished party is the result of the refresh; otherwise the refreshed xi is wiped, and the new Paillier key and safe
+// primes too if the party generated them.
+func (p *LocalParty) wipe(finished bool) {
+	p.journal.Wipe()
+	for _, share := range p.temp.shares {
+		if share != nil {
+			common.WipeBigInt(share.Share)
+		}
+	}
+	cmt.WipeDeCommitment(p.temp.deCommit)
+	p.temp.shares, p.temp.deCommit = nil, nil
+	if finished {
+		return
+	}
+	common.WipeBigInt(p.data.Xi)
+	p.data.Xi = nil
+	if p.temp.ownPreParams {
+		p.temp.preParams.Wipe()
+		p.temp.ownPreParams = false
+	}
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *RefreshRound1Message:
+		p.temp.refreshRound1Messages[fromPIdx] = msg
+	case *RefreshRound2Message:
+		p.temp.refreshRound2Messages[fromPIdx] = msg
+	case *RefreshRound3Message1:
+		p.temp.refreshRound3Message1s[fromPIdx] = msg
+	case *RefreshRound3Message2:
+		p.temp.refreshRound3Message2s[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
+	return true, nil
+}
+
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *RefreshRound1Message:
+		return 1
+	case *RefreshRound2Message:
+		return 2
+	case *RefreshRound3Message1, *RefreshRound3Message2:
+		return 3
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot, such as
+// a different round 1 commitment.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), new(big.Int).SetUint64(p.key.RefreshEpoch).Bytes(), bigIntBytes(p.temp.preParams.NTildei)}
+	if p.key.ECDSAPub != nil {
+		in = append(in, p.key.ECDSAPub.X().Bytes(), p.key.ECDSAPub.Y().Bytes())
+	}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
+
+func bigIntBytes(i *big.Int) []byte {
+	if i == nil {
+		return nil
+	}
+	return i.Bytes()
+}
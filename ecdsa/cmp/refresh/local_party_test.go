@@ -0,0 +1,176 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testParticipants, len(keys))
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties; the pre-params of the fixtures are rotated so that every party gets new Paillier keys
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		preParams := keys[(i+1)%len(keys)].LocalPreParams
+		P := NewLocalParty(params, keys[i], outCh, endCh, preParams).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// PHASE: refresh
+	var ended int32
+refresh:
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break refresh
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+					return
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
+				t.Logf("Done. Received save data from %d participants", ended)
+
+				oldShares, newShares := make(vss.Shares, 0, len(parties)), make(vss.Shares, 0, len(parties))
+				for j, Pj := range parties {
+					data := Pj.data
+					assert.NotEqual(t, 0, data.Xi.Cmp(keys[j].Xi), "the key share must change")
+					assert.True(t, data.ECDSAPub.Equals(keys[j].ECDSAPub), "the public key must not change")
+					assert.Equal(t, keys[j].RefreshEpoch+1, data.RefreshEpoch)
+					assert.Equal(t, 0, data.PaillierSK.N.Cmp(keys[(j+1)%len(keys)].PaillierSK.N), "the Paillier key must be rotated")
+					for k := range parties {
+						assert.True(t, data.BigXj[k].Equals(parties[k].data.BigXj[k]), "everyone has the same BigXj")
+						assert.Equal(t, 0, data.PaillierPKs[k].N.Cmp(parties[k].data.PaillierSK.N))
+						assert.Equal(t, 0, data.NTildej[k].Cmp(parties[k].data.NTildei))
+					}
+					assert.True(t, data.BigXj[j].Equals(crypto.ScalarBaseMult(group.Secp256k1(), data.Xi)), "ensure BigX_j == g^x_j")
+					oldShares = append(oldShares, &vss.Share{Threshold: threshold, ID: keys[j].ShareID, Share: keys[j].Xi})
+					newShares = append(newShares, &vss.Share{Threshold: threshold, ID: data.ShareID, Share: data.Xi})
+				}
+				oldX, err := oldShares[:threshold+1].ReConstruct(group.Secp256k1())
+				assert.NoError(t, err)
+				newX, err := newShares[1 : threshold+2].ReConstruct(group.Secp256k1())
+				assert.NoError(t, err)
+				assert.Equal(t, 0, oldX.Cmp(newX), "the refreshed shares must reconstruct the same secret")
+				break refresh
+			}
+		}
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
+	newParty := func(preParams ...keygen.LocalPreParams) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, keys[0], outCh, endCh, preParams...).(*LocalParty)
+	}
+
+	// the safe primes cannot be generated again by a restored session, so the pre-params must be given
+	assert.NotNil(t, newParty().Start())
+
+	P := newParty(keys[1].LocalPreParams)
+	assert.Nil(t, P.Start())
+	sent := make([][]byte, 0, len(pIDs))
+	for len(outCh) > 0 {
+		bz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		sent = append(sent, bz)
+	}
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(keys[1].LocalPreParams).Restore(snapshot, keystore.NoEncryption()))
+	for _, bz := range sent {
+		reBz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, bz, reBz)
+	}
+
+	// the snapshot cannot be restored into a session with other pre-params
+	assert.NotNil(t, newParty(keys[2].LocalPreParams).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(keys[1].LocalPreParams).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
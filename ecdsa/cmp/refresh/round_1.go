@@ -0,0 +1,141 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	// ridBitLen is the bit length of the random identifier each party contributes to the session
+	ridBitLen = 256
+)
+
+// round 1 represents round 1 of the key refresh part of the CGGMP21 ECDSA TSS spec (Canetti et al.; 2021)
+func newRound1(params *tss.Parameters, key, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, key, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 0. the key must belong to this party and to the same set of parties
+	ids := round.Parties().IDs().Keys()
+	if len(round.key.Ks) != len(ids) || round.key.Xi == nil || round.key.ShareID == nil || round.key.ECDSAPub == nil {
+		return round.WrapError(errors.New("the key does not match the set of parties"))
+	}
+	for j, id := range ids {
+		if round.key.Ks[j] == nil || round.key.Ks[j].Cmp(id) != 0 || round.key.BigXj[j] == nil {
+			return round.WrapError(errors.New("the key does not match the set of parties"))
+		}
+	}
+	if round.key.ShareID.Cmp(Pi.KeyInt()) != 0 {
+		return round.WrapError(errors.New("the key does not belong to this party"))
+	}
+
+	// 1. use the pre-params if they were provided to the LocalParty constructor
+	preParams := &round.temp.preParams
+	if !preParams.ValidateWithProof() {
+		var err error
+		ctx, cancel := context.WithTimeout(context.Background(), round.SafePrimeGenTimeout())
+		defer cancel()
+		preParams, err = keygen.GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
+		if err != nil {
+			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+		}
+		round.temp.preParams = *preParams
+		round.temp.ownPreParams = true
+	}
+
+	// 2. compute a sharing of zero to re-randomize the key shares
+	vs, shares, err := vss.CreateZeroSharing(round.Group(), round.Threshold(), ids, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// 3. sample this party's contribution to the session identifier rid
+	rid := common.MustGetRandomInt(round.Rand(), ridBitLen)
+
+	// make commitment -> (C, D) over rid || N || NTilde || h1 || h2 || poly*G
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	secrets := []*big.Int{rid, preParams.PaillierSK.N, preParams.NTildei, preParams.H1i, preParams.H2i}
+	cmt := cmts.NewHashCommitment(round.Rand(), append(secrets, pGFlat...)...)
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.rid = rid
+	round.temp.deCommit = cmt.D
+	round.temp.paillierPKs[i] = &preParams.PaillierSK.PublicKey
+	round.temp.NTildej[i] = preParams.NTildei
+	round.temp.H1j[i], round.temp.H2j[i] = preParams.H1i, preParams.H2i
+
+	// BROADCAST commitment; round 1 message
+	{
+		msg := NewRefreshRound1Message(round.PartyID(), cmt.C)
+		round.temp.refreshRound1Messages[i] = msg
+		if err := round.send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.refreshRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// de-commitment check is in round 3
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,86 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	i := round.PartyID().Index
+
+	// store r1 message pieces
+	for j, msg := range round.temp.refreshRound1Messages {
+		if j == i {
+			continue
+		}
+		r1msg := msg.Content().(*RefreshRound1Message)
+		round.temp.KGCs[j] = r1msg.UnmarshalCommitment()
+	}
+
+	// 1. generate the dlnproofs for the new ring-Pedersen parameters
+	preParams := round.temp.preParams
+	h1i, h2i, alpha, beta, p, q, NTildei := preParams.H1i,
+		preParams.H2i,
+		preParams.Alpha,
+		preParams.Beta,
+		preParams.P,
+		preParams.Q,
+		preParams.NTildei
+	dlnProof1 := dlnproof.NewDLNProof(h1i, h2i, alpha, p, q, NTildei, round.Rand())
+	dlnProof2 := dlnproof.NewDLNProof(h2i, h1i, beta, p, q, NTildei, round.Rand())
+
+	// 2. BROADCAST de-commitment and dlnproofs
+	r2msg, err := NewRefreshRound2Message(round.PartyID(), round.temp.deCommit, dlnProof1, dlnProof2)
+	if err != nil {
+		return round.WrapError(err, round.PartyID())
+	}
+	round.temp.refreshRound2Messages[i] = r2msg
+	if err := round.send(r2msg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.refreshRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// de-commitment and dlnproof checks are in round 3
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,187 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. verify the de-commitments, store the new auxiliary info of each Pj and ensure uniqueness of h1j, h2j
+	rid := new(big.Int).Set(round.temp.rid)
+	h1H2Map := make(map[string]struct{}, len(Ps)*2)
+	for j, msg := range round.temp.refreshRound2Messages {
+		if j == i {
+			continue
+		}
+		r2msg := msg.Content().(*RefreshRound2Message)
+		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.KGCs[j], D: r2msg.UnmarshalDeCommitment()}
+		ok, flat := cmtDeCmt.DeCommit()
+		if !ok || len(flat) != 5+2*round.Threshold() {
+			return round.WrapError(errors.New("de-commitment verify failed"), msg.GetFrom())
+		}
+		ridj, paillierPKj, NTildej, H1j, H2j := flat[0], &paillier.PublicKey{N: flat[1]}, flat[2], flat[3], flat[4]
+		PjVs, err := crypto.UnFlattenECPoints(round.Group(), flat[5:])
+		if err != nil {
+			return round.WrapError(err, msg.GetFrom())
+		}
+		if paillierPKj.N.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		if NTildej.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+
+		rid = new(big.Int).Xor(rid, ridj)
+		round.temp.pjVs[j] = PjVs
+		round.temp.paillierPKs[j] = paillierPKj
+		round.temp.NTildej[j] = NTildej
+		round.temp.H1j[j], round.temp.H2j[j] = H1j, H2j
+	}
+	round.temp.rid = rid
+
+	// 2. verify the dln proofs
+	dlnVerifier := keygen.NewDlnProofVerifier(round.Concurrency())
+	dlnProof1FailCulprits := make([]*tss.PartyID, len(Ps))
+	dlnProof2FailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.refreshRound2Messages {
+		if j == i {
+			continue
+		}
+		r2msg := msg.Content().(*RefreshRound2Message)
+		H1j, H2j, NTildej := round.temp.H1j[j], round.temp.H2j[j], round.temp.NTildej[j]
+
+		wg.Add(2)
+		_j := j
+		_msg := msg
+
+		dlnVerifier.VerifyDLNProof1(r2msg, H1j, H2j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof1FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+		dlnVerifier.VerifyDLNProof2(r2msg, H2j, H1j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof2FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+	}
+	wg.Wait()
+	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+		}
+	}
+
+	// 3. p2p send share ij to Pj with a proof that the new Paillier modulus has no small factors
+	preParams := round.temp.preParams
+	ContextI := common.AppendBigIntToBytesSlice(common.AppendBigIntToBytesSlice(round.temp.ssid, rid), big.NewInt(int64(i)))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		facProof, err := facproof.NewProof(ContextI, round.Group(), preParams.PaillierSK.N, round.temp.NTildej[j],
+			round.temp.H1j[j], round.temp.H2j[j], preParams.PaillierSK.P, preParams.PaillierSK.Q, round.Rand())
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+		r3msg1 := NewRefreshRound3Message1(Pj, round.PartyID(), round.temp.shares[j], facProof)
+		if err := round.send(r3msg1); err != nil {
+			return err
+		}
+	}
+
+	// 4. BROADCAST a proof that the new Paillier modulus is a Paillier-Blum modulus
+	modProof, err := modproof.NewProof(ContextI, preParams.PaillierSK.N, preParams.PaillierSK.P, preParams.PaillierSK.Q, round.Rand())
+	if err != nil {
+		return round.WrapError(err, round.PartyID())
+	}
+	r3msg2 := NewRefreshRound3Message2(round.PartyID(), modProof)
+	round.temp.refreshRound3Message2s[i] = r3msg2
+	if err := round.send(r3msg2); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound3Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RefreshRound3Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.refreshRound3Message2s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		if j != round.PartyID().Index {
+			msg1 := round.temp.refreshRound3Message1s[j]
+			if msg1 == nil || !round.CanAccept(msg1) {
+				ret = false
+				continue
+			}
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	round.started = false
+	return &round4{round}
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	sumS := round.temp.sigma
+	modN := common.ModInt(round.Params().Group().Order())
+
+	for j := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		r1msg := round.temp.signRoundMessages[j].Content().(*SignRoundMessage)
+		sumS = modN.Add(sumS, r1msg.UnmarshalSigma())
+	}
+
+	recid := 0
+	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
+	if round.preSig.R.X().Cmp(round.Params().Group().Order()) > 0 {
+		recid = 2
+	}
+	if round.temp.ry.Bit(0) != 0 {
+		recid |= 1
+	}
+
+	// This is copied from:
+	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
+	// This is needed because of tendermint checks here:
+	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
+	// Other curves keep s as computed unless low-S is requested with tss.Parameters.SetLowS.
+	halfN := new(big.Int).Rsh(round.Params().Group().Order(), 1)
+	if round.Params().LowS() && sumS.Cmp(halfN) > 0 {
+		sumS.Sub(round.Params().Group().Order(), sumS)
+		recid ^= 1
+	}
+
+	// save the signature for final output
+	bitSizeInBytes := round.Group().ScalarLen()
+	round.data.R = padToLengthBytesInPlace(round.temp.rx.Bytes(), bitSizeInBytes)
+	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
+	round.data.Signature = append(round.data.R, round.data.S...)
+	round.data.SignatureRecovery = []byte{byte(recid)}
+	if round.temp.fullBytesLen == 0 {
+		round.data.M = round.temp.m.Bytes()
+	} else {
+		var mBytes = make([]byte, round.temp.fullBytesLen)
+		round.temp.m.FillBytes(mBytes)
+		round.data.M = mBytes
+	}
+
+	pk := ecdsa.PublicKey{
+		Curve: round.Params().Group().Curve(),
+		X:     round.preSig.ECDSAPub.X(),
+		Y:     round.preSig.ECDSAPub.Y(),
+	}
+
+	ok := ecdsa.Verify(&pk, round.data.M, round.temp.rx, sumS)
+	if !ok {
+		return round.WrapError(fmt.Errorf("signature verification failed"))
+	}
+
+	round.end <- round.data
+
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+func padToLengthBytesInPlace(src []byte, length int) []byte {
+	oriLen := len(src)
+	if oriLen < length {
+		for i := 0; i < length-oriLen; i++ {
+			src = append([]byte{0}, src...)
+		}
+	}
+	return src
+}
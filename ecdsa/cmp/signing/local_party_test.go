@@ -0,0 +1,202 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/cmp/presign"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	// PHASE: presigning
+	preSigs := runPresign(t, keys, signPIDs)
+
+	// PHASE: signing
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewLocalParty(big.NewInt(42), params, *preSigs[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			ps := make([]tss.Party, len(parties))
+			for i, P := range parties {
+				ps[i] = P
+			}
+			route(t, ps, msg, errCh)
+
+		case data := <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+
+				// BEGIN ECDSA verify
+				pk := ecdsa.PublicKey{
+					Curve: tss.S256(),
+					X:     keys[0].ECDSAPub.X(),
+					Y:     keys[0].ECDSAPub.Y(),
+				}
+				ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), new(big.Int).SetBytes(data.R), new(big.Int).SetBytes(data.S))
+				assert.True(t, ok, "ecdsa verify must pass")
+				t.Log("ECDSA signing test done.")
+				// END ECDSA verify
+
+				break signing
+			}
+		}
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preSigs := runPresign(t, keys, signPIDs)
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *common.SignatureData, 1)
+	newParty := func(msg int64, preSig presign.PreSignatureData) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(big.NewInt(msg), params, preSig, outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(42, *preSigs[0])
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same σi
+	assert.Nil(t, newParty(42, *preSigs[0]).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session that signs another message
+	assert.NotNil(t, newParty(43, *preSigs[0]).Restore(snapshot, keystore.NoEncryption()))
+
+	// a party whose shares of the presignature no longer give the σi that was sent refuses to send another one
+	other := *preSigs[0]
+	other.KShare = new(big.Int).Add(other.KShare, big.NewInt(1))
+	err = newParty(42, other).Restore(snapshot, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+// runPresign runs a presigning and returns the presignature of each party
+func runPresign(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs) []*presign.PreSignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	preSigs := make([]*presign.PreSignatureData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *presign.PreSignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := presign.NewLocalParty(params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			route(t, parties, msg, errCh)
+		case preSig := <-endCh:
+			preSigs[signPIDs.FindByKey(preSig.ShareID).Index] = preSig
+			ended++
+		}
+	}
+	return preSigs
+}
+
+func route(t *testing.T, parties []tss.Party, msg tss.Message, errCh chan<- *tss.Error) {
+	dest := msg.GetTo()
+	if dest == nil {
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			go test.SharedPartyUpdater(P, msg, errCh)
+		}
+		return
+	}
+	if dest[0].Index == msg.GetFrom().Index {
+		t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+	}
+	go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+}
@@ -0,0 +1,50 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-cmp-signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRoundMessage)(nil),
+	}
+)
+
+// ----- //
+
+func NewSignRoundMessage(
+	from *tss.PartyID,
+	sigma *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRoundMessage{
+		Sigma: sigma.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRoundMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetSigma())
+}
+
+func (m *SignRoundMessage) UnmarshalSigma() *big.Int {
+	return new(big.Int).SetBytes(m.GetSigma())
+}
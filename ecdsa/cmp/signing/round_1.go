@@ -0,0 +1,99 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/cmp/presign"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents the online round of the signing part of the CGGMP21 ECDSA TSS spec (Canetti et al.; 2021)
+func newRound1(params *tss.Parameters, preSig *presign.PreSignatureData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, preSig, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+
+	// Spec requires calculate H(M) here,
+	// but considered different blockchain use different hash function we accept the converted big.Int
+	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
+	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
+	if round.temp.m.Cmp(round.Params().Group().Order()) >= 0 {
+		return round.WrapError(errors.New("hashed message is not valid"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	// 0. the presignature must have been produced by this party for the same set of parties
+	preSig := round.preSig
+	ids := round.Parties().IDs().Keys()
+	if preSig.R == nil || preSig.KShare == nil || preSig.ChiShare == nil || len(preSig.Ks) != len(ids) {
+		return round.WrapError(errors.New("the presignature does not match the set of signing parties"))
+	}
+	for j, id := range ids {
+		if preSig.Ks[j] == nil || preSig.Ks[j].Cmp(id) != 0 {
+			return round.WrapError(errors.New("the presignature does not match the set of signing parties"))
+		}
+	}
+	if preSig.ShareID == nil || preSig.ShareID.Cmp(round.PartyID().KeyInt()) != 0 {
+		return round.WrapError(errors.New("the presignature does not belong to this party"))
+	}
+
+	// 1. r = R.x, σi = ki*m + r*χi
+	modN := common.ModInt(round.Params().Group().Order())
+	round.temp.rx = new(big.Int).Mod(preSig.R.X(), round.Params().Group().Order())
+	round.temp.ry = preSig.R.Y()
+	sigma := modN.Add(modN.Mul(preSig.KShare, round.temp.m), modN.Mul(round.temp.rx, preSig.ChiShare))
+	round.temp.sigma = sigma
+
+	// 2. BROADCAST σi
+	i := round.PartyID().Index
+	r1msg := NewSignRoundMessage(round.PartyID(), sigma)
+	round.temp.signRoundMessages[i] = r1msg
+	if err := round.send(r1msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRoundMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRoundMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
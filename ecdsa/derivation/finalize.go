@@ -0,0 +1,94 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	g := crypto.ScalarBaseMult(round.Group(), big.NewInt(1))
+
+	// 1. verify the share of each Pj against Wj and sum the shares into x * H
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r1msg := round.temp.deriveRound1Messages[j].Content().(*DeriveRound1Message)
+		share, err := r1msg.UnmarshalShare(round.Group())
+		if err != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		proof, err := r1msg.UnmarshalDLEQProof(round.Group())
+		if err != nil || !proof.Verify(proofSession(round.key.Ks[j]), g, round.temp.bigWs[j], round.temp.bigH, share) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		round.temp.shares[j] = share
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("hardened secret share verification failed"), culprits...)
+	}
+	point := round.temp.shares[0]
+	for _, share := range round.temp.shares[1:] {
+		var err error
+		if point, err = point.Add(share); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	secret := &ckd.HardenedSecret{Base: round.temp.bigH, Point: point}
+
+	// 2. derive the child key along the path
+	pub := round.key.ECDSAPub
+	master := &ckd.ExtendedKey{
+		PublicKey: ecdsa.PublicKey{Curve: round.Group().Curve(), X: pub.X(), Y: pub.Y()},
+		ChainCode: round.temp.chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+		Version:   chaincfg.MainNetParams.HDPublicKeyID[:],
+	}
+	delta, child, err := ckd.DeriveChildKeyFromHierarchyWithSecret(round.temp.path, master, secret, round.Group().Order(), round.Group())
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	round.end <- &ChildKey{Delta: delta, ExtendedKey: child}
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,222 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package derivation lets a committee of t+1 signers derive a child key along a path that may contain hardened
+// indices. The hardened steps use the threshold-friendly scheme of crypto/ckd, which is not compatible with BIP-32.
+package derivation
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a derivation session in a keystore container
+	SessionSnapshotKind = "ecdsa/derivation.Session"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	// LocalParty jointly computes x * H for the hardened derivation of the keygen key x, proving each share with a
+	// DLEQ proof, and then derives the child key of the path locally. The protocol has one round.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		keys    keygen.LocalPartySaveData
+		temp    localTempData
+		journal *tss.SessionJournal
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *ChildKey
+	}
+
+	// ChildKey is the output of the derivation. Delta is the key derivation delta to pass to
+	// signing.NewLocalPartyWithKDD along with ExtendedKey.PublicKey.
+	ChildKey struct {
+		Delta       *big.Int
+		ExtendedKey *ckd.ExtendedKey
+	}
+
+	localMessageStore struct {
+		deriveRound1Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after derivation) / round 1
+		chainCode []byte
+		path      []uint32
+		bigH      *crypto.ECPoint
+		bigWs     []*crypto.ECPoint
+		shares    []*crypto.ECPoint
+	}
+)
+
+// NewLocalParty returns a party that derives the child of the keygen public key and chainCode at path
+func NewLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	chainCode []byte,
+	path []uint32,
+	out chan<- tss.Message,
+	end chan<- *ChildKey,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		params: params,
+		keys:   keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:   localTempData{},
+		out:    out,
+		end:    end,
+	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
+	// msgs init
+	p.temp.deriveRound1Messages = make([]tss.ParsedMessage, partyCount)
+
+	// temp data init
+	p.temp.chainCode = chainCode
+	p.temp.path = path
+	p.temp.shares = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.keys, &p.temp, p.out, p.end, p.journal)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
+	return tss.BaseStart(p, TaskName)
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the randomness of the session, see tss.NewBaseParty
+func (p *LocalParty) wipe(bool) {
+	p.journal.Wipe()
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *DeriveRound1Message:
+		p.temp.deriveRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
+	return true, nil
+}
+
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *DeriveRound1Message:
+		return 1
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot, such as
+// a different round 1 commitment.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), p.temp.chainCode}
+	for _, index := range p.temp.path {
+		in = append(in, big.NewInt(int64(index)).Bytes())
+	}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,183 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"crypto/rand"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	chainCode := make([]byte, 32)
+	_, _ = rand.Read(chainCode)
+	// m/44'/60'/0'/0/7
+	path := []uint32{ckd.HardenedKeyStart + 44, ckd.HardenedKeyStart + 60, ckd.HardenedKeyStart, 0, 7}
+
+	// PHASE: derive with two random sets of signers
+	var children []*ChildKey
+	var keys []keygen.LocalPartySaveData
+	for run := 0; run < 2; run++ {
+		var signPIDs tss.SortedPartyIDs
+		var err error
+		keys, signPIDs, err = keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+		if !assert.NoError(t, err, "should load keygen fixtures") {
+			return
+		}
+		children = append(children, runDerivation(t, keys, signPIDs, chainCode, path))
+	}
+
+	// the child does not depend on the set of signers
+	assert.Equal(t, 0, children[0].Delta.Cmp(children[1].Delta))
+	assert.Equal(t, children[0].ExtendedKey.String(), children[1].ExtendedKey.String())
+
+	// the child key is x + delta, and matches a local derivation with the secret x * H
+	modQ := common.ModInt(group.Secp256k1().Order())
+	x := big.NewInt(0)
+	for j, key := range keys {
+		lambda := big.NewInt(1)
+		for c, other := range keys {
+			if c != j {
+				lambda = modQ.Mul(lambda, modQ.Mul(other.ShareID, modQ.ModInverse(modQ.Sub(other.ShareID, key.ShareID))))
+			}
+		}
+		x = modQ.Add(x, modQ.Mul(lambda, key.Xi))
+	}
+	child := children[0]
+	childPub, err := crypto.NewECPoint(group.Secp256k1(), child.ExtendedKey.X, child.ExtendedKey.Y)
+	assert.NoError(t, err)
+	assert.True(t, crypto.ScalarBaseMult(group.Secp256k1(), modQ.Add(x, child.Delta)).Equals(childPub))
+
+	bigH, err := ckd.HardenedBase(group.Secp256k1(), keys[0].ECDSAPub)
+	assert.NoError(t, err)
+	secret := &ckd.HardenedSecret{Base: bigH, Point: bigH.ScalarMult(x)}
+	master := &ckd.ExtendedKey{
+		PublicKey: *keys[0].ECDSAPub.ToECDSAPubKey(),
+		ChainCode: chainCode,
+		ParentFP:  []byte{0x00, 0x00, 0x00, 0x00},
+		Version:   child.ExtendedKey.Version,
+	}
+	delta, expected, err := ckd.DeriveChildKeyFromHierarchyWithSecret(path, master, secret, group.Secp256k1().Order(), group.Secp256k1())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delta.Cmp(child.Delta))
+	assert.Equal(t, expected.String(), child.ExtendedKey.String())
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *ChildKey, 1)
+	chainCode := make([]byte, 32)
+	_, _ = rand.Read(chainCode)
+	newParty := func(path []uint32) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, keys[0], chainCode, path, outCh, endCh).(*LocalParty)
+	}
+	path := []uint32{ckd.HardenedKeyStart + 44, 0}
+
+	P := newParty(path)
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same share and proof
+	assert.Nil(t, newParty(path).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a derivation along another path
+	assert.NotNil(t, newParty([]uint32{ckd.HardenedKeyStart + 44, 1}).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the proof is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(path).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+func runDerivation(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, chainCode []byte, path []uint32) *ChildKey {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *ChildKey, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(params, keys[i], chainCode, path, outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+	var child *ChildKey
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			if child != nil {
+				assert.Equal(t, 0, child.Delta.Cmp(data.Delta), "all parties must output the same delta")
+				assert.Equal(t, child.ExtendedKey.String(), data.ExtendedKey.String(), "all parties must output the same key")
+			}
+			child = data
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				return child
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dleqproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-derivation.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that derivation messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*DeriveRound1Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewDeriveRound1Message(
+	from *tss.PartyID,
+	share *crypto.ECPoint,
+	proof *dleqproof.ProofDLEQ,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	proofBzs := proof.Bytes()
+	content := &DeriveRound1Message{
+		ShareX:    share.X().Bytes(),
+		ShareY:    share.Y().Bytes(),
+		DleqProof: proofBzs[:],
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *DeriveRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetShareX()) &&
+		common.NonEmptyBytes(m.GetShareY()) &&
+		common.NonEmptyMultiBytes(m.GetDleqProof(), dleqproof.ProofDLEQBytesParts)
+}
+
+func (m *DeriveRound1Message) UnmarshalShare(g group.AffineGroup) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		g,
+		new(big.Int).SetBytes(m.GetShareX()),
+		new(big.Int).SetBytes(m.GetShareY()))
+}
+
+func (m *DeriveRound1Message) UnmarshalDLEQProof(g group.AffineGroup) (*dleqproof.ProofDLEQ, error) {
+	return dleqproof.NewProofFromBytes(g, m.GetDleqProof())
+}
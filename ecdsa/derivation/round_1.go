@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dleqproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 broadcasts wi * H with a proof that it has the same discrete log as Wi = wi * G
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *ChildKey, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, key, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	if len(round.temp.chainCode) != 32 {
+		return round.WrapError(errors.New("the chain code must be 32 bytes"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 1. H is derived from the public key, so that no party knows its discrete log
+	bigH, err := ckd.HardenedBase(round.Group(), round.key.ECDSAPub)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.bigH = bigH
+
+	// 2. wi = λi * xi, so that x * H = Σ wj * H
+	wi, bigWs := signing.PrepareForSigning(round.Group(), i, len(round.Parties().IDs()), round.key.Xi, round.key.Ks, round.key.BigXj)
+	round.temp.bigWs = bigWs
+
+	// 3. BROADCAST wi * H and a proof that log_G(Wi) = log_H(wi * H)
+	g := crypto.ScalarBaseMult(round.Group(), big.NewInt(1))
+	share := bigH.ScalarMult(wi)
+	proof, err := dleqproof.NewProof(proofSession(round.key.Ks[i]), wi, g, bigWs[i], bigH, share, round.Rand())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NewProof(dleq)"))
+	}
+	round.temp.shares[i] = share
+
+	r1msg := NewDeriveRound1Message(Pi, share, proof)
+	round.temp.deriveRound1Messages[i] = r1msg
+	if err := round.send(r1msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.deriveRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*DeriveRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+// proofSession binds a DLEQ proof to the party that made it
+func proofSession(kj *big.Int) []byte {
+	return append([]byte(TaskName), kj.Bytes()...)
+}
@@ -0,0 +1,111 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package derivation
+
+import (
+	"io"
+
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	TaskName = "ecdsa-derivation"
+)
+
+type (
+	base struct {
+		*tss.Parameters
+		key     *keygen.LocalPartySaveData
+		temp    *localTempData
+		out     chan<- tss.Message
+		end     chan<- *ChildKey
+		ok      []bool // `ok` tracks parties which have been verified by Update()
+		started bool
+		number  int
+		journal *tss.SessionJournal // set when session snapshots are enabled
+	}
+	round1 struct {
+		*base
+	}
+	finalization struct {
+		*round1
+	}
+)
+
+var (
+	_ tss.Round = (*round1)(nil)
+	_ tss.Round = (*finalization)(nil)
+)
+
+// ----- //
+
+func (round *base) Params() *tss.Parameters {
+	return round.Parameters
+}
+
+func (round *base) RoundNumber() int {
+	return round.number
+}
+
+// CanProceed is inherited by other rounds
+func (round *base) CanProceed() bool {
+	if !round.started {
+		return false
+	}
+	for _, ok := range round.ok {
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// WaitingFor is called by a Party for reporting back to the caller
+func (round *base) WaitingFor() []*tss.PartyID {
+	Ps := round.Parties().IDs()
+	ids := make([]*tss.PartyID, 0, len(round.ok))
+	for j, ok := range round.ok {
+		if ok {
+			continue
+		}
+		ids = append(ids, Ps[j])
+	}
+	return ids
+}
+
+// Rand returns the random source of the round, which is the session journal when snapshots are enabled
+func (round *base) Rand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.Parameters.Rand()
+}
+
+// send records msg in the session journal, which refuses it if a restored session diverges, then sends it
+func (round *base) send(msg tss.Message) *tss.Error {
+	if round.journal != nil {
+		if err := round.journal.Send(msg); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	round.out <- msg
+	return nil
+}
+
+func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
+	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
+}
+
+// ----- //
+
+// `ok` tracks parties which have been verified by Update()
+func (round *base) resetOK() {
+	for j := range round.ok {
+		round.ok[j] = false
+	}
+}
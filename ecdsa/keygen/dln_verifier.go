@@ -7,10 +7,10 @@
 package keygen
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 type DlnProofVerifier struct {
@@ -24,7 +24,7 @@ type message interface {
 
 func NewDlnProofVerifier(concurrency int) *DlnProofVerifier {
 	if concurrency == 0 {
-		panic(errors.New("NewDlnProofverifier: concurrency level must not be zero"))
+		panic(tss.Errorf(tss.ErrInvalidParameters, "NewDlnProofverifier: concurrency level must not be zero"))
 	}
 
 	semaphore := make(chan interface{}, concurrency)
@@ -0,0 +1,373 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/ecdsa-save-data.proto
+
+package keygen
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The canonical encoding of the LocalPartySaveData of the ECDSA TSS protocols. Points are encoded as their
+// flattened coordinates and missing values as empty bytes.
+type SaveDataMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Curve           string   `protobuf:"bytes,1,opt,name=curve,proto3" json:"curve,omitempty"`
+	PaillierN       []byte   `protobuf:"bytes,2,opt,name=paillier_n,json=paillierN,proto3" json:"paillier_n,omitempty"`
+	PaillierLambdaN []byte   `protobuf:"bytes,3,opt,name=paillier_lambda_n,json=paillierLambdaN,proto3" json:"paillier_lambda_n,omitempty"`
+	PaillierPhiN    []byte   `protobuf:"bytes,4,opt,name=paillier_phi_n,json=paillierPhiN,proto3" json:"paillier_phi_n,omitempty"`
+	PaillierP       []byte   `protobuf:"bytes,5,opt,name=paillier_p,json=paillierP,proto3" json:"paillier_p,omitempty"`
+	PaillierQ       []byte   `protobuf:"bytes,6,opt,name=paillier_q,json=paillierQ,proto3" json:"paillier_q,omitempty"`
+	NTildeI         []byte   `protobuf:"bytes,7,opt,name=n_tilde_i,json=nTildeI,proto3" json:"n_tilde_i,omitempty"`
+	H1I             []byte   `protobuf:"bytes,8,opt,name=h1i,proto3" json:"h1i,omitempty"`
+	H2I             []byte   `protobuf:"bytes,9,opt,name=h2i,proto3" json:"h2i,omitempty"`
+	Alpha           []byte   `protobuf:"bytes,10,opt,name=alpha,proto3" json:"alpha,omitempty"`
+	Beta            []byte   `protobuf:"bytes,11,opt,name=beta,proto3" json:"beta,omitempty"`
+	P               []byte   `protobuf:"bytes,12,opt,name=p,proto3" json:"p,omitempty"`
+	Q               []byte   `protobuf:"bytes,13,opt,name=q,proto3" json:"q,omitempty"`
+	Xi              []byte   `protobuf:"bytes,14,opt,name=xi,proto3" json:"xi,omitempty"`
+	ShareId         []byte   `protobuf:"bytes,15,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Ks              [][]byte `protobuf:"bytes,16,rep,name=ks,proto3" json:"ks,omitempty"`
+	NTildeJ         [][]byte `protobuf:"bytes,17,rep,name=n_tilde_j,json=nTildeJ,proto3" json:"n_tilde_j,omitempty"`
+	H1J             [][]byte `protobuf:"bytes,18,rep,name=h1j,proto3" json:"h1j,omitempty"`
+	H2J             [][]byte `protobuf:"bytes,19,rep,name=h2j,proto3" json:"h2j,omitempty"`
+	BigXj           [][]byte `protobuf:"bytes,20,rep,name=big_xj,json=bigXj,proto3" json:"big_xj,omitempty"`
+	PaillierPks     [][]byte `protobuf:"bytes,21,rep,name=paillier_pks,json=paillierPks,proto3" json:"paillier_pks,omitempty"`
+	EcdsaPub        [][]byte `protobuf:"bytes,22,rep,name=ecdsa_pub,json=ecdsaPub,proto3" json:"ecdsa_pub,omitempty"`
+	RefreshEpoch    uint64   `protobuf:"varint,23,opt,name=refresh_epoch,json=refreshEpoch,proto3" json:"refresh_epoch,omitempty"`
+	ChainCode       []byte   `protobuf:"bytes,24,opt,name=chain_code,json=chainCode,proto3" json:"chain_code,omitempty"`
+}
+
+func (x *SaveDataMessage) Reset() {
+	*x = SaveDataMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_save_data_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveDataMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDataMessage) ProtoMessage() {}
+
+func (x *SaveDataMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_save_data_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDataMessage.ProtoReflect.Descriptor instead.
+func (*SaveDataMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_save_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SaveDataMessage) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *SaveDataMessage) GetPaillierN() []byte {
+	if x != nil {
+		return x.PaillierN
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetPaillierLambdaN() []byte {
+	if x != nil {
+		return x.PaillierLambdaN
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetPaillierPhiN() []byte {
+	if x != nil {
+		return x.PaillierPhiN
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetPaillierP() []byte {
+	if x != nil {
+		return x.PaillierP
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetPaillierQ() []byte {
+	if x != nil {
+		return x.PaillierQ
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetNTildeI() []byte {
+	if x != nil {
+		return x.NTildeI
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetH1I() []byte {
+	if x != nil {
+		return x.H1I
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetH2I() []byte {
+	if x != nil {
+		return x.H2I
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetAlpha() []byte {
+	if x != nil {
+		return x.Alpha
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetBeta() []byte {
+	if x != nil {
+		return x.Beta
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetP() []byte {
+	if x != nil {
+		return x.P
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetQ() []byte {
+	if x != nil {
+		return x.Q
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetXi() []byte {
+	if x != nil {
+		return x.Xi
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetShareId() []byte {
+	if x != nil {
+		return x.ShareId
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetKs() [][]byte {
+	if x != nil {
+		return x.Ks
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetNTildeJ() [][]byte {
+	if x != nil {
+		return x.NTildeJ
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetH1J() [][]byte {
+	if x != nil {
+		return x.H1J
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetH2J() [][]byte {
+	if x != nil {
+		return x.H2J
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetBigXj() [][]byte {
+	if x != nil {
+		return x.BigXj
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetPaillierPks() [][]byte {
+	if x != nil {
+		return x.PaillierPks
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetEcdsaPub() [][]byte {
+	if x != nil {
+		return x.EcdsaPub
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetRefreshEpoch() uint64 {
+	if x != nil {
+		return x.RefreshEpoch
+	}
+	return 0
+}
+
+func (x *SaveDataMessage) GetChainCode() []byte {
+	if x != nil {
+		return x.ChainCode
+	}
+	return nil
+}
+
+var File_protob_ecdsa_save_data_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_save_data_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73,
+	0x61, 0x76, 0x65, 0x2d, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b,
+	0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
+	0x63, 0x64, 0x73, 0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0xf2, 0x04, 0x0a, 0x0f,
+	0x53, 0x61, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x75, 0x72, 0x76, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65,
+	0x72, 0x5f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69, 0x6c, 0x6c,
+	0x69, 0x65, 0x72, 0x4e, 0x12, 0x2a, 0x0a, 0x11, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72,
+	0x5f, 0x6c, 0x61, 0x6d, 0x62, 0x64, 0x61, 0x5f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x0f, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x4c, 0x61, 0x6d, 0x62, 0x64, 0x61, 0x4e,
+	0x12, 0x24, 0x0a, 0x0e, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x68, 0x69,
+	0x5f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69,
+	0x65, 0x72, 0x50, 0x68, 0x69, 0x4e, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69,
+	0x65, 0x72, 0x5f, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69, 0x6c,
+	0x6c, 0x69, 0x65, 0x72, 0x50, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65,
+	0x72, 0x5f, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x61, 0x69, 0x6c, 0x6c,
+	0x69, 0x65, 0x72, 0x51, 0x12, 0x1a, 0x0a, 0x09, 0x6e, 0x5f, 0x74, 0x69, 0x6c, 0x64, 0x65, 0x5f,
+	0x69, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x6e, 0x54, 0x69, 0x6c, 0x64, 0x65, 0x49,
+	0x12, 0x10, 0x0a, 0x03, 0x68, 0x31, 0x69, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x68,
+	0x31, 0x69, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x32, 0x69, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x03, 0x68, 0x32, 0x69, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x05, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x65,
+	0x74, 0x61, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x65, 0x74, 0x61, 0x12, 0x0c,
+	0x0a, 0x01, 0x70, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x70, 0x12, 0x0c, 0x0a, 0x01,
+	0x71, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x78, 0x69,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x78, 0x69, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x68,
+	0x61, 0x72, 0x65, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28,
+	0x0c, 0x52, 0x02, 0x6b, 0x73, 0x12, 0x1a, 0x0a, 0x09, 0x6e, 0x5f, 0x74, 0x69, 0x6c, 0x64, 0x65,
+	0x5f, 0x6a, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x6e, 0x54, 0x69, 0x6c, 0x64, 0x65,
+	0x4a, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x31, 0x6a, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x03,
+	0x68, 0x31, 0x6a, 0x12, 0x10, 0x0a, 0x03, 0x68, 0x32, 0x6a, 0x18, 0x13, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x03, 0x68, 0x32, 0x6a, 0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x67, 0x5f, 0x78, 0x6a, 0x18,
+	0x14, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x62, 0x69, 0x67, 0x58, 0x6a, 0x12, 0x21, 0x0a, 0x0c,
+	0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x5f, 0x70, 0x6b, 0x73, 0x18, 0x15, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x0b, 0x70, 0x61, 0x69, 0x6c, 0x6c, 0x69, 0x65, 0x72, 0x50, 0x6b, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x63, 0x64, 0x73, 0x61, 0x5f, 0x70, 0x75, 0x62, 0x18, 0x16, 0x20, 0x03,
+	0x28, 0x0c, 0x52, 0x08, 0x65, 0x63, 0x64, 0x73, 0x61, 0x50, 0x75, 0x62, 0x12, 0x23, 0x0a, 0x0d,
+	0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x17, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x45, 0x70, 0x6f, 0x63,
+	0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18,
+	0x18, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65,
+	0x42, 0x0e, 0x5a, 0x0c, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_save_data_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_save_data_proto_rawDescData = file_protob_ecdsa_save_data_proto_rawDesc
+)
+
+func file_protob_ecdsa_save_data_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_save_data_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_save_data_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_save_data_proto_rawDescData)
+	})
+	return file_protob_ecdsa_save_data_proto_rawDescData
+}
+
+var file_protob_ecdsa_save_data_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_ecdsa_save_data_proto_goTypes = []interface{}{
+	(*SaveDataMessage)(nil), // 0: binance.tsslib.ecdsa.keygen.SaveDataMessage
+}
+var file_protob_ecdsa_save_data_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_save_data_proto_init() }
+func file_protob_ecdsa_save_data_proto_init() {
+	if File_protob_ecdsa_save_data_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_save_data_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveDataMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_save_data_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_save_data_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_save_data_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_save_data_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_save_data_proto = out.File
+	file_protob_ecdsa_save_data_proto_rawDesc = nil
+	file_protob_ecdsa_save_data_proto_goTypes = nil
+	file_protob_ecdsa_save_data_proto_depIdxs = nil
+}
@@ -7,7 +7,7 @@
 package keygen
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -15,6 +15,12 @@ import (
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a keygen session in a keystore container
+	SessionSnapshotKind = "ecdsa/keygen.Session"
 )
 
 // Implements Party
@@ -29,8 +35,9 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		temp localTempData
-		data LocalPartySaveData
+		temp    localTempData
+		data    LocalPartySaveData
+		journal *tss.SessionJournal
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -48,13 +55,14 @@ type (
 		localMessageStore
 
 		// temp data (thrown away after keygen)
-		ui            *big.Int // used for tests
+		chainCode     *big.Int
 		KGCs          []cmt.HashCommitment
 		vs            vss.Vs
 		ssid          []byte
 		ssidNonce     *big.Int
 		shares        vss.Shares
 		deCommitPolyG cmt.HashDeCommitment
+		ownPreParams  bool // the pre-params were generated in round 1 rather than given to the constructor
 	}
 )
 
@@ -70,21 +78,21 @@ func NewLocalParty(
 	// when `optionalPreParams` is provided we'll use the pre-computed primes instead of generating them from scratch
 	if 0 < len(optionalPreParams) {
 		if 1 < len(optionalPreParams) {
-			panic(errors.New("keygen.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
+			panic(tss.Errorf(tss.ErrInvalidParameters, "keygen.NewLocalParty expected 0 or 1 item in `optionalPreParams`"))
 		}
 		if !optionalPreParams[0].ValidateWithProof() {
-			panic(errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+			panic(tss.Errorf(tss.ErrInvalidParameters, "`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
 		}
 		data.LocalPreParams = optionalPreParams[0]
 	}
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		temp:      localTempData{},
-		data:      data,
-		out:       out,
-		end:       end,
+		params: params,
+		temp:   localTempData{},
+		data:   data,
+		out:    out,
+		end:    end,
 	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
 	// msgs init
 	p.temp.kgRound1Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.kgRound2Message1s = make([]tss.ParsedMessage, partyCount)
@@ -96,13 +104,55 @@ func NewLocalParty(
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.data, &p.temp, p.out, p.end)
+	return newRound1(p.params, &p.data, &p.temp, p.out, p.end, p.journal)
 }
 
 func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		// the safe primes are generated concurrently, so a restored session could not generate the same ones
+		if !p.data.LocalPreParams.ValidateWithProof() {
+			return p.WrapError(tss.Errorf(tss.ErrInvalidParameters, "session snapshots require the pre-params to be given to the constructor"))
+		}
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
 	return tss.BaseStart(p, TaskName)
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() {
+		p.temp = localTempData{}
+		p.data.Xi = nil // xi is computed before the last round
+	})
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The save data of a finished party is the result of the
+// keygen; otherwise xi is wiped, and the Paillier key and the safe primes too if the party generated them.
+func (p *LocalParty) wipe(finished bool) {
+	for _, share := range p.temp.shares {
+		if share != nil {
+			common.WipeBigInt(share.Share)
+		}
+	}
+	// the chain code contribution is also the first committed value of the decommitment
+	common.WipeBigInt(p.temp.chainCode)
+	cmt.WipeDeCommitment(p.temp.deCommitPolyG)
+	p.temp.shares, p.temp.chainCode, p.temp.deCommitPolyG = nil, nil, nil
+	p.journal.Wipe()
+	if finished {
+		return
+	}
+	common.WipeBigInt(p.data.Xi)
+	p.data.Xi = nil
+	if p.temp.ownPreParams {
+		p.data.LocalPreParams.Wipe()
+		p.temp.ownPreParams = false
+	}
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -121,7 +171,7 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	}
 	// check that the message's "from index" will fit into the array
 	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with a sender index too great (%d <= %d)",
 			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
 	}
 	return true, nil
@@ -149,9 +199,55 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
 	return true, nil
 }
 
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *KGRound1Message:
+		return 1
+	case *KGRound2Message1, *KGRound2Message2:
+		return 2
+	case *KGRound3Message:
+		return 3
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled and the pre-params must have been given to the
+// constructor. The snapshot holds the secrets of the session and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), bigIntToBytes(p.data.NTildei)}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
 // recovers a party's original index in the set of parties during keygen
 func (save LocalPartySaveData) OriginalIndex() (int, error) {
 	index := -1
@@ -164,7 +260,7 @@ func (save LocalPartySaveData) OriginalIndex() (int, error) {
 		break
 	}
 	if index < 0 {
-		return -1, errors.New("a party index could not be recovered from Ks")
+		return -1, tss.Errorf(tss.ErrInvalidSaveData, "a party index could not be recovered from Ks")
 	}
 	return index, nil
 }
@@ -17,16 +17,22 @@ import (
 	"sync/atomic"
 	"testing"
 
+	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
 )
 
 const (
@@ -46,7 +52,7 @@ func TestStartRound1Paillier(t *testing.T) {
 	pIDs := tss.GenerateTestPartyIDs(1)
 	p2pCtx := tss.NewPeerContext(pIDs)
 	threshold := 1
-	params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[0], len(pIDs), threshold)
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), threshold)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
@@ -86,7 +92,7 @@ func TestFinishAndSaveH1H2(t *testing.T) {
 	pIDs := tss.GenerateTestPartyIDs(1)
 	p2pCtx := tss.NewPeerContext(pIDs)
 	threshold := 1
-	params := tss.NewParameters(tss.EC(), p2pCtx, pIDs[0], len(pIDs), threshold)
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), threshold)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
@@ -133,7 +139,7 @@ func TestBadMessageCulprits(t *testing.T) {
 
 	pIDs := tss.GenerateTestPartyIDs(2)
 	p2pCtx := tss.NewPeerContext(pIDs)
-	params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[0], len(pIDs), 1)
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), 1)
 
 	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
 	if err != nil {
@@ -161,18 +167,154 @@ func TestBadMessageCulprits(t *testing.T) {
 	}
 	assert.Equal(t, 1, len(err2.Culprits()))
 	assert.Equal(t, pIDs[1], err2.Culprits()[0])
+	assert.Equal(t, tss.ErrInvalidMessage, err2.Kind())
 	assert.Equal(t,
 		"task ecdsa-keygen, party {0,P[1]}, round 1, culprits [{1,2}]: message failed ValidateBasic: Type: binance.tsslib.ecdsa.keygen.KGRound1Message, From: {1,2}, To: all",
 		err2.Error())
 }
 
-func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
+func TestCloseWipesSecrets(t *testing.T) {
 	setUp("info")
 
-	// tss.SetCurve(elliptic.P256())
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), 1)
+
+	fixtures, _, err := LoadKeygenTestFixtures(testParticipants)
+	if err != nil {
+		t.Skip("the test fixtures are required for the pre-params")
+	}
+	out := make(chan tss.Message, len(pIDs))
+	lp := NewLocalParty(params, out, nil, fixtures[0].LocalPreParams).(*LocalParty)
+	if err := lp.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	r1msg := <-out
+	secrets := test.SecretWords(lp.temp.shares[0].Share, lp.temp.shares[1].Share, lp.temp.chainCode, lp.temp.deCommitPolyG[0])
+	assert.False(t, test.Wiped(secrets))
+
+	lp.Close()
+	assert.True(t, test.Wiped(secrets), "the shares, the chain code and the decommitment randomness must be wiped")
+	assert.Nil(t, lp.temp.shares)
+	assert.Nil(t, lp.temp.chainCode)
+	assert.Nil(t, lp.temp.deCommitPolyG)
+	assert.Equal(t, 1, fixtures[0].PaillierSK.P.Sign(), "the pre-params of the caller are left alone")
+	assert.Equal(t, 1, fixtures[0].Alpha.Sign(), "the pre-params of the caller are left alone")
+
+	// a closed party is stopped for good
+	assert.False(t, lp.Running())
+	bz, _, err := r1msg.WireBytes()
+	assert.NoError(t, err)
+	_, err2 := lp.UpdateFromBytes(bz, pIDs[1], true)
+	if assert.NotNil(t, err2) {
+		assert.Equal(t, tss.ErrAborted, err2.Kind())
+	}
+	assert.NotNil(t, lp.Start())
+	lp.Close()
+}
+
+func TestE2EOversizedChainCode(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(2)
+	if err != nil {
+		t.Skip("the test fixtures are required for the pre-params")
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+	outCh := make(chan tss.Message, 4*len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	for i := range pIDs {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[i], len(pIDs), 1)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		parties = append(parties, NewLocalParty(params, outCh, endCh, fixtures[i].LocalPreParams).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// party 1 commits to a chain code share that is wider than the chain code of the key
+	var r1msg tss.ParsedMessage
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, pIDs[0], err.Victim())
+			assert.Equal(t, tss.ErrBadShare, err.Kind(), err.Error())
+			if assert.Equal(t, 1, len(err.Culprits())) {
+				assert.Equal(t, pIDs[1], err.Culprits()[0])
+			}
+			return
+		case <-endCh:
+			t.Fatal("keygen must fail")
+		case msg := <-outCh:
+			if pMsg, ok := msg.(tss.ParsedMessage); ok && msg.GetFrom().Index == 1 && msg.IsBroadcast() {
+				switch pMsg.Content().(type) {
+				case *KGRound1Message:
+					// held back until the decommitment is known
+					r1msg = pMsg
+					continue
+				case *KGRound2Message2:
+					badR1msg, badR2msg := recommitChainCode(r1msg, pMsg, new(big.Int).Lsh(big.NewInt(1), chainCodeBitLen))
+					go func() {
+						test.SharedPartyUpdater(parties[0], badR1msg, errCh)
+						test.SharedPartyUpdater(parties[0], badR2msg, errCh)
+					}()
+					continue
+				}
+			}
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		}
+	}
+}
+
+// recommitChainCode returns the round 1 and round 2 broadcasts of a party re-committed to another chain code share
+func recommitChainCode(r1msg, r2msg tss.ParsedMessage, chainCode *big.Int) (tss.ParsedMessage, tss.ParsedMessage) {
+	D := r2msg.Content().(*KGRound2Message2).UnmarshalDeCommitment()
+	D[1] = chainCode
+	cmt := commitments.NewHashCommitmentWithRandomness(D[0], D[1:]...)
+	r1 := proto.Clone(r1msg.Content()).(*KGRound1Message)
+	r1.Commitment = cmt.C.Bytes()
+	r2 := proto.Clone(r2msg.Content()).(*KGRound2Message2)
+	r2.DeCommitment = common.BigIntsToBytes(cmt.D)
+	meta := tss.MessageRouting{From: r1msg.GetFrom(), IsBroadcast: true}
+	return tss.NewMessage(meta, r1, tss.NewMessageWrapper(meta, r1)), tss.NewMessage(meta, r2, tss.NewMessageWrapper(meta, r2))
+}
+
+func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
+	testE2EConcurrentAndSaveFixtures(t, group.Secp256k1())
+}
+
+func TestE2EConcurrentAndSaveFixturesP256(t *testing.T) {
+	testE2EConcurrentAndSaveFixtures(t, group.P256())
+}
+
+func testE2EConcurrentAndSaveFixtures(t *testing.T, curve group.AffineGroup) {
+	setUp("info")
 
 	threshold := testThreshold
-	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	fixtures, pIDs, err := LoadKeygenTestFixturesForCurve(curve, testParticipants)
+	if err != nil {
+		// the pre-params do not depend on the curve, so reuse those of the secp256k1 fixtures
+		fixtures, pIDs, err = LoadKeygenTestFixtures(testParticipants)
+	}
 	if err != nil {
 		common.Logger.Info("No test fixtures were found, so the safe primes will be generated from scratch. This may take a while...")
 		pIDs = tss.GenerateTestPartyIDs(testParticipants)
@@ -192,7 +334,7 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
 		var P *LocalParty
-		params := tss.NewParameters(tss.S256(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		params := tss.NewParameters(curve, p2pCtx, pIDs[i], len(pIDs), threshold)
 		// do not use in untrusted setting
 		params.SetNoProofMod()
 		// do not use in untrusted setting
@@ -243,7 +385,7 @@ keygen:
 			// .. here comes a workaround to recover this party's index (it was removed from save data)
 			index, err := save.OriginalIndex()
 			assert.NoErrorf(t, err, "should not be an error getting a party's index from save data")
-			tryWriteTestFixtureFile(t, index, *save)
+			tryWriteTestFixtureFile(t, curve, index, *save)
 
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
@@ -263,17 +405,16 @@ keygen:
 						}
 						pShares = append(pShares, shareStruct)
 					}
-					uj, err := pShares[:threshold+1].ReConstruct(tss.S256())
+					uj, err := pShares[:threshold+1].ReConstruct(curve)
 					assert.NoError(t, err, "vss.ReConstruct should not throw error")
 
 					// uG test: u*G[j] == V[0]
-					assert.Equal(t, uj, Pj.temp.ui)
-					uG := crypto.ScalarBaseMult(tss.EC(), uj)
+					uG := crypto.ScalarBaseMult(curve, uj)
 					assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
 
 					// xj tests: BigXj == xj*G
 					xj := Pj.data.Xi
-					gXj := crypto.ScalarBaseMult(tss.EC(), xj)
+					gXj := crypto.ScalarBaseMult(curve, xj)
 					BigXj := Pj.data.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 
@@ -281,10 +422,10 @@ keygen:
 					{
 						badShares := pShares[:threshold]
 						badShares[len(badShares)-1].Share.Set(big.NewInt(0))
-						uj, err := pShares[:threshold].ReConstruct(tss.S256())
+						badUj, err := pShares[:threshold].ReConstruct(curve)
 						assert.NoError(t, err)
-						assert.NotEqual(t, parties[j].temp.ui, uj)
-						BigXjX, BigXjY := tss.EC().ScalarBaseMult(uj.Bytes())
+						assert.NotEqual(t, uj, badUj)
+						BigXjX, BigXjY := curve.Curve().ScalarBaseMult(badUj.Bytes())
 						assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
 						assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
 					}
@@ -294,20 +435,20 @@ keygen:
 				// build ecdsa key pair
 				pkX, pkY := save.ECDSAPub.X(), save.ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: curve.Curve(),
 					X:     pkX,
 					Y:     pkY,
 				}
 				sk := ecdsa.PrivateKey{
 					PublicKey: pk,
-					D:         u,
+					D:         new(big.Int).Mod(u, curve.Order()),
 				}
 				// test pub key, should be on curve and match pkX, pkY
 				assert.True(t, sk.IsOnCurve(pkX, pkY), "public key must be on curve")
 
 				// public key tests
 				assert.NotZero(t, u, "u should not be zero")
-				ourPkX, ourPkY := tss.EC().ScalarBaseMult(u.Bytes())
+				ourPkX, ourPkY := curve.Curve().ScalarBaseMult(u.Bytes())
 				assert.Equal(t, pkX, ourPkX, "pkX should match expected pk derived from u")
 				assert.Equal(t, pkY, ourPkY, "pkY should match expected pk derived from u")
 				t.Log("Public key tests done.")
@@ -319,6 +460,19 @@ keygen:
 				}
 				t.Log("Public key distribution test done.")
 
+				// make sure everyone has the same chain code
+				assert.Len(t, save.ChainCode, 32)
+				for _, Pj := range parties {
+					assert.Equal(t, save.ChainCode, Pj.data.ChainCode)
+				}
+				xpub, err := save.MasterExtendedKey(chaincfg.MainNetParams.HDPublicKeyID[:])
+				assert.NoError(t, err)
+				parsed, err := ckd.NewExtendedKeyFromString(xpub.String(), curve)
+				assert.NoError(t, err)
+				assert.Equal(t, save.ChainCode, parsed.ChainCode)
+				assert.Equal(t, pkX, parsed.X)
+				t.Log("Chain code distribution test done.")
+
 				// test sign/verify
 				data := make([]byte, 32)
 				for i := range data {
@@ -338,8 +492,126 @@ keygen:
 	}
 }
 
-func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
-	fixtureFileName := makeTestFixtureFilePath(index)
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if err != nil {
+		t.Skip("the test fixtures are needed for the pre-params")
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	// the messages are delivered one at a time, so the channels must hold every message of a round
+	errCh := make(chan *tss.Error, 10*len(pIDs))
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	newParams := func(i int) *tss.Parameters {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		// do not use in untrusted setting
+		params.SetNoProofFac()
+		params.SetSessionSnapshots(true)
+		return params
+	}
+	assert.NotNil(t, NewLocalParty(newParams(0), outCh, endCh).Start(), "the pre-params are required")
+	for i := range pIDs {
+		P := NewLocalParty(newParams(i), outCh, endCh, fixtures[i].LocalPreParams)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// party 0 crashes once it has received the round 1 messages
+	key := keystore.KEK(make([]byte, keystore.KEKSize))
+	received, crashed := 0, false
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index || (dest != nil && dest[0].Index != P.PartyID().Index) {
+					continue
+				}
+				test.SharedPartyUpdater(P, msg, errCh)
+				if P.PartyID().Index == 0 {
+					received++
+				}
+			}
+			if received < len(pIDs)-1 || crashed {
+				continue
+			}
+			crashed = true
+			snapshot, err := parties[0].(*LocalParty).Snapshot(key)
+			assert.NoError(t, err)
+
+			restored := NewLocalParty(newParams(0), outCh, endCh, fixtures[0].LocalPreParams).(*LocalParty)
+			if err := restored.Restore(snapshot, key); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, parties[0].(*LocalParty).temp.shares, restored.temp.shares)
+			assert.Equal(t, parties[0].(*LocalParty).temp.chainCode, restored.temp.chainCode)
+			assert.Contains(t, restored.String(), "round: 2")
+			parties[0] = restored
+		case save := <-endCh:
+			saves = append(saves, save)
+		}
+	}
+	assert.True(t, crashed)
+	for _, save := range saves {
+		assert.True(t, saves[0].ECDSAPub.Equals(save.ECDSAPub))
+		assert.NoError(t, ValidateSaveData(save))
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	fixtures, pIDs, err := LoadKeygenTestFixtures(testParticipants)
+	if err != nil {
+		t.Skip("the test fixtures are needed for the pre-params")
+	}
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *LocalPartySaveData, 1)
+	newParty := func(preParams LocalPreParams) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, outCh, endCh, preParams).(*LocalParty)
+	}
+
+	P := newParty(fixtures[0].LocalPreParams)
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(fixtures[0].LocalPreParams).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session with other pre-params
+	assert.NotNil(t, newParty(fixtures[1].LocalPreParams).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(fixtures[0].LocalPreParams).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+func tryWriteTestFixtureFile(t *testing.T, curve group.AffineGroup, index int, data LocalPartySaveData) {
+	fixtureFileName := makeTestFixtureFilePathForCurve(curve, index)
 
 	// fixture file does not already exist?
 	// if it does, we won't re-create it here
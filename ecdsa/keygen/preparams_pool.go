@@ -0,0 +1,359 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// PreParamsKind identifies the pre-params in a keystore container
+	PreParamsKind = "ecdsa/keygen.LocalPreParams"
+
+	// PreParamsVersion is the version of the encoding of the pre-params persisted by a PreParamsPool
+	PreParamsVersion uint32 = 1
+
+	preParamsFileExt = ".preparams"
+
+	// the delay before a worker retries a failed generation
+	preParamsRetryDelay = 5 * time.Second
+)
+
+var ErrPreParamsPoolClosed = tss.Errorf(tss.ErrInvalidState, "pre-params pool: closed")
+
+type (
+	// PreParamsPoolConfig configures a PreParamsPool
+	PreParamsPoolConfig struct {
+		// Depth is the number of pre-params the pool keeps ready
+		Depth int
+		// Workers is the number of pre-params generated at the same time; 1 if not set
+		Workers int
+		// Concurrency is passed to GeneratePreParams by each worker; the number of CPU cores if not set
+		Concurrency int
+		// Dir is the directory the ready pre-params are persisted to, so that they survive a restart; the pool is
+		// only kept in memory if it is empty. A directory must not be shared by two pools.
+		Dir string
+		// Key protects the persisted pre-params; it should not be keystore.NoEncryption()
+		Key keystore.Key
+		// Rand is the source of randomness; crypto/rand if not set
+		Rand io.Reader
+
+		// generate replaces the generation of pre-params in tests
+		generate func(ctx context.Context) (*LocalPreParams, error)
+	}
+
+	// PreParamsPoolMetrics is a snapshot of the state and the counters of a PreParamsPool
+	PreParamsPoolMetrics struct {
+		// the number of pre-params ready and being generated
+		Ready, Generating int
+		// the number of pre-params generated, handed out, loaded from Dir at startup and failed generations
+		Generated, Served, Loaded, Failed uint64
+		// the duration of the last successful generation
+		LastGenerationTime time.Duration
+	}
+
+	// PreParamsPool generates LocalPreParams in background workers, so that keygen and resharing do not wait for
+	// the generation of safe primes. Each of the pre-params is handed out once: it is removed from the pool, and
+	// from Dir, before Get returns it.
+	PreParamsPool struct {
+		config PreParamsPoolConfig
+
+		mtx     sync.Mutex
+		ready   []*pooledPreParams
+		changed chan struct{} // closed and replaced whenever ready or generating changes
+		closed  bool
+		metrics PreParamsPoolMetrics
+
+		cancel context.CancelFunc
+		wg     sync.WaitGroup
+	}
+
+	pooledPreParams struct {
+		preParams *LocalPreParams
+		file      string // empty when not persisted
+	}
+)
+
+// NewPreParamsPool loads the pre-params persisted in config.Dir and starts the workers that fill the pool up to
+// config.Depth. Close stops the workers.
+func NewPreParamsPool(config PreParamsPoolConfig) (*PreParamsPool, error) {
+	if config.Depth < 1 {
+		return nil, tss.Errorf(tss.ErrInvalidParameters, "pre-params pool: the depth must be positive")
+	}
+	if config.Workers < 1 {
+		config.Workers = 1
+	}
+	if config.Concurrency < 1 {
+		config.Concurrency = runtime.NumCPU()
+	}
+	if config.Rand == nil {
+		config.Rand = rand.Reader
+	}
+	if config.generate == nil {
+		config.generate = func(ctx context.Context) (*LocalPreParams, error) {
+			return GeneratePreParamsWithContextAndRandom(ctx, config.Rand, config.Concurrency)
+		}
+	}
+	pool := &PreParamsPool{
+		config:  config,
+		changed: make(chan struct{}),
+	}
+	if config.Dir != "" {
+		if err := os.MkdirAll(config.Dir, 0700); err != nil {
+			return nil, err
+		}
+		if err := pool.load(); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	pool.cancel = cancel
+	for w := 0; w < config.Workers; w++ {
+		pool.wg.Add(1)
+		go pool.work(ctx)
+	}
+	return pool, nil
+}
+
+// Get returns pre-params from the pool, waiting for a worker to generate them if none are ready
+func (pool *PreParamsPool) Get(ctx context.Context) (*LocalPreParams, error) {
+	for {
+		preParams, changed, err := pool.take()
+		if preParams != nil || err != nil {
+			return preParams, err
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// TryGet returns pre-params from the pool if any are ready, without waiting
+func (pool *PreParamsPool) TryGet() (*LocalPreParams, error) {
+	preParams, _, err := pool.take()
+	return preParams, err
+}
+
+// Metrics returns a snapshot of the state and the counters of the pool
+func (pool *PreParamsPool) Metrics() PreParamsPoolMetrics {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	metrics := pool.metrics
+	metrics.Ready = len(pool.ready)
+	return metrics
+}
+
+// Close stops the workers, abandoning the generations in progress. The ready pre-params stay in Dir.
+func (pool *PreParamsPool) Close() {
+	pool.mtx.Lock()
+	if pool.closed {
+		pool.mtx.Unlock()
+		return
+	}
+	pool.closed = true
+	pool.notify()
+	pool.mtx.Unlock()
+
+	pool.cancel()
+	pool.wg.Wait()
+}
+
+// ----- //
+
+// take removes the first ready pre-params from the pool and from Dir. It returns nil and a channel that is closed
+// on the next change if none are ready.
+func (pool *PreParamsPool) take() (*LocalPreParams, <-chan struct{}, error) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+	for len(pool.ready) > 0 {
+		pooled := pool.ready[0]
+		pool.ready = pool.ready[1:]
+		pool.notify()
+		if pooled.file != "" {
+			if err := os.Remove(pooled.file); err != nil {
+				if os.IsNotExist(err) {
+					// handed out by someone else
+					common.Logger.Warningf("pre-params pool: %s was removed from the pool directory", pooled.file)
+					continue
+				}
+				pool.ready = append(pool.ready, pooled)
+				return nil, nil, err
+			}
+		}
+		pool.metrics.Served++
+		return pooled.preParams, nil, nil
+	}
+	if pool.closed {
+		return nil, nil, ErrPreParamsPoolClosed
+	}
+	return nil, pool.changed, nil
+}
+
+func (pool *PreParamsPool) work(ctx context.Context) {
+	defer pool.wg.Done()
+	for {
+		pool.mtx.Lock()
+		for !pool.closed && len(pool.ready)+pool.metrics.Generating >= pool.config.Depth {
+			changed := pool.changed
+			pool.mtx.Unlock()
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return
+			}
+			pool.mtx.Lock()
+		}
+		if pool.closed {
+			pool.mtx.Unlock()
+			return
+		}
+		pool.metrics.Generating++
+		pool.mtx.Unlock()
+
+		start := time.Now()
+		preParams, err := pool.config.generate(ctx)
+		var pooled *pooledPreParams
+		if err == nil {
+			pooled, err = pool.persist(preParams)
+		}
+
+		pool.mtx.Lock()
+		pool.metrics.Generating--
+		if err == nil {
+			pool.ready = append(pool.ready, pooled)
+			pool.metrics.Generated++
+			pool.metrics.LastGenerationTime = time.Since(start)
+		} else if ctx.Err() == nil {
+			pool.metrics.Failed++
+		}
+		pool.notify()
+		pool.mtx.Unlock()
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			common.Logger.Errorf("pre-params pool: generation failed: %v", err)
+			select {
+			case <-time.After(preParamsRetryDelay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// persist writes the pre-params to a new file in Dir, if the pool has one
+func (pool *PreParamsPool) persist(preParams *LocalPreParams) (*pooledPreParams, error) {
+	if pool.config.Dir == "" {
+		return &pooledPreParams{preParams: preParams}, nil
+	}
+	payload, err := json.Marshal(preParams)
+	if err != nil {
+		return nil, err
+	}
+	data, err := keystore.Seal(pool.config.Rand, PreParamsKind, PreParamsVersion, payload, pool.config.Key)
+	if err != nil {
+		return nil, err
+	}
+	name, err := common.GetRandomBytes(pool.config.Rand, 16)
+	if err != nil {
+		return nil, err
+	}
+	file := filepath.Join(pool.config.Dir, hex.EncodeToString(name)+preParamsFileExt)
+	// write to a temporary file first so that a crash never leaves a partial file behind
+	tmp := file + ".tmp"
+	if err = writeFileSync(tmp, data); err != nil {
+		return nil, err
+	}
+	if err = os.Rename(tmp, file); err != nil {
+		_ = os.Remove(tmp)
+		return nil, err
+	}
+	return &pooledPreParams{preParams: preParams, file: file}, nil
+}
+
+// load adds the pre-params persisted in Dir to the pool
+func (pool *PreParamsPool) load() error {
+	entries, err := os.ReadDir(pool.config.Dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), preParamsFileExt) {
+			continue
+		}
+		file := filepath.Join(pool.config.Dir, entry.Name())
+		preParams, err := readPreParams(file, pool.config.Key)
+		if err != nil {
+			return tss.Errorf(tss.ErrPreParamsGeneration, "pre-params pool: unable to load %s: %w", file, err)
+		}
+		pool.ready = append(pool.ready, &pooledPreParams{preParams: preParams, file: file})
+		pool.metrics.Loaded++
+	}
+	return nil
+}
+
+func readPreParams(file string, key keystore.Key) (*LocalPreParams, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	version, payload, err := keystore.Open(data, PreParamsKind, key)
+	if err != nil {
+		return nil, err
+	}
+	if version != PreParamsVersion {
+		return nil, fmt.Errorf("unsupported version %d", version)
+	}
+	preParams := new(LocalPreParams)
+	if err = json.Unmarshal(payload, preParams); err != nil {
+		return nil, err
+	}
+	if !preParams.ValidateWithProof() {
+		return nil, errors.New("the pre-params are incomplete")
+	}
+	return preParams, nil
+}
+
+func writeFileSync(file string, data []byte) error {
+	fd, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = fd.Write(data); err == nil {
+		err = fd.Sync()
+	}
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// notify wakes the callers and workers waiting for a change. it must be called with the mutex held.
+func (pool *PreParamsPool) notify() {
+	close(pool.changed)
+	pool.changed = make(chan struct{})
+}
@@ -0,0 +1,105 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+func testPreParamsGenerator(t *testing.T, calls *int32) func(ctx context.Context) (*LocalPreParams, error) {
+	keys, _, err := LoadKeygenTestFixtures(testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		t.FailNow()
+	}
+	return func(ctx context.Context) (*LocalPreParams, error) {
+		i := atomic.AddInt32(calls, 1)
+		preParams := keys[int(i-1)%len(keys)].LocalPreParams
+		return &preParams, nil
+	}
+}
+
+func TestPreParamsPoolPersistsAndHandsOutOnce(t *testing.T) {
+	dir := t.TempDir()
+	key := keystore.KEK(make([]byte, keystore.KEKSize))
+	var calls int32
+	config := PreParamsPoolConfig{Depth: 2, Dir: dir, Key: key, generate: testPreParamsGenerator(t, &calls)}
+
+	pool, err := NewPreParamsPool(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	first, err := pool.Get(ctx)
+	assert.NoError(t, err)
+	assert.True(t, first.ValidateWithProof())
+
+	// the pool is refilled up to its depth
+	assert.Eventually(t, func() bool { return pool.Metrics().Ready == 2 }, 10*time.Second, 10*time.Millisecond)
+	pool.Close()
+	files, _ := filepath.Glob(filepath.Join(dir, "*"+preParamsFileExt))
+	assert.Len(t, files, 2)
+	metrics := pool.Metrics()
+	assert.Equal(t, uint64(3), metrics.Generated)
+	assert.Equal(t, uint64(1), metrics.Served)
+
+	// a new pool serves the persisted pre-params and removes them from the directory
+	config.generate = func(ctx context.Context) (*LocalPreParams, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	reopened, err := NewPreParamsPool(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer reopened.Close()
+	assert.Equal(t, uint64(2), reopened.Metrics().Loaded)
+	for i := 0; i < 2; i++ {
+		preParams, err := reopened.TryGet()
+		assert.NoError(t, err)
+		if assert.NotNil(t, preParams) {
+			assert.NotEqual(t, 0, preParams.NTildei.Cmp(first.NTildei), "the pre-params must not be handed out twice")
+		}
+	}
+	preParams, err := reopened.TryGet()
+	assert.NoError(t, err)
+	assert.Nil(t, preParams)
+	files, _ = filepath.Glob(filepath.Join(dir, "*"+preParamsFileExt))
+	assert.Len(t, files, 0)
+
+	reopened.Close()
+	_, err = reopened.Get(ctx)
+	assert.Equal(t, ErrPreParamsPoolClosed, err)
+}
+
+func TestPreParamsPoolRejectsWrongKey(t *testing.T) {
+	dir := t.TempDir()
+	var calls int32
+	config := PreParamsPoolConfig{Depth: 1, Dir: dir, Key: keystore.ScryptPassphrase([]byte("pool")), generate: testPreParamsGenerator(t, &calls)}
+	pool, err := NewPreParamsPool(config)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Eventually(t, func() bool { return pool.Metrics().Ready == 1 }, 10*time.Second, 10*time.Millisecond)
+	pool.Close()
+
+	config.Key = keystore.ScryptPassphrase([]byte("wrong"))
+	_, err = NewPreParamsPool(config)
+	assert.True(t, errors.Is(err, keystore.ErrDecryption))
+	_, err = os.Stat(dir)
+	assert.NoError(t, err)
+}
@@ -9,7 +9,6 @@ package keygen
 import (
 	"context"
 	"crypto/rand"
-	"errors"
 	"io"
 	"math/big"
 	"runtime"
@@ -17,6 +16,7 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 const (
@@ -56,7 +56,7 @@ func GeneratePreParamsWithContextAndRandom(ctx context.Context, rand io.Reader,
 	var concurrency int
 	if 0 < len(optionalConcurrency) {
 		if 1 < len(optionalConcurrency) {
-			panic(errors.New("GeneratePreParams: expected 0 or 1 item in `optionalConcurrency`"))
+			panic(tss.Errorf(tss.ErrInvalidParameters, "GeneratePreParams: expected 0 or 1 item in `optionalConcurrency`"))
 		}
 		concurrency = optionalConcurrency[0]
 	} else {
@@ -114,14 +114,14 @@ consumer:
 				sgps[0] == nil || sgps[1] == nil ||
 				!sgps[0].Prime().ProbablyPrime(30) || !sgps[1].Prime().ProbablyPrime(30) ||
 				!sgps[0].SafePrime().ProbablyPrime(30) || !sgps[1].SafePrime().ProbablyPrime(30) {
-				return nil, errors.New("timeout or error while generating the safe primes")
+				return nil, tss.Errorf(tss.ErrPreParamsGeneration, "timeout or error while generating the safe primes")
 			}
 			if paiSK != nil {
 				break consumer
 			}
 		case paiSK = <-paiCh:
 			if paiSK == nil {
-				return nil, errors.New("timeout or error while generating the Paillier secret key")
+				return nil, tss.Errorf(tss.ErrPreParamsGeneration, "timeout or error while generating the Paillier secret key")
 			}
 			if sgps != nil {
 				break consumer
@@ -8,7 +8,6 @@ package keygen
 
 import (
 	"context"
-	"errors"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -21,16 +20,19 @@ import (
 
 var zero = big.NewInt(0)
 
+// chainCodeBitLen is the bit length of the contribution of each party to the chain code of the key
+const chainCodeBitLen = 256
+
 // round 1 represents round 1 of the keygen part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
-func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *LocalPartySaveData) tss.Round {
+func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *LocalPartySaveData, journal *tss.SessionJournal) tss.Round {
 	return &round1{
-		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
 	}
 }
 
 func (round *round1) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 1
 	round.started = true
@@ -40,28 +42,28 @@ func (round *round1) Start() *tss.Error {
 	i := Pi.Index
 
 	// 1. calculate "partial" key share ui
-	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.EC().Params().N)
-
-	round.temp.ui = ui
+	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.Group().Order())
 
 	// 2. compute the vss shares
 	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
+	vs, shares, err := vss.Create(round.Group(), round.Threshold(), ui, ids, round.Rand())
 	if err != nil {
-		return round.WrapError(err, Pi)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 	}
 	round.save.Ks = ids
 
 	// security: the original u_i may be discarded
-	ui = zero // clears the secret data from memory
-	_ = ui    // silences a linter warning
+	common.WipeBigInt(ui)
 
-	// make commitment -> (C, D)
+	// 3. sample this party's contribution to the chain code
+	chainCode := common.MustGetRandomInt(round.Rand(), chainCodeBitLen)
+
+	// make commitment -> (C, D) over chainCode || poly*G
 	pGFlat, err := crypto.FlattenECPoints(vs)
 	if err != nil {
-		return round.WrapError(err, Pi)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 	}
-	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+	cmt := cmts.NewHashCommitment(round.Rand(), append([]*big.Int{chainCode}, pGFlat...)...)
 
 	// 4. generate Paillier public key E_i, private key and proof
 	// 5-7. generate safe primes for ZKPs used later on
@@ -70,7 +72,7 @@ func (round *round1) Start() *tss.Error {
 	var preParams *LocalPreParams
 	if round.save.LocalPreParams.Validate() && !round.save.LocalPreParams.ValidateWithProof() {
 		return round.WrapError(
-			errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+			tss.Errorf(tss.ErrInvalidParameters, "`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
 	} else if round.save.LocalPreParams.ValidateWithProof() {
 		preParams = &round.save.LocalPreParams
 	} else {
@@ -79,8 +81,9 @@ func (round *round1) Start() *tss.Error {
 			defer cancel()
 			preParams, err = GeneratePreParamsWithContextAndRandom(ctx, round.Rand(), round.Concurrency())
 			if err != nil {
-				return round.WrapError(errors.New("pre-params generation failed"), Pi)
+				return round.WrapError(tss.Errorf(tss.ErrPreParamsGeneration, "pre-params generation failed"), Pi)
 			}
+			round.temp.ownPreParams = true
 		}
 	}
 	round.save.LocalPreParams = *preParams
@@ -108,7 +111,7 @@ func (round *round1) Start() *tss.Error {
 	round.temp.vs = vs
 	ssid, err := round.getSSID()
 	if err != nil {
-		return round.WrapError(errors.New("failed to generate ssid"))
+		return round.WrapError(tss.Errorf(tss.ErrInternal, "failed to generate ssid"))
 	}
 	round.temp.ssid = ssid
 	round.temp.shares = shares
@@ -116,6 +119,7 @@ func (round *round1) Start() *tss.Error {
 	// for this P: SAVE de-commitments, paillier keys for round 2
 	round.save.PaillierSK = preParams.PaillierSK
 	round.save.PaillierPKs[i] = &preParams.PaillierSK.PublicKey
+	round.temp.chainCode = chainCode
 	round.temp.deCommitPolyG = cmt.D
 
 	// BROADCAST commitments, paillier pk + proof; round 1 message
@@ -123,10 +127,12 @@ func (round *round1) Start() *tss.Error {
 		msg, err := NewKGRound1Message(
 			round.PartyID(), cmt.C, &preParams.PaillierSK.PublicKey, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
 		if err != nil {
-			return round.WrapError(err, Pi)
+			return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 		}
 		round.temp.kgRound1Messages[i] = msg
-		round.out <- msg
+		if err := round.send(msg); err != nil {
+			return err
+		}
 	}
 	return nil
 }
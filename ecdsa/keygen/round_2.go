@@ -8,9 +8,9 @@ package keygen
 
 import (
 	"encoding/hex"
-	"errors"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
@@ -25,7 +25,7 @@ const (
 
 func (round *round2) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 2
 	round.started = true
@@ -52,20 +52,20 @@ func (round *round2) Start() *tss.Error {
 			r1msg.UnmarshalNTilde(),
 			r1msg.UnmarshalPaillierPK()
 		if paillierPKj.N.BitLen() != paillierBitsLen {
-			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "got paillier modulus with insufficient bits for this party"), msg.GetFrom())
 		}
 		if H1j.Cmp(H2j) == 0 {
-			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "h1j and h2j were equal for this party"), msg.GetFrom())
 		}
 		if NTildej.BitLen() != paillierBitsLen {
-			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "got NTildej with insufficient bits for this party"), msg.GetFrom())
 		}
 		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
 		if _, found := h1H2Map[h1JHex]; found {
-			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrDuplicateMessage, "this h1j was already used by another party"), msg.GetFrom())
 		}
 		if _, found := h1H2Map[h2JHex]; found {
-			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrDuplicateMessage, "this h2j was already used by another party"), msg.GetFrom())
 		}
 		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
 
@@ -73,13 +73,16 @@ func (round *round2) Start() *tss.Error {
 		_j := j
 		_msg := msg
 
+		started := time.Now()
 		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, func(isValid bool) {
+			round.observeProof("DLNProof1", _msg.GetFrom(), isValid, started)
 			if !isValid {
 				dlnProof1FailCulprits[_j] = _msg.GetFrom()
 			}
 			wg.Done()
 		})
 		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, func(isValid bool) {
+			round.observeProof("DLNProof2", _msg.GetFrom(), isValid, started)
 			if !isValid {
 				dlnProof2FailCulprits[_j] = _msg.GetFrom()
 			}
@@ -89,7 +92,7 @@ func (round *round2) Start() *tss.Error {
 	wg.Wait()
 	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
 		if culprit != nil {
-			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "dln proof verification failed"), culprit)
 		}
 	}
 	// save NTilde_j, h1_j, h2_j, ...
@@ -120,10 +123,10 @@ func (round *round2) Start() *tss.Error {
 		}
 		if !round.Params().NoProofFac() {
 			var err error
-			facProof, err = facproof.NewProof(ContextI, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+			facProof, err = facproof.NewProof(ContextI, round.Group(), round.save.PaillierSK.N, round.save.NTildej[j],
 				round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
 			if err != nil {
-				return round.WrapError(err, round.PartyID())
+				return round.WrapError(tss.WithKind(tss.ErrInternal, err), round.PartyID())
 			}
 
 		}
@@ -133,7 +136,9 @@ func (round *round2) Start() *tss.Error {
 			round.temp.kgRound2Message1s[j] = r2msg1
 			continue
 		}
-		round.out <- r2msg1
+		if err := round.send(r2msg1); err != nil {
+			return err
+		}
 	}
 
 	// 7. BROADCAST de-commitments of Shamir poly*G
@@ -143,12 +148,14 @@ func (round *round2) Start() *tss.Error {
 		modProof, err = modproof.NewProof(ContextI, round.save.PaillierSK.N,
 			round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
 		if err != nil {
-			return round.WrapError(err, round.PartyID())
+			return round.WrapError(tss.WithKind(tss.ErrInternal, err), round.PartyID())
 		}
 	}
 	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, modProof)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	if err := round.send(r2msg2); err != nil {
+		return err
+	}
 
 	return nil
 }
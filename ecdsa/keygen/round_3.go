@@ -7,8 +7,8 @@
 package keygen
 
 import (
-	"errors"
 	"math/big"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	errors2 "github.com/pkg/errors"
@@ -22,7 +22,7 @@ import (
 
 func (round *round3) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 3
 	round.started = true
@@ -41,7 +41,7 @@ func (round *round3) Start() *tss.Error {
 		share := r2msg1.UnmarshalShare()
 		xi = new(big.Int).Add(xi, share)
 	}
-	round.save.Xi = new(big.Int).Mod(xi, round.Params().EC().Params().N)
+	round.save.Xi = new(big.Int).Mod(xi, round.Params().Group().Order())
 
 	// 2-3.
 	Vc := make(vss.Vs, round.Threshold()+1)
@@ -52,6 +52,7 @@ func (round *round3) Start() *tss.Error {
 	// 4-11.
 	type vssOut struct {
 		unWrappedErr error
+		chainCode    *big.Int
 		pjVs         vss.Vs
 	}
 	chs := make([]chan vssOut, len(Ps))
@@ -73,14 +74,19 @@ func (round *round3) Start() *tss.Error {
 			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
 			KGDj := r2msg2.UnmarshalDeCommitment()
 			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
-			ok, flatPolyGs := cmtDeCmt.DeCommit()
-			if !ok || flatPolyGs == nil {
-				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+			ok, flat := cmtDeCmt.DeCommit()
+			if !ok || len(flat) != 1+2*(round.Threshold()+1) {
+				ch <- vssOut{tss.Errorf(tss.ErrBadCommitment, "de-commitment verify failed"), nil, nil}
 				return
 			}
-			PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
+			// a wider chain code share would not fit the chain code of the key
+			if flat[0].Sign() < 0 || flat[0].BitLen() > chainCodeBitLen {
+				ch <- vssOut{tss.Errorf(tss.ErrBadShare, "chain code share is out of range"), nil, nil}
+				return
+			}
+			PjVs, err := crypto.UnFlattenECPoints(round.Params().Group(), flat[1:])
 			if err != nil {
-				ch <- vssOut{err, nil}
+				ch <- vssOut{tss.WithKind(tss.ErrInvalidMessage, err), nil, nil}
 				return
 			}
 			modProof, err := r2msg2.UnmarshalModProof()
@@ -90,11 +96,14 @@ func (round *round3) Start() *tss.Error {
 				common.Logger.Warningf("modProof not exist:%s", Ps[j])
 			} else {
 				if err != nil {
-					ch <- vssOut{errors.New("modProof verify failed"), nil}
+					ch <- vssOut{tss.Errorf(tss.ErrInvalidProof, "modProof verify failed"), nil, nil}
 					return
 				}
-				if ok = modProof.Verify(ContextJ, round.save.PaillierPKs[j].N); !ok {
-					ch <- vssOut{errors.New("modProof verify failed"), nil}
+				started := time.Now()
+				ok = modProof.Verify(ContextJ, round.save.PaillierPKs[j].N)
+				round.observeProof("ModProof", Ps[j], ok, started)
+				if !ok {
+					ch <- vssOut{tss.Errorf(tss.ErrInvalidProof, "modProof verify failed"), nil, nil}
 					return
 				}
 			}
@@ -104,8 +113,8 @@ func (round *round3) Start() *tss.Error {
 				ID:        round.PartyID().KeyInt(),
 				Share:     r2msg1.UnmarshalShare(),
 			}
-			if ok = PjShare.Verify(round.Params().EC(), round.Threshold(), PjVs); !ok {
-				ch <- vssOut{errors.New("vss verify failed"), nil}
+			if ok = PjShare.Verify(round.Params().Group(), round.Threshold(), PjVs); !ok {
+				ch <- vssOut{tss.Errorf(tss.ErrBadShare, "vss verify failed"), nil, nil}
 				return
 			}
 			facProof, err := r2msg1.UnmarshalFacProof()
@@ -115,18 +124,21 @@ func (round *round3) Start() *tss.Error {
 				common.Logger.Warningf("facProof not exist:%s", Ps[j])
 			} else {
 				if err != nil {
-					ch <- vssOut{errors.New("facProof verify failed"), nil}
+					ch <- vssOut{tss.Errorf(tss.ErrInvalidProof, "facProof verify failed"), nil, nil}
 					return
 				}
-				if ok = facProof.Verify(ContextJ, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
-					round.save.H1i, round.save.H2i); !ok {
-					ch <- vssOut{errors.New("facProof verify failed"), nil}
+				started := time.Now()
+				ok = facProof.Verify(ContextJ, round.Group(), round.save.PaillierPKs[j].N, round.save.NTildei,
+					round.save.H1i, round.save.H2i)
+				round.observeProof("FacProof", Ps[j], ok, started)
+				if !ok {
+					ch <- vssOut{tss.Errorf(tss.ErrInvalidProof, "facProof verify failed"), nil, nil}
 					return
 				}
 			}
 
 			// (9) handled above
-			ch <- vssOut{nil, PjVs}
+			ch <- vssOut{nil, flat[0], PjVs}
 		}(j, chs[j])
 	}
 
@@ -154,6 +166,7 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(multiErr, culprits...)
 		}
 	}
+	chainCode := new(big.Int).Set(round.temp.chainCode)
 	{
 		var err error
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
@@ -161,6 +174,7 @@ func (round *round3) Start() *tss.Error {
 			if j == PIdx {
 				continue
 			}
+			chainCode = new(big.Int).Xor(chainCode, vssResults[j].chainCode)
 			// 10-11.
 			PjVs := vssResults[j].pjVs
 			for c := 0; c <= round.Threshold(); c++ {
@@ -171,14 +185,14 @@ func (round *round3) Start() *tss.Error {
 			}
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
+			return round.WrapError(tss.Errorf(tss.ErrBadShare, "adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
 		}
 	}
 
 	// 12-16. compute Xj for each Pj
 	{
 		var err error
-		modQ := common.ModInt(round.Params().EC().Params().N)
+		modQ := common.ModInt(round.Params().Group().Order())
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
 		bigXj := round.save.BigXj
 		for j := 0; j < round.PartyCount(); j++ {
@@ -196,18 +210,21 @@ func (round *round3) Start() *tss.Error {
 			bigXj[j] = BigXj
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
+			return round.WrapError(tss.Errorf(tss.ErrBadShare, "adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
 		}
 		round.save.BigXj = bigXj
 	}
 
 	// 17. compute and SAVE the ECDSA public key `y`
-	ecdsaPubKey, err := crypto.NewECPoint(round.Params().EC(), Vc[0].X(), Vc[0].Y())
+	ecdsaPubKey, err := crypto.NewECPoint(round.Params().Group(), Vc[0].X(), Vc[0].Y())
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
+		return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "public key is not on the curve")))
 	}
 	round.save.ECDSAPub = ecdsaPubKey
 
+	// SAVE the chain code, which no party could bias as every contribution was committed to in round 1
+	round.save.ChainCode = chainCode.FillBytes(make([]byte, chainCodeBitLen/8))
+
 	// PRINT public key & private share
 	common.Logger.Debugf("%s public key: %x", round.PartyID(), ecdsaPubKey)
 
@@ -216,7 +233,9 @@ func (round *round3) Start() *tss.Error {
 	proof := round.save.PaillierSK.Proof(ki, ecdsaPubKey)
 	r3msg := NewKGRound3Message(round.PartyID(), proof)
 	round.temp.kgRound3Messages[PIdx] = r3msg
-	round.out <- r3msg
+	if err := round.send(r3msg); err != nil {
+		return err
+	}
 	return nil
 }
 
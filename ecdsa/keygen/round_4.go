@@ -7,7 +7,7 @@
 package keygen
 
 import (
-	"errors"
+	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
@@ -16,7 +16,7 @@ import (
 
 func (round *round4) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 4
 	round.started = true
@@ -41,9 +41,11 @@ func (round *round4) Start() *tss.Error {
 		r3msg := msg.Content().(*KGRound3Message)
 		go func(prf paillier.Proof, j int, ch chan<- bool) {
 			ppk := round.save.PaillierPKs[j]
+			started := time.Now()
 			ok, err := prf.Verify(ppk.N, PIDs[j], ecdsaPub)
+			round.observeProof("PaillierProof", Ps[j], ok && err == nil, started)
 			if err != nil {
-				common.Logger.Error(round.WrapError(err, Ps[j]).Error())
+				common.Logger.Error(round.WrapError(tss.WithKind(tss.ErrInvalidProof, err), Ps[j]).Error())
 				ch <- false
 				return
 			}
@@ -70,7 +72,7 @@ func (round *round4) Start() *tss.Error {
 
 	}
 	if len(culprits) > 0 {
-		return round.WrapError(errors.New("paillier verify failed"), culprits...)
+		return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "paillier verify failed"), culprits...)
 	}
 
 	round.end <- round.save
@@ -7,9 +7,12 @@
 package keygen
 
 import (
+	"io"
 	"math/big"
+	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -27,6 +30,7 @@ type (
 		ok      []bool // `ok` tracks parties which have been verified by Update()
 		started bool
 		number  int
+		journal *tss.SessionJournal // set when session snapshots are enabled
 	}
 	round1 struct {
 		*base
@@ -59,6 +63,46 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+// Rand returns the random source of the round, which is the session journal when snapshots are enabled
+func (round *base) Rand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.Parameters.Rand()
+}
+
+// PartialKeyRand returns the random source of ui, which is the session journal when snapshots are enabled
+func (round *base) PartialKeyRand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.Parameters.PartialKeyRand()
+}
+
+// observeProof reports the verification of a proof of prover that started at started
+func (round *base) observeProof(proof string, prover *tss.PartyID, valid bool, started time.Time) {
+	round.Observe(tss.ProofVerified{
+		Party:    round.PartyID(),
+		Task:     TaskName,
+		Round:    round.number,
+		Prover:   prover,
+		Proof:    proof,
+		Valid:    valid,
+		Duration: time.Since(started),
+	})
+}
+
+// send records msg in the session journal, which refuses it if a restored session diverges, then sends it
+func (round *base) send(msg tss.Message) *tss.Error {
+	if round.journal != nil {
+		if err := round.journal.Send(msg); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	round.out <- msg
+	return nil
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -100,7 +144,7 @@ func (round *base) resetOK() {
 
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
-	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
+	ssidList := []*big.Int{round.Group().Order(), crypto.Generator(round.Group()).X(), crypto.Generator(round.Group()).Y()} // group
 	ssidList = append(ssidList, round.Parties().IDs().Keys()...)
 	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
 	ssidList = append(ssidList, round.temp.ssidNonce)
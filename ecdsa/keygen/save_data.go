@@ -8,10 +8,11 @@ package keygen
 
 import (
 	"encoding/hex"
-	"errors"
 	"math/big"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -47,6 +48,12 @@ type (
 
 		// used for test assertions (may be discarded)
 		ECDSAPub *crypto.ECPoint // y
+
+		// the chain code of ECDSAPub generated jointly in keygen; may be empty for keys generated by older versions
+		ChainCode []byte
+
+		// the number of proactive refreshes applied to the key shares since keygen
+		RefreshEpoch uint64
 	}
 )
 
@@ -76,6 +83,15 @@ func (preParams LocalPreParams) ValidateWithProof() bool {
 		preParams.Q != nil
 }
 
+// Wipe zeroes the secrets of the pre-params: the Paillier private key and the safe primes of NTildei with their
+// discrete logs. The pre-params must not be shared, e.g. with a key that is still in use.
+func (preParams *LocalPreParams) Wipe() {
+	if sk := preParams.PaillierSK; sk != nil {
+		common.WipeBigInts(sk.LambdaN, sk.PhiN, sk.P, sk.Q)
+	}
+	common.WipeBigInts(preParams.Alpha, preParams.Beta, preParams.P, preParams.Q)
+}
+
 // BuildLocalSaveDataSubset re-creates the LocalPartySaveData to contain data for only the list of signing parties.
 func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.SortedPartyIDs) LocalPartySaveData {
 	keysToIndices := make(map[string]int, len(sourceData.Ks))
@@ -86,10 +102,12 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData.LocalPreParams = sourceData.LocalPreParams
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.ECDSAPub = sourceData.ECDSAPub
+	newData.ChainCode = sourceData.ChainCode
+	newData.RefreshEpoch = sourceData.RefreshEpoch
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
-			panic(errors.New("BuildLocalSaveDataSubset: unable to find a signer party in the local save data"))
+			panic(tss.Errorf(tss.ErrInvalidSaveData, "BuildLocalSaveDataSubset: unable to find a signer party in the local save data"))
 		}
 		newData.Ks[j] = sourceData.Ks[savedIdx]
 		newData.NTildej[j] = sourceData.NTildej[savedIdx]
@@ -100,3 +118,22 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	}
 	return newData
 }
+
+// MasterExtendedKey returns the extended public key of ECDSAPub with the chain code generated in keygen, for the
+// derivation of child keys. Its String() is the master xpub when version is e.g. chaincfg.MainNetParams.HDPublicKeyID.
+func (save LocalPartySaveData) MasterExtendedKey(version []byte) (*ckd.ExtendedKey, error) {
+	if save.ECDSAPub == nil {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "MasterExtendedKey: missing ECDSAPub")
+	}
+	if len(save.ChainCode) != 32 {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "MasterExtendedKey: the save data has no chain code")
+	}
+	return &ckd.ExtendedKey{
+		PublicKey:  *save.ECDSAPub.ToECDSAPubKey(),
+		Depth:      0,
+		ChildIndex: 0,
+		ChainCode:  save.ChainCode,
+		ParentFP:   []byte{0x00, 0x00, 0x00, 0x00},
+		Version:    version,
+	}, nil
+}
@@ -0,0 +1,253 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"io"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SaveDataKind identifies the ECDSA save data in a keystore container
+	SaveDataKind = "ecdsa/keygen.LocalPartySaveData"
+
+	// SaveDataVersion is the version of the SaveDataMessage encoding written by MarshalSaveData
+	SaveDataVersion uint32 = 1
+)
+
+// saveDataMigrations upgrades the SaveDataMessage encodings of older versions. Add an entry for the previous
+// version whenever SaveDataVersion is bumped.
+var saveDataMigrations = keystore.Migrations{}
+
+// MarshalSaveData encodes the save data in a versioned keystore container protected by key
+func MarshalSaveData(rand io.Reader, save *LocalPartySaveData, key keystore.Key) ([]byte, error) {
+	msg, err := save.toProto()
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	return keystore.Seal(rand, SaveDataKind, SaveDataVersion, payload, key)
+}
+
+// UnmarshalSaveData decodes save data written by MarshalSaveData, migrating it from older versions of the encoding.
+// The decoded save data is checked with ValidateSaveData.
+func UnmarshalSaveData(data []byte, key keystore.Key) (*LocalPartySaveData, error) {
+	version, payload, err := keystore.Open(data, SaveDataKind, key)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err = saveDataMigrations.Apply(version, SaveDataVersion, payload); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	msg := new(SaveDataMessage)
+	if err = proto.Unmarshal(payload, msg); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	save, err := saveDataFromProto(msg)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	if err = ValidateSaveData(save); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	return save, nil
+}
+
+// ValidateSaveData checks that the public data of the parties in save are consistent with each other and with the
+// secret share of this party: Ks are distinct, Xi*G is the BigXj of this party and the BigXj interpolate to ECDSAPub.
+func ValidateSaveData(save *LocalPartySaveData) error {
+	n := len(save.Ks)
+	if n == 0 || len(save.BigXj) != n || len(save.NTildej) != n || len(save.H1j) != n || len(save.H2j) != n ||
+		len(save.PaillierPKs) != n {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: the lengths of the party data do not match")
+	}
+	if save.Xi == nil || save.ShareID == nil || save.ECDSAPub == nil {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: missing Xi, ShareID or ECDSAPub")
+	}
+	g := save.ECDSAPub.Group()
+	q := g.Order()
+	modQ := common.ModInt(q)
+	i := -1
+	seen := make(map[string]struct{}, n)
+	for j, kj := range save.Ks {
+		if kj == nil || kj.Sign() <= 0 {
+			return tss.Errorf(tss.ErrInvalidSaveData, "save data: invalid Ks[%d]", j)
+		}
+		kjModQ := new(big.Int).Mod(kj, q).String()
+		if _, dup := seen[kjModQ]; dup || kjModQ == "0" {
+			return tss.Errorf(tss.ErrInvalidSaveData, "save data: duplicate or zero Ks[%d]", j)
+		}
+		seen[kjModQ] = struct{}{}
+		if kj.Cmp(save.ShareID) == 0 {
+			i = j
+		}
+		if save.BigXj[j] == nil || !save.BigXj[j].ValidateBasic() || save.BigXj[j].Group() != g {
+			return tss.Errorf(tss.ErrInvalidSaveData, "save data: invalid BigXj[%d]", j)
+		}
+	}
+	if i < 0 {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: ShareID is not one of Ks")
+	}
+	if !crypto.ScalarBaseMult(g, save.Xi).Equals(save.BigXj[i]) {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: Xi does not match BigXj")
+	}
+	var pub *crypto.ECPoint
+	for j, kj := range save.Ks {
+		lambda := big.NewInt(1)
+		for c, kc := range save.Ks {
+			if c == j {
+				continue
+			}
+			lambda = modQ.Mul(lambda, modQ.Mul(kc, modQ.ModInverse(modQ.Sub(kc, kj))))
+		}
+		term := save.BigXj[j].ScalarMult(lambda)
+		if pub == nil {
+			pub = term
+			continue
+		}
+		var err error
+		if pub, err = pub.Add(term); err != nil {
+			return tss.WithKind(tss.ErrInvalidSaveData, err)
+		}
+	}
+	if !pub.Equals(save.ECDSAPub) {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: BigXj do not interpolate to ECDSAPub")
+	}
+	return nil
+}
+
+// ----- //
+
+func (save *LocalPartySaveData) toProto() (*SaveDataMessage, error) {
+	if save.ECDSAPub == nil {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: missing ECDSAPub")
+	}
+	curveName, ok := tss.GetGroupName(save.ECDSAPub.Group())
+	if !ok {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: unknown curve")
+	}
+	bigXj, err := crypto.FlattenECPoints(save.BigXj)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	ecdsaPub, err := crypto.FlattenECPoints([]*crypto.ECPoint{save.ECDSAPub})
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	paillierPKs := make([]*big.Int, len(save.PaillierPKs))
+	for j, pk := range save.PaillierPKs {
+		if pk != nil {
+			paillierPKs[j] = pk.N
+		}
+	}
+	msg := &SaveDataMessage{
+		Curve:        string(curveName),
+		NTildeI:      bigIntToBytes(save.NTildei),
+		H1I:          bigIntToBytes(save.H1i),
+		H2I:          bigIntToBytes(save.H2i),
+		Alpha:        bigIntToBytes(save.Alpha),
+		Beta:         bigIntToBytes(save.Beta),
+		P:            bigIntToBytes(save.P),
+		Q:            bigIntToBytes(save.Q),
+		Xi:           bigIntToBytes(save.Xi),
+		ShareId:      bigIntToBytes(save.ShareID),
+		Ks:           common.BigIntsToBytes(save.Ks),
+		NTildeJ:      common.BigIntsToBytes(save.NTildej),
+		H1J:          common.BigIntsToBytes(save.H1j),
+		H2J:          common.BigIntsToBytes(save.H2j),
+		BigXj:        common.BigIntsToBytes(bigXj),
+		PaillierPks:  common.BigIntsToBytes(paillierPKs),
+		EcdsaPub:     common.BigIntsToBytes(ecdsaPub),
+		RefreshEpoch: save.RefreshEpoch,
+		ChainCode:    save.ChainCode,
+	}
+	if sk := save.PaillierSK; sk != nil {
+		msg.PaillierN = bigIntToBytes(sk.N)
+		msg.PaillierLambdaN = bigIntToBytes(sk.LambdaN)
+		msg.PaillierPhiN = bigIntToBytes(sk.PhiN)
+		msg.PaillierP = bigIntToBytes(sk.P)
+		msg.PaillierQ = bigIntToBytes(sk.Q)
+	}
+	return msg, nil
+}
+
+func saveDataFromProto(msg *SaveDataMessage) (*LocalPartySaveData, error) {
+	g, ok := tss.GetGroupByName(tss.CurveName(msg.GetCurve()))
+	if !ok {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: unknown curve %q", msg.GetCurve())
+	}
+	n := len(msg.GetKs())
+	save := NewLocalPartySaveData(n)
+	if len(msg.GetNTildeJ()) != n || len(msg.GetH1J()) != n || len(msg.GetH2J()) != n ||
+		len(msg.GetPaillierPks()) != n || len(msg.GetBigXj()) != 2*n {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: the lengths of the party data do not match")
+	}
+	var err error
+	if save.BigXj, err = crypto.UnFlattenECPoints(g, common.MultiBytesToBigInts(msg.GetBigXj())); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	ecdsaPub, err := crypto.UnFlattenECPoints(g, common.MultiBytesToBigInts(msg.GetEcdsaPub()))
+	if err != nil || len(ecdsaPub) != 1 {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: invalid ECDSAPub")
+	}
+	save.ECDSAPub = ecdsaPub[0]
+	if common.NonEmptyBytes(msg.GetPaillierN()) {
+		save.PaillierSK = &paillier.PrivateKey{
+			PublicKey: paillier.PublicKey{N: bytesToBigInt(msg.GetPaillierN())},
+			LambdaN:   bytesToBigInt(msg.GetPaillierLambdaN()),
+			PhiN:      bytesToBigInt(msg.GetPaillierPhiN()),
+			P:         bytesToBigInt(msg.GetPaillierP()),
+			Q:         bytesToBigInt(msg.GetPaillierQ()),
+		}
+	}
+	save.NTildei = bytesToBigInt(msg.GetNTildeI())
+	save.H1i, save.H2i = bytesToBigInt(msg.GetH1I()), bytesToBigInt(msg.GetH2I())
+	save.Alpha, save.Beta = bytesToBigInt(msg.GetAlpha()), bytesToBigInt(msg.GetBeta())
+	save.P, save.Q = bytesToBigInt(msg.GetP()), bytesToBigInt(msg.GetQ())
+	save.Xi, save.ShareID = bytesToBigInt(msg.GetXi()), bytesToBigInt(msg.GetShareId())
+	for j := 0; j < n; j++ {
+		save.Ks[j] = bytesToBigInt(msg.GetKs()[j])
+		save.NTildej[j] = bytesToBigInt(msg.GetNTildeJ()[j])
+		save.H1j[j] = bytesToBigInt(msg.GetH1J()[j])
+		save.H2j[j] = bytesToBigInt(msg.GetH2J()[j])
+		if N := bytesToBigInt(msg.GetPaillierPks()[j]); N != nil {
+			save.PaillierPKs[j] = &paillier.PublicKey{N: N}
+		}
+	}
+	save.RefreshEpoch = msg.GetRefreshEpoch()
+	if common.NonEmptyBytes(msg.GetChainCode()) {
+		save.ChainCode = msg.GetChainCode()
+	}
+	return &save, nil
+}
+
+// bigIntToBytes encodes a missing value as empty bytes
+func bigIntToBytes(i *big.Int) []byte {
+	if i == nil {
+		return nil
+	}
+	return i.Bytes()
+}
+
+// bytesToBigInt decodes empty bytes as a missing value
+func bytesToBigInt(bz []byte) *big.Int {
+	if !common.NonEmptyBytes(bz) {
+		return nil
+	}
+	return new(big.Int).SetBytes(bz)
+}
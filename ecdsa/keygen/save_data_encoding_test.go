@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+func TestSaveDataMarshalRoundTrip(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	save := keys[0]
+	save.ChainCode = bytes.Repeat([]byte{0xcc}, 32)
+	passphrase := keystore.Passphrase([]byte("correct horse battery staple"))
+
+	data, err := MarshalSaveData(rand.Reader, &save, passphrase)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.False(t, bytes.Contains(data, save.Xi.Bytes()), "Xi should be encrypted")
+	assert.False(t, bytes.Contains(data, save.PaillierSK.P.Bytes()), "the Paillier secret key should be encrypted")
+
+	loaded, err := UnmarshalSaveData(data, passphrase)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, save, *loaded)
+
+	_, err = UnmarshalSaveData(data, keystore.Passphrase([]byte("wrong")))
+	assert.Equal(t, keystore.ErrDecryption, err)
+}
+
+func TestSaveDataMarshalWithoutPaillier(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	// a key of ecdsa/cmp/keygen that has not been refreshed yet has no Paillier or ring-Pedersen parameters
+	save := NewLocalPartySaveData(len(keys[0].Ks))
+	save.LocalSecrets, save.ECDSAPub = keys[0].LocalSecrets, keys[0].ECDSAPub
+	copy(save.Ks, keys[0].Ks)
+	copy(save.BigXj, keys[0].BigXj)
+
+	data, err := MarshalSaveData(rand.Reader, &save, keystore.NoEncryption())
+	if !assert.NoError(t, err) {
+		return
+	}
+	loaded, err := UnmarshalSaveData(data, keystore.NoEncryption())
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, save, *loaded)
+}
+
+func TestValidateSaveData(t *testing.T) {
+	keys, _, err := LoadKeygenTestFixtures(1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	assert.NoError(t, ValidateSaveData(&keys[0]))
+
+	swapped := keys[0]
+	swapped.BigXj = append(swapped.BigXj[:0:0], keys[0].BigXj...)
+	swapped.BigXj[0], swapped.BigXj[1] = swapped.BigXj[1], swapped.BigXj[0]
+	assert.Error(t, ValidateSaveData(&swapped), "BigXj swapped")
+
+	wrongXi := keys[0]
+	wrongXi.LocalSecrets.Xi = new(big.Int).Add(keys[0].Xi, big.NewInt(1))
+	assert.Error(t, ValidateSaveData(&wrongXi), "Xi does not match BigXj")
+
+	duplicate := keys[0]
+	duplicate.Ks = append(duplicate.Ks[:0:0], keys[0].Ks...)
+	duplicate.Ks[1] = duplicate.Ks[0]
+	assert.Error(t, ValidateSaveData(&duplicate), "duplicate Ks")
+
+	truncated := keys[0]
+	truncated.BigXj = truncated.BigXj[1:]
+	assert.Error(t, ValidateSaveData(&truncated), "mismatched lengths")
+}
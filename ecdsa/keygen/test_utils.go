@@ -18,6 +18,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -29,34 +30,27 @@ const (
 	TestThreshold    = test.TestParticipants / 2
 )
 const (
-	testFixtureDirFormat  = "%s/../../test/_ecdsa_fixtures"
-	testFixtureFileFormat = "keygen_data_%d.json"
+	testFixtureDirFormat     = "%s/../../test/_ecdsa_fixtures"
+	testP256FixtureDirFormat = "%s/../../test/_ecdsa_p256_fixtures"
+	testFixtureFileFormat    = "keygen_data_%d.json"
 )
 
 func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadKeygenTestFixturesForCurve(group.Secp256k1(), qty, optionalStart...)
+}
+
+// LoadKeygenTestFixturesForCurve loads the fixtures of the keygen of secp256k1 or P-256
+func LoadKeygenTestFixturesForCurve(g group.AffineGroup, qty int, optionalStart ...int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	keys := make([]LocalPartySaveData, 0, qty)
 	start := 0
 	if 0 < len(optionalStart) {
 		start = optionalStart[0]
 	}
 	for i := start; i < qty; i++ {
-		fixtureFilePath := makeTestFixtureFilePath(i)
-		bz, err := ioutil.ReadFile(fixtureFilePath)
+		key, err := loadKeygenTestFixture(g, i)
 		if err != nil {
-			return nil, nil, errors.Wrapf(err,
-				"could not open the test fixture for party %d in the expected location: %s. run keygen tests first.",
-				i, fixtureFilePath)
-		}
-		var key LocalPartySaveData
-		if err = json.Unmarshal(bz, &key); err != nil {
-			return nil, nil, errors.Wrapf(err,
-				"could not unmarshal fixture data for party %d located at: %s",
-				i, fixtureFilePath)
-		}
-		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			return nil, nil, err
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -69,6 +63,10 @@ func LoadKeygenTestFixtures(qty int, optionalStart ...int) ([]LocalPartySaveData
 }
 
 func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
+	return LoadKeygenTestFixturesRandomSetForCurve(group.Secp256k1(), qty, fixtureCount)
+}
+
+func LoadKeygenTestFixturesRandomSetForCurve(g group.AffineGroup, qty, fixtureCount int) ([]LocalPartySaveData, tss.SortedPartyIDs, error) {
 	keys := make([]LocalPartySaveData, 0, qty)
 	plucked := make(map[int]interface{}, qty)
 	for i := 0; len(plucked) < qty; i = (i + 1) % fixtureCount {
@@ -78,23 +76,10 @@ func LoadKeygenTestFixturesRandomSet(qty, fixtureCount int) ([]LocalPartySaveDat
 		}
 	}
 	for i := range plucked {
-		fixtureFilePath := makeTestFixtureFilePath(i)
-		bz, err := ioutil.ReadFile(fixtureFilePath)
+		key, err := loadKeygenTestFixture(g, i)
 		if err != nil {
-			return nil, nil, errors.Wrapf(err,
-				"could not open the test fixture for party %d in the expected location: %s. run keygen tests first.",
-				i, fixtureFilePath)
-		}
-		var key LocalPartySaveData
-		if err = json.Unmarshal(bz, &key); err != nil {
-			return nil, nil, errors.Wrapf(err,
-				"could not unmarshal fixture data for party %d located at: %s",
-				i, fixtureFilePath)
-		}
-		for _, kbxj := range key.BigXj {
-			kbxj.SetCurve(tss.S256())
+			return nil, nil, err
 		}
-		key.ECDSAPub.SetCurve(tss.S256())
 		keys = append(keys, key)
 	}
 	partyIDs := make(tss.UnSortedPartyIDs, len(keys))
@@ -120,9 +105,34 @@ func LoadNTildeH1H2FromTestFixture(idx int) (NTildei, h1i, h2i *big.Int, err err
 	return
 }
 
+func loadKeygenTestFixture(g group.AffineGroup, i int) (LocalPartySaveData, error) {
+	var key LocalPartySaveData
+	fixtureFilePath := makeTestFixtureFilePathForCurve(g, i)
+	bz, err := ioutil.ReadFile(fixtureFilePath)
+	if err != nil {
+		return key, errors.Wrapf(err,
+			"could not open the test fixture for party %d in the expected location: %s. run keygen tests first.",
+			i, fixtureFilePath)
+	}
+	if err = json.Unmarshal(bz, &key); err != nil {
+		return key, errors.Wrapf(err,
+			"could not unmarshal fixture data for party %d located at: %s",
+			i, fixtureFilePath)
+	}
+	return key, nil
+}
+
 func makeTestFixtureFilePath(partyIndex int) string {
+	return makeTestFixtureFilePathForCurve(group.Secp256k1(), partyIndex)
+}
+
+func makeTestFixtureFilePathForCurve(g group.AffineGroup, partyIndex int) string {
 	_, callerFileName, _, _ := runtime.Caller(0)
 	srcDirName := filepath.Dir(callerFileName)
-	fixtureDirName := fmt.Sprintf(testFixtureDirFormat, srcDirName)
+	dirFormat := testFixtureDirFormat
+	if g == group.P256() {
+		dirFormat = testP256FixtureDirFormat
+	}
+	fixtureDirName := fmt.Sprintf(dirFormat, srcDirName)
 	return fmt.Sprintf("%s/"+testFixtureFileFormat, fixtureDirName, partyIndex)
 }
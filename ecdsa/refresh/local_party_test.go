@@ -0,0 +1,275 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testParticipants, len(keys))
+
+	refreshed := runRefresh(t, keys, pIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData) *LocalParty {
+		return NewLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+	})
+	assertRefreshed(t, keys, refreshed)
+	for j, data := range refreshed {
+		assert.Equal(t, 0, data.PaillierSK.N.Cmp(keys[j].PaillierSK.N), "the Paillier key must be kept")
+		for k := range refreshed {
+			assert.Equal(t, 0, data.NTildej[k].Cmp(keys[j].NTildej[k]), "the ring-Pedersen parameters must be kept")
+		}
+	}
+
+	// a second refresh moves the key to the next epoch
+	refreshedTwice := runRefresh(t, refreshed, pIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData) *LocalParty {
+		return NewLocalParty(params, refreshed[i], outCh, endCh).(*LocalParty)
+	})
+	assertRefreshed(t, refreshed, refreshedTwice)
+	assert.Equal(t, keys[0].RefreshEpoch+2, refreshedTwice[0].RefreshEpoch)
+}
+
+func TestE2EConcurrentWithPaillierRotation(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testParticipants, len(keys))
+
+	// the pre-params of the fixtures are rotated so that every party gets new Paillier keys
+	refreshed := runRefresh(t, keys, pIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData) *LocalParty {
+		preParams := keys[(i+1)%len(keys)].LocalPreParams
+		return NewLocalPartyWithPaillierRotation(params, keys[i], outCh, endCh, preParams).(*LocalParty)
+	})
+	assertRefreshed(t, keys, refreshed)
+	for j, data := range refreshed {
+		assert.Equal(t, 0, data.PaillierSK.N.Cmp(keys[(j+1)%len(keys)].PaillierSK.N), "the Paillier key must be rotated")
+		for k := range refreshed {
+			assert.Equal(t, 0, data.PaillierPKs[k].N.Cmp(refreshed[k].PaillierSK.N))
+			assert.Equal(t, 0, data.NTildej[k].Cmp(refreshed[k].NTildei))
+			assert.Equal(t, 0, data.H1j[k].Cmp(refreshed[k].H1i))
+			assert.Equal(t, 0, data.H2j[k].Cmp(refreshed[k].H2i))
+		}
+	}
+}
+
+func TestE2EEpochMismatch(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	// one party presents a key from a later epoch
+	stale := 1
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		key := keys[i]
+		if i == stale {
+			key.RefreshEpoch++
+		}
+		P := NewLocalParty(params, key, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, 2, err.Round())
+			assert.Equal(t, 1, len(err.Culprits()))
+			if err.Victim().Index == stale {
+				assert.NotEqual(t, stale, err.Culprits()[0].Index)
+			} else {
+				assert.Equal(t, stale, err.Culprits()[0].Index)
+			}
+			return
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go test.SharedPartyUpdater(P, msg, errCh)
+			}
+
+		case <-endCh:
+			t.Fatal("the refresh must not complete")
+		}
+	}
+}
+
+func runRefresh(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	pIDs tss.SortedPartyIDs,
+	newParty func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData) *LocalParty,
+) []keygen.LocalPartySaveData {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := newParty(params, i, outCh, endCh)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// PHASE: refresh
+	var ended int32
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+					return nil
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
+				t.Logf("Done. Received save data from %d participants", ended)
+				refreshed := make([]keygen.LocalPartySaveData, len(parties))
+				for j, Pj := range parties {
+					refreshed[j] = Pj.data
+				}
+				return refreshed
+			}
+		}
+	}
+}
+
+func assertRefreshed(t *testing.T, keys, refreshed []keygen.LocalPartySaveData) {
+	oldShares, newShares := make(vss.Shares, 0, len(keys)), make(vss.Shares, 0, len(keys))
+	for j, data := range refreshed {
+		assert.NotEqual(t, 0, data.Xi.Cmp(keys[j].Xi), "the key share must change")
+		assert.True(t, data.ECDSAPub.Equals(keys[j].ECDSAPub), "the public key must not change")
+		assert.Equal(t, keys[j].RefreshEpoch+1, data.RefreshEpoch)
+		for k := range refreshed {
+			assert.True(t, data.BigXj[k].Equals(refreshed[k].BigXj[k]), "everyone has the same BigXj")
+		}
+		assert.True(t, data.BigXj[j].Equals(crypto.ScalarBaseMult(group.Secp256k1(), data.Xi)), "ensure BigX_j == g^x_j")
+		oldShares = append(oldShares, &vss.Share{Threshold: testThreshold, ID: keys[j].ShareID, Share: keys[j].Xi})
+		newShares = append(newShares, &vss.Share{Threshold: testThreshold, ID: data.ShareID, Share: data.Xi})
+	}
+	oldX, err := oldShares[:testThreshold+1].ReConstruct(group.Secp256k1())
+	assert.NoError(t, err)
+	newX, err := newShares[1 : testThreshold+2].ReConstruct(group.Secp256k1())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, oldX.Cmp(newX), "the refreshed shares must reconstruct the same secret")
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
+	newParams := func() *tss.Parameters {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pIDs[0], len(pIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return params
+	}
+	newParty := func() *LocalParty {
+		return NewLocalPartyWithPaillierRotation(newParams(), keys[0], outCh, endCh, keys[1].LocalPreParams).(*LocalParty)
+	}
+
+	// a rotation must be given its pre-params, which a restored session could not generate again
+	assert.NotNil(t, NewLocalPartyWithPaillierRotation(newParams(), keys[0], outCh, endCh).Start())
+
+	P := newParty()
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty().Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session that does not rotate the Paillier key
+	assert.NotNil(t, NewLocalParty(newParams(), keys[0], outCh, endCh).(*LocalParty).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty().Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
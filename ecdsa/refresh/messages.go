@@ -0,0 +1,200 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into ecdsa-refresh.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that refresh messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*RefreshRound1Message)(nil),
+		(*RefreshRound2Message1)(nil),
+		(*RefreshRound2Message2)(nil),
+	}
+)
+
+// ----- //
+
+// NewRefreshRound1Message returns the round 1 message; paillierPK is nil when the party keeps its Paillier and
+// ring-Pedersen parameters, in which case the other parameters are ignored.
+func NewRefreshRound1Message(
+	from *tss.PartyID,
+	ct cmt.HashCommitment,
+	epoch uint64,
+	paillierPK *paillier.PublicKey,
+	nTildeI, h1I, h2I *big.Int,
+	dlnProof1, dlnProof2 *dlnproof.Proof,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RefreshRound1Message{
+		Commitment: ct.Bytes(),
+		Epoch:      epoch,
+	}
+	if paillierPK != nil {
+		dlnProof1Bz, err := dlnProof1.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		dlnProof2Bz, err := dlnProof2.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		content.PaillierN = paillierPK.N.Bytes()
+		content.NTilde = nTildeI.Bytes()
+		content.H1 = h1I.Bytes()
+		content.H2 = h2I.Bytes()
+		content.Dlnproof_1 = dlnProof1Bz
+		content.Dlnproof_2 = dlnProof2Bz
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *RefreshRound1Message) ValidateBasic() bool {
+	if m == nil || !common.NonEmptyBytes(m.GetCommitment()) {
+		return false
+	}
+	if !m.IsRotating() {
+		return len(m.GetNTilde()) == 0 &&
+			len(m.GetH1()) == 0 &&
+			len(m.GetH2()) == 0 &&
+			len(m.GetDlnproof_1()) == 0 &&
+			len(m.GetDlnproof_2()) == 0
+	}
+	return common.NonEmptyBytes(m.GetNTilde()) &&
+		common.NonEmptyBytes(m.GetH1()) &&
+		common.NonEmptyBytes(m.GetH2()) &&
+		// expected len of dln proof = sizeof(int64) + len(alpha) + len(t)
+		common.NonEmptyMultiBytes(m.GetDlnproof_1(), 2+(dlnproof.Iterations*2)) &&
+		common.NonEmptyMultiBytes(m.GetDlnproof_2(), 2+(dlnproof.Iterations*2))
+}
+
+// IsRotating reports whether the sender replaces its Paillier and ring-Pedersen parameters
+func (m *RefreshRound1Message) IsRotating() bool {
+	return len(m.GetPaillierN()) > 0
+}
+
+func (m *RefreshRound1Message) UnmarshalCommitment() *big.Int {
+	return new(big.Int).SetBytes(m.GetCommitment())
+}
+
+func (m *RefreshRound1Message) UnmarshalPaillierPK() *paillier.PublicKey {
+	return &paillier.PublicKey{N: new(big.Int).SetBytes(m.GetPaillierN())}
+}
+
+func (m *RefreshRound1Message) UnmarshalNTilde() *big.Int {
+	return new(big.Int).SetBytes(m.GetNTilde())
+}
+
+func (m *RefreshRound1Message) UnmarshalH1() *big.Int {
+	return new(big.Int).SetBytes(m.GetH1())
+}
+
+func (m *RefreshRound1Message) UnmarshalH2() *big.Int {
+	return new(big.Int).SetBytes(m.GetH2())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof1() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_1())
+}
+
+func (m *RefreshRound1Message) UnmarshalDLNProof2() (*dlnproof.Proof, error) {
+	return dlnproof.UnmarshalDLNProof(m.GetDlnproof_2())
+}
+
+// ----- //
+
+// NewRefreshRound2Message1 returns the round 2 P2P message; proof is nil when the sender does not rotate its Paillier key.
+func NewRefreshRound2Message1(
+	to, from *tss.PartyID,
+	share *vss.Share,
+	proof *facproof.ProofFac,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          []*tss.PartyID{to},
+		IsBroadcast: false,
+	}
+	content := &RefreshRound2Message1{
+		Share: share.Share.Bytes(),
+	}
+	if proof != nil {
+		proofBzs := proof.Bytes()
+		content.FacProof = proofBzs[:]
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message1) ValidateBasic() bool {
+	// the share of a zero sharing may legitimately be encoded as empty bytes
+	return m != nil &&
+		(len(m.GetFacProof()) == 0 || common.NonEmptyMultiBytes(m.GetFacProof(), facproof.ProofFacBytesParts))
+}
+
+func (m *RefreshRound2Message1) UnmarshalShare() *big.Int {
+	return new(big.Int).SetBytes(m.Share)
+}
+
+func (m *RefreshRound2Message1) UnmarshalFacProof() (*facproof.ProofFac, error) {
+	return facproof.NewProofFromBytes(m.GetFacProof())
+}
+
+// ----- //
+
+// NewRefreshRound2Message2 returns the round 2 broadcast message; proof is nil when the sender does not rotate its Paillier key.
+func NewRefreshRound2Message2(
+	from *tss.PartyID,
+	deCommitment cmt.HashDeCommitment,
+	proof *modproof.ProofMod,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &RefreshRound2Message2{
+		DeCommitment: common.BigIntsToBytes(deCommitment),
+	}
+	if proof != nil {
+		proofBzs := proof.Bytes()
+		content.ModProof = proofBzs[:]
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *RefreshRound2Message2) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyMultiBytes(m.GetDeCommitment()) &&
+		(len(m.GetModProof()) == 0 || common.NonEmptyMultiBytes(m.GetModProof(), modproof.ProofModBytesParts))
+}
+
+func (m *RefreshRound2Message2) UnmarshalDeCommitment() []*big.Int {
+	deComBzs := m.GetDeCommitment()
+	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
+}
+
+func (m *RefreshRound2Message2) UnmarshalModProof() (*modproof.ProofMod, error) {
+	return modproof.NewProofFromBytes(m.GetModProof())
+}
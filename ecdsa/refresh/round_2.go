@@ -0,0 +1,188 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	paillierBitsLen = 2048
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. every party must refresh the same epoch of the key
+	for j, msg := range round.temp.refreshRound1Messages {
+		r1msg := msg.Content().(*RefreshRound1Message)
+		if r1msg.GetEpoch() != round.key.RefreshEpoch {
+			return round.WrapError(errors.New("the refresh epoch of this party does not match"), msg.GetFrom())
+		}
+		if j != i {
+			round.temp.KGCs[j] = r1msg.UnmarshalCommitment()
+		}
+	}
+
+	// 2. verify the dln proofs of the parties that rotate their parameters and store them
+	dlnVerifier := keygen.NewDlnProofVerifier(round.Concurrency())
+	dlnProof1FailCulprits := make([]*tss.PartyID, len(Ps))
+	dlnProof2FailCulprits := make([]*tss.PartyID, len(Ps))
+	wg := new(sync.WaitGroup)
+	for j, msg := range round.temp.refreshRound1Messages {
+		r1msg := msg.Content().(*RefreshRound1Message)
+		if j == i || !r1msg.IsRotating() {
+			continue
+		}
+		H1j, H2j, NTildej, paillierPKj := r1msg.UnmarshalH1(),
+			r1msg.UnmarshalH2(),
+			r1msg.UnmarshalNTilde(),
+			r1msg.UnmarshalPaillierPK()
+		if paillierPKj.N.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got paillier modulus with insufficient bits for this party"), msg.GetFrom())
+		}
+		if H1j.Cmp(H2j) == 0 {
+			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+		}
+		if NTildej.BitLen() != paillierBitsLen {
+			return round.WrapError(errors.New("got NTildej with insufficient bits for this party"), msg.GetFrom())
+		}
+
+		wg.Add(2)
+		_j := j
+		_msg := msg
+
+		dlnVerifier.VerifyDLNProof1(r1msg, H1j, H2j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof1FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+		dlnVerifier.VerifyDLNProof2(r1msg, H2j, H1j, NTildej, func(isValid bool) {
+			if !isValid {
+				dlnProof2FailCulprits[_j] = _msg.GetFrom()
+			}
+			wg.Done()
+		})
+
+		round.temp.paillierPKs[j] = paillierPKj
+		round.temp.NTildej[j] = NTildej
+		round.temp.H1j[j], round.temp.H2j[j] = H1j, H2j
+	}
+	wg.Wait()
+	for _, culprit := range append(dlnProof1FailCulprits, dlnProof2FailCulprits...) {
+		if culprit != nil {
+			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+		}
+	}
+
+	// 3. ensure uniqueness of h1j, h2j among the parameters used after the refresh
+	h1H2Map := make(map[string]struct{}, len(Ps)*2)
+	for j, Pj := range Ps {
+		h1JHex, h2JHex := hex.EncodeToString(round.temp.H1j[j].Bytes()), hex.EncodeToString(round.temp.H2j[j].Bytes())
+		if _, found := h1H2Map[h1JHex]; found {
+			return round.WrapError(errors.New("this h1j was already used by another party"), Pj)
+		}
+		if _, found := h1H2Map[h2JHex]; found {
+			return round.WrapError(errors.New("this h2j was already used by another party"), Pj)
+		}
+		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
+	}
+
+	// 4. p2p send share ij to Pj, with a proof that the new Paillier modulus has no small factors when rotating
+	preParams := round.temp.preParams
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		var facProof *facproof.ProofFac
+		if round.temp.rotate {
+			var err error
+			facProof, err = facproof.NewProof(ContextI, round.Group(), preParams.PaillierSK.N, round.temp.NTildej[j],
+				round.temp.H1j[j], round.temp.H2j[j], preParams.PaillierSK.P, preParams.PaillierSK.Q, round.Rand())
+			if err != nil {
+				return round.WrapError(err, round.PartyID())
+			}
+		}
+		r2msg1 := NewRefreshRound2Message1(Pj, round.PartyID(), round.temp.shares[j], facProof)
+		if err := round.send(r2msg1); err != nil {
+			return err
+		}
+	}
+
+	// 5. BROADCAST de-commitment, with a proof that the new Paillier modulus is a Paillier-Blum modulus when rotating
+	var modProof *modproof.ProofMod
+	if round.temp.rotate {
+		var err error
+		modProof, err = modproof.NewProof(ContextI, preParams.PaillierSK.N, preParams.PaillierSK.P, preParams.PaillierSK.Q, round.Rand())
+		if err != nil {
+			return round.WrapError(err, round.PartyID())
+		}
+	}
+	r2msg2 := NewRefreshRound2Message2(round.PartyID(), round.temp.deCommit, modProof)
+	round.temp.refreshRound2Message2s[i] = r2msg2
+	if err := round.send(r2msg2); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RefreshRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.refreshRound2Message2s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		if j != round.PartyID().Index {
+			msg1 := round.temp.refreshRound2Message1s[j]
+			if msg1 == nil || !round.CanAccept(msg1) {
+				ret = false
+				continue
+			}
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
@@ -0,0 +1,184 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round3) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	PIdx := round.PartyID().Index
+
+	// 1. verify the de-commitments, shares and, for the parties that rotate, the fac and mod proofs (concurrent)
+	chs := make([]chan error, len(Ps))
+	for j := range chs {
+		if j == PIdx {
+			continue
+		}
+		chs[j] = make(chan error)
+	}
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		go func(j int, ch chan<- error) {
+			ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+			r1msg := round.temp.refreshRound1Messages[j].Content().(*RefreshRound1Message)
+			r2msg1 := round.temp.refreshRound2Message1s[j].Content().(*RefreshRound2Message1)
+			r2msg2 := round.temp.refreshRound2Message2s[j].Content().(*RefreshRound2Message2)
+			cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.KGCs[j], D: r2msg2.UnmarshalDeCommitment()}
+			ok, flatPolyGs := cmtDeCmt.DeCommit()
+			if !ok || len(flatPolyGs) != 2*round.Threshold() {
+				ch <- errors.New("de-commitment verify failed")
+				return
+			}
+			PjVs, err := crypto.UnFlattenECPoints(round.Group(), flatPolyGs)
+			if err != nil {
+				ch <- err
+				return
+			}
+			PjShare := vss.Share{
+				Threshold: round.Threshold(),
+				ID:        round.PartyID().KeyInt(),
+				Share:     r2msg1.UnmarshalShare(),
+			}
+			if ok = PjShare.VerifyZeroSharing(round.Group(), round.Threshold(), PjVs); !ok {
+				ch <- errors.New("vss verify failed")
+				return
+			}
+			if r1msg.IsRotating() {
+				facProof, err := r2msg1.UnmarshalFacProof()
+				if err != nil || !facProof.Verify(ContextJ, round.Group(), round.temp.paillierPKs[j].N, round.temp.NTildej[PIdx],
+					round.temp.H1j[PIdx], round.temp.H2j[PIdx]) {
+					ch <- errors.New("facProof verify failed")
+					return
+				}
+				modProof, err := r2msg2.UnmarshalModProof()
+				if err != nil || !modProof.Verify(ContextJ, round.temp.paillierPKs[j].N) {
+					ch <- errors.New("modProof verify failed")
+					return
+				}
+			}
+			round.temp.pjVs[j] = PjVs
+			ch <- nil
+		}(j, chs[j])
+	}
+
+	// consume unbuffered channels (end the goroutines)
+	{
+		var multiErr error
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for j, Pj := range Ps {
+			if j == PIdx {
+				continue
+			}
+			if err := <-chs[j]; err != nil {
+				multiErr = multierror.Append(multiErr, err)
+				culprits = append(culprits, Pj)
+			}
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(multiErr, culprits...)
+		}
+	}
+
+	// 2. compute the refreshed xi
+	modQ := common.ModInt(round.Group().Order())
+	xi := modQ.Add(round.key.Xi, round.temp.shares[PIdx].Share)
+	for j := range Ps {
+		if j == PIdx {
+			continue
+		}
+		r2msg1 := round.temp.refreshRound2Message1s[j].Content().(*RefreshRound2Message1)
+		xi = modQ.Add(xi, r2msg1.UnmarshalShare())
+	}
+
+	// 3. compute the refreshed Xj for each Pj
+	bigXj := make([]*crypto.ECPoint, len(Ps))
+	{
+		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
+		for k, Pk := range Ps {
+			BigXk := round.key.BigXj[k]
+			for j := range Ps {
+				Vs := round.temp.vs
+				if j != PIdx {
+					Vs = round.temp.pjVs[j]
+				}
+				delta, err := vss.EvaluateZeroSharing(round.Group(), Vs, Pk.KeyInt())
+				if err == nil {
+					BigXk, err = BigXk.Add(delta)
+				}
+				if err != nil {
+					culprits = append(culprits, Ps[j])
+				}
+			}
+			bigXj[k] = BigXk
+		}
+		if len(culprits) > 0 {
+			return round.WrapError(errors.New("adding the zero sharing to BigXj resulted in a point not on the curve"), culprits...)
+		}
+	}
+	if !crypto.ScalarBaseMult(round.Group(), xi).Equals(bigXj[PIdx]) {
+		return round.WrapError(errors.New("the refreshed key share does not match its public commitment"), round.PartyID())
+	}
+
+	// 4. SAVE the refreshed key in the next epoch
+	round.save.LocalPreParams = round.key.LocalPreParams
+	if round.temp.rotate {
+		round.save.LocalPreParams = round.temp.preParams
+	}
+	round.save.Xi = xi
+	round.save.ShareID = round.key.ShareID
+	round.save.ECDSAPub = round.key.ECDSAPub
+	round.save.ChainCode = round.key.ChainCode
+	round.save.RefreshEpoch = round.key.RefreshEpoch + 1
+	for j := range Ps {
+		round.save.Ks[j] = round.key.Ks[j]
+		round.save.BigXj[j] = bigXj[j]
+		round.save.PaillierPKs[j] = round.temp.paillierPKs[j]
+		round.save.NTildej[j] = round.temp.NTildej[j]
+		round.save.H1j[j], round.save.H2j[j] = round.temp.H1j[j], round.temp.H2j[j]
+	}
+
+	for j := range round.ok {
+		round.ok[j] = true
+	}
+	round.end <- round.save
+
+	return nil
+}
+
+func (round *round3) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *round3) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *round3) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -19,11 +19,13 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	. "github.com/bnb-chain/tss-lib/v2/ecdsa/resharing"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
 )
 
 const (
@@ -38,15 +40,21 @@ func setUp(level string) {
 }
 
 func TestE2EConcurrent(t *testing.T) {
-	setUp("info")
+	testE2EConcurrent(t, group.Secp256k1())
+}
+
+func TestE2EConcurrentP256(t *testing.T) {
+	testE2EConcurrent(t, group.P256())
+}
 
-	// tss.SetCurve(elliptic.P256())
+func testE2EConcurrent(t *testing.T, curve group.AffineGroup) {
+	setUp("info")
 
 	threshold, newThreshold := testThreshold, testThreshold
 
 	// PHASE: load keygen fixtures
 	firstPartyIdx, extraParties := 1, 1 // extra can be 0 to N-first
-	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixturesForCurve(curve, testThreshold+1+extraParties+firstPartyIdx, firstPartyIdx)
 	assert.NoError(t, err, "should load keygen fixtures")
 
 	// PHASE: resharing
@@ -72,13 +80,13 @@ func TestE2EConcurrent(t *testing.T) {
 
 	// init the old parties first
 	for j, pID := range oldPIDs {
-		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params := tss.NewReSharingParameters(curve, oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
 		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
 		oldCommittee = append(oldCommittee, P)
 	}
 	// init the new parties
 	for j, pID := range newPIDs {
-		params := tss.NewReSharingParameters(tss.S256(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params := tss.NewReSharingParameters(curve, oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
 		// do not use in untrusted setting
 		params.SetNoProofMod()
 		// do not use in untrusted setting
@@ -154,7 +162,7 @@ func TestE2EConcurrent(t *testing.T) {
 				for j, key := range newKeys {
 					// xj test: BigXj == xj*G
 					xj := key.Xi
-					gXj := crypto.ScalarBaseMult(tss.S256(), xj)
+					gXj := crypto.ScalarBaseMult(curve, xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 				}
@@ -176,7 +184,7 @@ signing:
 	signEndCh := make(chan *common.SignatureData, len(signPIDs))
 
 	for j, signPID := range signPIDs {
-		params := tss.NewParameters(tss.S256(), signP2pCtx, signPID, len(signPIDs), newThreshold)
+		params := tss.NewParameters(curve, signP2pCtx, signPID, len(signPIDs), newThreshold)
 		P := signing.NewLocalParty(big.NewInt(42), params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
 		signParties = append(signParties, P)
 		go func(P *signing.LocalParty) {
@@ -219,7 +227,7 @@ signing:
 				// BEGIN ECDSA verify
 				pkX, pkY := signKeys[0].ECDSAPub.X(), signKeys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.S256(),
+					Curve: curve.Curve(),
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -236,3 +244,88 @@ signing:
 		}
 	}
 }
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	outCh := make(chan tss.Message, 10*len(newPIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
+	newParty := func(pID *tss.PartyID, key keygen.LocalPartySaveData, newThreshold int) *LocalParty {
+		params := tss.NewReSharingParameters(group.Secp256k1(), oldP2PCtx, newP2PCtx, pID, testParticipants, testThreshold, len(newPIDs), newThreshold)
+		params.SetSessionSnapshots(true)
+		// do not use in untrusted setting
+		params.SetNoProofMod()
+		return NewLocalParty(params, key, outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(oldPIDs[0], oldKeys[0], testThreshold)
+	assert.Nil(t, P.Start())
+	r1msgs := drain(outCh)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(oldPIDs[0], oldKeys[0], testThreshold).Restore(snapshot, keystore.NoEncryption()))
+	assertSameMessages(t, r1msgs, drain(outCh))
+
+	// the snapshot cannot be restored into a session with another threshold
+	assert.NotNil(t, newParty(oldPIDs[0], oldKeys[0], testThreshold+1).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(oldPIDs[0], oldKeys[0], testThreshold).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+
+	// a member of the new committee must be given its pre-params, which a restored session could not generate again
+	assert.NotNil(t, newParty(newPIDs[0], keygen.NewLocalPartySaveData(len(newPIDs)), testThreshold).Start())
+
+	// a member of the new committee is restored with the messages of the old committee
+	save := keygen.NewLocalPartySaveData(len(newPIDs))
+	save.LocalPreParams = oldKeys[0].LocalPreParams
+	Q := newParty(newPIDs[0], save, testThreshold)
+	assert.Nil(t, Q.Start())
+	for j := 1; j < len(oldPIDs); j++ {
+		assert.Nil(t, newParty(oldPIDs[j], oldKeys[j], testThreshold).Start())
+	}
+	errCh := make(chan *tss.Error, len(oldPIDs))
+	for _, msg := range append(r1msgs, drain(outCh)...) {
+		test.SharedPartyUpdater(Q, msg, errCh)
+	}
+	assert.Zero(t, len(errCh))
+	r2msgs := drain(outCh)
+	assert.Len(t, r2msgs, 2)
+	snapshot, err = Q.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+	restored := newParty(newPIDs[0], save, testThreshold)
+	assert.Nil(t, restored.Restore(snapshot, keystore.NoEncryption()))
+	assertSameMessages(t, r2msgs, drain(outCh))
+	assert.Equal(t, Q.String(), restored.String())
+}
+
+func drain(ch chan tss.Message) []tss.Message {
+	msgs := make([]tss.Message, 0, len(ch))
+	for len(ch) > 0 {
+		msgs = append(msgs, <-ch)
+	}
+	return msgs
+}
+
+func assertSameMessages(t *testing.T, expected, actual []tss.Message) {
+	if !assert.Equal(t, len(expected), len(actual)) {
+		return
+	}
+	for i := range expected {
+		bz, _, err := expected[i].WireBytes()
+		assert.NoError(t, err)
+		reBz, _, err := actual[i].WireBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, bz, reBz)
+	}
+}
@@ -7,7 +7,6 @@
 package resharing
 
 import (
-	"crypto/elliptic"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -15,6 +14,7 @@ import (
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/dlnproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/facproof"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
 	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
@@ -68,9 +68,9 @@ func (m *DGRound1Message) ValidateBasic() bool {
 		common.NonEmptyBytes(m.VCommitment)
 }
 
-func (m *DGRound1Message) UnmarshalECDSAPub(ec elliptic.Curve) (*crypto.ECPoint, error) {
+func (m *DGRound1Message) UnmarshalECDSAPub(g group.AffineGroup) (*crypto.ECPoint, error) {
 	return crypto.NewECPoint(
-		ec,
+		g,
 		new(big.Int).SetBytes(m.EcdsaPubX),
 		new(big.Int).SetBytes(m.EcdsaPubY))
 }
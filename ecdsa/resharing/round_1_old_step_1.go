@@ -7,10 +7,9 @@
 package resharing
 
 import (
-	"errors"
-	"fmt"
 	"math/big"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
@@ -20,15 +19,15 @@ import (
 )
 
 // round 1 represents round 1 of the keygen part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
-func newRound1(params *tss.ReSharingParameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+func newRound1(params *tss.ReSharingParameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData, journal *tss.SessionJournal) tss.Round {
 	return &round1{
-		&base{params, temp, input, save, out, end, make([]bool, len(params.OldParties().IDs())), make([]bool, len(params.NewParties().IDs())), false, 1},
+		&base{params, temp, input, save, out, end, make([]bool, len(params.OldParties().IDs())), make([]bool, len(params.NewParties().IDs())), false, 1, journal},
 	}
 }
 
 func (round *round1) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 1
 	round.started = true
@@ -43,7 +42,7 @@ func (round *round1) Start() *tss.Error {
 	round.temp.ssidNonce = new(big.Int).SetUint64(uint64(0))
 	ssid, err := round.getSSID()
 	if err != nil {
-		return round.WrapError(err)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err))
 	}
 	round.temp.ssid = ssid
 	Pi := round.PartyID()
@@ -52,21 +51,23 @@ func (round *round1) Start() *tss.Error {
 	// 1. PrepareForSigning() -> w_i
 	xi, ks, bigXj := round.input.Xi, round.input.Ks, round.input.BigXj
 	if round.Threshold()+1 > len(ks) {
-		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
+		return round.WrapError(tss.Errorf(tss.ErrInvalidParameters, "t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
 	}
 	newKs := round.NewParties().IDs().Keys()
-	wi, _ := signing.PrepareForSigning(round.Params().EC(), i, len(round.OldParties().IDs()), xi, ks, bigXj)
+	wi, _ := signing.PrepareForSigning(round.Params().Group(), i, len(round.OldParties().IDs()), xi, ks, bigXj)
 
 	// 2.
-	vi, shares, err := vss.Create(round.Params().EC(), round.NewThreshold(), wi, newKs, round.Rand())
+	vi, shares, err := vss.Create(round.Params().Group(), round.NewThreshold(), wi, newKs, round.Rand())
+	// security: w_i may be discarded once it is shared
+	common.WipeBigInt(wi)
 	if err != nil {
-		return round.WrapError(err, round.PartyID())
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), round.PartyID())
 	}
 
 	// 3.
 	flatVis, err := crypto.FlattenECPoints(vi)
 	if err != nil {
-		return round.WrapError(err, round.PartyID())
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), round.PartyID())
 	}
 	vCmt := commitments.NewHashCommitment(round.Rand(), flatVis...)
 
@@ -79,7 +80,9 @@ func (round *round1) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		round.input.ECDSAPub, vCmt.C, ssid)
 	round.temp.dgRound1Messages[i] = r1msg
-	round.out <- r1msg
+	if err := round.send(r1msg); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -115,14 +118,14 @@ func (round *round1) Update() (bool, *tss.Error) {
 			continue
 		}
 		r1msg := round.temp.dgRound1Messages[0].Content().(*DGRound1Message)
-		candidate, err := r1msg.UnmarshalECDSAPub(round.Params().EC())
+		candidate, err := r1msg.UnmarshalECDSAPub(round.Params().Group())
 		if err != nil {
-			return false, round.WrapError(errors.New("unable to unmarshal the ecdsa pub key"), msg.GetFrom())
+			return false, round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "unable to unmarshal the ecdsa pub key"), msg.GetFrom())
 		}
 		if round.save.ECDSAPub != nil &&
 			!candidate.Equals(round.save.ECDSAPub) {
 			// uh oh - anomaly!
-			return false, round.WrapError(errors.New("ecdsa pub key did not match what we received previously"), msg.GetFrom())
+			return false, round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "ecdsa pub key did not match what we received previously"), msg.GetFrom())
 		}
 		round.save.ECDSAPub = candidate
 	}
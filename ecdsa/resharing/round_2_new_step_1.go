@@ -8,7 +8,6 @@ package resharing
 
 import (
 	"bytes"
-	"errors"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/crypto/modproof"
@@ -22,7 +21,7 @@ var zero = big.NewInt(0)
 
 func (round *round2) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 2
 	round.started = true
@@ -46,7 +45,7 @@ func (round *round2) Start() *tss.Error {
 		r1msg := round.temp.dgRound1Messages[j].Content().(*DGRound1Message)
 		SSIDj := r1msg.UnmarshalSSID()
 		if !bytes.Equal(SSID, SSIDj) {
-			return round.WrapError(errors.New("ssid mismatch"), Pj)
+			return round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "ssid mismatch"), Pj)
 		}
 	}
 	round.temp.ssid = SSID
@@ -55,7 +54,9 @@ func (round *round2) Start() *tss.Error {
 	r2msg1 := NewDGRound2Message2(
 		round.OldParties().IDs().Exclude(round.PartyID()), round.PartyID())
 	round.temp.dgRound2Message2s[i] = r2msg1
-	round.out <- r2msg1
+	if err := round.send(r2msg1); err != nil {
+		return err
+	}
 
 	// 1.
 	// generate Paillier public key E_i, private key and proof
@@ -65,15 +66,16 @@ func (round *round2) Start() *tss.Error {
 	var preParams *keygen.LocalPreParams
 	if round.save.LocalPreParams.Validate() && !round.save.LocalPreParams.ValidateWithProof() {
 		return round.WrapError(
-			errors.New("`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
+			tss.Errorf(tss.ErrInvalidParameters, "`optionalPreParams` failed to validate; it might have been generated with an older version of tss-lib"))
 	} else if round.save.LocalPreParams.ValidateWithProof() {
 		preParams = &round.save.LocalPreParams
 	} else {
 		var err error
 		preParams, err = keygen.GeneratePreParams(round.SafePrimeGenTimeout(), round.Concurrency())
 		if err != nil {
-			return round.WrapError(errors.New("pre-params generation failed"), Pi)
+			return round.WrapError(tss.Errorf(tss.ErrPreParamsGeneration, "pre-params generation failed"), Pi)
 		}
+		round.temp.ownPreParams = true
 	}
 	round.save.LocalPreParams = *preParams
 	round.save.NTildej[i] = preParams.NTildei
@@ -96,17 +98,19 @@ func (round *round2) Start() *tss.Error {
 		var err error
 		modProof, err = modproof.NewProof(ContextI, preParams.PaillierSK.N, preParams.PaillierSK.P, preParams.PaillierSK.Q, round.Rand())
 		if err != nil {
-			return round.WrapError(err, Pi)
+			return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 		}
 	}
 	r2msg2, err := NewDGRound2Message1(
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		&preParams.PaillierSK.PublicKey, modProof, preParams.NTildei, preParams.H1i, preParams.H2i, dlnProof1, dlnProof2)
 	if err != nil {
-		return round.WrapError(err, Pi)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 	}
 	round.temp.dgRound2Message1s[i] = r2msg2
-	round.out <- r2msg2
+	if err := round.send(r2msg2); err != nil {
+		return err
+	}
 
 	// for this P: SAVE de-commitments, paillier keys for round 2
 	round.save.PaillierSK = preParams.PaillierSK
@@ -176,7 +180,7 @@ func (round *round2) Update() (bool, *tss.Error) {
 			round.newOK[j] = true
 		}
 	} else {
-		return false, round.WrapError(errors.New("this party is not in the old or the new committee"), round.PartyID())
+		return false, round.WrapError(tss.Errorf(tss.ErrInvalidParameters, "this party is not in the old or the new committee"), round.PartyID())
 	}
 	return ret, nil
 }
@@ -7,14 +7,12 @@
 package resharing
 
 import (
-	"errors"
-
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *round3) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 3
 	round.started = true
@@ -34,7 +32,9 @@ func (round *round3) Start() *tss.Error {
 		share := round.temp.NewShares[j]
 		r3msg1 := NewDGRound3Message1(Pj, round.PartyID(), share)
 		round.temp.dgRound3Message1s[i] = r3msg1
-		round.out <- r3msg1
+		if err := round.send(r3msg1); err != nil {
+			return err
+		}
 	}
 
 	vDeCmt := round.temp.VD
@@ -42,7 +42,9 @@ func (round *round3) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		vDeCmt)
 	round.temp.dgRound3Message2s[i] = r3msg2
-	round.out <- r3msg2
+	if err := round.send(r3msg2); err != nil {
+		return err
+	}
 
 	return nil
 }
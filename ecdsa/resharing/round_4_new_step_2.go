@@ -8,7 +8,6 @@ package resharing
 
 import (
 	"encoding/hex"
-	"errors"
 	"math/big"
 	"sync"
 
@@ -26,7 +25,7 @@ import (
 
 func (round *round4) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 4
 	round.started = true
@@ -63,14 +62,14 @@ func (round *round4) Start() *tss.Error {
 			r2msg1.UnmarshalH1(),
 			r2msg1.UnmarshalH2()
 		if H1j.Cmp(H2j) == 0 {
-			return round.WrapError(errors.New("h1j and h2j were equal for this party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "h1j and h2j were equal for this party"), msg.GetFrom())
 		}
 		h1JHex, h2JHex := hex.EncodeToString(H1j.Bytes()), hex.EncodeToString(H2j.Bytes())
 		if _, found := h1H2Map[h1JHex]; found {
-			return round.WrapError(errors.New("this h1j was already used by another party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrDuplicateMessage, "this h1j was already used by another party"), msg.GetFrom())
 		}
 		if _, found := h1H2Map[h2JHex]; found {
-			return round.WrapError(errors.New("this h2j was already used by another party"), msg.GetFrom())
+			return round.WrapError(tss.Errorf(tss.ErrDuplicateMessage, "this h2j was already used by another party"), msg.GetFrom())
 		}
 		h1H2Map[h1JHex], h1H2Map[h2JHex] = struct{}{}, struct{}{}
 		wg.Add(3)
@@ -110,7 +109,7 @@ func (round *round4) Start() *tss.Error {
 	wg.Wait()
 	for _, culprit := range append(append(paiProofCulprits, dlnProof1FailCulprits...), dlnProof2FailCulprits...) {
 		if culprit != nil {
-			return round.WrapError(errors.New("dln proof verification failed"), culprit)
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "dln proof verification failed"), culprit)
 		}
 	}
 	// save NTilde_j, h1_j, h2_j received in NewCommitteeStep1 here
@@ -128,7 +127,7 @@ func (round *round4) Start() *tss.Error {
 	newXi := big.NewInt(0)
 
 	// 5-9.
-	modQ := common.ModInt(round.Params().EC().Params().N)
+	modQ := common.ModInt(round.Params().Group().Order())
 	vjc := make([][]*crypto.ECPoint, len(round.OldParties().IDs()))
 	for j := 0; j <= len(vjc)-1; j++ { // P1..P_t+1. Ps are indexed from 0 here
 		// 6-7.
@@ -142,11 +141,11 @@ func (round *round4) Start() *tss.Error {
 		ok, flatVs := vCmtDeCmt.DeCommit()
 		if !ok || len(flatVs) != (round.NewThreshold()+1)*2 { // they're points so * 2
 			// TODO collect culprits and return a list of them as per convention
-			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
+			return round.WrapError(tss.Errorf(tss.ErrBadCommitment, "de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
 		}
-		vj, err := crypto.UnFlattenECPoints(round.Params().EC(), flatVs)
+		vj, err := crypto.UnFlattenECPoints(round.Params().Group(), flatVs)
 		if err != nil {
-			return round.WrapError(err, round.Parties().IDs()[j])
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, err), round.Parties().IDs()[j])
 		}
 		vjc[j] = vj
 
@@ -157,9 +156,9 @@ func (round *round4) Start() *tss.Error {
 			ID:        round.PartyID().KeyInt(),
 			Share:     new(big.Int).SetBytes(r3msg1.Share),
 		}
-		if ok := sharej.Verify(round.Params().EC(), round.NewThreshold(), vj); !ok {
+		if ok := sharej.Verify(round.Params().Group(), round.NewThreshold(), vj); !ok {
 			// TODO collect culprits and return a list of them as per convention
-			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
+			return round.WrapError(tss.Errorf(tss.ErrBadShare, "share from old committee did not pass Verify()"), round.Parties().IDs()[j])
 		}
 
 		// 9.
@@ -174,14 +173,14 @@ func (round *round4) Start() *tss.Error {
 		for j := 1; j <= len(vjc)-1; j++ {
 			Vc[c], err = Vc[c].Add(vjc[j][c])
 			if err != nil {
-				return round.WrapError(errors2.Wrapf(err, "Vc[c].Add(vjc[j][c])"))
+				return round.WrapError(tss.WithKind(tss.ErrBadShare, errors2.Wrapf(err, "Vc[c].Add(vjc[j][c])")))
 			}
 		}
 	}
 
 	// 14.
 	if !Vc[0].Equals(round.save.ECDSAPub) {
-		return round.WrapError(errors.New("assertion failed: V_0 != y"), round.PartyID())
+		return round.WrapError(tss.Errorf(tss.ErrBadShare, "assertion failed: V_0 != y"), round.PartyID())
 	}
 
 	// 15-19.
@@ -204,7 +203,7 @@ func (round *round4) Start() *tss.Error {
 		newBigXjs[j] = newBigXj
 	}
 	if len(paiProofCulprits) > 0 {
-		return round.WrapError(errors2.Wrapf(err, "newBigXj.Add(Vc[c].ScalarMult(z))"), paiProofCulprits...)
+		return round.WrapError(tss.WithKind(tss.ErrBadShare, errors2.Wrapf(err, "newBigXj.Add(Vc[c].ScalarMult(z))")), paiProofCulprits...)
 	}
 
 	round.temp.newXi = newXi
@@ -222,20 +221,24 @@ func (round *round4) Start() *tss.Error {
 			Z1: zero, Z2: zero, W1: zero, W2: zero, V: zero,
 		}
 		if !round.Parameters.NoProofFac() {
-			facProof, err = facproof.NewProof(ContextJ, round.EC(), round.save.PaillierSK.N, round.save.NTildej[j],
+			facProof, err = facproof.NewProof(ContextJ, round.Group(), round.save.PaillierSK.N, round.save.NTildej[j],
 				round.save.H1j[j], round.save.H2j[j], round.save.PaillierSK.P, round.save.PaillierSK.Q, round.Rand())
 			if err != nil {
-				return round.WrapError(err, Pi)
+				return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 			}
 		}
 		r4msg1 := NewDGRound4Message1(Pj, Pi, facProof)
-		round.out <- r4msg1
+		if err := round.send(r4msg1); err != nil {
+			return err
+		}
 	}
 
 	// Send an "ACK" message to both committees to signal that we're ready to save our data
 	r4msg2 := NewDGRound4Message2(round.OldAndNewParties(), Pi)
 	round.temp.dgRound4Message2s[i] = r4msg2
-	round.out <- r4msg2
+	if err := round.send(r4msg2); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -7,7 +7,6 @@
 package resharing
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -16,7 +15,7 @@ import (
 
 func (round *round5) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 5
 	round.started = true
@@ -34,6 +33,7 @@ func (round *round5) Start() *tss.Error {
 		round.save.BigXj = round.temp.newBigXjs
 		round.save.ShareID = round.PartyID().KeyInt()
 		round.save.Xi = round.temp.newXi
+		round.temp.newXi = nil // the save data is the result of the party and must not be wiped with the temp data
 		round.save.Ks = round.temp.newKs
 
 		// misc: build list of paillier public keys to save
@@ -55,18 +55,19 @@ func (round *round5) Start() *tss.Error {
 			} else {
 				if err != nil {
 					common.Logger.Warningf("facProof verify failed for party %s", msg.GetFrom(), err)
-					return round.WrapError(err, round.NewParties().IDs()[j])
+					return round.WrapError(tss.WithKind(tss.ErrInvalidProof, err), round.NewParties().IDs()[j])
 				}
-				if ok := proof.Verify(ContextI, round.EC(), round.save.PaillierPKs[j].N, round.save.NTildei,
+				if ok := proof.Verify(ContextI, round.Group(), round.save.PaillierPKs[j].N, round.save.NTildei,
 					round.save.H1i, round.save.H2i); !ok {
 					common.Logger.Warningf("facProof verify failed for party %s", msg.GetFrom(), err)
-					return round.WrapError(err, round.NewParties().IDs()[j])
+					return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "facProof verify failed"), round.NewParties().IDs()[j])
 				}
 			}
 
 		}
 	} else if round.IsOldCommittee() {
-		round.input.Xi.SetInt64(0)
+		// the old share of the caller's key is destroyed
+		common.WipeBigInt(round.input.Xi)
 	}
 
 	round.end <- round.save
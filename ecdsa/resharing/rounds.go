@@ -7,7 +7,7 @@
 package resharing
 
 import (
-	"errors"
+	"io"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -31,6 +31,7 @@ type (
 		newOK []bool // `ok` tracks parties which have been verified by Update(); this one is for the new committee
 		started bool
 		number  int
+		journal *tss.SessionJournal // set when session snapshots are enabled
 	}
 	round1 struct {
 		*base
@@ -109,6 +110,25 @@ func (round *base) WaitingFor() []*tss.PartyID {
 	return ids
 }
 
+// Rand returns the random source of the round, which is the session journal when snapshots are enabled
+func (round *base) Rand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.ReSharingParameters.Rand()
+}
+
+// send records msg in the session journal, which refuses it if a restored session diverges, then sends it
+func (round *base) send(msg tss.Message) *tss.Error {
+	if round.journal != nil {
+		if err := round.journal.Send(msg); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	round.out <- msg
+	return nil
+}
+
 func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
 	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
 }
@@ -141,11 +161,11 @@ func (round *base) allNewOK() {
 
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
-	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().B, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
-	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                                                // parties
+	ssidList := []*big.Int{round.Group().Order(), crypto.Generator(round.Group()).X(), crypto.Generator(round.Group()).Y()} // group
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                            // parties
 	BigXjList, err := crypto.FlattenECPoints(round.input.BigXj)
 	if err != nil {
-		return nil, round.WrapError(errors.New("read BigXj failed"), round.PartyID())
+		return nil, round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "read BigXj failed"), round.PartyID())
 	}
 	ssidList = append(ssidList, BigXjList...)                    // BigXj
 	ssidList = append(ssidList, round.input.NTildej...)          // NTilde
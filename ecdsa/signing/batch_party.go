@@ -0,0 +1,399 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*BatchLocalParty)(nil)
+	_ fmt.Stringer = (*BatchLocalParty)(nil)
+)
+
+type (
+	// BatchLocalParty signs many messages in one session. It runs a signing instance per message and sends the
+	// messages of one type that the instances address to the same recipient in one SignBatchMessage, so that the
+	// instances advance through the rounds together and the round trips are paid once per batch. It sends a
+	// slice with the SignatureData of each message, in the order of the messages, to `end` once all are signed.
+	//
+	// Each instance has its own ssid, and the parties of a session must construct their batches with the same
+	// messages in the same order. A party started with Start runs a goroutine per instance until the batch is
+	// signed; use StartWithContext to bound its lifetime.
+	BatchLocalParty struct {
+		tss.Party // the first instance
+		params    *tss.Parameters
+		instances []*LocalParty
+		outs      []chan tss.Message
+		ends      []chan *common.SignatureData
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- []*common.SignatureData
+
+		mtx      sync.Mutex
+		pending  map[string]*batchPending // by message type and recipient
+		data     []*common.SignatureData
+		started  bool
+		done     chan struct{}
+		doneOnce sync.Once
+	}
+
+	// batchPending holds the messages of one type and recipient, by instance index
+	batchPending struct {
+		to          []*tss.PartyID
+		isBroadcast bool
+		messages    [][]byte
+	}
+)
+
+// NewBatchLocalParty returns a party that signs each of msgs. keyDerivationDeltas may be nil, or hold the key
+// derivation delta of each message, which may be nil for the master key. Unlike NewLocalPartyWithKDD, key must be
+// the key of the master public key; the instances adjust their copies of it to their child keys.
+func NewBatchLocalParty(
+	msgs []*big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDeltas []*big.Int,
+	out chan<- tss.Message,
+	end chan<- []*common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	if len(msgs) == 0 {
+		panic(tss.Errorf(tss.ErrInvalidParameters, "signing.NewBatchLocalParty expected at least one message"))
+	}
+	if keyDerivationDeltas != nil && len(keyDerivationDeltas) != len(msgs) {
+		panic(tss.Errorf(tss.ErrInvalidParameters, "signing.NewBatchLocalParty expected a key derivation delta for each message"))
+	}
+	// every message an instance may send in a session, so that an instance never blocks on its outbound channel
+	outBufferSize := 2*params.PartyCount() + 8
+	p := &BatchLocalParty{
+		params:    params,
+		instances: make([]*LocalParty, len(msgs)),
+		outs:      make([]chan tss.Message, len(msgs)),
+		ends:      make([]chan *common.SignatureData, len(msgs)),
+		out:       out,
+		end:       end,
+		pending:   make(map[string]*batchPending),
+		data:      make([]*common.SignatureData, len(msgs)),
+		done:      make(chan struct{}),
+	}
+	for k, msg := range msgs {
+		var delta *big.Int
+		if keyDerivationDeltas != nil {
+			delta = keyDerivationDeltas[k]
+		}
+		instanceKey := key
+		if delta != nil {
+			var err error
+			if instanceKey, err = childKey(key, delta); err != nil {
+				panic(tss.Errorf(tss.ErrInvalidParameters, "signing.NewBatchLocalParty could not derive the key of message %d: %v", k, err))
+			}
+		}
+		p.outs[k] = make(chan tss.Message, outBufferSize)
+		p.ends[k] = make(chan *common.SignatureData, 1)
+		instance := NewLocalPartyWithKDD(msg, params, instanceKey, delta, p.outs[k], p.ends[k], fullBytesLen...).(*LocalParty)
+		instance.temp.ssidNonce = new(big.Int).SetInt64(int64(k))
+		p.instances[k] = instance
+	}
+	p.Party = p.instances[0]
+	return p
+}
+
+func (p *BatchLocalParty) Start() *tss.Error {
+	p.startPumps()
+	for _, instance := range p.instances {
+		if err := instance.Start(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StartWithContext starts every instance with ctx and blocks until the batch is signed. If one instance is aborted,
+// the others are aborted too and the error of the first is returned.
+func (p *BatchLocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	if err := ctx.Err(); err != nil {
+		return p.WrapError(tss.ContextError(err))
+	}
+	p.startPumps()
+	defer p.stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan *tss.Error, len(p.instances))
+	for _, instance := range p.instances {
+		go func(instance *LocalParty) {
+			errCh <- instance.StartWithContext(ctx)
+		}(instance)
+	}
+	var first *tss.Error
+	for range p.instances {
+		if err := <-errCh; err != nil && first == nil {
+			first = err
+			cancel()
+		}
+	}
+	return first
+}
+
+func (p *BatchLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	if msg == nil || msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with an invalid sender: %s", msg))
+	}
+	batch, ok := msg.Content().(*SignBatchMessage)
+	if !ok || !msg.ValidateBasic() || len(batch.GetMessages()) != len(p.instances) {
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received an invalid batch msg: %s", msg), msg.GetFrom())
+	}
+	for k, bz := range batch.GetMessages() {
+		if len(bz) == 0 {
+			continue
+		}
+		if ok, err = p.instances[k].UpdateFromBytes(bz, msg.GetFrom(), msg.IsBroadcast()); err != nil || !ok {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+func (p *BatchLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+// Snapshot returns the state of the batch sealed with key, from which Restore resumes it after a crash. It holds a
+// snapshot of each instance, see LocalParty.Snapshot.
+func (p *BatchLocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	snapshots := make([][]byte, len(p.instances))
+	for k, instance := range p.instances {
+		snapshot, err := instance.Snapshot(keystore.NoEncryption())
+		if err != nil {
+			return nil, err
+		}
+		snapshots[k] = snapshot
+	}
+	payload, err := json.Marshal(snapshots)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInternal, err)
+	}
+	return keystore.Seal(p.params.Rand(), BatchSessionSnapshotKind, tss.SessionSnapshotVersion, payload, key)
+}
+
+// Restore resumes the batch of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. Each instance is restored from its snapshot, see LocalParty.Restore.
+func (p *BatchLocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	version, payload, err := keystore.Open(snapshot, BatchSessionSnapshotKind, key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	if version != tss.SessionSnapshotVersion {
+		return p.WrapError(tss.Errorf(tss.ErrInvalidParameters, "snapshot: unsupported version %d", version))
+	}
+	var snapshots [][]byte
+	if err = json.Unmarshal(payload, &snapshots); err != nil {
+		return p.WrapError(tss.WithKind(tss.ErrInvalidParameters, err))
+	}
+	if len(snapshots) != len(p.instances) {
+		return p.WrapError(tss.Errorf(tss.ErrInvalidParameters, "snapshot: expected %d instances, got %d", len(p.instances), len(snapshots)))
+	}
+	p.startPumps()
+	for k, instance := range p.instances {
+		if err := instance.Restore(snapshots[k], keystore.NoEncryption()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Running returns true while any instance is running
+func (p *BatchLocalParty) Running() bool {
+	for _, instance := range p.instances {
+		if instance.Running() {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitingFor returns the parties that any instance is waiting for, in the order of their indexes
+func (p *BatchLocalParty) WaitingFor() []*tss.PartyID {
+	waiting := make(map[int]*tss.PartyID)
+	for _, instance := range p.instances {
+		for _, Pj := range instance.WaitingFor() {
+			waiting[Pj.Index] = Pj
+		}
+	}
+	ids := make([]*tss.PartyID, 0, len(waiting))
+	for _, Pj := range p.params.Parties().IDs() {
+		if _, ok := waiting[Pj.Index]; ok {
+			ids = append(ids, Pj)
+		}
+	}
+	return ids
+}
+
+// Close closes every instance, which wipes its secrets, and stops the goroutines of the batch
+func (p *BatchLocalParty) Close() {
+	for _, instance := range p.instances {
+		instance.Close()
+	}
+	p.stop()
+}
+
+func (p *BatchLocalParty) String() string {
+	return fmt.Sprintf("batch of %d, %s", len(p.instances), p.Party.String())
+}
+
+// ----- //
+
+// startPumps starts a goroutine per instance that collects the messages and the signature of the instance
+func (p *BatchLocalParty) startPumps() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.started {
+		return
+	}
+	p.started = true
+	for k := range p.instances {
+		go p.pump(k)
+	}
+}
+
+func (p *BatchLocalParty) pump(k int) {
+	for {
+		select {
+		case msg := <-p.outs[k]:
+			p.collect(k, msg)
+		case data := <-p.ends[k]:
+			// an instance sends all of its messages before it ends
+			for drained := false; !drained; {
+				select {
+				case msg := <-p.outs[k]:
+					p.collect(k, msg)
+				default:
+					drained = true
+				}
+			}
+			p.finish(k, data)
+			return
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// collect adds the message of instance k to the batch of its type and recipient, and sends the batches it completes
+func (p *BatchLocalParty) collect(k int, msg tss.Message) {
+	bz, routing, err := msg.WireBytes()
+	if err != nil {
+		common.Logger.Errorf("batch signing: unable to encode a message of instance %d: %v", k, err)
+		return
+	}
+	to := -1
+	if !routing.IsBroadcast && len(routing.To) == 1 {
+		to = routing.To[0].Index
+	}
+	batchKey := fmt.Sprintf("%s/%d", msg.Type(), to)
+
+	p.mtx.Lock()
+	pending, ok := p.pending[batchKey]
+	if !ok {
+		pending = &batchPending{
+			to:          routing.To,
+			isBroadcast: routing.IsBroadcast,
+			messages:    make([][]byte, len(p.instances)),
+		}
+		p.pending[batchKey] = pending
+	}
+	pending.messages[k] = bz
+	ready := p.takeReady()
+	p.mtx.Unlock()
+
+	p.send(ready)
+}
+
+// finish records the signature of instance k, and sends the signatures once every instance has finished. The
+// batches that were only waiting for a finished instance are sent as well.
+func (p *BatchLocalParty) finish(k int, data *common.SignatureData) {
+	p.mtx.Lock()
+	p.data[k] = data
+	ready := p.takeReady()
+	finished := true
+	for _, d := range p.data {
+		if d == nil {
+			finished = false
+			break
+		}
+	}
+	p.mtx.Unlock()
+
+	p.send(ready)
+	if finished {
+		p.end <- p.data
+		p.stop()
+	}
+}
+
+// takeReady removes and returns the batches that hold a message of every instance that has not finished yet. it
+// must be called with the mutex held.
+func (p *BatchLocalParty) takeReady() []*batchPending {
+	var ready []*batchPending
+	for batchKey, pending := range p.pending {
+		complete := true
+		for k, bz := range pending.messages {
+			if bz == nil && p.data[k] == nil {
+				complete = false
+				break
+			}
+		}
+		if complete {
+			ready = append(ready, pending)
+			delete(p.pending, batchKey)
+		}
+	}
+	return ready
+}
+
+func (p *BatchLocalParty) send(ready []*batchPending) {
+	for _, pending := range ready {
+		p.out <- NewSignBatchMessage(pending.to, p.params.PartyID(), pending.isBroadcast, pending.messages)
+	}
+}
+
+func (p *BatchLocalParty) stop() {
+	p.doneOnce.Do(func() { close(p.done) })
+}
+
+// childKey returns a copy of key for the child key with the key derivation delta
+func childKey(key keygen.LocalPartySaveData, delta *big.Int) (keygen.LocalPartySaveData, error) {
+	g := key.ECDSAPub.Group()
+	childPub, err := key.ECDSAPub.Add(crypto.ScalarBaseMult(g, delta))
+	if err != nil {
+		return key, err
+	}
+	key.BigXj = append(key.BigXj[:0:0], key.BigXj...)
+	keys := []keygen.LocalPartySaveData{key}
+	if err = UpdatePublicKeyAndAdjustBigXj(delta, keys, childPub.ToECDSAPubKey(), g); err != nil {
+		return key, err
+	}
+	return keys[0], nil
+}
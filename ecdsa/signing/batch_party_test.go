@@ -0,0 +1,211 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+func TestE2EBatch(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43), big.NewInt(42)}
+	deltas := []*big.Int{nil, big.NewInt(7), common.GetRandomPositiveInt(rand.Reader, group.Secp256k1().Order())}
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan []*common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewBatchLocalParty(msgs, params, keys[i], deltas, outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+	var sent int
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			sent++
+			assert.IsType(t, &SignBatchMessage{}, msg.(tss.ParsedMessage).Content())
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			if !assert.Len(t, data, len(msgs)) {
+				break signing
+			}
+			for k, sig := range data {
+				pub := keys[0].ECDSAPub
+				if deltas[k] != nil {
+					pub, err = pub.Add(crypto.ScalarBaseMult(group.Secp256k1(), deltas[k]))
+					assert.NoError(t, err)
+				}
+				assert.Equal(t, msgs[k].Bytes(), sig.GetM())
+				ok := ecdsa.Verify(pub.ToECDSAPubKey(), msgs[k].Bytes(), new(big.Int).SetBytes(sig.GetR()), new(big.Int).SetBytes(sig.GetS()))
+				assert.True(t, ok, "ecdsa verify of message %d must pass", k)
+			}
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				// a single session sends as many messages as a signing without a batch
+				n := len(signPIDs)
+				assert.Equal(t, n*(2*(n-1)+8), sent)
+				t.Logf("Done. Received %d signatures from %d participants", len(data), ended)
+				break signing
+			}
+		}
+	}
+}
+
+func TestBatchRunningAndWaitingFor(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	params := tss.NewParameters(group.Secp256k1(), tss.NewPeerContext(signPIDs), signPIDs[0], len(signPIDs), testThreshold)
+	P := NewBatchLocalParty([]*big.Int{big.NewInt(42), big.NewInt(43)}, params, keys[0], nil,
+		make(chan tss.Message, 4*len(signPIDs)), make(chan []*common.SignatureData, 1)).(*BatchLocalParty)
+	defer P.Close()
+	if err := P.Start(); err != nil {
+		assert.FailNow(t, err.Error())
+	}
+	assert.True(t, P.Running())
+	assert.Equal(t, []*tss.PartyID(signPIDs[1:]), P.WaitingFor())
+
+	// the batch is still blocked on the second instance once the first has stopped
+	P.instances[0].Close()
+	assert.False(t, P.instances[0].Running())
+	assert.True(t, P.Running(), "the batch runs while any instance runs")
+	assert.Equal(t, []*tss.PartyID(signPIDs[1:]), P.WaitingFor(), "the batch waits for the parties of every instance")
+
+	P.instances[1].Close()
+	assert.False(t, P.Running())
+	assert.Empty(t, P.WaitingFor())
+}
+
+func TestE2EBatchRestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	msgs := []*big.Int{big.NewInt(42), big.NewInt(43), big.NewInt(44)}
+	newParty := func(msgs []*big.Int) *BatchLocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewBatchLocalParty(msgs, params, keys[0], nil, outCh, make(chan []*common.SignatureData, 1)).(*BatchLocalParty)
+	}
+	// round 1 sends a message to each other party and a broadcast
+	receiveRound1 := func() []string {
+		sent := make([]string, 0, len(signPIDs))
+		for range signPIDs {
+			select {
+			case msg := <-outCh:
+				bz, _, err := msg.WireBytes()
+				assert.NoError(t, err)
+				sent = append(sent, string(bz))
+			case <-time.After(time.Minute):
+				t.Fatal("timed out waiting for the round 1 batches")
+			}
+		}
+		sort.Strings(sent)
+		return sent
+	}
+
+	P := newParty(msgs)
+	defer P.Close()
+	assert.Nil(t, P.Start())
+	sent := receiveRound1()
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored batch re-emits the same round 1 batches
+	restored := newParty(msgs)
+	defer restored.Close()
+	assert.Nil(t, restored.Restore(snapshot, keystore.NoEncryption()))
+	assert.Equal(t, sent, receiveRound1())
+
+	// the snapshot cannot be restored into a batch of other messages
+	other := newParty([]*big.Int{big.NewInt(42), big.NewInt(43), big.NewInt(45)})
+	defer other.Close()
+	assert.NotNil(t, other.Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot in which an instance no longer reproduces its round 1 commitment is refused, and as that instance
+	// sends nothing, no batch is complete
+	_, payload, err := keystore.Open(snapshot, BatchSessionSnapshotKind, keystore.NoEncryption())
+	assert.NoError(t, err)
+	var snapshots [][]byte
+	assert.NoError(t, json.Unmarshal(payload, &snapshots))
+	snapshots[1], err = test.ReseedSnapshot(snapshots[1], SessionSnapshotKind)
+	assert.NoError(t, err)
+	payload, err = json.Marshal(snapshots)
+	assert.NoError(t, err)
+	tampered, err := keystore.Seal(rand.Reader, BatchSessionSnapshotKind, tss.SessionSnapshotVersion, payload, keystore.NoEncryption())
+	assert.NoError(t, err)
+	bad := newParty(msgs)
+	defer bad.Close()
+	err = bad.Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
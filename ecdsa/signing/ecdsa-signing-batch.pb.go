@@ -0,0 +1,153 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/ecdsa-signing-batch.proto
+
+package signing
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Carries the messages of one type that the signing instances of a batch send to the same recipient.
+type SignBatchMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the wire bytes of the message of each instance, by instance index; empty for an instance that sent none
+	Messages [][]byte `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+}
+
+func (x *SignBatchMessage) Reset() {
+	*x = SignBatchMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_batch_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignBatchMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignBatchMessage) ProtoMessage() {}
+
+func (x *SignBatchMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_batch_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignBatchMessage.ProtoReflect.Descriptor instead.
+func (*SignBatchMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_batch_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignBatchMessage) GetMessages() [][]byte {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+var File_protob_ecdsa_signing_batch_proto protoreflect.FileDescriptor
+
+var file_protob_ecdsa_signing_batch_proto_rawDesc = []byte{
+	0x0a, 0x20, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2d, 0x73,
+	0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x2d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x1c, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c,
+	0x69, 0x62, 0x2e, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2e, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67,
+	0x22, 0x2e, 0x0a, 0x10, 0x53, 0x69, 0x67, 0x6e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x73,
+	0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e,
+	0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_ecdsa_signing_batch_proto_rawDescOnce sync.Once
+	file_protob_ecdsa_signing_batch_proto_rawDescData = file_protob_ecdsa_signing_batch_proto_rawDesc
+)
+
+func file_protob_ecdsa_signing_batch_proto_rawDescGZIP() []byte {
+	file_protob_ecdsa_signing_batch_proto_rawDescOnce.Do(func() {
+		file_protob_ecdsa_signing_batch_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_ecdsa_signing_batch_proto_rawDescData)
+	})
+	return file_protob_ecdsa_signing_batch_proto_rawDescData
+}
+
+var file_protob_ecdsa_signing_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_ecdsa_signing_batch_proto_goTypes = []interface{}{
+	(*SignBatchMessage)(nil), // 0: binance.tsslib.ecdsa.signing.SignBatchMessage
+}
+var file_protob_ecdsa_signing_batch_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_ecdsa_signing_batch_proto_init() }
+func file_protob_ecdsa_signing_batch_proto_init() {
+	if File_protob_ecdsa_signing_batch_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_ecdsa_signing_batch_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignBatchMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_ecdsa_signing_batch_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_ecdsa_signing_batch_proto_goTypes,
+		DependencyIndexes: file_protob_ecdsa_signing_batch_proto_depIdxs,
+		MessageInfos:      file_protob_ecdsa_signing_batch_proto_msgTypes,
+	}.Build()
+	File_protob_ecdsa_signing_batch_proto = out.File
+	file_protob_ecdsa_signing_batch_proto_rawDesc = nil
+	file_protob_ecdsa_signing_batch_proto_goTypes = nil
+	file_protob_ecdsa_signing_batch_proto_depIdxs = nil
+}
@@ -6,8 +6,8 @@
 
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.27.1
-// 	protoc        v3.14.0
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
 // source: protob/ecdsa-signing.proto
 
 package signing
@@ -26,7 +26,6 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-//
 // Represents a P2P message sent to each party during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message1 struct {
 	state         protoimpl.MessageState
@@ -83,7 +82,6 @@ func (x *SignRound1Message1) GetRangeProofAlice() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 1 of the ECDSA TSS signing protocol.
 type SignRound1Message2 struct {
 	state         protoimpl.MessageState
@@ -132,7 +130,6 @@ func (x *SignRound1Message2) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a P2P message sent to each party during Round 2 of the ECDSA TSS signing protocol.
 type SignRound2Message struct {
 	state         protoimpl.MessageState
@@ -205,7 +202,6 @@ func (x *SignRound2Message) GetProofBobWc() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 3 of the ECDSA TSS signing protocol.
 type SignRound3Message struct {
 	state         protoimpl.MessageState
@@ -254,7 +250,6 @@ func (x *SignRound3Message) GetTheta() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 4 of the ECDSA TSS signing protocol.
 type SignRound4Message struct {
 	state         protoimpl.MessageState
@@ -327,7 +322,6 @@ func (x *SignRound4Message) GetProofT() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 5 of the ECDSA TSS signing protocol.
 type SignRound5Message struct {
 	state         protoimpl.MessageState
@@ -376,7 +370,6 @@ func (x *SignRound5Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 6 of the ECDSA TSS signing protocol.
 type SignRound6Message struct {
 	state         protoimpl.MessageState
@@ -481,7 +474,6 @@ func (x *SignRound6Message) GetVProofU() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 7 of the ECDSA TSS signing protocol.
 type SignRound7Message struct {
 	state         protoimpl.MessageState
@@ -530,7 +522,6 @@ func (x *SignRound7Message) GetCommitment() []byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 8 of the ECDSA TSS signing protocol.
 type SignRound8Message struct {
 	state         protoimpl.MessageState
@@ -579,7 +570,6 @@ func (x *SignRound8Message) GetDeCommitment() [][]byte {
 	return nil
 }
 
-//
 // Represents a BROADCAST message sent to all parties during Round 9 of the ECDSA TSS signing protocol.
 type SignRound9Message struct {
 	state         protoimpl.MessageState
@@ -587,6 +577,7 @@ type SignRound9Message struct {
 	unknownFields protoimpl.UnknownFields
 
 	S []byte `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+	L []byte `protobuf:"bytes,2,opt,name=l,proto3" json:"l,omitempty"`
 }
 
 func (x *SignRound9Message) Reset() {
@@ -628,6 +619,281 @@ func (x *SignRound9Message) GetS() []byte {
 	return nil
 }
 
+func (x *SignRound9Message) GetL() []byte {
+	if x != nil {
+		return x.L
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties when the Round 9 check of the ECDSA TSS signing protocol fails.
+// It reveals the nonce shares and MtA openings used to identify the cheating parties.
+// The per-party lists skip the sender and are ordered by party index.
+type SignIdentificationMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	K           []byte   `protobuf:"bytes,1,opt,name=k,proto3" json:"k,omitempty"`
+	Gamma       []byte   `protobuf:"bytes,2,opt,name=gamma,proto3" json:"gamma,omitempty"`
+	Roi         []byte   `protobuf:"bytes,3,opt,name=roi,proto3" json:"roi,omitempty"`
+	L           []byte   `protobuf:"bytes,4,opt,name=l,proto3" json:"l,omitempty"`
+	KRandomness [][]byte `protobuf:"bytes,5,rep,name=k_randomness,json=kRandomness,proto3" json:"k_randomness,omitempty"`
+	BetaPrm     [][]byte `protobuf:"bytes,6,rep,name=beta_prm,json=betaPrm,proto3" json:"beta_prm,omitempty"`
+	NuPrmPoint  [][]byte `protobuf:"bytes,7,rep,name=nu_prm_point,json=nuPrmPoint,proto3" json:"nu_prm_point,omitempty"`
+}
+
+func (x *SignIdentificationMessage) Reset() {
+	*x = SignIdentificationMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignIdentificationMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignIdentificationMessage) ProtoMessage() {}
+
+func (x *SignIdentificationMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignIdentificationMessage.ProtoReflect.Descriptor instead.
+func (*SignIdentificationMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SignIdentificationMessage) GetK() []byte {
+	if x != nil {
+		return x.K
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetGamma() []byte {
+	if x != nil {
+		return x.Gamma
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetRoi() []byte {
+	if x != nil {
+		return x.Roi
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetL() []byte {
+	if x != nil {
+		return x.L
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetKRandomness() [][]byte {
+	if x != nil {
+		return x.KRandomness
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetBetaPrm() [][]byte {
+	if x != nil {
+		return x.BetaPrm
+	}
+	return nil
+}
+
+func (x *SignIdentificationMessage) GetNuPrmPoint() [][]byte {
+	if x != nil {
+		return x.NuPrmPoint
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during the last round of presigning with the ECDSA TSS signing
+// protocol. It holds R^k_i and R^sigma_i with proofs of knowledge of their discrete logarithms to the base R.
+type SignPreSignMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RBarX           []byte `protobuf:"bytes,1,opt,name=r_bar_x,json=rBarX,proto3" json:"r_bar_x,omitempty"`
+	RBarY           []byte `protobuf:"bytes,2,opt,name=r_bar_y,json=rBarY,proto3" json:"r_bar_y,omitempty"`
+	SX              []byte `protobuf:"bytes,3,opt,name=s_x,json=sX,proto3" json:"s_x,omitempty"`
+	SY              []byte `protobuf:"bytes,4,opt,name=s_y,json=sY,proto3" json:"s_y,omitempty"`
+	RBarProofAlphaX []byte `protobuf:"bytes,5,opt,name=r_bar_proof_alpha_x,json=rBarProofAlphaX,proto3" json:"r_bar_proof_alpha_x,omitempty"`
+	RBarProofAlphaY []byte `protobuf:"bytes,6,opt,name=r_bar_proof_alpha_y,json=rBarProofAlphaY,proto3" json:"r_bar_proof_alpha_y,omitempty"`
+	RBarProofT      []byte `protobuf:"bytes,7,opt,name=r_bar_proof_t,json=rBarProofT,proto3" json:"r_bar_proof_t,omitempty"`
+	SProofAlphaX    []byte `protobuf:"bytes,8,opt,name=s_proof_alpha_x,json=sProofAlphaX,proto3" json:"s_proof_alpha_x,omitempty"`
+	SProofAlphaY    []byte `protobuf:"bytes,9,opt,name=s_proof_alpha_y,json=sProofAlphaY,proto3" json:"s_proof_alpha_y,omitempty"`
+	SProofT         []byte `protobuf:"bytes,10,opt,name=s_proof_t,json=sProofT,proto3" json:"s_proof_t,omitempty"`
+}
+
+func (x *SignPreSignMessage) Reset() {
+	*x = SignPreSignMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignPreSignMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignPreSignMessage) ProtoMessage() {}
+
+func (x *SignPreSignMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignPreSignMessage.ProtoReflect.Descriptor instead.
+func (*SignPreSignMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SignPreSignMessage) GetRBarX() []byte {
+	if x != nil {
+		return x.RBarX
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetRBarY() []byte {
+	if x != nil {
+		return x.RBarY
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetSX() []byte {
+	if x != nil {
+		return x.SX
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetSY() []byte {
+	if x != nil {
+		return x.SY
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetRBarProofAlphaX() []byte {
+	if x != nil {
+		return x.RBarProofAlphaX
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetRBarProofAlphaY() []byte {
+	if x != nil {
+		return x.RBarProofAlphaY
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetRBarProofT() []byte {
+	if x != nil {
+		return x.RBarProofT
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetSProofAlphaX() []byte {
+	if x != nil {
+		return x.SProofAlphaX
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetSProofAlphaY() []byte {
+	if x != nil {
+		return x.SProofAlphaY
+	}
+	return nil
+}
+
+func (x *SignPreSignMessage) GetSProofT() []byte {
+	if x != nil {
+		return x.SProofT
+	}
+	return nil
+}
+
+// Represents a BROADCAST message sent to all parties during the online round of the ECDSA TSS signing protocol,
+// when signing with a presignature.
+type SignOnlineMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	S []byte `protobuf:"bytes,1,opt,name=s,proto3" json:"s,omitempty"`
+}
+
+func (x *SignOnlineMessage) Reset() {
+	*x = SignOnlineMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignOnlineMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignOnlineMessage) ProtoMessage() {}
+
+func (x *SignOnlineMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_ecdsa_signing_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignOnlineMessage.ProtoReflect.Descriptor instead.
+func (*SignOnlineMessage) Descriptor() ([]byte, []int) {
+	return file_protob_ecdsa_signing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *SignOnlineMessage) GetS() []byte {
+	if x != nil {
+		return x.S
+	}
+	return nil
+}
+
 var File_protob_ecdsa_signing_proto protoreflect.FileDescriptor
 
 var file_protob_ecdsa_signing_proto_rawDesc = []byte{
@@ -691,10 +957,47 @@ var file_protob_ecdsa_signing_proto_rawDesc = []byte{
 	0x6f, 0x75, 0x6e, 0x64, 0x38, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x23, 0x0a, 0x0d,
 	0x64, 0x65, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20,
 	0x03, 0x28, 0x0c, 0x52, 0x0c, 0x64, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x6d, 0x65, 0x6e,
-	0x74, 0x22, 0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
+	0x74, 0x22, 0x2f, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x39, 0x4d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x01, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64, 0x73, 0x61, 0x2f, 0x73, 0x69,
-	0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x0c, 0x52, 0x01, 0x73, 0x12, 0x0c, 0x0a, 0x01, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x01, 0x6c, 0x22, 0xbf, 0x01, 0x0a, 0x19, 0x53, 0x69, 0x67, 0x6e, 0x49, 0x64, 0x65, 0x6e, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x0c, 0x0a, 0x01, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x6b, 0x12, 0x14,
+	0x0a, 0x05, 0x67, 0x61, 0x6d, 0x6d, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x67,
+	0x61, 0x6d, 0x6d, 0x61, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x72, 0x6f, 0x69, 0x12, 0x0c, 0x0a, 0x01, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x01, 0x6c, 0x12, 0x21, 0x0a, 0x0c, 0x6b, 0x5f, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d,
+	0x6e, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0b, 0x6b, 0x52, 0x61, 0x6e,
+	0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x65, 0x74, 0x61, 0x5f,
+	0x70, 0x72, 0x6d, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x65, 0x74, 0x61, 0x50,
+	0x72, 0x6d, 0x12, 0x20, 0x0a, 0x0c, 0x6e, 0x75, 0x5f, 0x70, 0x72, 0x6d, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x0a, 0x6e, 0x75, 0x50, 0x72, 0x6d, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x22, 0xcf, 0x02, 0x0a, 0x12, 0x53, 0x69, 0x67, 0x6e, 0x50, 0x72, 0x65,
+	0x53, 0x69, 0x67, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x07, 0x72,
+	0x5f, 0x62, 0x61, 0x72, 0x5f, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x72, 0x42,
+	0x61, 0x72, 0x58, 0x12, 0x16, 0x0a, 0x07, 0x72, 0x5f, 0x62, 0x61, 0x72, 0x5f, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x72, 0x42, 0x61, 0x72, 0x59, 0x12, 0x0f, 0x0a, 0x03, 0x73,
+	0x5f, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x73, 0x58, 0x12, 0x0f, 0x0a, 0x03,
+	0x73, 0x5f, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x73, 0x59, 0x12, 0x2c, 0x0a,
+	0x13, 0x72, 0x5f, 0x62, 0x61, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70,
+	0x68, 0x61, 0x5f, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x72, 0x42, 0x61, 0x72,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x58, 0x12, 0x2c, 0x0a, 0x13, 0x72,
+	0x5f, 0x62, 0x61, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61,
+	0x5f, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0f, 0x72, 0x42, 0x61, 0x72, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x21, 0x0a, 0x0d, 0x72, 0x5f, 0x62,
+	0x61, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x72, 0x42, 0x61, 0x72, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x12, 0x25, 0x0a, 0x0f,
+	0x73, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x5f, 0x78, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70,
+	0x68, 0x61, 0x58, 0x12, 0x25, 0x0a, 0x0f, 0x73, 0x5f, 0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x61,
+	0x6c, 0x70, 0x68, 0x61, 0x5f, 0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x73, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x41, 0x6c, 0x70, 0x68, 0x61, 0x59, 0x12, 0x1a, 0x0a, 0x09, 0x73, 0x5f,
+	0x70, 0x72, 0x6f, 0x6f, 0x66, 0x5f, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73,
+	0x50, 0x72, 0x6f, 0x6f, 0x66, 0x54, 0x22, 0x21, 0x0a, 0x11, 0x53, 0x69, 0x67, 0x6e, 0x4f, 0x6e,
+	0x6c, 0x69, 0x6e, 0x65, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x73, 0x42, 0x0f, 0x5a, 0x0d, 0x65, 0x63, 0x64,
+	0x73, 0x61, 0x2f, 0x73, 0x69, 0x67, 0x6e, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -709,18 +1012,21 @@ func file_protob_ecdsa_signing_proto_rawDescGZIP() []byte {
 	return file_protob_ecdsa_signing_proto_rawDescData
 }
 
-var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_protob_ecdsa_signing_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_protob_ecdsa_signing_proto_goTypes = []interface{}{
-	(*SignRound1Message1)(nil), // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
-	(*SignRound1Message2)(nil), // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
-	(*SignRound2Message)(nil),  // 2: binance.tsslib.ecdsa.signing.SignRound2Message
-	(*SignRound3Message)(nil),  // 3: binance.tsslib.ecdsa.signing.SignRound3Message
-	(*SignRound4Message)(nil),  // 4: binance.tsslib.ecdsa.signing.SignRound4Message
-	(*SignRound5Message)(nil),  // 5: binance.tsslib.ecdsa.signing.SignRound5Message
-	(*SignRound6Message)(nil),  // 6: binance.tsslib.ecdsa.signing.SignRound6Message
-	(*SignRound7Message)(nil),  // 7: binance.tsslib.ecdsa.signing.SignRound7Message
-	(*SignRound8Message)(nil),  // 8: binance.tsslib.ecdsa.signing.SignRound8Message
-	(*SignRound9Message)(nil),  // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignRound1Message1)(nil),        // 0: binance.tsslib.ecdsa.signing.SignRound1Message1
+	(*SignRound1Message2)(nil),        // 1: binance.tsslib.ecdsa.signing.SignRound1Message2
+	(*SignRound2Message)(nil),         // 2: binance.tsslib.ecdsa.signing.SignRound2Message
+	(*SignRound3Message)(nil),         // 3: binance.tsslib.ecdsa.signing.SignRound3Message
+	(*SignRound4Message)(nil),         // 4: binance.tsslib.ecdsa.signing.SignRound4Message
+	(*SignRound5Message)(nil),         // 5: binance.tsslib.ecdsa.signing.SignRound5Message
+	(*SignRound6Message)(nil),         // 6: binance.tsslib.ecdsa.signing.SignRound6Message
+	(*SignRound7Message)(nil),         // 7: binance.tsslib.ecdsa.signing.SignRound7Message
+	(*SignRound8Message)(nil),         // 8: binance.tsslib.ecdsa.signing.SignRound8Message
+	(*SignRound9Message)(nil),         // 9: binance.tsslib.ecdsa.signing.SignRound9Message
+	(*SignIdentificationMessage)(nil), // 10: binance.tsslib.ecdsa.signing.SignIdentificationMessage
+	(*SignPreSignMessage)(nil),        // 11: binance.tsslib.ecdsa.signing.SignPreSignMessage
+	(*SignOnlineMessage)(nil),         // 12: binance.tsslib.ecdsa.signing.SignOnlineMessage
 }
 var file_protob_ecdsa_signing_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -856,6 +1162,42 @@ func file_protob_ecdsa_signing_proto_init() {
 				return nil
 			}
 		}
+		file_protob_ecdsa_signing_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignIdentificationMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignPreSignMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protob_ecdsa_signing_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignOnlineMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -863,7 +1205,7 @@ func file_protob_ecdsa_signing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protob_ecdsa_signing_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
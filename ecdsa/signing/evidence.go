@@ -0,0 +1,365 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/envelope"
+)
+
+type (
+	// Evidence proves that a party deviated from the signing protocol.
+	// Verify recomputes the check from the values in the evidence alone, so a third party can run it offline;
+	// it returns true if the evidence shows that the accused party misbehaved.
+	// The evidence about point-to-point messages holds the accused's signed messages as envelope receipts and takes
+	// its values from them, so it can only be produced when the signers are wrapped with envelope.NewParty. Whoever
+	// checks it must also know that the SenderKey of the receipts and the public keys in it are those of the parties.
+	// The rest of the evidence takes the broadcast values, which every party received alike, at face value.
+	Evidence interface {
+		Culprit() *tss.PartyID
+		Verify(g group.AffineGroup) bool
+	}
+
+	// IdentifiedAbortError is the cause of the tss.Error returned when signing aborts and the identification
+	// sub-protocol pins the failure on one or more parties; it has the kind tss.ErrInvalidSignature and is found with
+	// errors.As. The culprits are also listed on the tss.Error.
+	IdentifiedAbortError struct {
+		Evidence []Evidence
+	}
+
+	// PointOpeningEvidence shows that a revealed scalar does not open a point committed to earlier: Point != Base^Scalar
+	PointOpeningEvidence struct {
+		Accused     *tss.PartyID
+		Base, Point *crypto.ECPoint
+		Scalar      *big.Int
+	}
+
+	// CiphertextOpeningEvidence shows that the ciphertext of kj the accused sent in round 1 does not open to the kj
+	// it revealed under its PK with any of the randomness it revealed: C != Enc(kj; x)
+	CiphertextOpeningEvidence struct {
+		Accused                *tss.PartyID
+		PK                     *paillier.PublicKey
+		Round1, Identification *envelope.Receipt
+	}
+
+	// MtAOpening opens the accuser's side of an MtA with the accused: CA = Enc(KA; RhoA) under the accuser's PK, and
+	// the accused's response CB = Enc(Alpha; XB). The accused proved CB for CA with the accuser's NTilde, H1 and H2 in
+	// the given Session, which ties its response to CA.
+	MtAOpening struct {
+		PK             *paillier.PublicKey
+		NTilde, H1, H2 *big.Int
+		Session        []byte
+		CA, KA, RhoA   *big.Int
+		Alpha, XB      *big.Int
+	}
+
+	// MtAEvidence shows that Bob's response in the MtA of ki and γj, sent in round 2, does not match the γj and
+	// any of the β' he revealed
+	MtAEvidence struct {
+		Accused *tss.PartyID
+		MtAOpening
+		Round2, Identification *envelope.Receipt
+	}
+
+	// MtAwcEvidence shows that Bob's response in the MtA of ki and wj, sent in round 2, does not match any of the
+	// g^ν' he revealed; BigW = g^wj follows from the public key
+	MtAwcEvidence struct {
+		Accused *tss.PartyID
+		MtAOpening
+		BigW                   *crypto.ECPoint
+		Round2, Identification *envelope.Receipt
+	}
+
+	// DeltaEvidence shows that the δi broadcast in round 3 does not match the revealed nonce shares and MtA openings:
+	// δi != ki * Σγj + Σ(β'ji - β'ij)
+	DeltaEvidence struct {
+		Accused                 *tss.PartyID
+		Delta, K                *big.Int
+		Gammas                  []*big.Int
+		BetaPrmsIn, BetaPrmsOut []*big.Int
+	}
+
+	// SigmaEvidence shows that the Vi committed in round 5 does not commit to si = m * ki + r * σi,
+	// where g^σi = y^ki * Σg^ν'ji - Σg^ν'ij is implied by the revealed MtA openings and R = g^(1/Σkj)
+	SigmaEvidence struct {
+		Accused                       *tss.PartyID
+		ECDSAPub, R, BigV             *crypto.ECPoint
+		M, K, L                       *big.Int
+		Ks                            []*big.Int
+		NuPrmPointsIn, NuPrmPointsOut []*crypto.ECPoint
+	}
+
+	// PhaseFiveEvidence shows that the Ui or Ti committed in round 7 does not match the revealed ρi and li:
+	// Ui != V^ρi or Ti != A^li, where V = g^-m * y^-r * ΣVj and A = ΣAj
+	PhaseFiveEvidence struct {
+		Accused      *tss.PartyID
+		ECDSAPub, R  *crypto.ECPoint
+		M, RoI, L    *big.Int
+		BigVs, BigAs []*crypto.ECPoint
+		BigU, BigT   *crypto.ECPoint
+	}
+
+	// SignatureShareEvidence shows that the si broadcast in round 9 does not match the Vi committed in round 5: Vi != R^si * g^li
+	SignatureShareEvidence struct {
+		Accused *tss.PartyID
+		R, BigV *crypto.ECPoint
+		S, L    *big.Int
+	}
+)
+
+var (
+	_ Evidence = (*PointOpeningEvidence)(nil)
+	_ Evidence = (*CiphertextOpeningEvidence)(nil)
+	_ Evidence = (*MtAEvidence)(nil)
+	_ Evidence = (*MtAwcEvidence)(nil)
+	_ Evidence = (*DeltaEvidence)(nil)
+	_ Evidence = (*SigmaEvidence)(nil)
+	_ Evidence = (*PhaseFiveEvidence)(nil)
+	_ Evidence = (*SignatureShareEvidence)(nil)
+)
+
+func (err *IdentifiedAbortError) Error() string {
+	return fmt.Sprintf("signing aborted; identified %d protocol violation(s)", len(err.Evidence))
+}
+
+// Culprits returns the parties accused by the evidence, without duplicates
+func (err *IdentifiedAbortError) Culprits() []*tss.PartyID {
+	culprits := make([]*tss.PartyID, 0, len(err.Evidence))
+	seen := make(map[string]struct{}, len(err.Evidence))
+	for _, ev := range err.Evidence {
+		key := ev.Culprit().KeyInt().String()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		culprits = append(culprits, ev.Culprit())
+	}
+	return culprits
+}
+
+// ----- //
+
+func (ev *PointOpeningEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *PointOpeningEvidence) Verify(g group.AffineGroup) bool {
+	if !inGroup(g, ev.Base, ev.Point) || ev.Scalar == nil {
+		return false
+	}
+	return !ev.Point.Point().Equal(scalarMult(g, ev.Base.Point(), ev.Scalar))
+}
+
+func (ev *CiphertextOpeningEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *CiphertextOpeningEvidence) Verify(_ group.AffineGroup) bool {
+	content, idMsg, ok := openReceipts(ev.Accused, ev.Round1, ev.Identification)
+	r1msg, isRound1 := content.(*SignRound1Message1)
+	if !ok || !isRound1 || ev.PK == nil {
+		return false
+	}
+	c, k := r1msg.UnmarshalC(), idMsg.UnmarshalK()
+	for _, x := range idMsg.UnmarshalKRandomness() {
+		if cx, err := ev.PK.EncryptWithRandomness(k, x); err == nil && cx.Cmp(c) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// verify checks that the accuser's ciphertext and the accused's response cB open to the values given
+func (o *MtAOpening) verify(cB *big.Int) bool {
+	if o.PK == nil || o.NTilde == nil || o.H1 == nil || o.H2 == nil ||
+		o.CA == nil || o.KA == nil || o.RhoA == nil || o.Alpha == nil || o.XB == nil {
+		return false
+	}
+	cA, err := o.PK.EncryptWithRandomness(o.KA, o.RhoA)
+	if err != nil || cA.Cmp(o.CA) != 0 {
+		return false
+	}
+	cAlpha, err := o.PK.EncryptWithRandomness(o.Alpha, o.XB)
+	return err == nil && cAlpha.Cmp(cB) == 0
+}
+
+func (ev *MtAEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *MtAEvidence) Verify(g group.AffineGroup) bool {
+	content, idMsg, ok := openReceipts(ev.Accused, ev.Round2, ev.Identification)
+	r2msg, isRound2 := content.(*SignRound2Message)
+	if !ok || !isRound2 {
+		return false
+	}
+	cB := new(big.Int).SetBytes(r2msg.GetC1())
+	proof, err := r2msg.UnmarshalProofBob()
+	if err != nil || !ev.MtAOpening.verify(cB) || !proof.Verify(ev.Session, g, ev.PK, ev.NTilde, ev.H1, ev.H2, ev.CA, cB) {
+		return false
+	}
+	modQ := common.ModInt(g.Order())
+	alpha := new(big.Int).Mod(ev.Alpha, g.Order())
+	kGamma := modQ.Mul(ev.KA, idMsg.UnmarshalGamma())
+	for _, betaPrm := range idMsg.UnmarshalBetaPrms() {
+		if alpha.Cmp(modQ.Add(kGamma, betaPrm)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (ev *MtAwcEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *MtAwcEvidence) Verify(g group.AffineGroup) bool {
+	content, idMsg, ok := openReceipts(ev.Accused, ev.Round2, ev.Identification)
+	r2msg, isRound2 := content.(*SignRound2Message)
+	if !ok || !isRound2 || !inGroup(g, ev.BigW) {
+		return false
+	}
+	cB := new(big.Int).SetBytes(r2msg.GetC2())
+	proof, err := r2msg.UnmarshalProofBobWC(g)
+	if err != nil || !ev.MtAOpening.verify(cB) || !proof.Verify(ev.Session, g, ev.PK, ev.NTilde, ev.H1, ev.H2, ev.CA, cB, ev.BigW) {
+		return false
+	}
+	nuPrmPoints, err := idMsg.UnmarshalNuPrmPoints(g)
+	if err != nil {
+		return false
+	}
+	a := scalarBaseMult(g, ev.Alpha)
+	w := scalarMult(g, ev.BigW.Point(), ev.KA)
+	for _, nuPrmPoint := range nuPrmPoints {
+		if a.Equal(g.NewPoint().Add(w, nuPrmPoint.Point())) {
+			return false
+		}
+	}
+	return true
+}
+
+func (ev *DeltaEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *DeltaEvidence) Verify(g group.AffineGroup) bool {
+	if ev.Delta == nil || ev.K == nil || len(ev.Gammas) == 0 ||
+		len(ev.BetaPrmsIn) != len(ev.Gammas)-1 || len(ev.BetaPrmsOut) != len(ev.Gammas)-1 {
+		return false
+	}
+	modQ := common.ModInt(g.Order())
+	gammaSum := big.NewInt(0)
+	for _, gamma := range ev.Gammas {
+		gammaSum = modQ.Add(gammaSum, gamma)
+	}
+	expected := modQ.Mul(ev.K, gammaSum)
+	for j := range ev.BetaPrmsIn {
+		expected = modQ.Add(expected, modQ.Sub(ev.BetaPrmsIn[j], ev.BetaPrmsOut[j]))
+	}
+	return new(big.Int).Mod(ev.Delta, g.Order()).Cmp(expected) != 0
+}
+
+func (ev *SigmaEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *SigmaEvidence) Verify(g group.AffineGroup) bool {
+	if !inGroup(g, ev.ECDSAPub, ev.R, ev.BigV) || ev.M == nil || ev.K == nil || ev.L == nil ||
+		len(ev.Ks) == 0 || len(ev.NuPrmPointsIn) != len(ev.Ks)-1 || len(ev.NuPrmPointsOut) != len(ev.Ks)-1 ||
+		!inGroup(g, ev.NuPrmPointsIn...) || !inGroup(g, ev.NuPrmPointsOut...) {
+		return false
+	}
+	q := g.Order()
+	modQ := common.ModInt(q)
+	kSum := big.NewInt(0)
+	for _, kj := range ev.Ks {
+		kSum = modQ.Add(kSum, kj)
+	}
+	kInv := modQ.ModInverse(kSum)
+	if kInv == nil {
+		return false
+	}
+	r := new(big.Int).Mod(ev.R.X(), q)
+
+	// g^σi = y^ki * Σg^ν'ji - Σg^ν'ij
+	sigma := scalarMult(g, ev.ECDSAPub.Point(), ev.K)
+	for j := range ev.NuPrmPointsIn {
+		sigma.Add(sigma, ev.NuPrmPointsIn[j].Point())
+		sigma.Sub(sigma, ev.NuPrmPointsOut[j].Point())
+	}
+	// Vi = R^(m * ki) * (g^σi)^(r / k) * g^li
+	v := scalarMult(g, ev.R.Point(), modQ.Mul(ev.M, ev.K))
+	v.Add(v, scalarMult(g, sigma, modQ.Mul(r, kInv)))
+	v.Add(v, scalarBaseMult(g, ev.L))
+	return !ev.BigV.Point().Equal(v)
+}
+
+func (ev *PhaseFiveEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *PhaseFiveEvidence) Verify(g group.AffineGroup) bool {
+	if !inGroup(g, ev.ECDSAPub, ev.R, ev.BigU, ev.BigT) || ev.M == nil || ev.RoI == nil || ev.L == nil ||
+		len(ev.BigVs) == 0 || len(ev.BigVs) != len(ev.BigAs) || !inGroup(g, ev.BigVs...) || !inGroup(g, ev.BigAs...) {
+		return false
+	}
+	q := g.Order()
+	modQ := common.ModInt(q)
+	r := new(big.Int).Mod(ev.R.X(), q)
+	v := scalarBaseMult(g, modQ.Sub(zero, ev.M))
+	v.Add(v, scalarMult(g, ev.ECDSAPub.Point(), modQ.Sub(zero, r)))
+	a := g.NewPoint()
+	for j := range ev.BigVs {
+		v.Add(v, ev.BigVs[j].Point())
+		a.Add(a, ev.BigAs[j].Point())
+	}
+	return !ev.BigU.Point().Equal(scalarMult(g, v, ev.RoI)) || !ev.BigT.Point().Equal(scalarMult(g, a, ev.L))
+}
+
+func (ev *SignatureShareEvidence) Culprit() *tss.PartyID { return ev.Accused }
+
+func (ev *SignatureShareEvidence) Verify(g group.AffineGroup) bool {
+	if !inGroup(g, ev.R, ev.BigV) || ev.S == nil || ev.L == nil {
+		return false
+	}
+	v := scalarMult(g, ev.R.Point(), ev.S)
+	v.Add(v, scalarBaseMult(g, ev.L))
+	return !ev.BigV.Point().Equal(v)
+}
+
+// ----- //
+
+// openReceipts opens the receipts of a point-to-point message of the accused and of its identification message,
+// which must have been sealed for the same session
+func openReceipts(accused *tss.PartyID, p2p, identification *envelope.Receipt) (tss.MessageContent, *SignIdentificationMessage, bool) {
+	msg, session, err := p2p.Open(accused)
+	if err != nil || msg.IsBroadcast() || !msg.ValidateBasic() {
+		return nil, nil, false
+	}
+	idMsg, idSession, err := identification.Open(accused)
+	if err != nil || !idMsg.IsBroadcast() || idSession != session || !idMsg.ValidateBasic() {
+		return nil, nil, false
+	}
+	content, ok := idMsg.Content().(*SignIdentificationMessage)
+	if !ok {
+		return nil, nil, false
+	}
+	return msg.Content(), content, true
+}
+
+// the evidence is checked in the group, in which unlike with crypto.ECPoint a sum may be the identity
+
+func scalarMult(g group.AffineGroup, p group.Point, k *big.Int) group.Point {
+	return g.NewPoint().ScalarMult(g.NewScalar().SetBigInt(k), p)
+}
+
+func scalarBaseMult(g group.AffineGroup, k *big.Int) group.Point {
+	return g.NewPoint().ScalarBaseMult(g.NewScalar().SetBigInt(k))
+}
+
+// inGroup returns true if each of the points is a valid point of g
+func inGroup(g group.AffineGroup, points ...*crypto.ECPoint) bool {
+	for _, p := range points {
+		if !p.ValidateBasic() || p.Group() != g {
+			return false
+		}
+	}
+	return true
+}
@@ -8,24 +8,23 @@ package signing
 
 import (
 	"crypto/ecdsa"
-	"errors"
-	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *finalization) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 10
 	round.started = true
 	round.resetOK()
 
 	sumS := round.temp.si
-	modN := common.ModInt(round.Params().EC().Params().N)
+	modN := common.ModInt(round.Params().Group().Order())
 
 	for j := range round.Parties().IDs() {
 		round.ok[j] = true
@@ -36,12 +35,52 @@ func (round *finalization) Start() *tss.Error {
 		sumS = modN.Add(sumS, r9msg.UnmarshalS())
 	}
 
+	tssErr := round.finalizeSignature(sumS, round.temp.rx, round.temp.ry, round.key.ECDSAPub)
+	if tssErr == nil {
+		return nil
+	}
+
+	// the signature does not verify: find the parties whose sj does not match the Vj they committed to in round 5
+	evidence := make([]Evidence, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		r9msg := round.temp.signRound9Messages[j].Content().(*SignRound9Message)
+		ev := &SignatureShareEvidence{Accused: Pj, R: round.temp.bigR, BigV: round.temp.bigVjs[j], S: r9msg.UnmarshalS(), L: r9msg.UnmarshalL()}
+		if ev.Verify(round.Params().Group()) {
+			evidence = append(evidence, ev)
+		}
+	}
+	if len(evidence) == 0 {
+		return tssErr
+	}
+	abortErr := &IdentifiedAbortError{Evidence: evidence}
+	return round.WrapError(tss.WithKind(tss.ErrInvalidSignature, abortErr), abortErr.Culprits()...)
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// finalizeSignature normalises s, saves the signature for final output and verifies it against the public key
+func (round *base) finalizeSignature(sumS, rx, ry *big.Int, pub *crypto.ECPoint) *tss.Error {
 	recid := 0
 	// byte v = if(R.X > curve.N) then 2 else 0) | (if R.Y.IsEven then 0 else 1);
-	if round.temp.rx.Cmp(round.Params().EC().Params().N) > 0 {
+	if rx.Cmp(round.Params().Group().Order()) > 0 {
 		recid = 2
 	}
-	if round.temp.ry.Bit(0) != 0 {
+	if ry.Bit(0) != 0 {
 		recid |= 1
 	}
 
@@ -49,15 +88,16 @@ func (round *finalization) Start() *tss.Error {
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L442-L444
 	// This is needed because of tendermint checks here:
 	// https://github.com/tendermint/tendermint/blob/d9481e3648450cb99e15c6a070c1fb69aa0c255b/crypto/secp256k1/secp256k1_nocgo.go#L43-L47
-	secp256k1halfN := new(big.Int).Rsh(round.Params().EC().Params().N, 1)
-	if sumS.Cmp(secp256k1halfN) > 0 {
-		sumS.Sub(round.Params().EC().Params().N, sumS)
+	// Other curves keep s as computed unless low-S is requested with tss.Parameters.SetLowS.
+	halfN := new(big.Int).Rsh(round.Params().Group().Order(), 1)
+	if round.Params().LowS() && sumS.Cmp(halfN) > 0 {
+		sumS.Sub(round.Params().Group().Order(), sumS)
 		recid ^= 1
 	}
 
 	// save the signature for final output
-	bitSizeInBytes := round.Params().EC().Params().BitSize / 8
-	round.data.R = padToLengthBytesInPlace(round.temp.rx.Bytes(), bitSizeInBytes)
+	bitSizeInBytes := round.Group().ScalarLen()
+	round.data.R = padToLengthBytesInPlace(rx.Bytes(), bitSizeInBytes)
 	round.data.S = padToLengthBytesInPlace(sumS.Bytes(), bitSizeInBytes)
 	round.data.Signature = append(round.data.R, round.data.S...)
 	round.data.SignatureRecovery = []byte{byte(recid)}
@@ -70,14 +110,14 @@ func (round *finalization) Start() *tss.Error {
 	}
 
 	pk := ecdsa.PublicKey{
-		Curve: round.Params().EC(),
-		X:     round.key.ECDSAPub.X(),
-		Y:     round.key.ECDSAPub.Y(),
+		Curve: round.Params().Group().Curve(),
+		X:     pub.X(),
+		Y:     pub.Y(),
 	}
 
-	ok := ecdsa.Verify(&pk, round.data.M, round.temp.rx, sumS)
+	ok := ecdsa.Verify(&pk, round.data.M, rx, sumS)
 	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidSignature, "signature verification failed"))
 	}
 
 	round.end <- round.data
@@ -85,20 +125,6 @@ func (round *finalization) Start() *tss.Error {
 	return nil
 }
 
-func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
-	// not expecting any incoming messages in this round
-	return false
-}
-
-func (round *finalization) Update() (bool, *tss.Error) {
-	// not expecting any incoming messages in this round
-	return false, nil
-}
-
-func (round *finalization) NextRound() tss.Round {
-	return nil // finished!
-}
-
 func padToLengthBytesInPlace(src []byte, length int) []byte {
 	oriLen := len(src)
 	if oriLen < length {
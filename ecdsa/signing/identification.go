@@ -0,0 +1,260 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/envelope"
+)
+
+// startIdentification is called by round 9 when U != T. Each party reveals ki, γi, ρi, li and the openings of the MtAs
+// it took part in; si was never revealed, so this does not leak anything about the key.
+func (round *round9) startIdentification() *tss.Error {
+	round.temp.identifying = true
+
+	i := round.PartyID().Index
+	modQ := common.ModInt(round.Params().Group().Order())
+	others := len(round.Parties().IDs()) - 1
+	kRandomness := make([]*big.Int, 0, others)
+	betaPrms := make([]*big.Int, 0, others)
+	nuPrmPoints := make([]*crypto.ECPoint, 0, others)
+	for j := range round.Parties().IDs() {
+		if j == i {
+			continue
+		}
+		// the randomness of the encryption of ki sent to Pj in round 1
+		_, rho, err := round.key.PaillierSK.DecryptAndRecoverRandomness(round.temp.cis[j])
+		if err != nil {
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "DecryptAndRecoverRandomness(cij)")))
+		}
+		kRandomness = append(kRandomness, rho)
+		// β' and g^ν' of the MtAs in which this party was Bob for Pj
+		betaPrms = append(betaPrms, modQ.Sub(zero, round.temp.betas[j]))
+		nuPrmPoints = append(nuPrmPoints, crypto.ScalarBaseMult(round.Params().Group(), modQ.Sub(zero, round.temp.vs[j])))
+	}
+	msg, err := NewSignIdentificationMessage(round.PartyID(), round.temp.k, round.temp.gamma, round.temp.roi, round.temp.li, kRandomness, betaPrms, nuPrmPoints)
+	if err != nil {
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err))
+	}
+	round.temp.signIdentificationMessages[i] = msg
+	if err := round.send(msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+// identification checks every party's revealed values against what it sent earlier and ends the protocol
+// with a tss.Error naming the culprits, the cause of which is an *IdentifiedAbortError holding the evidence.
+func (round *identification) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
+	}
+	round.number = 10
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().Group()
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	g := crypto.Generator(ec)
+
+	ks, gammas, rois, ls := make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps)), make([]*big.Int, len(Ps))
+	kRandomness, betaPrms := make([][]*big.Int, len(Ps)), make([][]*big.Int, len(Ps))
+	nuPrmPoints := make([][]*crypto.ECPoint, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		msg := round.temp.signIdentificationMessages[j].Content().(*SignIdentificationMessage)
+		ks[j], gammas[j], rois[j], ls[j] = msg.UnmarshalK(), msg.UnmarshalGamma(), msg.UnmarshalRoi(), msg.UnmarshalL()
+		kRandomness[j], betaPrms[j] = msg.UnmarshalKRandomness(), msg.UnmarshalBetaPrms()
+		var err error
+		nuPrmPoints[j], err = msg.UnmarshalNuPrmPoints(ec)
+		if err != nil || len(kRandomness[j]) != len(Ps)-1 || len(betaPrms[j]) != len(Ps)-1 || len(nuPrmPoints[j]) != len(Ps)-1 {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "malformed identification message"), Pj)
+		}
+	}
+
+	evidence := make([]Evidence, 0, len(Ps))
+	collect := func(ev Evidence) {
+		if ev.Verify(ec) {
+			evidence = append(evidence, ev)
+		}
+	}
+	// set when the point-to-point messages of a party cannot be checked for want of their receipts
+	unchecked := false
+	// the direct checks: values this party can verify against what it committed to or received itself
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		// 1. γj and ρj must open Γj (round 4) and Aj (round 6)
+		collect(&PointOpeningEvidence{Accused: Pj, Base: g, Point: round.temp.bigGammas[j], Scalar: gammas[j]})
+		collect(&PointOpeningEvidence{Accused: Pj, Base: g, Point: round.temp.bigAjs[j], Scalar: rois[j]})
+
+		// 2. and 3. blame Pj for its point-to-point messages, which only their receipts can show a third party
+		r1Receipt := envelope.ReceiptOf(round.temp.signRound1Message1s[j])
+		r2Receipt := envelope.ReceiptOf(round.temp.signRound2Messages[j])
+		idReceipt := envelope.ReceiptOf(round.temp.signIdentificationMessages[j])
+		if r1Receipt == nil || r2Receipt == nil || idReceipt == nil {
+			unchecked = true
+		} else {
+			// 2. kj must open the ciphertext Pj sent to this party in round 1
+			collect(&CiphertextOpeningEvidence{Accused: Pj, PK: round.key.PaillierPKs[j], Round1: r1Receipt, Identification: idReceipt})
+
+			// 3. the MtA responses Pj sent to this party in round 2 must match the β' and g^ν' it revealed
+			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
+			opening1, err := round.openMtA(j, ks[i], kRandomness[i][otherIndex(i, j)], new(big.Int).SetBytes(r2msg.GetC1()))
+			if err != nil {
+				return round.WrapError(err)
+			}
+			collect(&MtAEvidence{Accused: Pj, MtAOpening: *opening1, Round2: r2Receipt, Identification: idReceipt})
+			opening2, err := round.openMtA(j, ks[i], kRandomness[i][otherIndex(i, j)], new(big.Int).SetBytes(r2msg.GetC2()))
+			if err != nil {
+				return round.WrapError(err)
+			}
+			collect(&MtAwcEvidence{Accused: Pj, MtAOpening: *opening2, BigW: round.temp.bigWs[j], Round2: r2Receipt, Identification: idReceipt})
+		}
+
+		// 4. Uj and Tj committed in round 7 must match ρj and lj
+		collect(&PhaseFiveEvidence{
+			Accused:  Pj,
+			ECDSAPub: round.key.ECDSAPub,
+			R:        round.temp.bigR,
+			M:        round.temp.m,
+			RoI:      rois[j],
+			L:        ls[j],
+			BigVs:    round.temp.bigVjs,
+			BigAs:    round.temp.bigAjs,
+			BigU:     round.temp.bigUjs[j],
+			BigT:     round.temp.bigTjs[j],
+		})
+	}
+
+	// the checks below take the openings revealed by all parties at face value, including those of MtAs this party
+	// did not take part in. they are only run when the direct checks found nothing, so that a lie about an MtA is
+	// blamed on the liar rather than on its peer; a liar that cheated only another party is named by that party.
+	// without the receipts, a lie about an MtA with this party cannot be ruled out, so they are not run at all.
+
+	// 5. δj broadcast in round 3 must match the openings of all parties
+	if len(evidence) == 0 && !unchecked {
+		for j, Pj := range Ps {
+			if j == i {
+				continue
+			}
+			betaPrmsIn, betaPrmsOut := make([]*big.Int, 0, len(Ps)-1), make([]*big.Int, 0, len(Ps)-1)
+			for l := range Ps {
+				if l == j {
+					continue
+				}
+				betaPrmsIn = append(betaPrmsIn, betaPrms[l][otherIndex(l, j)])
+				betaPrmsOut = append(betaPrmsOut, betaPrms[j][otherIndex(j, l)])
+			}
+			r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
+			collect(&DeltaEvidence{
+				Accused:     Pj,
+				Delta:       new(big.Int).SetBytes(r3msg.GetTheta()),
+				K:           ks[j],
+				Gammas:      gammas,
+				BetaPrmsIn:  betaPrmsIn,
+				BetaPrmsOut: betaPrmsOut,
+			})
+		}
+	}
+
+	// 6. once R is known to be g^(1/k), Vj committed in round 5 must commit to sj = m * kj + r * σj
+	if len(evidence) == 0 && !unchecked {
+		for j, Pj := range Ps {
+			if j == i {
+				continue
+			}
+			nuPrmPointsIn, nuPrmPointsOut := make([]*crypto.ECPoint, 0, len(Ps)-1), make([]*crypto.ECPoint, 0, len(Ps)-1)
+			for l := range Ps {
+				if l == j {
+					continue
+				}
+				nuPrmPointsIn = append(nuPrmPointsIn, nuPrmPoints[l][otherIndex(l, j)])
+				nuPrmPointsOut = append(nuPrmPointsOut, nuPrmPoints[j][otherIndex(j, l)])
+			}
+			collect(&SigmaEvidence{
+				Accused:        Pj,
+				ECDSAPub:       round.key.ECDSAPub,
+				R:              round.temp.bigR,
+				BigV:           round.temp.bigVjs[j],
+				M:              round.temp.m,
+				K:              ks[j],
+				L:              ls[j],
+				Ks:             ks,
+				NuPrmPointsIn:  nuPrmPointsIn,
+				NuPrmPointsOut: nuPrmPointsOut,
+			})
+		}
+	}
+
+	// security: k_i is no longer needed
+	common.WipeBigInt(round.temp.k)
+	round.temp.k = nil
+
+	if len(evidence) == 0 && unchecked {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidSignature,
+			"U doesn't equal T and the culprit cannot be identified without the receipts of the point-to-point messages; see envelope.NewParty"))
+	}
+	if len(evidence) == 0 {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidSignature, "U doesn't equal T and identification found no culprit"))
+	}
+	abortErr := &IdentifiedAbortError{Evidence: evidence}
+	return round.WrapError(tss.WithKind(tss.ErrInvalidSignature, abortErr), abortErr.Culprits()...)
+}
+
+func (round *identification) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *identification) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *identification) NextRound() tss.Round {
+	return nil // finished!
+}
+
+// ----- //
+
+// openMtA opens this party's ciphertext of ki sent to Pj and Pj's response cB to it
+func (round *identification) openMtA(j int, ki, rhoI, cB *big.Int) (*MtAOpening, error) {
+	alpha, xB, err := round.key.PaillierSK.DecryptAndRecoverRandomness(cB)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "DecryptAndRecoverRandomness(cB)"))
+	}
+	i := round.PartyID().Index
+	return &MtAOpening{
+		PK:      round.key.PaillierPKs[i],
+		NTilde:  round.key.NTildej[i],
+		H1:      round.key.H1j[i],
+		H2:      round.key.H2j[i],
+		Session: common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j))),
+		CA:      round.temp.cis[j],
+		KA:      ki,
+		RhoA:    rhoI,
+		Alpha:   alpha,
+		XB:      xB,
+	}, nil
+}
+
+// otherIndex returns the position of Pl in the per-party lists of Pj, which skip Pj itself
+func otherIndex(j, l int) int {
+	if l < j {
+		return l
+	}
+	return l - 1
+}
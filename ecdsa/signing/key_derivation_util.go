@@ -4,7 +4,6 @@ package signing
 
 import (
 	"crypto/ecdsa"
-	"crypto/elliptic"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -12,14 +11,15 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/btcsuite/btcd/chaincfg"
 )
 
-func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.LocalPartySaveData, extendedChildPk *ecdsa.PublicKey, ec elliptic.Curve) error {
+func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.LocalPartySaveData, extendedChildPk *ecdsa.PublicKey, g group.AffineGroup) error {
 	var err error
-	gDelta := crypto.ScalarBaseMult(ec, keyDerivationDelta)
+	gDelta := crypto.ScalarBaseMult(g, keyDerivationDelta)
 	for k := range keys {
-		keys[k].ECDSAPub, err = crypto.NewECPoint(ec, extendedChildPk.X, extendedChildPk.Y)
+		keys[k].ECDSAPub, err = crypto.NewECPoint(g, extendedChildPk.X, extendedChildPk.Y)
 		if err != nil {
 			common.Logger.Errorf("error creating new extended child public key")
 			return err
@@ -37,10 +37,10 @@ func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.Lo
 	return nil
 }
 
-func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, ec elliptic.Curve) (*big.Int, *ckd.ExtendedKey, error) {
+func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32, g group.AffineGroup) (*big.Int, *ckd.ExtendedKey, error) {
 	// build ecdsa key pair
 	pk := ecdsa.PublicKey{
-		Curve: ec,
+		Curve: g.Curve(),
 		X:     masterPub.X(),
 		Y:     masterPub.Y(),
 	}
@@ -55,5 +55,5 @@ func derivingPubkeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []
 		Version:    net.HDPrivateKeyID[:],
 	}
 
-	return ckd.DeriveChildKeyFromHierarchy(path, extendedParentPk, ec.Params().N, ec)
+	return ckd.DeriveChildKeyFromHierarchy(path, extendedParentPk, g.Order(), g)
 }
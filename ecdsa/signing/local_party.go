@@ -7,7 +7,7 @@
 package signing
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -17,6 +17,16 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a signing session in a keystore container
+	SessionSnapshotKind = "ecdsa/signing.Session"
+	// OnlineSessionSnapshotKind identifies the snapshot of an online signing session in a keystore container
+	OnlineSessionSnapshotKind = "ecdsa/signing.OnlineSession"
+	// BatchSessionSnapshotKind identifies the snapshot of a batch signing session in a keystore container
+	BatchSessionSnapshotKind = "ecdsa/signing.BatchSession"
 )
 
 // Implements Party
@@ -31,13 +41,15 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		keys keygen.LocalPartySaveData
-		temp localTempData
-		data *common.SignatureData
+		keys    keygen.LocalPartySaveData
+		temp    localTempData
+		data    *common.SignatureData
+		journal *tss.SessionJournal
 
 		// outbound messaging
-		out chan<- tss.Message
-		end chan<- *common.SignatureData
+		out       chan<- tss.Message
+		end       chan<- *common.SignatureData
+		preSigEnd chan<- *PreSignatureData
 	}
 
 	localMessageStore struct {
@@ -50,7 +62,10 @@ type (
 		signRound6Messages,
 		signRound7Messages,
 		signRound8Messages,
-		signRound9Messages []tss.ParsedMessage
+		signRound9Messages,
+		signIdentificationMessages,
+		signPreSignMessages,
+		signOnlineMessages []tss.ParsedMessage
 	}
 
 	localTempData struct {
@@ -88,13 +103,26 @@ type (
 		bigR,
 		bigAi,
 		bigVi *crypto.ECPoint
-		DPower cmt.HashDeCommitment
+		bigGammas []*crypto.ECPoint
+		DPower    cmt.HashDeCommitment
 
 		// round 7
+		bigVjs,
+		bigAjs []*crypto.ECPoint
+
 		Ui,
 		Ti *crypto.ECPoint
 		DTelda cmt.HashDeCommitment
 
+		// round 9
+		bigUjs,
+		bigTjs []*crypto.ECPoint
+		identifying bool
+
+		// presigning: R^kj and R^σj of every party
+		bigRBars,
+		bigSs []*crypto.ECPoint
+
 		ssidNonce *big.Int
 		ssid      []byte
 	}
@@ -122,14 +150,14 @@ func NewLocalPartyWithKDD(
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
-		temp:      localTempData{},
-		data:      &common.SignatureData{},
-		out:       out,
-		end:       end,
+		params: params,
+		keys:   keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:   localTempData{},
+		data:   &common.SignatureData{},
+		out:    out,
+		end:    end,
 	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
 	// msgs init
 	p.temp.signRound1Message1s = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound1Message2s = make([]tss.ParsedMessage, partyCount)
@@ -141,6 +169,8 @@ func NewLocalPartyWithKDD(
 	p.temp.signRound7Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound8Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound9Messages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signIdentificationMessages = make([]tss.ParsedMessage, partyCount)
+	p.temp.signPreSignMessages = make([]tss.ParsedMessage, partyCount)
 	// temp data init
 	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
@@ -157,18 +187,45 @@ func NewLocalPartyWithKDD(
 	p.temp.pi1jis = make([]*mta.ProofBob, partyCount)
 	p.temp.pi2jis = make([]*mta.ProofBobWC, partyCount)
 	p.temp.vs = make([]*big.Int, partyCount)
+	p.temp.bigGammas = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigVjs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigAjs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigUjs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigTjs = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigRBars = make([]*crypto.ECPoint, partyCount)
+	p.temp.bigSs = make([]*crypto.ECPoint, partyCount)
+	return p
+}
+
+// NewPreSigningLocalParty returns a party that runs the message-independent rounds of signing and outputs a
+// PreSignatureData to `end` instead of a signature. The presignature is later turned into a signature with NewOnlineLocalParty.
+func NewPreSigningLocalParty(
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	out chan<- tss.Message,
+	end chan<- *PreSignatureData,
+) tss.Party {
+	p := NewLocalParty(nil, params, key, out, nil).(*LocalParty)
+	p.preSigEnd = end
 	return p
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.preSigEnd, p.journal)
 }
 
 func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
 	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
 		round1, ok := round.(*round1)
 		if !ok {
-			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+			return round.WrapError(tss.Errorf(tss.ErrInvalidState, "unable to Start(). party is in an unexpected round"))
 		}
 		if err := round1.prepare(); err != nil {
 			return round.WrapError(err)
@@ -177,6 +234,34 @@ func (p *LocalParty) Start() *tss.Error {
 	})
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The key belongs to the caller and is left alone.
+func (p *LocalParty) wipe(finished bool) {
+	p.temp.wipe(finished)
+	p.journal.Wipe()
+}
+
+// wipe zeroes the secret nonces, shares and MtA values of a session in place and drops them. s_i is revealed in the
+// last round, so it is only wiped if the party did not finish.
+func (temp *localTempData) wipe(finished bool) {
+	common.WipeBigInts(temp.w, temp.k, temp.gamma, temp.sigma, temp.li, temp.roi)
+	common.WipeBigInts(temp.betas...)
+	common.WipeBigInts(temp.vs...)
+	cmt.WipeDeCommitment(temp.deCommit)
+	cmt.WipeDeCommitment(temp.DPower)
+	cmt.WipeDeCommitment(temp.DTelda)
+	temp.w, temp.k, temp.gamma, temp.sigma, temp.li, temp.roi = nil, nil, nil, nil, nil, nil
+	temp.betas, temp.vs = nil, nil
+	temp.deCommit, temp.DPower, temp.DTelda = nil, nil, nil
+	if !finished {
+		common.WipeBigInt(temp.si)
+		temp.si = nil
+	}
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -195,7 +280,7 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	}
 	// check that the message's "from index" will fit into the array
 	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with a sender index too great (%d <= %d)",
 			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
 	}
 	return true, nil
@@ -231,13 +316,80 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		p.temp.signRound8Messages[fromPIdx] = msg
 	case *SignRound9Message:
 		p.temp.signRound9Messages[fromPIdx] = msg
+	case *SignIdentificationMessage:
+		p.temp.signIdentificationMessages[fromPIdx] = msg
+	case *SignPreSignMessage:
+		p.temp.signPreSignMessages[fromPIdx] = msg
 	default: // unrecognised message, just ignore!
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
 	return true, nil
 }
 
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *SignRound1Message1, *SignRound1Message2:
+		return 1
+	case *SignRound2Message:
+		return 2
+	case *SignRound3Message:
+		return 3
+	case *SignRound4Message:
+		return 4
+	case *SignRound5Message, *SignPreSignMessage:
+		return 5
+	case *SignRound6Message:
+		return 6
+	case *SignRound7Message:
+		return 7
+	case *SignRound8Message:
+		return 8
+	case *SignRound9Message, *SignIdentificationMessage:
+		return 9
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot, such as
+// a different round 1 commitment.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	presign := []byte{0}
+	if p.preSigEnd != nil {
+		presign[0] = 1
+	}
+	in := [][]byte{p.PartyID().GetKey(), bigIntBytes(p.temp.m), bigIntBytes(p.temp.keyDerivationDelta), presign}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
@@ -245,3 +397,10 @@ func (p *LocalParty) PartyID() *tss.PartyID {
 func (p *LocalParty) String() string {
 	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
 }
+
+func bigIntBytes(i *big.Int) []byte {
+	if i == nil {
+		return nil
+	}
+	return i.Bytes()
+}
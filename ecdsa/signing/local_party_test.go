@@ -9,21 +9,31 @@ package signing
 import (
 	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
-	"github.com/btcsuite/btcd/btcec/v2"
+	btcecdsa "github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/paillier"
 	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/envelope"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
 )
 
 const (
@@ -59,7 +69,7 @@ func TestE2EConcurrent(t *testing.T) {
 	updater := test.SharedPartyUpdater
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
 		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
 		parties = append(parties, P)
 		go func(P *LocalParty) {
@@ -95,7 +105,7 @@ signing:
 				go updater(parties[dest[0].Index], msg, errCh)
 			}
 
-		case <-endCh:
+		case data := <-endCh:
 			atomic.AddInt32(&ended, 1)
 			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
 				t.Logf("Done. Received signature data from %d participants", ended)
@@ -103,7 +113,7 @@ signing:
 				r := parties[0].temp.rx
 				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				modN := common.ModInt(tss.S256().Params().N)
+				modN := common.ModInt(group.Secp256k1().Order())
 
 				// BEGIN check s correctness
 				sumS := big.NewInt(0)
@@ -116,12 +126,19 @@ signing:
 				// BEGIN ECDSA verify
 				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.S256(),
 					X:     pkX,
 					Y:     pkY,
 				}
 				ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), R.X(), sumS)
 				assert.True(t, ok, "ecdsa verify must pass")
+
+				// the recovery id recovers the public key from the compact encoding
+				compact, err := data.ToCompact(true)
+				assert.NoError(t, err)
+				recovered, _, err := btcecdsa.RecoverCompact(compact, data.GetM())
+				assert.NoError(t, err)
+				assert.True(t, recovered.ToECDSA().Equal(&pk))
 				t.Log("ECDSA signing test done.")
 				// END ECDSA verify
 
@@ -131,6 +148,95 @@ signing:
 	}
 }
 
+func TestE2EConcurrentP256(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSetForCurve(group.P256(), testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	// a WebAuthn style SHA-256 digest
+	digest := sha256.Sum256([]byte("tss-lib p256"))
+	msg := new(big.Int).SetBytes(digest[:])
+
+	// PHASE: signing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.P256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		assert.False(t, params.LowS(), "P-256 signatures are not normalized by default")
+		P := NewLocalParty(msg, params, keys[i], outCh, endCh, len(digest)).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case data := <-endCh:
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+				modN := common.ModInt(group.P256().Order())
+				sumS := big.NewInt(0)
+				for _, p := range parties {
+					sumS = modN.Add(sumS, p.temp.si)
+				}
+				assert.Equal(t, digest[:], data.GetM())
+				assert.Equal(t, sumS.FillBytes(make([]byte, 32)), data.GetS(), "s must not be normalized")
+
+				// verify through crypto/ecdsa, with the raw and the ASN.1 DER encodings
+				pk := keys[0].ECDSAPub.ToECDSAPubKey()
+				assert.Equal(t, tss.P256(), pk.Curve)
+				r, s := new(big.Int).SetBytes(data.GetR()), new(big.Int).SetBytes(data.GetS())
+				assert.True(t, ecdsa.Verify(pk, digest[:], r, s), "ecdsa verify must pass")
+				assert.True(t, data.Verify(pk))
+				der, err := data.ToDER()
+				assert.NoError(t, err)
+				assert.True(t, ecdsa.VerifyASN1(pk, digest[:], der), "ecdsa verify of the DER signature must pass")
+				t.Log("P-256 ECDSA signing test done.")
+
+				break signing
+			}
+		}
+	}
+}
+
 func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
 	setUp("info")
 	threshold := testThreshold
@@ -154,7 +260,7 @@ func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
 	msgData, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
 		P := NewLocalParty(new(big.Int).SetBytes(msgData), params, keys[i], outCh, endCh, len(msgData)).(*LocalParty)
 		parties = append(parties, P)
 		go func(P *LocalParty) {
@@ -198,7 +304,7 @@ signing:
 				r := parties[0].temp.rx
 				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				modN := common.ModInt(tss.S256().Params().N)
+				modN := common.ModInt(group.Secp256k1().Order())
 
 				// BEGIN check s correctness
 				sumS := big.NewInt(0)
@@ -211,7 +317,7 @@ signing:
 				// BEGIN ECDSA verify
 				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.S256(),
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -241,12 +347,12 @@ func TestE2EWithHDKeyDerivation(t *testing.T) {
 	max32b = new(big.Int).Sub(max32b, new(big.Int).SetUint64(1))
 	fillBytes(common.GetRandomPositiveInt(rand.Reader, max32b), chainCode)
 
-	il, extendedChildPk, errorDerivation := derivingPubkeyFromPath(keys[0].ECDSAPub, chainCode, []uint32{12, 209, 3}, btcec.S256())
+	il, extendedChildPk, errorDerivation := derivingPubkeyFromPath(keys[0].ECDSAPub, chainCode, []uint32{12, 209, 3}, group.Secp256k1())
 	assert.NoErrorf(t, errorDerivation, "there should not be an error deriving the child public key")
 
 	keyDerivationDelta := il
 
-	err = UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta, keys, &extendedChildPk.PublicKey, btcec.S256())
+	err = UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta, keys, &extendedChildPk.PublicKey, group.Secp256k1())
 	assert.NoErrorf(t, err, "there should not be an error setting the derived keys")
 
 	// PHASE: signing
@@ -262,7 +368,7 @@ func TestE2EWithHDKeyDerivation(t *testing.T) {
 
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.S256(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
 
 		P := NewLocalPartyWithKDD(big.NewInt(42), params, keys[i], keyDerivationDelta, outCh, endCh, 0).(*LocalParty)
 		parties = append(parties, P)
@@ -306,7 +412,7 @@ signing:
 				r := parties[0].temp.rx
 				fmt.Printf("sign result: R(%s, %s), r=%s\n", R.X().String(), R.Y().String(), r.String())
 
-				modN := common.ModInt(tss.S256().Params().N)
+				modN := common.ModInt(group.Secp256k1().Order())
 
 				// BEGIN check s correctness
 				sumS := big.NewInt(0)
@@ -319,7 +425,7 @@ signing:
 				// BEGIN ECDSA verify
 				pkX, pkY := keys[0].ECDSAPub.X(), keys[0].ECDSAPub.Y()
 				pk := ecdsa.PublicKey{
-					Curve: tss.EC(),
+					Curve: tss.S256(),
 					X:     pkX,
 					Y:     pkY,
 				}
@@ -334,6 +440,991 @@ signing:
 	}
 }
 
+func TestE2EPreSigningAndOnlineSigning(t *testing.T) {
+	setUp("info")
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	updater := test.SharedPartyUpdater
+
+	// PHASE: presigning
+	preParties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	preSigCh := make(chan *PreSignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewPreSigningLocalParty(params, keys[i], outCh, preSigCh)
+		preParties = append(preParties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	preSigs := make([]*PreSignatureData, len(signPIDs))
+presigning:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break presigning
+
+		case msg := <-outCh:
+			routeTestMessage(t, preParties, msg, errCh, updater)
+
+		case preSig := <-preSigCh:
+			// presignatures survive a round trip through JSON
+			bz, err := json.Marshal(preSig)
+			assert.NoError(t, err)
+			var loaded PreSignatureData
+			assert.NoError(t, json.Unmarshal(bz, &loaded))
+			assert.True(t, loaded.ValidateBasic())
+			idx := signPIDs.FindByKey(loaded.ShareID).Index
+			preSigs[idx] = &loaded
+			done := true
+			for _, ps := range preSigs {
+				done = done && ps != nil
+			}
+			if done {
+				break presigning
+			}
+		}
+	}
+	assert.True(t, preSigs[0].R.Equals(preSigs[1].R), "all parties must agree on R")
+
+	// PHASE: online signing
+	msg := big.NewInt(42)
+	onlineParties := make([]tss.Party, 0, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		P := NewOnlineLocalParty(msg, params, preSigs[i], outCh, endCh)
+		onlineParties = append(onlineParties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+online:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break online
+
+		case msg := <-outCh:
+			routeTestMessage(t, onlineParties, msg, errCh, updater)
+
+		case sig := <-endCh:
+			pk := ecdsa.PublicKey{
+				Curve: tss.S256(),
+				X:     keys[0].ECDSAPub.X(),
+				Y:     keys[0].ECDSAPub.Y(),
+			}
+			ok := ecdsa.Verify(&pk, msg.Bytes(), new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+			assert.Equal(t, 0, preSigs[0].R.X().Cmp(new(big.Int).SetBytes(sig.R)))
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				break online
+			}
+		}
+	}
+
+	// PHASE: a presignature is single-use
+	for _, preSig := range preSigs {
+		assert.True(t, preSig.IsConsumed())
+		_, _, err := preSig.Consume()
+		assert.Equal(t, ErrPreSignatureConsumed, err)
+	}
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), threshold)
+	P := NewOnlineLocalParty(big.NewInt(43), params, preSigs[0], outCh, endCh)
+	assert.NotNil(t, P.Start(), "a consumed presignature must not sign again")
+}
+
+func TestE2EPreSigningRejectsBadSigma(t *testing.T) {
+	setUp("info")
+	const cheater = 0
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// the cheater shifts its share of k * x once the MtAs are done; every proof of presigning still passes
+	tampered := false
+	var held []tss.Message
+	preSigs, errs := runPreSigning(t, keys, signPIDs, func(parties []*LocalParty, msg tss.Message) []tss.Message {
+		if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound4Message); !ok || tampered {
+			return []tss.Message{msg}
+		}
+		if msg.GetFrom().Index == cheater {
+			parties[cheater].temp.sigma = new(big.Int).Add(parties[cheater].temp.sigma, big.NewInt(1))
+			tampered = true
+			return append(held, msg)
+		}
+		held = append(held, msg)
+		return nil
+	})
+	for _, preSig := range preSigs {
+		assert.Nil(t, preSig, "no presignature may be output")
+	}
+	for _, err := range errs {
+		if assert.NotNil(t, err) {
+			assert.ErrorIs(t, err, tss.ErrInvalidSignature)
+			assert.Contains(t, err.Error(), "does not match the public key")
+		}
+	}
+}
+
+func TestE2EOnlineSigningBlamesBadShare(t *testing.T) {
+	setUp("info")
+	const cheater = 1
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preSigs, errs := runPreSigning(t, keys, signPIDs, func(_ []*LocalParty, msg tss.Message) []tss.Message {
+		return []tss.Message{msg}
+	})
+	for _, err := range errs {
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewOnlineLocalParty(big.NewInt(42), params, preSigs[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	honest := make([]*tss.Error, 0, len(signPIDs)-1)
+	for len(honest) < len(signPIDs)-1 {
+		select {
+		case err := <-errCh:
+			if err.Victim().Index != cheater {
+				honest = append(honest, err)
+			}
+
+		case msg := <-outCh:
+			if online, ok := msg.(tss.ParsedMessage).Content().(*SignOnlineMessage); ok && msg.GetFrom().Index == cheater {
+				msg = NewSignOnlineMessage(msg.GetFrom(), new(big.Int).Add(online.UnmarshalS(), big.NewInt(1)))
+			}
+			routeTestMessage(t, parties, msg, errCh, test.SharedPartyUpdater)
+
+		case <-endCh:
+			// only the cheater may finish, as the shares it received are correct
+
+		case <-time.After(time.Minute):
+			t.Fatal("timed out waiting for the honest parties to abort")
+		}
+	}
+	for _, err := range honest {
+		assert.ErrorIs(t, err, tss.ErrInvalidSignature)
+		if assert.Len(t, err.Culprits(), 1, err.Error()) {
+			assert.Equal(t, cheater, err.Culprits()[0].Index)
+		}
+	}
+}
+
+// runPreSigning runs a presigning in which every outgoing message passes through intercept, which returns the
+// messages to deliver in its place. it returns the presignature or the error of each party.
+func runPreSigning(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs, intercept func([]*LocalParty, tss.Message) []tss.Message) ([]*PreSignatureData, []*tss.Error) {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	tssParties := make([]tss.Party, 0, len(signPIDs))
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	preSigCh := make(chan *PreSignatureData, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewPreSigningLocalParty(params, keys[i], outCh, preSigCh).(*LocalParty)
+		parties = append(parties, P)
+		tssParties = append(tssParties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	preSigs := make([]*PreSignatureData, len(signPIDs))
+	errs := make([]*tss.Error, len(signPIDs))
+	for done := 0; done < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			errs[err.Victim().Index] = err
+			done++
+
+		case msg := <-outCh:
+			for _, msg := range intercept(parties, msg) {
+				routeTestMessage(t, tssParties, msg, errCh, test.SharedPartyUpdater)
+			}
+
+		case preSig := <-preSigCh:
+			preSigs[signPIDs.FindByKey(preSig.ShareID).Index] = preSig
+			done++
+
+		case <-time.After(2 * time.Minute):
+			t.Fatal("timed out waiting for the presigning to end")
+		}
+	}
+	return preSigs, errs
+}
+
+func TestE2EIdentifiableAbortOnBadMtAShare(t *testing.T) {
+	setUp("info")
+	const cheater = 0
+
+	errs := runSigningWithCheater(t, cheater, true, lieAboutBetas(cheater))
+	assertIdentifiedAbort(t, errs, cheater, func(ev Evidence) bool {
+		_, ok := ev.(*MtAEvidence)
+		return ok
+	})
+
+	// the evidence stands on the cheater's signatures, not on the word of its accuser
+	var abortErr *IdentifiedAbortError
+	if !assert.True(t, errors.As(errs[0], &abortErr)) {
+		return
+	}
+	ev := abortErr.Evidence[0].(*MtAEvidence)
+	forged := *ev
+	forged.Round2 = &envelope.Receipt{Sealed: append([]byte(nil), ev.Round2.Sealed...), SenderKey: ev.Round2.SenderKey, OpeningKey: ev.Round2.OpeningKey}
+	forged.Round2.Sealed[len(forged.Round2.Sealed)-1] ^= 1
+	assert.False(t, forged.Verify(group.Secp256k1()), "a tampered receipt should not convince anyone")
+	forged = *ev
+	forged.Identification = &envelope.Receipt{Sealed: ev.Identification.Sealed, SenderKey: ev.Round2.SenderKey[:0]}
+	assert.False(t, forged.Verify(group.Secp256k1()), "a receipt without the sender's key should not convince anyone")
+	forged = *ev
+	forged.KA = new(big.Int).Add(ev.KA, big.NewInt(1))
+	assert.False(t, forged.Verify(group.Secp256k1()), "the accuser cannot change its side of the MtA")
+	forged = *ev
+	forged.Round2 = nil
+	assert.False(t, forged.Verify(group.Secp256k1()), "the evidence needs the receipt of the response")
+}
+
+func TestE2EIdentifiableAbortNeedsReceipts(t *testing.T) {
+	setUp("info")
+	const cheater = 0
+
+	// without envelopes, the honest parties cannot show who lied about the MtA, so they blame no one
+	errs := runSigningWithCheater(t, cheater, false, lieAboutBetas(cheater))
+	for _, err := range errs {
+		assert.ErrorIs(t, err, tss.ErrInvalidSignature)
+		assert.Empty(t, err.Culprits(), err.Error())
+		assert.Contains(t, err.Error(), "receipts")
+	}
+}
+
+// lieAboutBetas returns an intercept in which the cheater lies about its β shares in δ, which makes R wrong for
+// everyone but passes every proof until U = T
+func lieAboutBetas(cheater int) func([]*LocalParty, tss.Message) []tss.Message {
+	tampered := false
+	var held []tss.Message
+	return func(parties []*LocalParty, msg tss.Message) []tss.Message {
+		if _, ok := msg.(tss.ParsedMessage).Content().(*SignRound2Message); !ok {
+			return []tss.Message{msg}
+		}
+		if !tampered && msg.GetFrom().Index == cheater {
+			for j, beta := range parties[cheater].temp.betas {
+				if j != cheater {
+					parties[cheater].temp.betas[j] = new(big.Int).Add(beta, big.NewInt(1))
+				}
+			}
+			tampered = true
+			return append(held, msg)
+		}
+		// keep the cheater in round 2 until it has been tampered with
+		if !tampered && msg.GetTo()[0].Index == cheater {
+			held = append(held, msg)
+			return nil
+		}
+		return []tss.Message{msg}
+	}
+}
+
+func TestE2EIdentifiableAbortOnBadSignatureShare(t *testing.T) {
+	setUp("info")
+	const cheater = 0
+
+	errs := runSigningWithCheater(t, cheater, false, func(parties []*LocalParty, msg tss.Message) []tss.Message {
+		r9msg, ok := msg.(tss.ParsedMessage).Content().(*SignRound9Message)
+		if !ok || msg.GetFrom().Index != cheater {
+			return []tss.Message{msg}
+		}
+		badS := new(big.Int).Add(r9msg.UnmarshalS(), big.NewInt(1))
+		return []tss.Message{NewSignRound9Message(msg.GetFrom(), badS, r9msg.UnmarshalL())}
+	})
+	assertIdentifiedAbort(t, errs, cheater, func(ev Evidence) bool {
+		_, ok := ev.(*SignatureShareEvidence)
+		return ok
+	})
+}
+
+// runSigningWithCheater runs a signing in which every outgoing message passes through intercept, which returns the
+// messages to deliver in its place, and is then sealed in an envelope if sealed is set. it returns the errors of the
+// honest parties.
+func runSigningWithCheater(t *testing.T, cheater int, sealed bool, intercept func([]*LocalParty, tss.Message) []tss.Message) []*tss.Error {
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+	tssParties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	var sealers []*envelope.Sealer
+	if sealed {
+		sealers = newTestSealers(t, signPIDs)
+		updater = sealedPartyUpdater
+	}
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		if sealed {
+			tssParties = append(tssParties, envelope.NewParty(P, sealers[i]))
+		} else {
+			tssParties = append(tssParties, P)
+		}
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	errs := make([]*tss.Error, 0, len(signPIDs)-1)
+	for len(errs) < len(signPIDs)-1 {
+		select {
+		case err := <-errCh:
+			if err.Victim().Index != cheater {
+				errs = append(errs, err)
+			}
+
+		case msg := <-outCh:
+			for _, msg := range intercept(parties, msg) {
+				if !sealed {
+					routeTestMessage(t, tssParties, msg, errCh, updater)
+					continue
+				}
+				sealedMsgs, err := sealers[msg.GetFrom().Index].Seal(rand.Reader, msg)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, msg := range sealedMsgs {
+					routeTestMessage(t, tssParties, msg, errCh, updater)
+				}
+			}
+
+		case <-endCh:
+			// only the cheater may finish, as the shares it received are correct
+
+		case <-time.After(2 * time.Minute):
+			t.Fatal("timed out waiting for the honest parties to abort")
+		}
+	}
+	return errs
+}
+
+func newTestSealers(t *testing.T, pIDs tss.SortedPartyIDs) []*envelope.Sealer {
+	identities := make([]*envelope.Identity, len(pIDs))
+	publics := make(map[string]envelope.PublicIdentity, len(pIDs))
+	for i, pID := range pIDs {
+		id, err := envelope.GenerateIdentity(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identities[i] = id
+		if publics[pID.Id], err = id.Public(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sealers := make([]*envelope.Sealer, len(pIDs))
+	for i, pID := range pIDs {
+		s, err := envelope.NewSealer("signing", pID, identities[i], pIDs, publics)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sealers[i] = s
+	}
+	return sealers
+}
+
+// sealedPartyUpdater passes a sealed message to the UpdateFromBytes of a party wrapped with envelope.NewParty
+func sealedPartyUpdater(party tss.Party, msg tss.Message, errCh chan<- *tss.Error) {
+	bz, routing, err := msg.WireBytes()
+	if err != nil {
+		errCh <- party.WrapError(err)
+		return
+	}
+	if _, err := party.UpdateFromBytes(bz, routing.From, routing.IsBroadcast); err != nil {
+		errCh <- err
+	}
+}
+
+func assertIdentifiedAbort(t *testing.T, errs []*tss.Error, cheater int, isExpected func(Evidence) bool) {
+	for _, err := range errs {
+		if assert.Len(t, err.Culprits(), 1, err.Error()) {
+			assert.Equal(t, cheater, err.Culprits()[0].Index)
+		}
+		assert.ErrorIs(t, err, tss.ErrInvalidSignature)
+		var abortErr *IdentifiedAbortError
+		if !assert.True(t, errors.As(err, &abortErr), "the cause must be an *IdentifiedAbortError") {
+			continue
+		}
+		for _, ev := range abortErr.Evidence {
+			assert.Equal(t, cheater, ev.Culprit().Index)
+			assert.True(t, isExpected(ev), "unexpected evidence %T", ev)
+			// the evidence convinces anyone who checks it
+			assert.True(t, ev.Verify(group.Secp256k1()))
+		}
+	}
+}
+
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	// the messages are delivered one at a time, so the channels must hold every message of a round
+	errCh := make(chan *tss.Error, 100*len(signPIDs))
+	outCh := make(chan tss.Message, 100*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	newParty := func(i int) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh).(*LocalParty)
+	}
+	for i := range signPIDs {
+		P := newParty(i)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// party 0 crashes once it has received its round 2 messages
+	key := keystore.KEK(make([]byte, keystore.KEKSize))
+	received, crashed := 0, false
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index || (dest != nil && dest[0].Index != P.PartyID().Index) {
+					continue
+				}
+				test.SharedPartyUpdater(P, msg, errCh)
+				if P.PartyID().Index == 0 {
+					received++
+				}
+			}
+			if received < 3*(len(signPIDs)-1) || crashed {
+				continue
+			}
+			crashed = true
+			snapshot, err := parties[0].(*LocalParty).Snapshot(key)
+			assert.NoError(t, err)
+			_, err = parties[0].(*LocalParty).Snapshot(keystore.KEK(nil))
+			assert.Error(t, err, "the key must be a KEK")
+
+			restored := newParty(0)
+			if err := restored.Restore(snapshot, key); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, parties[0].(*LocalParty).temp.k, restored.temp.k)
+			assert.Equal(t, parties[0].(*LocalParty).temp.gamma, restored.temp.gamma)
+			assert.Equal(t, parties[0].(*LocalParty).temp.betas, restored.temp.betas)
+			assert.Contains(t, restored.String(), "round: 3")
+			parties[0] = restored
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		case <-time.After(2 * time.Minute):
+			t.Fatal("timed out waiting for the signatures")
+		}
+	}
+	assert.True(t, crashed)
+	pk := keys[0].ECDSAPub.ToECDSAPubKey()
+	for _, data := range signatures {
+		assert.Equal(t, signatures[0].GetSignature(), data.GetSignature())
+		assert.True(t, data.Verify(pk), "ecdsa verify must pass")
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *common.SignatureData, 1)
+	newParty := func(msg int64, snapshots bool) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(snapshots)
+		return NewLocalParty(big.NewInt(msg), params, keys[0], outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(42, false)
+	assert.Nil(t, P.Start())
+	_, err = P.Snapshot(keystore.NoEncryption())
+	assert.Error(t, err, "snapshots are not enabled")
+	for len(outCh) > 0 {
+		<-outCh
+	}
+
+	P = newParty(42, true)
+	assert.Nil(t, P.Start())
+	sent := make([][]byte, 0, len(signPIDs))
+	for len(outCh) > 0 {
+		bz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		sent = append(sent, bz)
+	}
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 messages
+	restored := newParty(42, true)
+	assert.Nil(t, restored.Restore(snapshot, keystore.NoEncryption()))
+	assert.Nil(t, newParty(42, true).Restore(snapshot, keystore.NoEncryption()))
+	for _, bz := range append(sent, sent...) {
+		reBz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, bz, reBz)
+	}
+	assert.NotNil(t, restored.Restore(snapshot, keystore.NoEncryption()), "the party is already running")
+
+	// the snapshot cannot be restored into a session that signs another message
+	assert.NotNil(t, newParty(43, true).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(42, true).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+func TestE2EPreSigningRestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	newParty := func() *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewPreSigningLocalParty(params, keys[0], outCh, make(chan *PreSignatureData, 1)).(*LocalParty)
+	}
+
+	P := newParty()
+	assert.Nil(t, P.Start())
+	sent := make([][]byte, 0, len(signPIDs))
+	for len(outCh) > 0 {
+		bz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		sent = append(sent, bz)
+	}
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 messages
+	assert.Nil(t, newParty().Restore(snapshot, keystore.NoEncryption()))
+	for _, bz := range sent {
+		reBz, _, err := (<-outCh).WireBytes()
+		assert.NoError(t, err)
+		assert.Equal(t, bz, reBz)
+	}
+
+	// the snapshot of a presigning cannot be restored into a signing session
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+	params.SetSessionSnapshots(true)
+	signer := NewLocalParty(big.NewInt(42), params, keys[0], outCh, make(chan *common.SignatureData, 1)).(*LocalParty)
+	assert.NotNil(t, signer.Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty().Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+func TestE2EOnlineSigningRestoreRefusesDifferentShare(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	preSigs, errs := runPreSigning(t, keys, signPIDs, func(_ []*LocalParty, msg tss.Message) []tss.Message {
+		return []tss.Message{msg}
+	})
+	for _, err := range errs {
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	newParty := func(i int, msg int64) *OnlineLocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewOnlineLocalParty(big.NewInt(msg), params, preSigs[i], outCh, endCh).(*OnlineLocalParty)
+	}
+	parties := make([]tss.Party, 0, len(signPIDs))
+	onlineMsgs := make([]tss.Message, 0, len(signPIDs))
+	for i := range signPIDs {
+		P := newParty(i, 42)
+		assert.Nil(t, P.Start())
+		parties = append(parties, P)
+		onlineMsgs = append(onlineMsgs, <-outCh)
+	}
+	sent, _, err := onlineMsgs[0].WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := parties[0].(*OnlineLocalParty).Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party does not consume the presignature again and re-emits the same si
+	restored := newParty(0, 42)
+	assert.Nil(t, restored.Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session that signs another message
+	assert.NotNil(t, newParty(0, 43).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot that no longer holds the si that was sent is refused and nothing is sent
+	tampered, err := test.SetSnapshotField(snapshot, OnlineSessionSnapshotKind, "kept", big.NewInt(1).Bytes())
+	assert.NoError(t, err)
+	err = newParty(0, 42).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+
+	// the restored party completes the signature
+	parties[0] = restored
+	for _, msg := range onlineMsgs {
+		routeTestMessage(t, parties, msg, errCh, test.SharedPartyUpdater)
+	}
+	pk := ecdsa.PublicKey{
+		Curve: tss.S256(),
+		X:     keys[0].ECDSAPub.X(),
+		Y:     keys[0].ECDSAPub.Y(),
+	}
+	for range signPIDs {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case sig := <-endCh:
+			ok := ecdsa.Verify(&pk, big.NewInt(42).Bytes(), new(big.Int).SetBytes(sig.R), new(big.Int).SetBytes(sig.S))
+			assert.True(t, ok, "ecdsa verify must pass")
+		case <-time.After(time.Minute):
+			t.Fatal("timed out waiting for the signature")
+		}
+	}
+}
+
+func TestE2EOutOfOrderAndRetransmittedMessages(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	// the messages are delivered one at a time, so the channels must hold every message of a round
+	errCh := make(chan *tss.Error, 100*len(signPIDs))
+	outCh := make(chan tss.Message, 100*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// the newest message is delivered first, so the messages of a later round often arrive before those of the
+	// round the recipient is in, and every message is delivered twice
+	var pending []tss.Message
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		for len(outCh) > 0 {
+			pending = append(pending, <-outCh)
+		}
+		for len(endCh) > 0 {
+			signatures = append(signatures, <-endCh)
+		}
+		if len(errCh) > 0 {
+			assert.FailNow(t, (<-errCh).Error())
+		}
+		if len(pending) == 0 {
+			continue
+		}
+		msg := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		dest := msg.GetTo()
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index || (dest != nil && dest[0].Index != P.PartyID().Index) {
+				continue
+			}
+			test.SharedPartyUpdater(P, msg, errCh)
+			test.SharedPartyUpdater(P, msg, errCh)
+		}
+	}
+	pk := keys[0].ECDSAPub.ToECDSAPubKey()
+	for _, data := range signatures {
+		assert.True(t, data.Verify(pk), "ecdsa verify must pass")
+	}
+}
+
+func TestConflictingMessagesAreEquivocation(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[1], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(42), params, keys[1], outCh, nil)
+	assert.Nil(t, P.Start())
+
+	// a message of round 3 is queued until party 1 reaches round 3
+	ok, uerr := P.Update(NewSignRound3Message(signPIDs[0], big.NewInt(1)))
+	assert.True(t, ok)
+	assert.Nil(t, uerr)
+	assert.Nil(t, P.(*LocalParty).temp.signRound3Messages[0])
+
+	commitment := common.MustGetRandomInt(rand.Reader, 256)
+	ok, uerr = P.Update(NewSignRound1Message2(signPIDs[0], commitment))
+	assert.True(t, ok)
+	assert.Nil(t, uerr)
+	// a retransmission is dropped
+	ok, uerr = P.Update(NewSignRound1Message2(signPIDs[0], commitment))
+	assert.True(t, ok)
+	assert.Nil(t, uerr)
+
+	ok, uerr = P.Update(NewSignRound1Message2(signPIDs[0], new(big.Int).Add(commitment, big.NewInt(1))))
+	assert.False(t, ok)
+	if assert.NotNil(t, uerr) {
+		assert.True(t, errors.Is(uerr, tss.ErrEquivocation))
+		if assert.Len(t, uerr.Culprits(), 1) {
+			assert.Equal(t, signPIDs[0], uerr.Culprits()[0])
+		}
+	}
+}
+
+func TestQueuedMessagesAreBounded(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[1], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(42), params, keys[1], outCh, nil)
+	assert.Nil(t, P.Start())
+
+	// a message of another protocol is accepted by no round of the party
+	var proof paillier.Proof
+	for i := range proof {
+		proof[i] = big.NewInt(1)
+	}
+	ok, uerr := P.Update(keygen.NewKGRound3Message(signPIDs[0], proof))
+	assert.False(t, ok)
+	if assert.NotNil(t, uerr) {
+		assert.True(t, errors.Is(uerr, tss.ErrInvalidMessage))
+		assert.Equal(t, []*tss.PartyID{signPIDs[0]}, uerr.Culprits())
+	}
+
+	// a sender can only get a few messages ahead of the party
+	cmtment := cmt.NewHashCommitment(rand.Reader, big.NewInt(1))
+	ahead := []tss.ParsedMessage{
+		NewSignRound3Message(signPIDs[0], big.NewInt(1)),
+		NewSignRound5Message(signPIDs[0], cmtment.C),
+		NewSignRound7Message(signPIDs[0], cmtment.C),
+		NewSignRound9Message(signPIDs[0], big.NewInt(1), big.NewInt(1)),
+	}
+	for _, msg := range ahead {
+		ok, uerr = P.Update(msg)
+		assert.True(t, ok)
+		assert.Nil(t, uerr)
+	}
+	ok, uerr = P.Update(NewSignRound8Message(signPIDs[0], cmt.NewHashCommitment(rand.Reader, big.NewInt(1), big.NewInt(2)).D))
+	assert.False(t, ok)
+	if assert.NotNil(t, uerr) {
+		assert.True(t, errors.Is(uerr, tss.ErrInvalidMessage))
+		assert.Equal(t, []*tss.PartyID{signPIDs[0]}, uerr.Culprits())
+	}
+	// the other senders are not held back by it
+	ok, uerr = P.Update(NewSignRound3Message(signPIDs[2], big.NewInt(1)))
+	assert.True(t, ok)
+	assert.Nil(t, uerr)
+}
+
+func TestCloseWipesSecrets(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[1], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(42), params, keys[1], outCh, nil).(*LocalParty)
+	assert.Nil(t, P.Start())
+	secrets := test.SecretWords(P.temp.w, P.temp.k, P.temp.gamma, P.temp.deCommit[0])
+	assert.False(t, test.Wiped(secrets))
+
+	P.Close()
+	assert.True(t, test.Wiped(secrets), "w, k, gamma and the decommitment randomness must be wiped")
+	assert.Nil(t, P.temp.w)
+	assert.Nil(t, P.temp.k)
+	assert.Nil(t, P.temp.gamma)
+	assert.Equal(t, 1, keys[1].Xi.Sign(), "the key of the caller is left alone")
+
+	_, uerr := P.Update(NewSignRound1Message2(signPIDs[0], big.NewInt(1)))
+	if assert.NotNil(t, uerr) {
+		assert.Equal(t, tss.ErrAborted, uerr.Kind())
+	}
+}
+
+func TestE2EObserver(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	// record the events of party 0
+	var mtx sync.Mutex
+	var events []tss.Event
+	observer := tss.ObserverFunc(func(event tss.Event) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		events = append(events, event)
+	})
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		if i == 0 {
+			params.SetObserver(observer)
+		}
+		P := NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	for ended := 0; ended < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			routeTestMessage(t, parties, msg, errCh, test.SharedPartyUpdater)
+		case <-endCh:
+			ended++
+		}
+	}
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	var started, finished []int
+	stored, proofs := 0, 0
+	var waitingFor []tss.WaitingForChanged
+	for _, event := range events {
+		switch e := event.(type) {
+		case tss.RoundStarted:
+			assert.Equal(t, signPIDs[0], e.Party)
+			assert.Equal(t, TaskName, e.Task)
+			started = append(started, e.Round)
+		case tss.RoundFinished:
+			assert.Equal(t, len(started), e.Round)
+			finished = append(finished, e.Round)
+		case tss.MessageStored:
+			assert.NotEqual(t, signPIDs[0], e.From)
+			stored++
+		case tss.ProofVerified:
+			assert.True(t, e.Valid, "the proof %s of %s must be valid", e.Proof, e.Prover)
+			assert.NotEqual(t, signPIDs[0], e.Prover)
+			proofs++
+		case tss.WaitingForChanged:
+			waitingFor = append(waitingFor, e)
+		}
+	}
+	// the finalization is round 10
+	rounds := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	assert.Equal(t, rounds, started)
+	assert.Equal(t, rounds, finished)
+	// every other party sends two messages in round 1 and one in each later round
+	assert.Equal(t, 10*(len(signPIDs)-1), stored)
+	// two MtA proofs in round 3, one proof in round 5 and two in round 7 from every other party
+	assert.Equal(t, 5*(len(signPIDs)-1), proofs)
+	if assert.NotEmpty(t, waitingFor) {
+		assert.Len(t, waitingFor[0].WaitingFor, len(signPIDs)-1)
+		last := waitingFor[len(waitingFor)-1]
+		assert.Equal(t, 10, last.Round)
+		assert.Empty(t, last.WaitingFor)
+	}
+}
+
+func routeTestMessage(t *testing.T, parties []tss.Party, msg tss.Message, errCh chan *tss.Error, updater func(tss.Party, tss.Message, chan<- *tss.Error)) {
+	dest := msg.GetTo()
+	if dest == nil {
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			go updater(P, msg, errCh)
+		}
+	} else {
+		if dest[0].Index == msg.GetFrom().Index {
+			t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+		}
+		go updater(parties[dest[0].Index], msg, errCh)
+	}
+}
+
 func TestFillTo32BytesInPlace(t *testing.T) {
 	s := big.NewInt(123456789)
 	normalizedS := padToLengthBytesInPlace(s.Bytes(), 32)
@@ -7,12 +7,12 @@
 package signing
 
 import (
-	"crypto/elliptic"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/mta"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/tss"
@@ -34,6 +34,10 @@ var (
 		(*SignRound7Message)(nil),
 		(*SignRound8Message)(nil),
 		(*SignRound9Message)(nil),
+		(*SignPreSignMessage)(nil),
+		(*SignOnlineMessage)(nil),
+		(*SignIdentificationMessage)(nil),
+		(*SignBatchMessage)(nil),
 	}
 )
 
@@ -136,8 +140,8 @@ func (m *SignRound2Message) UnmarshalProofBob() (*mta.ProofBob, error) {
 	return mta.ProofBobFromBytes(m.ProofBob)
 }
 
-func (m *SignRound2Message) UnmarshalProofBobWC(ec elliptic.Curve) (*mta.ProofBobWC, error) {
-	return mta.ProofBobWCFromBytes(ec, m.ProofBobWc)
+func (m *SignRound2Message) UnmarshalProofBobWC(g group.AffineGroup) (*mta.ProofBobWC, error) {
+	return mta.ProofBobWCFromBytes(g, m.ProofBobWc)
 }
 
 // ----- //
@@ -197,9 +201,9 @@ func (m *SignRound4Message) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
-func (m *SignRound4Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
+func (m *SignRound4Message) UnmarshalZKProof(g group.AffineGroup) (*schnorr.ZKProof, error) {
 	point, err := crypto.NewECPoint(
-		ec,
+		g,
 		new(big.Int).SetBytes(m.GetProofAlphaX()),
 		new(big.Int).SetBytes(m.GetProofAlphaY()))
 	if err != nil {
@@ -281,9 +285,9 @@ func (m *SignRound6Message) UnmarshalDeCommitment() []*big.Int {
 	return cmt.NewHashDeCommitmentFromBytes(deComBzs)
 }
 
-func (m *SignRound6Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProof, error) {
+func (m *SignRound6Message) UnmarshalZKProof(g group.AffineGroup) (*schnorr.ZKProof, error) {
 	point, err := crypto.NewECPoint(
-		ec,
+		g,
 		new(big.Int).SetBytes(m.GetProofAlphaX()),
 		new(big.Int).SetBytes(m.GetProofAlphaY()))
 	if err != nil {
@@ -295,9 +299,9 @@ func (m *SignRound6Message) UnmarshalZKProof(ec elliptic.Curve) (*schnorr.ZKProo
 	}, nil
 }
 
-func (m *SignRound6Message) UnmarshalZKVProof(ec elliptic.Curve) (*schnorr.ZKVProof, error) {
+func (m *SignRound6Message) UnmarshalZKVProof(g group.AffineGroup) (*schnorr.ZKVProof, error) {
 	point, err := crypto.NewECPoint(
-		ec,
+		g,
 		new(big.Int).SetBytes(m.GetVProofAlphaX()),
 		new(big.Int).SetBytes(m.GetVProofAlphaY()))
 	if err != nil {
@@ -368,7 +372,7 @@ func (m *SignRound8Message) UnmarshalDeCommitment() []*big.Int {
 
 func NewSignRound9Message(
 	from *tss.PartyID,
-	si *big.Int,
+	si, li *big.Int,
 ) tss.ParsedMessage {
 	meta := tss.MessageRouting{
 		From:        from,
@@ -376,6 +380,7 @@ func NewSignRound9Message(
 	}
 	content := &SignRound9Message{
 		S: si.Bytes(),
+		L: li.Bytes(),
 	}
 	msg := tss.NewMessageWrapper(meta, content)
 	return tss.NewMessage(meta, content, msg)
@@ -383,9 +388,202 @@ func NewSignRound9Message(
 
 func (m *SignRound9Message) ValidateBasic() bool {
 	return m != nil &&
-		common.NonEmptyBytes(m.S)
+		common.NonEmptyBytes(m.S) &&
+		common.NonEmptyBytes(m.L)
 }
 
 func (m *SignRound9Message) UnmarshalS() *big.Int {
 	return new(big.Int).SetBytes(m.S)
 }
+
+func (m *SignRound9Message) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.L)
+}
+
+// ----- //
+
+func NewSignIdentificationMessage(
+	from *tss.PartyID,
+	ki, gammaI, roI, li *big.Int,
+	kRandomness, betaPrms []*big.Int,
+	nuPrmPoints []*crypto.ECPoint,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	nuPrmPointsFlat, err := crypto.FlattenECPoints(nuPrmPoints)
+	if err != nil {
+		return nil, err
+	}
+	content := &SignIdentificationMessage{
+		K:           ki.Bytes(),
+		Gamma:       gammaI.Bytes(),
+		Roi:         roI.Bytes(),
+		L:           li.Bytes(),
+		KRandomness: common.BigIntsToBytes(kRandomness),
+		BetaPrm:     common.BigIntsToBytes(betaPrms),
+		NuPrmPoint:  common.BigIntsToBytes(nuPrmPointsFlat),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *SignIdentificationMessage) ValidateBasic() bool {
+	if m == nil ||
+		!common.NonEmptyBytes(m.GetK()) ||
+		!common.NonEmptyBytes(m.GetGamma()) ||
+		!common.NonEmptyBytes(m.GetRoi()) ||
+		!common.NonEmptyBytes(m.GetL()) {
+		return false
+	}
+	others := len(m.GetKRandomness())
+	return 0 < others &&
+		common.NonEmptyMultiBytes(m.GetKRandomness(), others) &&
+		common.NonEmptyMultiBytes(m.GetBetaPrm(), others) &&
+		common.NonEmptyMultiBytes(m.GetNuPrmPoint(), others*2)
+}
+
+func (m *SignIdentificationMessage) UnmarshalK() *big.Int {
+	return new(big.Int).SetBytes(m.GetK())
+}
+
+func (m *SignIdentificationMessage) UnmarshalGamma() *big.Int {
+	return new(big.Int).SetBytes(m.GetGamma())
+}
+
+func (m *SignIdentificationMessage) UnmarshalRoi() *big.Int {
+	return new(big.Int).SetBytes(m.GetRoi())
+}
+
+func (m *SignIdentificationMessage) UnmarshalL() *big.Int {
+	return new(big.Int).SetBytes(m.GetL())
+}
+
+func (m *SignIdentificationMessage) UnmarshalKRandomness() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetKRandomness())
+}
+
+func (m *SignIdentificationMessage) UnmarshalBetaPrms() []*big.Int {
+	return common.MultiBytesToBigInts(m.GetBetaPrm())
+}
+
+func (m *SignIdentificationMessage) UnmarshalNuPrmPoints(g group.AffineGroup) ([]*crypto.ECPoint, error) {
+	return crypto.UnFlattenECPoints(g, common.MultiBytesToBigInts(m.GetNuPrmPoint()))
+}
+
+// ----- //
+
+func NewSignPreSignMessage(
+	from *tss.PartyID,
+	bigRBar, bigS *crypto.ECPoint,
+	rBarProof, sProof *schnorr.ZKProof,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignPreSignMessage{
+		RBarX:           bigRBar.X().Bytes(),
+		RBarY:           bigRBar.Y().Bytes(),
+		SX:              bigS.X().Bytes(),
+		SY:              bigS.Y().Bytes(),
+		RBarProofAlphaX: rBarProof.Alpha.X().Bytes(),
+		RBarProofAlphaY: rBarProof.Alpha.Y().Bytes(),
+		RBarProofT:      rBarProof.T.Bytes(),
+		SProofAlphaX:    sProof.Alpha.X().Bytes(),
+		SProofAlphaY:    sProof.Alpha.Y().Bytes(),
+		SProofT:         sProof.T.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignPreSignMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.RBarX) &&
+		common.NonEmptyBytes(m.RBarY) &&
+		common.NonEmptyBytes(m.SX) &&
+		common.NonEmptyBytes(m.SY) &&
+		common.NonEmptyBytes(m.RBarProofAlphaX) &&
+		common.NonEmptyBytes(m.RBarProofAlphaY) &&
+		common.NonEmptyBytes(m.RBarProofT) &&
+		common.NonEmptyBytes(m.SProofAlphaX) &&
+		common.NonEmptyBytes(m.SProofAlphaY) &&
+		common.NonEmptyBytes(m.SProofT)
+}
+
+func (m *SignPreSignMessage) UnmarshalBigRBar(g group.AffineGroup) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(g, new(big.Int).SetBytes(m.GetRBarX()), new(big.Int).SetBytes(m.GetRBarY()))
+}
+
+func (m *SignPreSignMessage) UnmarshalBigS(g group.AffineGroup) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(g, new(big.Int).SetBytes(m.GetSX()), new(big.Int).SetBytes(m.GetSY()))
+}
+
+func (m *SignPreSignMessage) UnmarshalRBarProof(g group.AffineGroup) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(g, new(big.Int).SetBytes(m.GetRBarProofAlphaX()), new(big.Int).SetBytes(m.GetRBarProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{Alpha: point, T: new(big.Int).SetBytes(m.GetRBarProofT())}, nil
+}
+
+func (m *SignPreSignMessage) UnmarshalSProof(g group.AffineGroup) (*schnorr.ZKProof, error) {
+	point, err := crypto.NewECPoint(g, new(big.Int).SetBytes(m.GetSProofAlphaX()), new(big.Int).SetBytes(m.GetSProofAlphaY()))
+	if err != nil {
+		return nil, err
+	}
+	return &schnorr.ZKProof{Alpha: point, T: new(big.Int).SetBytes(m.GetSProofT())}, nil
+}
+
+// ----- //
+
+func NewSignOnlineMessage(
+	from *tss.PartyID,
+	si *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignOnlineMessage{
+		S: si.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignOnlineMessage) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.S)
+}
+
+func (m *SignOnlineMessage) UnmarshalS() *big.Int {
+	return new(big.Int).SetBytes(m.S)
+}
+
+// ----- //
+
+func NewSignBatchMessage(
+	to []*tss.PartyID,
+	from *tss.PartyID,
+	isBroadcast bool,
+	messages [][]byte,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		To:          to,
+		IsBroadcast: isBroadcast,
+	}
+	content := &SignBatchMessage{
+		Messages: messages,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignBatchMessage) ValidateBasic() bool {
+	return m != nil &&
+		len(m.GetMessages()) > 0
+}
@@ -0,0 +1,217 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*OnlineLocalParty)(nil)
+	_ fmt.Stringer = (*OnlineLocalParty)(nil)
+)
+
+type (
+	// OnlineLocalParty turns a presignature produced by NewPreSigningLocalParty into a signature in one round.
+	OnlineLocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		preSig  *PreSignatureData
+		temp    localTempData
+		data    *common.SignatureData
+		journal *tss.SessionJournal
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *common.SignatureData
+	}
+)
+
+// NewOnlineLocalParty returns a party that signs msg with preSig. The presignature is consumed when the party is started,
+// and Start() fails if preSig was produced for another set of parties or has already been consumed.
+func NewOnlineLocalParty(
+	msg *big.Int,
+	params *tss.Parameters,
+	preSig *PreSignatureData,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &OnlineLocalParty{
+		params: params,
+		preSig: preSig,
+		temp:   localTempData{},
+		data:   &common.SignatureData{},
+		out:    out,
+		end:    end,
+	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
+	// msgs init
+	p.temp.signOnlineMessages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.m = msg
+	if len(fullBytesLen) > 0 {
+		p.temp.fullBytesLen = fullBytesLen[0]
+	} else {
+		p.temp.fullBytesLen = 0
+	}
+	return p
+}
+
+func (p *OnlineLocalParty) FirstRound() tss.Round {
+	return newOnlineRound(p.params, p.preSig, p.data, &p.temp, p.out, p.end, p.journal)
+}
+
+func (p *OnlineLocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		if !p.preSig.validatePublic() {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidState, "the presignature is invalid"))
+		}
+		if !p.preSig.IsBoundTo(p.params.Parties().IDs(), p.PartyID()) {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidParameters, "the presignature does not match the set of signing parties"))
+		}
+		// the presignature of a restored party was consumed before its snapshot, which holds the si it sent
+		if kept := p.journal.Kept(); kept != nil {
+			p.temp.si, p.temp.bigR = new(big.Int).SetBytes(kept), p.preSig.R
+			return nil
+		}
+		k, sigma, err := p.preSig.Consume()
+		if err != nil {
+			return round.WrapError(err)
+		}
+		p.temp.k, p.temp.sigma = k, sigma
+		p.temp.bigR = p.preSig.R
+		return nil
+	})
+}
+
+func (p *OnlineLocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty
+func (p *OnlineLocalParty) wipe(finished bool) {
+	p.temp.wipe(finished)
+	p.journal.Wipe()
+}
+
+func (p *OnlineLocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *OnlineLocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *OnlineLocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *OnlineLocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignOnlineMessage:
+		p.temp.signOnlineMessages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
+	return true, nil
+}
+
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *OnlineLocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *SignOnlineMessage:
+		return 1
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *OnlineLocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, OnlineSessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The presignature was consumed before the snapshot and is not consumed
+// again: the party sends the si of the snapshot once more, and fails rather than send an si that differs from it.
+func (p *OnlineLocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, OnlineSessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *OnlineLocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), bigIntBytes(p.temp.m)}
+	if p.preSig.validatePublic() {
+		in = append(in, p.preSig.R.X().Bytes(), p.preSig.R.Y().Bytes())
+		for j := range p.preSig.Ks {
+			in = append(in, p.preSig.BigRBars[j].X().Bytes(), p.preSig.BigRBars[j].Y().Bytes())
+			in = append(in, p.preSig.BigSs[j].X().Bytes(), p.preSig.BigSs[j].Y().Bytes())
+		}
+	}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
+func (p *OnlineLocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *OnlineLocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
@@ -0,0 +1,149 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// the online round signs the message with a presignature: si = m * ki + r * σi
+func newOnlineRound(params *tss.Parameters, preSig *PreSignatureData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, journal *tss.SessionJournal) tss.Round {
+	return &onlineRound{
+		&base{params, nil, data, temp, out, end, nil, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+		preSig,
+	}
+}
+
+func (round *onlineRound) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
+	}
+
+	// Spec requires calculate H(M) here,
+	// but considered different blockchain use different hash function we accept the converted big.Int
+	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
+	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
+	if round.temp.m == nil || round.temp.m.Cmp(round.Params().Group().Order()) >= 0 {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidParameters, "hashed message is not valid"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	modN := common.ModInt(round.Params().Group().Order())
+	R := round.temp.bigR
+	rx := R.X()
+	ry := R.Y()
+	// a restored session has no k_i and sigma_i, as the presignature was consumed, and re-sends the si it kept
+	si := round.temp.si
+	if si == nil {
+		si = modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(rx, round.temp.sigma))
+
+		// security: k_i and sigma_i are no longer needed
+		common.WipeBigInts(round.temp.k, round.temp.sigma)
+		round.temp.k, round.temp.sigma = nil, nil
+		if round.journal != nil {
+			round.journal.Keep(si.Bytes())
+		}
+	}
+
+	round.temp.si = si
+	round.temp.rx = rx
+	round.temp.ry = ry
+
+	i := round.PartyID().Index
+	msg := NewSignOnlineMessage(round.PartyID(), si)
+	round.temp.signOnlineMessages[i] = msg
+	if err := round.send(msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *onlineRound) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signOnlineMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *onlineRound) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignOnlineMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *onlineRound) NextRound() tss.Round {
+	round.started = false
+	return &onlineFinalization{round}
+}
+
+// ----- //
+
+func (round *onlineFinalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	g := round.Params().Group()
+	modN := common.ModInt(g.Order())
+	r := new(big.Int).Mod(round.temp.rx, g.Order())
+
+	// each sj must be m * kj + r * σj, that is R^sj = (R^kj)^m * (R^σj)^r, which the presigning checked to add up to
+	// a valid signature
+	sumS := big.NewInt(0)
+	culprits := make([]*tss.PartyID, 0, len(round.Parties().IDs()))
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		sj := round.temp.si
+		if j != round.PartyID().Index {
+			sj = round.temp.signOnlineMessages[j].Content().(*SignOnlineMessage).UnmarshalS()
+		}
+		rBar, bigS := round.preSig.BigRBars[j], round.preSig.BigSs[j]
+		e := scalarMult(g, rBar.Point(), round.temp.m)
+		e.Add(e, scalarMult(g, bigS.Point(), r))
+		if !scalarMult(g, round.temp.bigR.Point(), sj).Equal(e) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		sumS = modN.Add(sumS, sj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidSignature, "the signature shares do not match the presignature"), culprits...)
+	}
+	return round.finalizeSignature(sumS, round.temp.rx, round.temp.ry, round.preSig.ECDSAPub)
+}
+
+func (round *onlineFinalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *onlineFinalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *onlineFinalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -7,29 +7,29 @@
 package signing
 
 import (
-	"crypto/elliptic"
-	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 // PrepareForSigning(), GG18Spec (11) Fig. 14
-func PrepareForSigning(ec elliptic.Curve, i, pax int, xi *big.Int, ks []*big.Int, bigXs []*crypto.ECPoint) (wi *big.Int, bigWs []*crypto.ECPoint) {
-	modQ := common.ModInt(ec.Params().N)
+func PrepareForSigning(g group.AffineGroup, i, pax int, xi *big.Int, ks []*big.Int, bigXs []*crypto.ECPoint) (wi *big.Int, bigWs []*crypto.ECPoint) {
+	modQ := common.ModInt(g.Order())
 	if len(ks) != len(bigXs) {
-		panic(fmt.Errorf("PrepareForSigning: len(ks) != len(bigXs) (%d != %d)", len(ks), len(bigXs)))
+		panic(tss.Errorf(tss.ErrInvalidParameters, "PrepareForSigning: len(ks) != len(bigXs) (%d != %d)", len(ks), len(bigXs)))
 	}
 	if len(ks) != pax {
-		panic(fmt.Errorf("PrepareForSigning: len(ks) != pax (%d != %d)", len(ks), pax))
+		panic(tss.Errorf(tss.ErrInvalidParameters, "PrepareForSigning: len(ks) != pax (%d != %d)", len(ks), pax))
 	}
 	if len(ks) <= i {
-		panic(fmt.Errorf("PrepareForSigning: len(ks) <= i (%d <= %d)", len(ks), i))
+		panic(tss.Errorf(tss.ErrInvalidParameters, "PrepareForSigning: len(ks) <= i (%d <= %d)", len(ks), i))
 	}
 
 	// 2-4.
-	wi = xi
+	wi = new(big.Int).Set(xi) // wi is wiped once it is used, so it must not share xi
 	for j := 0; j < pax; j++ {
 		if j == i {
 			continue
@@ -37,7 +37,7 @@ func PrepareForSigning(ec elliptic.Curve, i, pax int, xi *big.Int, ks []*big.Int
 		ksj := ks[j]
 		ksi := ks[i]
 		if ksj.Cmp(ksi) == 0 {
-			panic(fmt.Errorf("index of two parties are equal"))
+			panic(tss.Errorf(tss.ErrInvalidParameters, "index of two parties are equal"))
 		}
 		// big.Int Div is calculated as: a/b = a * modInv(b,q)
 		coef := modQ.Mul(ks[j], modQ.ModInverse(new(big.Int).Sub(ksj, ksi)))
@@ -55,7 +55,7 @@ func PrepareForSigning(ec elliptic.Curve, i, pax int, xi *big.Int, ks []*big.Int
 			ksc := ks[c]
 			ksj := ks[j]
 			if ksj.Cmp(ksc) == 0 {
-				panic(fmt.Errorf("index of two parties are equal"))
+				panic(tss.Errorf(tss.ErrInvalidParameters, "index of two parties are equal"))
 			}
 			// big.Int Div is calculated as: a/b = a * modInv(b,q)
 			iota := modQ.Mul(ksc, modQ.ModInverse(new(big.Int).Sub(ksc, ksj)))
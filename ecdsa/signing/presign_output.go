@@ -0,0 +1,172 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+	"time"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// preSignRound5 replaces round 5 when presigning: it computes R and broadcasts R^ki and R^σi, as in GG20, so that the
+// presignature is checked against the public key before it is output and each si can be checked when signing
+func (round *preSignRound5) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
+	}
+	round.number = 5
+	round.started = true
+	round.resetOK()
+
+	R, rErr := round.computeR()
+	if rErr != nil {
+		return rErr
+	}
+	round.temp.bigR = R
+
+	i := round.PartyID().Index
+	ContextI := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(i)))
+	bigRBar, bigS := R.ScalarMult(round.temp.k), R.ScalarMult(round.temp.sigma)
+	rBarProof, err := schnorr.NewZKProofWithBase(ContextI, round.temp.k, R, bigRBar, round.Rand())
+	if err != nil {
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "NewZKProofWithBase(k, bigRBar)")))
+	}
+	sProof, err := schnorr.NewZKProofWithBase(ContextI, round.temp.sigma, R, bigS, round.Rand())
+	if err != nil {
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "NewZKProofWithBase(sigma, bigS)")))
+	}
+	round.temp.bigRBars[i], round.temp.bigSs[i] = bigRBar, bigS
+
+	msg := NewSignPreSignMessage(round.PartyID(), bigRBar, bigS, rBarProof, sProof)
+	round.temp.signPreSignMessages[i] = msg
+	if err := round.send(msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *preSignRound5) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signPreSignMessages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *preSignRound5) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignPreSignMessage); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *preSignRound5) NextRound() tss.Round {
+	round.started = false
+	return &preSignOutput{round}
+}
+
+// ----- //
+
+// preSignOutput checks that ΣR^kj = g and ΣR^σj = y, which hold only if R = g^(1/k) and Σσj = k * x, and outputs the
+// presignature. No share of a signature is revealed before this check.
+func (round *preSignOutput) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
+	}
+	round.number = 6
+	round.started = true
+	round.resetOK()
+
+	ec := round.Params().Group()
+	R := round.temp.bigR
+	for j, Pj := range round.Parties().IDs() {
+		round.ok[j] = true
+		if j == round.PartyID().Index {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		msg := round.temp.signPreSignMessages[j].Content().(*SignPreSignMessage)
+		bigRBar, err := msg.UnmarshalBigRBar(ec)
+		if err != nil {
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "NewECPoint(bigRBarJ)")), Pj)
+		}
+		bigS, err := msg.UnmarshalBigS(ec)
+		if err != nil {
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "NewECPoint(bigSJ)")), Pj)
+		}
+		rBarProof, err := msg.UnmarshalRBarProof(ec)
+		if err != nil {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to unmarshal bigRBar proof"), Pj)
+		}
+		sProof, err := msg.UnmarshalSProof(ec)
+		if err != nil {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to unmarshal bigS proof"), Pj)
+		}
+		started := time.Now()
+		ok := rBarProof.VerifyWithBase(ContextJ, R, bigRBar) && sProof.VerifyWithBase(ContextJ, R, bigS)
+		round.observeProof("ZKProof", Pj, ok, started)
+		if !ok {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to prove bigRBar and bigS"), Pj)
+		}
+		round.temp.bigRBars[j], round.temp.bigSs[j] = bigRBar, bigS
+	}
+
+	// the sums are taken in the group, as a partial sum may be the identity
+	sumR, sumS := ec.NewPoint(), ec.NewPoint()
+	for j := range round.Parties().IDs() {
+		sumR.Add(sumR, round.temp.bigRBars[j].Point())
+		sumS.Add(sumS, round.temp.bigSs[j].Point())
+	}
+	if !sumR.Equal(ec.Generator()) || !sumS.Equal(round.key.ECDSAPub.Point()) {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidSignature, "the presignature does not match the public key"))
+	}
+
+	preSig := &PreSignatureData{
+		Ks:       round.Parties().IDs().Keys(),
+		ShareID:  round.PartyID().KeyInt(),
+		R:        R,
+		BigRBars: append([]*crypto.ECPoint(nil), round.temp.bigRBars...),
+		BigSs:    append([]*crypto.ECPoint(nil), round.temp.bigSs...),
+		KI:       new(big.Int).Set(round.temp.k),
+		SigmaI:   new(big.Int).Set(round.temp.sigma),
+		ECDSAPub: round.key.ECDSAPub,
+	}
+
+	// security: the presignature holds copies of k_i and sigma_i
+	common.WipeBigInts(round.temp.w, round.temp.k, round.temp.sigma)
+	round.temp.w, round.temp.k, round.temp.sigma = nil, nil, nil
+
+	round.preSigEnd <- preSig
+	return nil
+}
+
+func (round *preSignOutput) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *preSignOutput) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *preSignOutput) NextRound() tss.Round {
+	return nil // finished!
+}
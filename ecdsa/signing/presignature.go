@@ -0,0 +1,112 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var (
+	ErrPreSignatureConsumed = tss.Errorf(tss.ErrInvalidState, "the presignature has already been consumed")
+)
+
+type (
+	// PreSignatureData is one party's output of the presigning protocol: rounds 1-4 of GG18 signing run without the
+	// message, followed by the GG20 check that R^k and R^σ of all parties add up to g and the public key.
+	// It is bound to the signer set and key it was produced for and is consumed by NewOnlineLocalParty.
+	//
+	// A presignature MUST be used to sign one message only: signing two messages with the same presignature reveals
+	// the key share. Consume() wipes the secret shares so that an in-memory presignature cannot be used twice;
+	// callers that persist presignatures must also delete the stored copy before starting the online party.
+	PreSignatureData struct {
+		// the signing parties' keys, in the order of the sorted party IDs
+		Ks      []*big.Int
+		ShareID *big.Int
+
+		// R = Γ^(θ^-1), the nonce point of the signature
+		R *crypto.ECPoint
+		// R^kj and R^σj of every signing party, in the order of Ks, which the online round checks each sj against
+		BigRBars, BigSs []*crypto.ECPoint
+		// ki and σi (additive shares of k and k * x)
+		KI, SigmaI *big.Int
+
+		// used for signature verification
+		ECDSAPub *crypto.ECPoint
+
+		mtx sync.Mutex
+	}
+)
+
+// ValidateBasic checks that the presignature is complete and has not been consumed
+func (preSig *PreSignatureData) ValidateBasic() bool {
+	return preSig.validatePublic() && !preSig.IsConsumed()
+}
+
+// validatePublic checks the public part of the presignature, which a restored online session still needs once the
+// secret shares have been consumed
+func (preSig *PreSignatureData) validatePublic() bool {
+	return preSig != nil &&
+		preSig.ShareID != nil &&
+		preSig.R != nil &&
+		preSig.R.ValidateBasic() &&
+		preSig.ECDSAPub != nil &&
+		preSig.ECDSAPub.ValidateBasic() &&
+		0 < len(preSig.Ks) &&
+		validPoints(preSig.BigRBars, len(preSig.Ks)) &&
+		validPoints(preSig.BigSs, len(preSig.Ks))
+}
+
+func validPoints(points []*crypto.ECPoint, n int) bool {
+	if len(points) != n {
+		return false
+	}
+	for _, point := range points {
+		if point == nil || !point.ValidateBasic() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsConsumed returns true once the secret shares of the presignature have been handed out by Consume()
+func (preSig *PreSignatureData) IsConsumed() bool {
+	preSig.mtx.Lock()
+	defer preSig.mtx.Unlock()
+	return preSig.KI == nil || preSig.SigmaI == nil
+}
+
+// Consume returns the secret shares of the presignature and wipes them from preSig.
+// It returns ErrPreSignatureConsumed if it is called more than once.
+func (preSig *PreSignatureData) Consume() (ki, sigmaI *big.Int, err error) {
+	preSig.mtx.Lock()
+	defer preSig.mtx.Unlock()
+	if preSig.KI == nil || preSig.SigmaI == nil {
+		return nil, nil, ErrPreSignatureConsumed
+	}
+	ki, sigmaI = new(big.Int).Set(preSig.KI), new(big.Int).Set(preSig.SigmaI)
+	common.WipeBigInts(preSig.KI, preSig.SigmaI)
+	preSig.KI, preSig.SigmaI = nil, nil
+	return
+}
+
+// IsBoundTo returns true if the presignature was produced by the party `self` for the set of parties `ids`
+func (preSig *PreSignatureData) IsBoundTo(ids tss.SortedPartyIDs, self *tss.PartyID) bool {
+	if preSig == nil || len(preSig.Ks) != len(ids) {
+		return false
+	}
+	for j, id := range ids.Keys() {
+		if preSig.Ks[j] == nil || preSig.Ks[j].Cmp(id) != 0 {
+			return false
+		}
+	}
+	return preSig.ShareID != nil && preSig.ShareID.Cmp(self.KeyInt()) == 0
+}
@@ -7,8 +7,6 @@
 package signing
 
 import (
-	"errors"
-	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -22,39 +20,43 @@ import (
 var zero = big.NewInt(0)
 
 // round 1 represents round 1 of the signing part of the GG18 ECDSA TSS spec (Gennaro, Goldfeder; 2018)
-func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, preSigEnd chan<- *PreSignatureData, journal *tss.SessionJournal) tss.Round {
 	return &round1{
-		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, key, data, temp, out, end, preSigEnd, make([]bool, len(params.Parties().IDs())), false, 1, journal},
 	}
 }
 
 func (round *round1) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 
 	// Spec requires calculate H(M) here,
 	// but considered different blockchain use different hash function we accept the converted big.Int
 	// if this big.Int is not belongs to Zq, the client might not comply with common rule (for ECDSA):
 	// https://github.com/btcsuite/btcd/blob/c26ffa870fd817666a857af1bf6498fabba1ffe3/btcec/signature.go#L263
-	if round.temp.m.Cmp(round.Params().EC().Params().N) >= 0 {
-		return round.WrapError(errors.New("hashed message is not valid"))
+	// the message is not known yet when presigning
+	if round.preSigEnd == nil && round.temp.m.Cmp(round.Params().Group().Order()) >= 0 {
+		return round.WrapError(tss.Errorf(tss.ErrInvalidParameters, "hashed message is not valid"))
 	}
 
 	round.number = 1
 	round.started = true
 	round.resetOK()
-	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	if round.temp.ssidNonce == nil {
+		// a batch sets the index of the instance as the nonce
+		round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	}
 	ssid, err := round.getSSID()
 	if err != nil {
-		return round.WrapError(err)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err))
 	}
 	round.temp.ssid = ssid
 
-	k := common.GetRandomPositiveInt(round.Rand(), round.EC().Params().N)
-	gamma := common.GetRandomPositiveInt(round.Rand(), round.EC().Params().N)
+	k := common.GetRandomPositiveInt(round.Rand(), round.Group().Order())
+	gamma := common.GetRandomPositiveInt(round.Rand(), round.Group().Order())
 
-	pointGamma := crypto.ScalarBaseMult(round.Params().EC(), gamma)
+	pointGamma := crypto.ScalarBaseMult(round.Params().Group(), gamma)
 	cmt := commitments.NewHashCommitment(round.Rand(), pointGamma.X(), pointGamma.Y())
 	round.temp.k = k
 	round.temp.gamma = gamma
@@ -68,18 +70,22 @@ func (round *round1) Start() *tss.Error {
 		if j == i {
 			continue
 		}
-		cA, pi, err := mta.AliceInit(round.Params().EC(), round.key.PaillierPKs[i], k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.Rand())
+		cA, pi, err := mta.AliceInit(round.Params().Group(), round.key.PaillierPKs[i], k, round.key.NTildej[j], round.key.H1j[j], round.key.H2j[j], round.Rand())
 		if err != nil {
-			return round.WrapError(fmt.Errorf("failed to init mta: %v", err))
+			return round.WrapError(tss.Errorf(tss.ErrInternal, "failed to init mta: %v", err))
 		}
 		r1msg1 := NewSignRound1Message1(Pj, round.PartyID(), cA, pi)
 		round.temp.cis[j] = cA
-		round.out <- r1msg1
+		if err := round.send(r1msg1); err != nil {
+			return err
+		}
 	}
 
 	r1msg2 := NewSignRound1Message2(round.PartyID(), cmt.C)
 	round.temp.signRound1Message2s[i] = r1msg2
-	round.out <- r1msg2
+	if err := round.send(r1msg2); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -130,15 +136,15 @@ func (round *round1) prepare() error {
 		// adding the key derivation delta to the xi's
 		// Suppose x has shamir shares x_0,     x_1,     ..., x_n
 		// So x + D has shamir shares  x_0 + D, x_1 + D, ..., x_n + D
-		mod := common.ModInt(round.Params().EC().Params().N)
+		mod := common.ModInt(round.Params().Group().Order())
 		xi = mod.Add(round.temp.keyDerivationDelta, xi)
 		round.key.Xi = xi
 	}
 
 	if round.Threshold()+1 > len(ks) {
-		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+		return tss.Errorf(tss.ErrInvalidParameters, "t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
-	wi, bigWs := PrepareForSigning(round.Params().EC(), i, len(ks), xi, ks, bigXs)
+	wi, bigWs := PrepareForSigning(round.Params().Group(), i, len(ks), xi, ks, bigXs)
 
 	round.temp.w = wi
 	round.temp.bigWs = bigWs
@@ -7,7 +7,6 @@
 package signing
 
 import (
-	"errors"
 	"math/big"
 	"sync"
 
@@ -19,7 +18,7 @@ import (
 
 func (round *round2) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 2
 	round.started = true
@@ -36,18 +35,19 @@ func (round *round2) Start() *tss.Error {
 		if j == i {
 			continue
 		}
+		rand1, rand2 := round.forkRand(), round.forkRand()
 		// Bob_mid
 		go func(j int, Pj *tss.PartyID) {
 			defer wg.Done()
 			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
 			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
 			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed")), Pj)
 				return
 			}
 			beta, c1ji, _, pi1ji, err := mta.BobMid(
 				ContextI,
-				round.Parameters.EC(),
+				round.Parameters.Group(),
 				round.key.PaillierPKs[j],
 				rangeProofAliceJ,
 				round.temp.gamma,
@@ -58,14 +58,14 @@ func (round *round2) Start() *tss.Error {
 				round.key.NTildej[i],
 				round.key.H1j[i],
 				round.key.H2j[i],
-				round.Rand(),
+				rand1,
 			)
 			// should be thread safe as these are pre-allocated
 			round.temp.betas[j] = beta
 			round.temp.c1jis[j] = c1ji
 			round.temp.pi1jis[j] = pi1ji
 			if err != nil {
-				errChs <- round.WrapError(err, Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, err), Pj)
 			}
 		}(j, Pj)
 		// Bob_mid_wc
@@ -74,12 +74,12 @@ func (round *round2) Start() *tss.Error {
 			r1msg := round.temp.signRound1Message1s[j].Content().(*SignRound1Message1)
 			rangeProofAliceJ, err := r1msg.UnmarshalRangeProofAlice()
 			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed"), Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, errorspkg.Wrapf(err, "UnmarshalRangeProofAlice failed")), Pj)
 				return
 			}
 			v, c2ji, _, pi2ji, err := mta.BobMidWC(
 				ContextI,
-				round.Parameters.EC(),
+				round.Parameters.Group(),
 				round.key.PaillierPKs[j],
 				rangeProofAliceJ,
 				round.temp.w,
@@ -91,13 +91,13 @@ func (round *round2) Start() *tss.Error {
 				round.key.H1j[i],
 				round.key.H2j[i],
 				round.temp.bigWs[i],
-				round.Rand(),
+				rand2,
 			)
 			round.temp.vs[j] = v
 			round.temp.c2jis[j] = c2ji
 			round.temp.pi2jis[j] = pi2ji
 			if err != nil {
-				errChs <- round.WrapError(err, Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, err), Pj)
 			}
 		}(j, Pj)
 	}
@@ -109,7 +109,7 @@ func (round *round2) Start() *tss.Error {
 		culprits = append(culprits, err.Culprits()...)
 	}
 	if len(culprits) > 0 {
-		return round.WrapError(errors.New("failed to calculate Bob_mid or Bob_mid_wc"), culprits...)
+		return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to calculate Bob_mid or Bob_mid_wc"), culprits...)
 	}
 	// create and send messages
 	for j, Pj := range round.Parties().IDs() {
@@ -118,7 +118,9 @@ func (round *round2) Start() *tss.Error {
 		}
 		r2msg := NewSignRound2Message(
 			Pj, round.PartyID(), round.temp.c1jis[j], round.temp.pi1jis[j], round.temp.c2jis[j], round.temp.pi2jis[j])
-		round.out <- r2msg
+		if err := round.send(r2msg); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -7,9 +7,9 @@
 package signing
 
 import (
-	"errors"
 	"math/big"
 	"sync"
+	"time"
 
 	errorspkg "github.com/pkg/errors"
 
@@ -20,7 +20,7 @@ import (
 
 func (round *round3) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 3
 	round.started = true
@@ -45,12 +45,13 @@ func (round *round3) Start() *tss.Error {
 			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
 			proofBob, err := r2msg.UnmarshalProofBob()
 			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBob failed"), Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, errorspkg.Wrapf(err, "UnmarshalProofBob failed")), Pj)
 				return
 			}
+			started := time.Now()
 			alphaIj, err := mta.AliceEnd(
 				ContextJ,
-				round.Params().EC(),
+				round.Params().Group(),
 				round.key.PaillierPKs[i],
 				proofBob,
 				round.key.H1j[i],
@@ -59,23 +60,25 @@ func (round *round3) Start() *tss.Error {
 				new(big.Int).SetBytes(r2msg.GetC1()),
 				round.key.NTildej[i],
 				round.key.PaillierSK)
+			round.observeProof("ProofBob", Pj, err == nil, started)
 			alphas[j] = alphaIj
 			if err != nil {
-				errChs <- round.WrapError(err, Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, err), Pj)
 			}
 		}(j, Pj)
 		// Alice_end_wc
 		go func(j int, Pj *tss.PartyID) {
 			defer wg.Done()
 			r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
-			proofBobWC, err := r2msg.UnmarshalProofBobWC(round.Parameters.EC())
+			proofBobWC, err := r2msg.UnmarshalProofBobWC(round.Parameters.Group())
 			if err != nil {
-				errChs <- round.WrapError(errorspkg.Wrapf(err, "UnmarshalProofBobWC failed"), Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, errorspkg.Wrapf(err, "UnmarshalProofBobWC failed")), Pj)
 				return
 			}
+			started := time.Now()
 			uIj, err := mta.AliceEndWC(
 				ContextJ,
-				round.Params().EC(),
+				round.Params().Group(),
 				round.key.PaillierPKs[i],
 				proofBobWC,
 				round.temp.bigWs[j],
@@ -85,9 +88,10 @@ func (round *round3) Start() *tss.Error {
 				round.key.H1j[i],
 				round.key.H2j[i],
 				round.key.PaillierSK)
+			round.observeProof("ProofBobWC", Pj, err == nil, started)
 			us[j] = uIj
 			if err != nil {
-				errChs <- round.WrapError(err, Pj)
+				errChs <- round.WrapError(tss.WithKind(tss.ErrInvalidProof, err), Pj)
 			}
 		}(j, Pj)
 	}
@@ -100,10 +104,10 @@ func (round *round3) Start() *tss.Error {
 		culprits = append(culprits, err.Culprits()...)
 	}
 	if len(culprits) > 0 {
-		return round.WrapError(errors.New("failed to calculate Alice_end or Alice_end_wc"), culprits...)
+		return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to calculate Alice_end or Alice_end_wc"), culprits...)
 	}
 
-	modN := common.ModInt(round.Params().EC().Params().N)
+	modN := common.ModInt(round.Params().Group().Order())
 	thelta := modN.Mul(round.temp.k, round.temp.gamma)
 	sigma := modN.Mul(round.temp.k, round.temp.w)
 
@@ -119,7 +123,9 @@ func (round *round3) Start() *tss.Error {
 	round.temp.sigma = sigma
 	r3msg := NewSignRound3Message(round.PartyID(), thelta)
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	if err := round.send(r3msg); err != nil {
+		return err
+	}
 
 	return nil
 }
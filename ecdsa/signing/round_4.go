@@ -7,7 +7,6 @@
 package signing
 
 import (
-	"errors"
 	"math/big"
 
 	errors2 "github.com/pkg/errors"
@@ -19,7 +18,7 @@ import (
 
 func (round *round4) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 4
 	round.started = true
@@ -28,7 +27,7 @@ func (round *round4) Start() *tss.Error {
 	theta := *round.temp.theta
 	thetaInverse := &theta
 
-	modN := common.ModInt(round.Params().EC().Params().N)
+	modN := common.ModInt(round.Params().Group().Order())
 
 	for j := range round.Parties().IDs() {
 		if j == round.PartyID().Index {
@@ -45,12 +44,14 @@ func (round *round4) Start() *tss.Error {
 	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
 	piGamma, err := schnorr.NewZKProof(ContextI, round.temp.gamma, round.temp.pointGamma, round.Rand())
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "NewZKProof(gamma, bigGamma)"))
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "NewZKProof(gamma, bigGamma)")))
 	}
 	round.temp.thetaInverse = thetaInverse
 	r4msg := NewSignRound4Message(round.PartyID(), round.temp.deCommit, piGamma)
 	round.temp.signRound4Messages[round.PartyID().Index] = r4msg
-	round.out <- r4msg
+	if err := round.send(r4msg); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -79,5 +80,8 @@ func (round *round4) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round4) NextRound() tss.Round {
 	round.started = false
+	if round.preSigEnd != nil {
+		return &preSignRound5{round}
+	}
 	return &round5{round}
 }
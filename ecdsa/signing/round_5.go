@@ -7,8 +7,8 @@
 package signing
 
 import (
-	"errors"
 	"math/big"
+	"time"
 
 	errors2 "github.com/pkg/errors"
 
@@ -20,69 +20,43 @@ import (
 
 func (round *round5) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 5
 	round.started = true
 	round.resetOK()
 
-	R := round.temp.pointGamma
-	for j, Pj := range round.Parties().IDs() {
-		if j == round.PartyID().Index {
-			continue
-		}
-		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
-		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
-		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
-		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
-		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
-		ok, bigGammaJ := cmtDeCmt.DeCommit()
-		if !ok || len(bigGammaJ) != 2 {
-			return round.WrapError(errors.New("commitment verify failed"), Pj)
-		}
-		bigGammaJPoint, err := crypto.NewECPoint(round.Params().EC(), bigGammaJ[0], bigGammaJ[1])
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigGammaJ)"), Pj)
-		}
-		proof, err := r4msg.UnmarshalZKProof(round.Params().EC())
-		if err != nil {
-			return round.WrapError(errors.New("failed to unmarshal bigGamma proof"), Pj)
-		}
-		ok = proof.Verify(ContextJ, bigGammaJPoint)
-		if !ok {
-			return round.WrapError(errors.New("failed to prove bigGamma"), Pj)
-		}
-		R, err = R.Add(bigGammaJPoint)
-		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "R.Add(bigGammaJ)"), Pj)
-		}
+	R, rErr := round.computeR()
+	if rErr != nil {
+		return rErr
 	}
-
-	R = R.ScalarMult(round.temp.thetaInverse)
-	N := round.Params().EC().Params().N
+	N := round.Params().Group().Order()
 	modN := common.ModInt(N)
 	rx := R.X()
 	ry := R.Y()
 	si := modN.Add(modN.Mul(round.temp.m, round.temp.k), modN.Mul(rx, round.temp.sigma))
 
-	// clear temp.w and temp.k from memory, lint ignore
-	round.temp.w = zero
-	round.temp.k = zero
+	// security: w_i and sigma_i are no longer needed
+	// temp.k is kept until round 9 in case it has to be revealed to identify a cheating party
+	common.WipeBigInts(round.temp.w, round.temp.sigma)
+	round.temp.w, round.temp.sigma = nil, nil
 
 	li := common.GetRandomPositiveInt(round.Rand(), N)  // li
 	roI := common.GetRandomPositiveInt(round.Rand(), N) // pi
 	rToSi := R.ScalarMult(si)
-	liPoint := crypto.ScalarBaseMult(round.Params().EC(), li)
-	bigAi := crypto.ScalarBaseMult(round.Params().EC(), roI)
+	liPoint := crypto.ScalarBaseMult(round.Params().Group(), li)
+	bigAi := crypto.ScalarBaseMult(round.Params().Group(), roI)
 	bigVi, err := rToSi.Add(liPoint)
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "rToSi.Add(li)"))
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "rToSi.Add(li)")))
 	}
 
 	cmt := commitments.NewHashCommitment(round.Rand(), bigVi.X(), bigVi.Y(), bigAi.X(), bigAi.Y())
 	r5msg := NewSignRound5Message(round.PartyID(), cmt.C)
 	round.temp.signRound5Messages[round.PartyID().Index] = r5msg
-	round.out <- r5msg
+	if err := round.send(r5msg); err != nil {
+		return err
+	}
 
 	round.temp.li = li
 	round.temp.bigAi = bigAi
@@ -97,6 +71,48 @@ func (round *round5) Start() *tss.Error {
 	return nil
 }
 
+// computeR decommits the Γj of the other parties and returns R = (ΣΓj)^(θ^-1)
+func (round *round4) computeR() (*crypto.ECPoint, *tss.Error) {
+	R := round.temp.pointGamma
+	round.temp.bigGammas[round.PartyID().Index] = round.temp.pointGamma
+	for j, Pj := range round.Parties().IDs() {
+		if j == round.PartyID().Index {
+			continue
+		}
+		ContextJ := common.AppendBigIntToBytesSlice(round.temp.ssid, big.NewInt(int64(j)))
+		r1msg2 := round.temp.signRound1Message2s[j].Content().(*SignRound1Message2)
+		r4msg := round.temp.signRound4Messages[j].Content().(*SignRound4Message)
+		SCj, SDj := r1msg2.UnmarshalCommitment(), r4msg.UnmarshalDeCommitment()
+		cmtDeCmt := commitments.HashCommitDecommit{C: SCj, D: SDj}
+		ok, bigGammaJ := cmtDeCmt.DeCommit()
+		if !ok || len(bigGammaJ) != 2 {
+			return nil, round.WrapError(tss.Errorf(tss.ErrBadCommitment, "commitment verify failed"), Pj)
+		}
+		bigGammaJPoint, err := crypto.NewECPoint(round.Params().Group(), bigGammaJ[0], bigGammaJ[1])
+		if err != nil {
+			return nil, round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "NewECPoint(bigGammaJ)")), Pj)
+		}
+		proof, err := r4msg.UnmarshalZKProof(round.Params().Group())
+		if err != nil {
+			return nil, round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to unmarshal bigGamma proof"), Pj)
+		}
+		started := time.Now()
+		ok = proof.Verify(ContextJ, bigGammaJPoint)
+		round.observeProof("ZKProof", Pj, ok, started)
+		if !ok {
+			return nil, round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to prove bigGamma"), Pj)
+		}
+		round.temp.bigGammas[j] = bigGammaJPoint
+		R, err = R.Add(bigGammaJPoint)
+		if err != nil {
+			return nil, round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "R.Add(bigGammaJ)")), Pj)
+		}
+	}
+
+	R = R.ScalarMult(round.temp.thetaInverse)
+	return R, nil
+}
+
 func (round *round5) Update() (bool, *tss.Error) {
 	ret := true
 	for j, msg := range round.temp.signRound5Messages {
@@ -7,7 +7,6 @@
 package signing
 
 import (
-	"errors"
 	"math/big"
 
 	errors2 "github.com/pkg/errors"
@@ -18,7 +17,7 @@ import (
 
 func (round *round6) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 6
 	round.started = true
@@ -28,16 +27,18 @@ func (round *round6) Start() *tss.Error {
 	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
 	piAi, err := schnorr.NewZKProof(ContextI, round.temp.roi, round.temp.bigAi, round.Rand())
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "NewZKProof(roi, bigAi)"))
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "NewZKProof(roi, bigAi)")))
 	}
 	piV, err := schnorr.NewZKVProof(ContextI, round.temp.bigVi, round.temp.bigR, round.temp.si, round.temp.li, round.Rand())
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "NewZKVProof(bigVi, bigR, si, li)"))
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "NewZKVProof(bigVi, bigR, si, li)")))
 	}
 
 	r6msg := NewSignRound6Message(round.PartyID(), round.temp.DPower, piAi, piV)
 	round.temp.signRound6Messages[round.PartyID().Index] = r6msg
-	round.out <- r6msg
+	if err := round.send(r6msg); err != nil {
+		return err
+	}
 	return nil
 }
 
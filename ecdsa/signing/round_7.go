@@ -7,8 +7,8 @@
 package signing
 
 import (
-	"errors"
 	"math/big"
+	"time"
 
 	errors2 "github.com/pkg/errors"
 
@@ -20,14 +20,16 @@ import (
 
 func (round *round7) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 7
 	round.started = true
 	round.resetOK()
 
-	bigVjs := make([]*crypto.ECPoint, len(round.Parties().IDs()))
-	bigAjs := make([]*crypto.ECPoint, len(round.Parties().IDs()))
+	bigVjs := round.temp.bigVjs
+	bigAjs := round.temp.bigAjs
+	bigVjs[round.PartyID().Index] = round.temp.bigVi
+	bigAjs[round.PartyID().Index] = round.temp.bigAi
 	for j, Pj := range round.Parties().IDs() {
 		if j == round.PartyID().Index {
 			continue
@@ -39,54 +41,66 @@ func (round *round7) Start() *tss.Error {
 		cmtDeCmt := commitments.HashCommitDecommit{C: cj, D: dj}
 		ok, values := cmtDeCmt.DeCommit()
 		if !ok || len(values) != 4 {
-			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
+			return round.WrapError(tss.Errorf(tss.ErrBadCommitment, "de-commitment for bigVj and bigAj failed"), Pj)
 		}
 		bigVjX, bigVjY, bigAjX, bigAjY := values[0], values[1], values[2], values[3]
-		bigVj, err := crypto.NewECPoint(round.Params().EC(), bigVjX, bigVjY)
+		bigVj, err := crypto.NewECPoint(round.Params().Group(), bigVjX, bigVjY)
 		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigVj)"), Pj)
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "NewECPoint(bigVj)")), Pj)
 		}
 		bigVjs[j] = bigVj
-		bigAj, err := crypto.NewECPoint(round.Params().EC(), bigAjX, bigAjY)
+		bigAj, err := crypto.NewECPoint(round.Params().Group(), bigAjX, bigAjY)
 		if err != nil {
-			return round.WrapError(errors2.Wrapf(err, "NewECPoint(bigAj)"), Pj)
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "NewECPoint(bigAj)")), Pj)
 		}
 		bigAjs[j] = bigAj
-		pijA, err := r6msg.UnmarshalZKProof(round.Params().EC())
-		if err != nil || !pijA.Verify(ContextJ, bigAj) {
-			return round.WrapError(errors.New("schnorr verify for Aj failed"), Pj)
+		started := time.Now()
+		pijA, err := r6msg.UnmarshalZKProof(round.Params().Group())
+		ok = err == nil && pijA.Verify(ContextJ, bigAj)
+		round.observeProof("ZKProof", Pj, ok, started)
+		if !ok {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "schnorr verify for Aj failed"), Pj)
 		}
-		pijV, err := r6msg.UnmarshalZKVProof(round.Params().EC())
-		if err != nil || !pijV.Verify(ContextJ, bigVj, round.temp.bigR) {
-			return round.WrapError(errors.New("vverify for Vj failed"), Pj)
+		started = time.Now()
+		pijV, err := r6msg.UnmarshalZKVProof(round.Params().Group())
+		ok = err == nil && pijV.Verify(ContextJ, bigVj, round.temp.bigR)
+		round.observeProof("ZKVProof", Pj, ok, started)
+		if !ok {
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "vverify for Vj failed"), Pj)
 		}
 	}
 
-	modN := common.ModInt(round.Params().EC().Params().N)
-	AX, AY := round.temp.bigAi.X(), round.temp.bigAi.Y()
+	g := round.Params().Group()
+	modN := common.ModInt(g.Order())
 	minusM := modN.Sub(big.NewInt(0), round.temp.m)
-	gToMInvX, gToMInvY := round.Params().EC().ScalarBaseMult(minusM.Bytes())
 	minusR := modN.Sub(big.NewInt(0), round.temp.rx)
-	yToRInvX, yToRInvY := round.Params().EC().ScalarMult(round.key.ECDSAPub.X(), round.key.ECDSAPub.Y(), minusR.Bytes())
-	VX, VY := round.Params().EC().Add(gToMInvX, gToMInvY, yToRInvX, yToRInvY)
-	VX, VY = round.Params().EC().Add(VX, VY, round.temp.bigVi.X(), round.temp.bigVi.Y())
-
+	V := scalarBaseMult(g, minusM)
+	V.Add(V, scalarMult(g, round.key.ECDSAPub.Point(), minusR))
+	V.Add(V, round.temp.bigVi.Point())
+	A := round.temp.bigAi.Point()
 	for j := range round.Parties().IDs() {
 		if j == round.PartyID().Index {
 			continue
 		}
-		VX, VY = round.Params().EC().Add(VX, VY, bigVjs[j].X(), bigVjs[j].Y())
-		AX, AY = round.Params().EC().Add(AX, AY, bigAjs[j].X(), bigAjs[j].Y())
+		V.Add(V, bigVjs[j].Point())
+		A.Add(A, bigAjs[j].Point())
 	}
 
-	UiX, UiY := round.Params().EC().ScalarMult(VX, VY, round.temp.roi.Bytes())
-	TiX, TiY := round.Params().EC().ScalarMult(AX, AY, round.temp.li.Bytes())
-	round.temp.Ui = crypto.NewECPointNoCurveCheck(round.Params().EC(), UiX, UiY)
-	round.temp.Ti = crypto.NewECPointNoCurveCheck(round.Params().EC(), TiX, TiY)
-	cmt := commitments.NewHashCommitment(round.Rand(), UiX, UiY, TiX, TiY)
+	Ui, err := crypto.NewECPointFromGroupPoint(scalarMult(g, V, round.temp.roi))
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "Ui"))
+	}
+	Ti, err := crypto.NewECPointFromGroupPoint(scalarMult(g, A, round.temp.li))
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "Ti"))
+	}
+	round.temp.Ui, round.temp.Ti = Ui, Ti
+	cmt := commitments.NewHashCommitment(round.Rand(), Ui.X(), Ui.Y(), Ti.X(), Ti.Y())
 	r7msg := NewSignRound7Message(round.PartyID(), cmt.C)
 	round.temp.signRound7Messages[round.PartyID().Index] = r7msg
-	round.out <- r7msg
+	if err := round.send(r7msg); err != nil {
+		return err
+	}
 	round.temp.DTelda = cmt.D
 
 	return nil
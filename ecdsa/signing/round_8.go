@@ -7,14 +7,12 @@
 package signing
 
 import (
-	"errors"
-
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *round8) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 8
 	round.started = true
@@ -22,7 +20,9 @@ func (round *round8) Start() *tss.Error {
 
 	r8msg := NewSignRound8Message(round.PartyID(), round.temp.DTelda)
 	round.temp.signRound8Messages[round.PartyID().Index] = r8msg
-	round.out <- r8msg
+	if err := round.send(r8msg); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -7,24 +7,28 @@
 package signing
 
 import (
-	"errors"
+	errors2 "github.com/pkg/errors"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *round9) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 9
 	round.started = true
 	round.resetOK()
 
-	UX, UY := round.temp.Ui.X(), round.temp.Ui.Y()
-	TX, TY := round.temp.Ti.X(), round.temp.Ti.Y()
+	i := round.PartyID().Index
+	round.temp.bigUjs[i] = round.temp.Ui
+	round.temp.bigTjs[i] = round.temp.Ti
+	U, T := round.temp.Ui.Point(), round.temp.Ti.Point()
 	for j, Pj := range round.Parties().IDs() {
-		if j == round.PartyID().Index {
+		if j == i {
 			continue
 		}
 
@@ -33,26 +37,45 @@ func (round *round9) Start() *tss.Error {
 		cj, dj := r7msg.UnmarshalCommitment(), r8msg.UnmarshalDeCommitment()
 		cmt := commitments.HashCommitDecommit{C: cj, D: dj}
 		ok, values := cmt.DeCommit()
-		if !ok && len(values) != 4 {
-			return round.WrapError(errors.New("de-commitment for bigVj and bigAj failed"), Pj)
+		if !ok || len(values) != 4 {
+			return round.WrapError(tss.Errorf(tss.ErrBadCommitment, "de-commitment for bigVj and bigAj failed"), Pj)
 		}
-		UjX, UjY, TjX, TjY := values[0], values[1], values[2], values[3]
-		UX, UY = round.Params().EC().Add(UX, UY, UjX, UjY)
-		TX, TY = round.Params().EC().Add(TX, TY, TjX, TjY)
+		Uj, err := crypto.NewECPoint(round.Params().Group(), values[0], values[1])
+		if err != nil {
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "NewECPoint(Uj)")), Pj)
+		}
+		Tj, err := crypto.NewECPoint(round.Params().Group(), values[2], values[3])
+		if err != nil {
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "NewECPoint(Tj)")), Pj)
+		}
+		round.temp.bigUjs[j], round.temp.bigTjs[j] = Uj, Tj
+		U.Add(U, Uj.Point())
+		T.Add(T, Tj.Point())
 	}
-	if UX.Cmp(TX) != 0 || UY.Cmp(TY) != 0 {
-		return round.WrapError(errors.New("U doesn't equal T"), round.PartyID())
+	if !U.Equal(T) {
+		// si has not been revealed yet, so ki and the MtA openings can be revealed to find out who cheated
+		common.Logger.Warningf("party %s: U doesn't equal T, starting identification", round.PartyID())
+		return round.startIdentification()
 	}
+	// security: k_i is no longer needed
+	common.WipeBigInt(round.temp.k)
+	round.temp.k = nil
 
-	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si)
-	round.temp.signRound9Messages[round.PartyID().Index] = r9msg
-	round.out <- r9msg
+	r9msg := NewSignRound9Message(round.PartyID(), round.temp.si, round.temp.li)
+	round.temp.signRound9Messages[i] = r9msg
+	if err := round.send(r9msg); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (round *round9) Update() (bool, *tss.Error) {
+	msgs := round.temp.signRound9Messages
+	if round.temp.identifying {
+		msgs = round.temp.signIdentificationMessages
+	}
 	ret := true
-	for j, msg := range round.temp.signRound9Messages {
+	for j, msg := range msgs {
 		if round.ok[j] {
 			continue
 		}
@@ -66,6 +89,12 @@ func (round *round9) Update() (bool, *tss.Error) {
 }
 
 func (round *round9) CanAccept(msg tss.ParsedMessage) bool {
+	if round.temp.identifying {
+		if _, ok := msg.Content().(*SignIdentificationMessage); ok {
+			return msg.IsBroadcast()
+		}
+		return false
+	}
 	if _, ok := msg.Content().(*SignRound9Message); ok {
 		return msg.IsBroadcast()
 	}
@@ -74,5 +103,8 @@ func (round *round9) CanAccept(msg tss.ParsedMessage) bool {
 
 func (round *round9) NextRound() tss.Round {
 	round.started = false
+	if round.temp.identifying {
+		return &identification{round}
+	}
 	return &finalization{round}
 }
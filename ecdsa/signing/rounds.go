@@ -7,8 +7,9 @@
 package signing
 
 import (
-	"errors"
+	"io"
 	"math/big"
+	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
@@ -23,14 +24,16 @@ const (
 type (
 	base struct {
 		*tss.Parameters
-		key     *keygen.LocalPartySaveData
-		data    *common.SignatureData
-		temp    *localTempData
-		out     chan<- tss.Message
-		end     chan<- *common.SignatureData
-		ok      []bool // `ok` tracks parties which have been verified by Update()
-		started bool
-		number  int
+		key       *keygen.LocalPartySaveData
+		data      *common.SignatureData
+		temp      *localTempData
+		out       chan<- tss.Message
+		end       chan<- *common.SignatureData
+		preSigEnd chan<- *PreSignatureData // set when presigning
+		ok        []bool                   // `ok` tracks parties which have been verified by Update()
+		started   bool
+		number    int
+		journal   *tss.SessionJournal // set when session snapshots are enabled
 	}
 	round1 struct {
 		*base
@@ -62,6 +65,22 @@ type (
 	finalization struct {
 		*round9
 	}
+	identification struct {
+		*round9
+	}
+	preSignRound5 struct {
+		*round4
+	}
+	preSignOutput struct {
+		*preSignRound5
+	}
+	onlineRound struct {
+		*base
+		preSig *PreSignatureData
+	}
+	onlineFinalization struct {
+		*onlineRound
+	}
 )
 
 var (
@@ -75,6 +94,11 @@ var (
 	_ tss.Round = (*round8)(nil)
 	_ tss.Round = (*round9)(nil)
 	_ tss.Round = (*finalization)(nil)
+	_ tss.Round = (*identification)(nil)
+	_ tss.Round = (*preSignRound5)(nil)
+	_ tss.Round = (*preSignOutput)(nil)
+	_ tss.Round = (*onlineRound)(nil)
+	_ tss.Round = (*onlineFinalization)(nil)
 )
 
 // ----- //
@@ -87,6 +111,46 @@ func (round *base) RoundNumber() int {
 	return round.number
 }
 
+// Rand returns the random source of the round, which is the session journal when snapshots are enabled
+func (round *base) Rand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.Parameters.Rand()
+}
+
+// forkRand returns the random source of one of the goroutines of a round
+func (round *base) forkRand() io.Reader {
+	if round.journal != nil {
+		return round.journal.Fork()
+	}
+	return round.Parameters.Rand()
+}
+
+// observeProof reports the verification of a proof of prover that started at started
+func (round *base) observeProof(proof string, prover *tss.PartyID, valid bool, started time.Time) {
+	round.Observe(tss.ProofVerified{
+		Party:    round.PartyID(),
+		Task:     TaskName,
+		Round:    round.number,
+		Prover:   prover,
+		Proof:    proof,
+		Valid:    valid,
+		Duration: time.Since(started),
+	})
+}
+
+// send records msg in the session journal, which refuses it if a restored session diverges, then sends it
+func (round *base) send(msg tss.Message) *tss.Error {
+	if round.journal != nil {
+		if err := round.journal.Send(msg); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	round.out <- msg
+	return nil
+}
+
 // CanProceed is inherited by other rounds
 func (round *base) CanProceed() bool {
 	if !round.started {
@@ -128,11 +192,11 @@ func (round *base) resetOK() {
 
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
-	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().B, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
-	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                                                // parties
+	ssidList := []*big.Int{round.Group().Order(), crypto.Generator(round.Group()).X(), crypto.Generator(round.Group()).Y()} // group
+	ssidList = append(ssidList, round.Parties().IDs().Keys()...)                                                            // parties
 	BigXjList, err := crypto.FlattenECPoints(round.key.BigXj)
 	if err != nil {
-		return nil, round.WrapError(errors.New("read BigXj failed"), round.PartyID())
+		return nil, round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "read BigXj failed"), round.PartyID())
 	}
 	ssidList = append(ssidList, BigXjList...)                    // BigXj
 	ssidList = append(ssidList, round.key.NTildej...)            // NTilde
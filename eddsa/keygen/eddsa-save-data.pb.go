@@ -0,0 +1,219 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/eddsa-save-data.proto
+
+package keygen
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The canonical encoding of the LocalPartySaveData of the EdDSA TSS protocols. Points are encoded as their
+// flattened coordinates.
+type SaveDataMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Curve        string   `protobuf:"bytes,1,opt,name=curve,proto3" json:"curve,omitempty"`
+	Xi           []byte   `protobuf:"bytes,2,opt,name=xi,proto3" json:"xi,omitempty"`
+	ShareId      []byte   `protobuf:"bytes,3,opt,name=share_id,json=shareId,proto3" json:"share_id,omitempty"`
+	Ks           [][]byte `protobuf:"bytes,4,rep,name=ks,proto3" json:"ks,omitempty"`
+	BigXj        [][]byte `protobuf:"bytes,5,rep,name=big_xj,json=bigXj,proto3" json:"big_xj,omitempty"`
+	EddsaPub     [][]byte `protobuf:"bytes,6,rep,name=eddsa_pub,json=eddsaPub,proto3" json:"eddsa_pub,omitempty"`
+	RefreshEpoch uint64   `protobuf:"varint,7,opt,name=refresh_epoch,json=refreshEpoch,proto3" json:"refresh_epoch,omitempty"`
+	ChainCode    []byte   `protobuf:"bytes,8,opt,name=chain_code,json=chainCode,proto3" json:"chain_code,omitempty"`
+}
+
+func (x *SaveDataMessage) Reset() {
+	*x = SaveDataMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_eddsa_save_data_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SaveDataMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDataMessage) ProtoMessage() {}
+
+func (x *SaveDataMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_eddsa_save_data_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDataMessage.ProtoReflect.Descriptor instead.
+func (*SaveDataMessage) Descriptor() ([]byte, []int) {
+	return file_protob_eddsa_save_data_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SaveDataMessage) GetCurve() string {
+	if x != nil {
+		return x.Curve
+	}
+	return ""
+}
+
+func (x *SaveDataMessage) GetXi() []byte {
+	if x != nil {
+		return x.Xi
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetShareId() []byte {
+	if x != nil {
+		return x.ShareId
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetKs() [][]byte {
+	if x != nil {
+		return x.Ks
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetBigXj() [][]byte {
+	if x != nil {
+		return x.BigXj
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetEddsaPub() [][]byte {
+	if x != nil {
+		return x.EddsaPub
+	}
+	return nil
+}
+
+func (x *SaveDataMessage) GetRefreshEpoch() uint64 {
+	if x != nil {
+		return x.RefreshEpoch
+	}
+	return 0
+}
+
+func (x *SaveDataMessage) GetChainCode() []byte {
+	if x != nil {
+		return x.ChainCode
+	}
+	return nil
+}
+
+var File_protob_eddsa_save_data_proto protoreflect.FileDescriptor
+
+var file_protob_eddsa_save_data_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x65, 0x64, 0x64, 0x73, 0x61, 0x2d, 0x73,
+	0x61, 0x76, 0x65, 0x2d, 0x64, 0x61, 0x74, 0x61, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x1b,
+	0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x65,
+	0x64, 0x64, 0x73, 0x61, 0x2e, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x22, 0xda, 0x01, 0x0a, 0x0f,
+	0x53, 0x61, 0x76, 0x65, 0x44, 0x61, 0x74, 0x61, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x75, 0x72, 0x76, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x63, 0x75, 0x72, 0x76, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x78, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x78, 0x69, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x68, 0x61, 0x72, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x73, 0x68, 0x61, 0x72, 0x65, 0x49, 0x64,
+	0x12, 0x0e, 0x0a, 0x02, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x02, 0x6b, 0x73,
+	0x12, 0x15, 0x0a, 0x06, 0x62, 0x69, 0x67, 0x5f, 0x78, 0x6a, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0c,
+	0x52, 0x05, 0x62, 0x69, 0x67, 0x58, 0x6a, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x64, 0x64, 0x73, 0x61,
+	0x5f, 0x70, 0x75, 0x62, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x64, 0x64, 0x73,
+	0x61, 0x50, 0x75, 0x62, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x5f,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x72, 0x65, 0x66,
+	0x72, 0x65, 0x73, 0x68, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x42, 0x0e, 0x5a, 0x0c, 0x65, 0x64, 0x64, 0x73,
+	0x61, 0x2f, 0x6b, 0x65, 0x79, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_eddsa_save_data_proto_rawDescOnce sync.Once
+	file_protob_eddsa_save_data_proto_rawDescData = file_protob_eddsa_save_data_proto_rawDesc
+)
+
+func file_protob_eddsa_save_data_proto_rawDescGZIP() []byte {
+	file_protob_eddsa_save_data_proto_rawDescOnce.Do(func() {
+		file_protob_eddsa_save_data_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_eddsa_save_data_proto_rawDescData)
+	})
+	return file_protob_eddsa_save_data_proto_rawDescData
+}
+
+var file_protob_eddsa_save_data_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_eddsa_save_data_proto_goTypes = []interface{}{
+	(*SaveDataMessage)(nil), // 0: binance.tsslib.eddsa.keygen.SaveDataMessage
+}
+var file_protob_eddsa_save_data_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_eddsa_save_data_proto_init() }
+func file_protob_eddsa_save_data_proto_init() {
+	if File_protob_eddsa_save_data_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_eddsa_save_data_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SaveDataMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_eddsa_save_data_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_eddsa_save_data_proto_goTypes,
+		DependencyIndexes: file_protob_eddsa_save_data_proto_depIdxs,
+		MessageInfos:      file_protob_eddsa_save_data_proto_msgTypes,
+	}.Build()
+	File_protob_eddsa_save_data_proto = out.File
+	file_protob_eddsa_save_data_proto_rawDesc = nil
+	file_protob_eddsa_save_data_proto_goTypes = nil
+	file_protob_eddsa_save_data_proto_depIdxs = nil
+}
@@ -7,7 +7,7 @@
 package keygen
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -15,6 +15,12 @@ import (
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a keygen session in a keystore container
+	SessionSnapshotKind = "eddsa/keygen.Session"
 )
 
 // Implements Party
@@ -27,8 +33,9 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		temp localTempData
-		data LocalPartySaveData
+		temp    localTempData
+		data    LocalPartySaveData
+		journal *tss.SessionJournal
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -46,7 +53,8 @@ type (
 		localMessageStore
 
 		// temp data (thrown away after keygen)
-		ui            *big.Int // used for tests
+		ui            *big.Int // wiped once round 2 has proven the knowledge of it
+		chainCode     *big.Int
 		KGCs          []cmt.HashCommitment
 		vs            vss.Vs
 		shares        vss.Shares
@@ -66,13 +74,13 @@ func NewLocalParty(
 	partyCount := params.PartyCount()
 	data := NewLocalPartySaveData(partyCount)
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		temp:      localTempData{},
-		data:      data,
-		out:       out,
-		end:       end,
+		params: params,
+		temp:   localTempData{},
+		data:   data,
+		out:    out,
+		end:    end,
 	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
 	// msgs init
 	p.temp.kgRound1Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.kgRound2Message1s = make([]tss.ParsedMessage, partyCount)
@@ -84,13 +92,44 @@ func NewLocalParty(
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.data, &p.temp, p.out, p.end)
+	return newRound1(p.params, &p.data, &p.temp, p.out, p.end, p.journal)
 }
 
 func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
 	return tss.BaseStart(p, TaskName)
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The save data of a finished party is the result of the
+// keygen; otherwise xi is wiped too.
+func (p *LocalParty) wipe(finished bool) {
+	common.WipeBigInt(p.temp.ui)
+	for _, share := range p.temp.shares {
+		if share != nil {
+			common.WipeBigInt(share.Share)
+		}
+	}
+	// the chain code contribution is also the first committed value of the decommitment
+	common.WipeBigInt(p.temp.chainCode)
+	cmt.WipeDeCommitment(p.temp.deCommitPolyG)
+	p.temp.ui, p.temp.shares, p.temp.chainCode, p.temp.deCommitPolyG = nil, nil, nil, nil
+	if !finished {
+		common.WipeBigInt(p.data.Xi)
+		p.data.Xi = nil
+	}
+	p.journal.Wipe()
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -109,7 +148,7 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	}
 	// check that the message's "from index" will fit into the array
 	if maxFromIdx := p.params.PartyCount() - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with a sender index too great (%d <= %d)",
 			p.params.PartyCount(), msg.GetFrom().Index), msg.GetFrom())
 	}
 	return true, nil
@@ -135,9 +174,53 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
 	return true, nil
 }
 
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *KGRound1Message:
+		return 1
+	case *KGRound2Message1, *KGRound2Message2:
+		return 2
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session and must
+// be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), big.NewInt(int64(p.params.Threshold())).Bytes()}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
 // recovers a party's original index in the set of parties during keygen
 func (save LocalPartySaveData) OriginalIndex() (int, error) {
 	index := -1
@@ -150,7 +233,7 @@ func (save LocalPartySaveData) OriginalIndex() (int, error) {
 		break
 	}
 	if index < 0 {
-		return -1, errors.New("a party index could not be recovered from Ks")
+		return -1, tss.Errorf(tss.ErrInvalidSaveData, "a party index could not be recovered from Ks")
 	}
 	return index, nil
 }
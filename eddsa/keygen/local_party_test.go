@@ -7,23 +7,31 @@
 package keygen
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"runtime"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 	"github.com/ipfs/go-log"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
 )
 
 const (
@@ -61,7 +69,7 @@ func TestE2EConcurrentAndSaveFixtures(t *testing.T) {
 	// init the parties
 	for i := 0; i < len(pIDs); i++ {
 		var P *LocalParty
-		params := tss.NewParameters(tss.Edwards(), p2pCtx, pIDs[i], len(pIDs), threshold)
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), threshold)
 		if i < len(fixtures) {
 			P = NewLocalParty(params, outCh, endCh).(*LocalParty)
 		} else {
@@ -131,17 +139,16 @@ keygen:
 						}
 						pShares = append(pShares, shareStruct)
 					}
-					uj, err := pShares[:threshold+1].ReConstruct(tss.Edwards())
+					uj, err := pShares[:threshold+1].ReConstruct(group.Ed25519())
 					assert.NoError(t, err, "vss.ReConstruct should not throw error")
 
 					// uG test: u*G[j] == V[0]
-					assert.Equal(t, uj, Pj.temp.ui)
-					uG := crypto.ScalarBaseMult(tss.Edwards(), uj)
+					uG := crypto.ScalarBaseMult(group.Ed25519(), uj)
 					assert.True(t, uG.Equals(Pj.temp.vs[0]), "ensure u*G[j] == V_0")
 
 					// xj tests: BigXj == xj*G
 					xj := Pj.data.Xi
-					gXj := crypto.ScalarBaseMult(tss.Edwards(), xj)
+					gXj := crypto.ScalarBaseMult(group.Ed25519(), xj)
 					BigXj := Pj.data.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 
@@ -149,16 +156,16 @@ keygen:
 					{
 						badShares := pShares[:threshold]
 						badShares[len(badShares)-1].Share.Set(big.NewInt(0))
-						uj, err := pShares[:threshold].ReConstruct(tss.Edwards())
+						badUj, err := pShares[:threshold].ReConstruct(group.Ed25519())
 						assert.NoError(t, err)
-						assert.NotEqual(t, parties[j].temp.ui, uj)
-						BigXjX, BigXjY := tss.Edwards().ScalarBaseMult(uj.Bytes())
+						assert.NotEqual(t, uj, badUj)
+						BigXjX, BigXjY := group.Ed25519().Curve().ScalarBaseMult(badUj.Bytes())
 						assert.NotEqual(t, BigXjX, Pj.temp.vs[0].X())
 						assert.NotEqual(t, BigXjY, Pj.temp.vs[0].Y())
 					}
 					u = new(big.Int).Add(u, uj)
 				}
-				u = new(big.Int).Mod(u, tss.Edwards().Params().N)
+				u = new(big.Int).Mod(u, group.Ed25519().Order())
 				scalar := make([]byte, 0, 32)
 				copy(scalar, u.Bytes())
 
@@ -180,7 +187,7 @@ keygen:
 
 				// public key tests
 				assert.NotZero(t, u, "u should not be zero")
-				ourPkX, ourPkY := tss.Edwards().ScalarBaseMult(u.Bytes())
+				ourPkX, ourPkY := group.Ed25519().Curve().ScalarBaseMult(u.Bytes())
 				assert.Equal(t, pkX, ourPkX, "pkX should match expected pk derived from u")
 				assert.Equal(t, pkY, ourPkY, "pkY should match expected pk derived from u")
 				t.Log("Public key tests done.")
@@ -190,6 +197,12 @@ keygen:
 					assert.Equal(t, pkX, Pj.data.EDDSAPub.X())
 					assert.Equal(t, pkY, Pj.data.EDDSAPub.Y())
 				}
+
+				// make sure everyone has the same chain code
+				assert.Len(t, save.ChainCode, 32)
+				for _, Pj := range parties {
+					assert.Equal(t, save.ChainCode, Pj.data.ChainCode)
+				}
 				t.Log("Public key distribution test done.")
 
 				// test sign/verify
@@ -211,6 +224,343 @@ keygen:
 	}
 }
 
+func TestE2EOversizedChainCode(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(2)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+	outCh := make(chan tss.Message, 4*len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	for i := range pIDs {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), 1)
+		parties = append(parties, NewLocalParty(params, outCh, endCh).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// party 1 commits to a chain code share that is wider than the chain code of the key
+	var r1msg tss.ParsedMessage
+	for {
+		select {
+		case err := <-errCh:
+			assert.Equal(t, pIDs[0], err.Victim())
+			assert.Equal(t, tss.ErrBadShare, err.Kind(), err.Error())
+			if assert.Equal(t, 1, len(err.Culprits())) {
+				assert.Equal(t, pIDs[1], err.Culprits()[0])
+			}
+			return
+		case <-endCh:
+			t.Fatal("keygen must fail")
+		case msg := <-outCh:
+			if pMsg, ok := msg.(tss.ParsedMessage); ok && msg.GetFrom().Index == 1 && msg.IsBroadcast() {
+				switch pMsg.Content().(type) {
+				case *KGRound1Message:
+					// held back until the decommitment is known
+					r1msg = pMsg
+					continue
+				case *KGRound2Message2:
+					badR1msg, badR2msg := recommitChainCode(r1msg, pMsg, new(big.Int).Lsh(big.NewInt(1), chainCodeBitLen))
+					go func() {
+						test.SharedPartyUpdater(parties[0], badR1msg, errCh)
+						test.SharedPartyUpdater(parties[0], badR2msg, errCh)
+					}()
+					continue
+				}
+			}
+			if dest := msg.GetTo(); dest != nil {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+				continue
+			}
+			for _, P := range parties {
+				if P.PartyID().Index != msg.GetFrom().Index {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+			}
+		}
+	}
+}
+
+// recommitChainCode returns the round 1 and round 2 broadcasts of a party re-committed to another chain code share
+func recommitChainCode(r1msg, r2msg tss.ParsedMessage, chainCode *big.Int) (tss.ParsedMessage, tss.ParsedMessage) {
+	D := r2msg.Content().(*KGRound2Message2).UnmarshalDeCommitment()
+	D[1] = chainCode
+	cmt := cmt.NewHashCommitmentWithRandomness(D[0], D[1:]...)
+	r1 := proto.Clone(r1msg.Content()).(*KGRound1Message)
+	r1.Commitment = cmt.C.Bytes()
+	r2 := proto.Clone(r2msg.Content()).(*KGRound2Message2)
+	r2.DeCommitment = common.BigIntsToBytes(cmt.D)
+	meta := tss.MessageRouting{From: r1msg.GetFrom(), IsBroadcast: true}
+	return tss.NewMessage(meta, r1, tss.NewMessageWrapper(meta, r1)), tss.NewMessage(meta, r2, tss.NewMessageWrapper(meta, r2))
+}
+
+func TestE2EStartWithContext(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	parties, errCh, endCh := runWithContext(context.Background(), pIDs, -1, 0)
+	for range parties {
+		assert.Nil(t, <-errCh, "StartWithContext must return nil once the party has finished")
+	}
+	assert.Equal(t, len(parties), len(endCh))
+}
+
+func TestE2EStartWithContextRoundTimeout(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	silent := 1
+	parties, errCh, endCh := runWithContext(context.Background(), pIDs, silent, 3*time.Second)
+	for i := 0; i < len(parties)-1; i++ {
+		err := <-errCh
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.True(t, errors.Is(err, tss.ErrTimeout))
+		assert.Equal(t, 1, err.Round())
+		if assert.Equal(t, 1, len(err.Culprits())) {
+			assert.Equal(t, silent, err.Culprits()[0].Index, "the silent party must be named as the culprit")
+		}
+	}
+	assert.Equal(t, 0, len(endCh))
+	for i, P := range parties {
+		if i == silent {
+			continue
+		}
+		assert.False(t, P.Running())
+		assert.Nil(t, P.temp.shares, "the temp data must be cleared")
+		assert.Empty(t, P.WaitingFor())
+	}
+}
+
+func TestE2EStartWithContextCancel(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	silent := 0
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	parties, errCh, _ := runWithContext(ctx, pIDs, silent, 0)
+	for i := 0; i < len(parties)-1; i++ {
+		err := <-errCh
+		assert.Error(t, err)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Equal(t, tss.ErrTimeout, err.Kind())
+		if assert.Equal(t, 1, len(err.Culprits())) {
+			assert.Equal(t, silent, err.Culprits()[0].Index)
+		}
+	}
+	// messages that arrive after the abort are rejected with the same error
+	assert.Nil(t, parties[1].temp.kgRound1Messages, "the temp data must be cleared")
+	_, err := parties[1].Update(NewKGRound1Message(pIDs[2], cmt.HashCommitment(big.NewInt(1))))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestE2EStartWithContextWipesSecrets(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	params := tss.NewParameters(group.Ed25519(), tss.NewPeerContext(pIDs), pIDs[0], len(pIDs), testThreshold)
+	var P *LocalParty
+	var secrets [][]big.Word
+	params.SetObserver(tss.ObserverFunc(func(event tss.Event) {
+		// the party is locked while the event is reported
+		if e, ok := event.(tss.RoundStarted); ok && e.Round == 1 {
+			secrets = test.SecretWords(P.temp.ui, P.temp.shares[0].Share, P.temp.shares[1].Share)
+		}
+	}))
+	P = NewLocalParty(params, make(chan tss.Message, len(pIDs)), make(chan *LocalPartySaveData, 1)).(*LocalParty)
+
+	// the other parties never start, so the party is aborted in round 1
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err := P.StartWithContext(ctx)
+	if assert.Error(t, err) {
+		assert.Equal(t, tss.ErrTimeout, err.Kind())
+	}
+	if assert.NotEmpty(t, secrets) {
+		assert.True(t, test.Wiped(secrets), "the secrets of an aborted party must be wiped")
+	}
+}
+
+func TestE2EStartWithContextBadShare(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	updateErrCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	// no round timeout and no deadline: a failed round must return from StartWithContext on its own
+	ctx := context.Background()
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		parties = append(parties, NewLocalParty(params, outCh, endCh).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			errCh <- P.StartWithContext(ctx)
+		}(P)
+	}
+	ended := 0
+	for {
+		select {
+		case err := <-errCh:
+			if err == nil {
+				// the share of party 1 is only bad for party 0; the others finish in the last round
+				if ended++; ended == len(pIDs) {
+					t.Fatal("party 0 must fail")
+				}
+				continue
+			}
+			assert.Equal(t, pIDs[0], err.Victim())
+			assert.Equal(t, tss.ErrBadShare, err.Kind())
+			assert.False(t, parties[0].Running())
+			assert.Nil(t, parties[0].temp.shares, "the shares must be wiped")
+			return
+		case <-time.After(time.Minute):
+			t.Fatal("StartWithContext did not return after the round failed")
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, updateErrCh)
+				}
+				continue
+			}
+			if msg.GetFrom().Index == 1 && dest[0].Index == 0 {
+				msg = NewKGRound2Message1(dest[0], msg.GetFrom(), &vss.Share{Threshold: testThreshold, ID: dest[0].KeyInt(), Share: big.NewInt(42)})
+			}
+			go test.SharedPartyUpdater(parties[dest[0].Index], msg, updateErrCh)
+		}
+	}
+}
+
+func TestE2EBadShare(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		parties = append(parties, NewLocalParty(params, outCh, endCh).(*LocalParty))
+	}
+	for _, P := range parties {
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+	for {
+		select {
+		case err := <-errCh:
+			// party 0 rejects the share of party 1, which does not match its commitments
+			assert.Equal(t, pIDs[0], err.Victim())
+			assert.True(t, errors.Is(err, tss.ErrBadShare), err.Error())
+			assert.Equal(t, tss.ErrBadShare, err.Kind())
+			if assert.Equal(t, 1, len(err.Culprits())) {
+				assert.Equal(t, pIDs[1], err.Culprits()[0])
+			}
+			// the failed party stops and wipes its secrets
+			assert.False(t, parties[0].Running())
+			assert.Nil(t, parties[0].temp.shares, "the shares must be wiped")
+			assert.Nil(t, parties[0].temp.chainCode, "the chain code must be wiped")
+			assert.Nil(t, parties[0].data.Xi, "xi must be wiped")
+			_, err = parties[0].Update(NewKGRound1Message(pIDs[2], cmt.HashCommitment(big.NewInt(1))))
+			assert.Equal(t, tss.ErrBadShare, err.Kind(), "later updates return the error of the party")
+			return
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+				continue
+			}
+			if msg.GetFrom().Index == 1 && dest[0].Index == 0 {
+				msg = NewKGRound2Message1(dest[0], msg.GetFrom(), &vss.Share{Threshold: testThreshold, ID: dest[0].KeyInt(), Share: big.NewInt(42)})
+			}
+			go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+		}
+	}
+}
+
+// runWithContext runs keygen with StartWithContext, except for the silent party which is never started, and returns
+// after every started party has returned from StartWithContext
+func runWithContext(ctx context.Context, pIDs tss.SortedPartyIDs, silent int, roundTimeout time.Duration) ([]*LocalParty, chan *tss.Error, chan *LocalPartySaveData) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+	updateErrCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		params.SetRoundTimeout(roundTimeout)
+		parties = append(parties, NewLocalParty(params, outCh, endCh).(*LocalParty))
+	}
+	wg := new(sync.WaitGroup)
+	for i, P := range parties {
+		if i == silent {
+			continue
+		}
+		wg.Add(1)
+		go func(P *LocalParty) {
+			defer wg.Done()
+			errCh <- P.StartWithContext(ctx)
+		}(P)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return parties, errCh, endCh
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go test.SharedPartyUpdater(P, msg, updateErrCh)
+				}
+			} else {
+				go test.SharedPartyUpdater(parties[dest[0].Index], msg, updateErrCh)
+			}
+		}
+	}
+}
+
 func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	fixtureFileName := makeTestFixtureFilePath(index)
 
@@ -236,3 +586,187 @@ func tryWriteTestFixtureFile(t *testing.T, index int, data LocalPartySaveData) {
 	}
 	//
 }
+
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+
+	// the messages are delivered one at a time, so the channels must hold every message of a round
+	errCh := make(chan *tss.Error, 10*len(pIDs))
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+
+	newParty := func(i int) *LocalParty {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, outCh, endCh).(*LocalParty)
+	}
+	for i := range pIDs {
+		P := newParty(i)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// party 0 crashes once it has received the round 1 messages
+	key := keystore.KEK(make([]byte, keystore.KEKSize))
+	received, crashed := 0, false
+	saves := make([]*LocalPartySaveData, 0, len(pIDs))
+	for len(saves) < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index || (dest != nil && dest[0].Index != P.PartyID().Index) {
+					continue
+				}
+				test.SharedPartyUpdater(P, msg, errCh)
+				if P.PartyID().Index == 0 {
+					received++
+				}
+			}
+			if received < len(pIDs)-1 || crashed {
+				continue
+			}
+			crashed = true
+			snapshot, err := parties[0].(*LocalParty).Snapshot(key)
+			assert.NoError(t, err)
+
+			restored := newParty(0)
+			if err := restored.Restore(snapshot, key); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, parties[0].(*LocalParty).temp.shares, restored.temp.shares)
+			assert.Equal(t, parties[0].(*LocalParty).temp.chainCode, restored.temp.chainCode)
+			assert.Contains(t, restored.String(), "round: 2")
+			parties[0] = restored
+		case save := <-endCh:
+			saves = append(saves, save)
+		case <-time.After(time.Minute):
+			t.Fatal("timed out waiting for the save data")
+		}
+	}
+	assert.True(t, crashed)
+	for _, save := range saves {
+		assert.True(t, saves[0].EDDSAPub.Equals(save.EDDSAPub))
+	}
+
+	// the journal is wiped once the session has finished
+	_, err := parties[0].(*LocalParty).Snapshot(key)
+	assert.Error(t, err)
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *LocalPartySaveData, 1)
+	newParty := func(threshold int) *LocalParty {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[0], len(pIDs), threshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(testThreshold)
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(testThreshold).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session with another threshold
+	assert.NotNil(t, newParty(testThreshold+1).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(testThreshold).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+// rejectingRound2 is a party that fails to store the round 2 broadcasts
+type rejectingRound2 struct {
+	*LocalParty
+}
+
+func (p rejectingRound2) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if _, ok := msg.Content().(*KGRound2Message2); ok {
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "rejected"), msg.GetFrom())
+	}
+	return p.LocalParty.StoreMessage(msg)
+}
+
+func TestE2EQueuedMessageStoreFailure(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(testParticipants)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	errCh := make(chan *tss.Error, 10*len(pIDs))
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *LocalPartySaveData, len(pIDs))
+	parties := make([]*LocalParty, 0, len(pIDs))
+	for i := range pIDs {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := NewLocalParty(params, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+	r1msgs := make([]tss.Message, 0, len(pIDs))
+	for range pIDs {
+		r1msgs = append(r1msgs, <-outCh)
+	}
+	// the other parties reach round 2 and send their shares and de-commitments
+	for _, msg := range r1msgs {
+		for _, P := range parties[1:] {
+			test.SharedPartyUpdater(P, msg, errCh)
+		}
+	}
+	assert.Zero(t, len(errCh))
+	parse := func(msg tss.Message) tss.ParsedMessage {
+		bz, _, err := msg.WireBytes()
+		assert.NoError(t, err)
+		pMsg, err := tss.ParseWireMessage(bz, msg.GetFrom(), msg.IsBroadcast())
+		assert.NoError(t, err)
+		return pMsg
+	}
+	var r2msg2 tss.ParsedMessage
+	for r2msg2 == nil {
+		if msg := parse(<-outCh); msg.Type() == "binance.tsslib.eddsa.keygen.KGRound2Message2" {
+			r2msg2 = msg
+		}
+	}
+
+	// party 0 queues a de-commitment while in round 1, then fails to store it once round 2 starts
+	P := rejectingRound2{parties[0]}
+	ok, err := tss.BaseUpdate(P, r2msg2, TaskName)
+	assert.True(t, ok)
+	assert.Nil(t, err)
+	for _, msg := range r1msgs[1:] {
+		ok, err = tss.BaseUpdate(P, parse(msg), TaskName)
+	}
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "rejected")
+	}
+	assert.False(t, ok)
+	assert.False(t, P.Running())
+	assert.Nil(t, P.temp.shares, "the shares must be wiped")
+	_, again := P.Update(r2msg2)
+	assert.Equal(t, err, again, "a failed party returns its error")
+}
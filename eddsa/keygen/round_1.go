@@ -7,7 +7,6 @@
 package keygen
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -17,18 +16,19 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
-var zero = big.NewInt(0)
+// chainCodeBitLen is the bit length of the contribution of each party to the chain code of the key
+const chainCodeBitLen = 256
 
 // round 1 represents round 1 of the keygen part of the EDDSA TSS spec
-func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *LocalPartySaveData) tss.Round {
+func newRound1(params *tss.Parameters, save *LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *LocalPartySaveData, journal *tss.SessionJournal) tss.Round {
 	return &round1{
-		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
 	}
 }
 
 func (round *round1) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 1
 	round.started = true
@@ -40,32 +40,29 @@ func (round *round1) Start() *tss.Error {
 	round.temp.ssidNonce = new(big.Int).SetUint64(0)
 	ssid, err := round.getSSID()
 	if err != nil {
-		return round.WrapError(err)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err))
 	}
 	round.temp.ssid = ssid
 
 	// 1. calculate "partial" key share ui
-	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.Params().EC().Params().N)
+	ui := common.GetRandomPositiveInt(round.PartialKeyRand(), round.Params().Group().Order())
 	round.temp.ui = ui
 
 	// 2. compute the vss shares
 	ids := round.Parties().IDs().Keys()
-	vs, shares, err := vss.Create(round.EC(), round.Threshold(), ui, ids, round.Rand())
+	vs, shares, err := vss.Create(round.Group(), round.Threshold(), ui, ids, round.Rand())
 	if err != nil {
-		return round.WrapError(err, Pi)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 	}
 	round.save.Ks = ids
 
-	// security: the original u_i may be discarded
-	ui = zero // clears the secret data from memory
-	_ = ui    // silences a linter warning
-
-	// 3. make commitment -> (C, D)
+	// 3. sample this party's contribution to the chain code and make commitment -> (C, D) over chainCode || poly*G
+	chainCode := common.MustGetRandomInt(round.Rand(), chainCodeBitLen)
 	pGFlat, err := crypto.FlattenECPoints(vs)
 	if err != nil {
-		return round.WrapError(err, Pi)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), Pi)
 	}
-	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+	cmt := cmts.NewHashCommitment(round.Rand(), append([]*big.Int{chainCode}, pGFlat...)...)
 
 	// for this P: SAVE
 	// - shareID
@@ -76,13 +73,16 @@ func (round *round1) Start() *tss.Error {
 	round.temp.vs = vs
 	round.temp.shares = shares
 
+	round.temp.chainCode = chainCode
 	round.temp.deCommitPolyG = cmt.D
 
 	// BROADCAST commitments
 	{
 		msg := NewKGRound1Message(round.PartyID(), cmt.C)
 		round.temp.kgRound1Messages[i] = msg
-		round.out <- msg
+		if err := round.send(msg); err != nil {
+			return err
+		}
 	}
 	return nil
 }
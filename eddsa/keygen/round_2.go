@@ -7,18 +7,18 @@
 package keygen
 
 import (
-	"errors"
 	"math/big"
 
 	errors2 "github.com/pkg/errors"
 
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto/schnorr"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *round2) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 2
 	round.started = true
@@ -42,20 +42,27 @@ func (round *round2) Start() *tss.Error {
 			continue
 		}
 		round.temp.kgRound2Message1s[i] = r2msg1
-		round.out <- r2msg1
+		if err := round.send(r2msg1); err != nil {
+			return err
+		}
 	}
 
 	// 5. compute Schnorr prove
 	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
 	pii, err := schnorr.NewZKProof(ContextI, round.temp.ui, round.temp.vs[0], round.Rand())
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "NewZKProof(ui, vi0)"))
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "NewZKProof(ui, vi0)")))
 	}
+	// security: the original u_i may be discarded once its knowledge is proven
+	common.WipeBigInt(round.temp.ui)
+	round.temp.ui = nil
 
 	// 5. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
 	r2msg2 := NewKGRound2Message2(round.PartyID(), round.temp.deCommitPolyG, pii)
 	round.temp.kgRound2Message2s[i] = r2msg2
-	round.out <- r2msg2
+	if err := round.send(r2msg2); err != nil {
+		return err
+	}
 
 	return nil
 }
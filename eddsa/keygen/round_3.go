@@ -7,7 +7,6 @@
 package keygen
 
 import (
-	"errors"
 	"math/big"
 
 	"github.com/hashicorp/go-multierror"
@@ -22,7 +21,7 @@ import (
 
 func (round *round3) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 3
 	round.started = true
@@ -41,7 +40,7 @@ func (round *round3) Start() *tss.Error {
 		share := r2msg1.UnmarshalShare()
 		xi = new(big.Int).Add(xi, share)
 	}
-	round.save.Xi = new(big.Int).Mod(xi, round.Params().EC().Params().N)
+	round.save.Xi = new(big.Int).Mod(xi, round.Params().Group().Order())
 
 	// 2-3.
 	Vc := make(vss.Vs, round.Threshold()+1)
@@ -52,6 +51,7 @@ func (round *round3) Start() *tss.Error {
 	// 4-12.
 	type vssOut struct {
 		unWrappedErr error
+		chainCode    *big.Int
 		pjVs         vss.Vs
 	}
 	chs := make([]chan vssOut, len(Ps))
@@ -74,29 +74,30 @@ func (round *round3) Start() *tss.Error {
 			r2msg2 := round.temp.kgRound2Message2s[j].Content().(*KGRound2Message2)
 			KGDj := r2msg2.UnmarshalDeCommitment()
 			cmtDeCmt := commitments.HashCommitDecommit{C: KGCj, D: KGDj}
-			ok, flatPolyGs := cmtDeCmt.DeCommit()
-			if !ok || flatPolyGs == nil {
-				ch <- vssOut{errors.New("de-commitment verify failed"), nil}
+			ok, flat := cmtDeCmt.DeCommit()
+			if !ok || len(flat) != 1+2*(round.Threshold()+1) {
+				ch <- vssOut{tss.Errorf(tss.ErrBadCommitment, "de-commitment verify failed"), nil, nil}
 				return
 			}
-
-			PjVs, err := crypto.UnFlattenECPoints(round.Params().EC(), flatPolyGs)
-			for i, PjV := range PjVs {
-				PjVs[i] = PjV.EightInvEight()
+			// a wider chain code share would not fit the chain code of the key
+			if flat[0].Sign() < 0 || flat[0].BitLen() > chainCodeBitLen {
+				ch <- vssOut{tss.Errorf(tss.ErrBadShare, "chain code share is out of range"), nil, nil}
+				return
 			}
 
+			PjVs, err := crypto.UnFlattenECPoints(round.Params().Group(), flat[1:])
 			if err != nil {
-				ch <- vssOut{err, nil}
+				ch <- vssOut{tss.WithKind(tss.ErrInvalidMessage, err), nil, nil}
 				return
 			}
-			proof, err := r2msg2.UnmarshalZKProof(round.Params().EC())
+			proof, err := r2msg2.UnmarshalZKProof(round.Params().Group())
 			if err != nil {
-				ch <- vssOut{errors.New("failed to unmarshal schnorr proof"), nil}
+				ch <- vssOut{tss.Errorf(tss.ErrInvalidProof, "failed to unmarshal schnorr proof"), nil, nil}
 				return
 			}
 			ok = proof.Verify(ContextJ, PjVs[0])
 			if !ok {
-				ch <- vssOut{errors.New("failed to prove schnorr proof"), nil}
+				ch <- vssOut{tss.Errorf(tss.ErrInvalidProof, "failed to prove schnorr proof"), nil, nil}
 				return
 			}
 			r2msg1 := round.temp.kgRound2Message1s[j].Content().(*KGRound2Message1)
@@ -105,12 +106,12 @@ func (round *round3) Start() *tss.Error {
 				ID:        round.PartyID().KeyInt(),
 				Share:     r2msg1.UnmarshalShare(),
 			}
-			if ok = PjShare.Verify(round.Params().EC(), round.Threshold(), PjVs); !ok {
-				ch <- vssOut{errors.New("vss verify failed"), nil}
+			if ok = PjShare.Verify(round.Params().Group(), round.Threshold(), PjVs); !ok {
+				ch <- vssOut{tss.Errorf(tss.ErrBadShare, "vss verify failed"), nil, nil}
 				return
 			}
 			// (9) handled above
-			ch <- vssOut{nil, PjVs}
+			ch <- vssOut{nil, flat[0], PjVs}
 		}(j, chs[j])
 	}
 
@@ -138,6 +139,7 @@ func (round *round3) Start() *tss.Error {
 			return round.WrapError(multiErr, culprits...)
 		}
 	}
+	chainCode := new(big.Int).Set(round.temp.chainCode)
 	{
 		var err error
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
@@ -145,6 +147,7 @@ func (round *round3) Start() *tss.Error {
 			if j == PIdx {
 				continue
 			}
+			chainCode = new(big.Int).Xor(chainCode, vssResults[j].chainCode)
 			// 11-12.
 			PjVs := vssResults[j].pjVs
 			for c := 0; c <= round.Threshold(); c++ {
@@ -155,14 +158,14 @@ func (round *round3) Start() *tss.Error {
 			}
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
+			return round.WrapError(tss.Errorf(tss.ErrBadShare, "adding PjVs[c] to Vc[c] resulted in a point not on the curve"), culprits...)
 		}
 	}
 
 	// 13-17. compute Xj for each Pj
 	{
 		var err error
-		modQ := common.ModInt(round.Params().EC().Params().N)
+		modQ := common.ModInt(round.Params().Group().Order())
 		culprits := make([]*tss.PartyID, 0, len(Ps)) // who caused the error(s)
 		bigXj := round.save.BigXj
 		for j := 0; j < round.PartyCount(); j++ {
@@ -180,21 +183,27 @@ func (round *round3) Start() *tss.Error {
 			bigXj[j] = BigXj
 		}
 		if len(culprits) > 0 {
-			return round.WrapError(errors.New("adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
+			return round.WrapError(tss.Errorf(tss.ErrBadShare, "adding Vc[c].ScalarMult(z) to BigXj resulted in a point not on the curve"), culprits...)
 		}
 		round.save.BigXj = bigXj
 	}
 
 	// 18. compute and SAVE the EDDSA public key `y`
-	eddsaPubKey, err := crypto.NewECPoint(round.Params().EC(), Vc[0].X(), Vc[0].Y())
+	eddsaPubKey, err := crypto.NewECPoint(round.Params().Group(), Vc[0].X(), Vc[0].Y())
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "public key is not on the curve"))
+		return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors2.Wrapf(err, "public key is not on the curve")))
 	}
 	round.save.EDDSAPub = eddsaPubKey
 
+	// SAVE the chain code, which no party could bias as every contribution was committed to in round 1
+	round.save.ChainCode = chainCode.FillBytes(make([]byte, chainCodeBitLen/8))
+
 	// PRINT public key & private share
 	common.Logger.Debugf("%s public key: %x", round.PartyID(), eddsaPubKey)
 
+	for j := range round.ok {
+		round.ok[j] = true
+	}
 	round.end <- round.save
 	return nil
 }
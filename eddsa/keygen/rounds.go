@@ -7,9 +7,11 @@
 package keygen
 
 import (
+	"io"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
@@ -27,6 +29,7 @@ type (
 		ok      []bool // `ok` tracks parties which have been verified by Update()
 		started bool
 		number  int
+		journal *tss.SessionJournal // set when session snapshots are enabled
 	}
 	round1 struct {
 		*base
@@ -73,6 +76,33 @@ func (round *base) WaitingFor() []*tss.PartyID {
 	return ids
 }
 
+// Rand returns the random source of the round, which is the session journal when snapshots are enabled
+func (round *base) Rand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.Parameters.Rand()
+}
+
+// PartialKeyRand returns the random source of ui, which is the session journal when snapshots are enabled
+func (round *base) PartialKeyRand() io.Reader {
+	if round.journal != nil {
+		return round.journal
+	}
+	return round.Parameters.PartialKeyRand()
+}
+
+// send records msg in the session journal, which refuses it if a restored session diverges, then sends it
+func (round *base) send(msg tss.Message) *tss.Error {
+	if round.journal != nil {
+		if err := round.journal.Send(msg); err != nil {
+			return round.WrapError(err)
+		}
+	}
+	round.out <- msg
+	return nil
+}
+
 func (round *base) WrapError(err error, culprits ...*tss.PartyID) *tss.Error {
 	return tss.NewError(err, TaskName, round.number, round.PartyID(), culprits...)
 }
@@ -88,7 +118,7 @@ func (round *base) resetOK() {
 
 // get ssid from local params
 func (round *base) getSSID() ([]byte, error) {
-	ssidList := []*big.Int{round.EC().Params().P, round.EC().Params().N, round.EC().Params().Gx, round.EC().Params().Gy} // ec curve
+	ssidList := []*big.Int{round.Group().Order(), crypto.Generator(round.Group()).X(), crypto.Generator(round.Group()).Y()} // group
 	ssidList = append(ssidList, round.Parties().IDs().Keys()...)
 	ssidList = append(ssidList, big.NewInt(int64(round.number))) // round number
 	ssidList = append(ssidList, round.temp.ssidNonce)
@@ -32,6 +32,12 @@ type (
 
 		// used for test assertions (may be discarded)
 		EDDSAPub *crypto.ECPoint // y
+
+		// the chain code of EDDSAPub generated jointly in keygen; may be empty for keys generated by older versions
+		ChainCode []byte
+
+		// the number of proactive refreshes applied to the key shares since keygen
+		RefreshEpoch uint64
 	}
 )
 
@@ -50,6 +56,8 @@ func BuildLocalSaveDataSubset(sourceData LocalPartySaveData, sortedIDs tss.Sorte
 	newData := NewLocalPartySaveData(sortedIDs.Len())
 	newData.LocalSecrets = sourceData.LocalSecrets
 	newData.EDDSAPub = sourceData.EDDSAPub
+	newData.ChainCode = sourceData.ChainCode
+	newData.RefreshEpoch = sourceData.RefreshEpoch
 	for j, id := range sortedIDs {
 		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
 		if !ok {
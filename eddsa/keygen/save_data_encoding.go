@@ -0,0 +1,190 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keygen
+
+import (
+	"io"
+	"math/big"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SaveDataKind identifies the EdDSA save data in a keystore container
+	SaveDataKind = "eddsa/keygen.LocalPartySaveData"
+
+	// SaveDataVersion is the version of the SaveDataMessage encoding written by MarshalSaveData
+	SaveDataVersion uint32 = 1
+)
+
+// saveDataMigrations upgrades the SaveDataMessage encodings of older versions. Add an entry for the previous
+// version whenever SaveDataVersion is bumped.
+var saveDataMigrations = keystore.Migrations{}
+
+// MarshalSaveData encodes the save data in a versioned keystore container protected by key
+func MarshalSaveData(rand io.Reader, save *LocalPartySaveData, key keystore.Key) ([]byte, error) {
+	msg, err := save.toProto()
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	payload, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	return keystore.Seal(rand, SaveDataKind, SaveDataVersion, payload, key)
+}
+
+// UnmarshalSaveData decodes save data written by MarshalSaveData, migrating it from older versions of the encoding.
+// The decoded save data is checked with ValidateSaveData.
+func UnmarshalSaveData(data []byte, key keystore.Key) (*LocalPartySaveData, error) {
+	version, payload, err := keystore.Open(data, SaveDataKind, key)
+	if err != nil {
+		return nil, err
+	}
+	if payload, err = saveDataMigrations.Apply(version, SaveDataVersion, payload); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	msg := new(SaveDataMessage)
+	if err = proto.Unmarshal(payload, msg); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	save, err := saveDataFromProto(msg)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	if err = ValidateSaveData(save); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	return save, nil
+}
+
+// ValidateSaveData checks that the public data of the parties in save are consistent with each other and with the
+// secret share of this party: Ks are distinct, Xi*G is the BigXj of this party and the BigXj interpolate to EDDSAPub.
+func ValidateSaveData(save *LocalPartySaveData) error {
+	n := len(save.Ks)
+	if n == 0 || len(save.BigXj) != n {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: the lengths of the party data do not match")
+	}
+	if save.Xi == nil || save.ShareID == nil || save.EDDSAPub == nil {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: missing Xi, ShareID or EDDSAPub")
+	}
+	g := save.EDDSAPub.Group()
+	q := g.Order()
+	modQ := common.ModInt(q)
+	i := -1
+	seen := make(map[string]struct{}, n)
+	for j, kj := range save.Ks {
+		if kj == nil || kj.Sign() <= 0 {
+			return tss.Errorf(tss.ErrInvalidSaveData, "save data: invalid Ks[%d]", j)
+		}
+		kjModQ := new(big.Int).Mod(kj, q).String()
+		if _, dup := seen[kjModQ]; dup || kjModQ == "0" {
+			return tss.Errorf(tss.ErrInvalidSaveData, "save data: duplicate or zero Ks[%d]", j)
+		}
+		seen[kjModQ] = struct{}{}
+		if kj.Cmp(save.ShareID) == 0 {
+			i = j
+		}
+		if save.BigXj[j] == nil || !save.BigXj[j].ValidateBasic() || save.BigXj[j].Group() != g {
+			return tss.Errorf(tss.ErrInvalidSaveData, "save data: invalid BigXj[%d]", j)
+		}
+	}
+	if i < 0 {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: ShareID is not one of Ks")
+	}
+	if !crypto.ScalarBaseMult(g, save.Xi).Equals(save.BigXj[i]) {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: Xi does not match BigXj")
+	}
+	var pub *crypto.ECPoint
+	for j, kj := range save.Ks {
+		lambda := big.NewInt(1)
+		for c, kc := range save.Ks {
+			if c == j {
+				continue
+			}
+			lambda = modQ.Mul(lambda, modQ.Mul(kc, modQ.ModInverse(modQ.Sub(kc, kj))))
+		}
+		term := save.BigXj[j].ScalarMult(lambda)
+		if pub == nil {
+			pub = term
+			continue
+		}
+		var err error
+		if pub, err = pub.Add(term); err != nil {
+			return tss.WithKind(tss.ErrInvalidSaveData, err)
+		}
+	}
+	if !pub.Equals(save.EDDSAPub) {
+		return tss.Errorf(tss.ErrInvalidSaveData, "save data: BigXj do not interpolate to EDDSAPub")
+	}
+	return nil
+}
+
+// ----- //
+
+func (save *LocalPartySaveData) toProto() (*SaveDataMessage, error) {
+	if save.EDDSAPub == nil || save.Xi == nil || save.ShareID == nil {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: missing Xi, ShareID or EDDSAPub")
+	}
+	curveName, ok := tss.GetGroupName(save.EDDSAPub.Group())
+	if !ok {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: unknown curve")
+	}
+	bigXj, err := crypto.FlattenECPoints(save.BigXj)
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	eddsaPub, err := crypto.FlattenECPoints([]*crypto.ECPoint{save.EDDSAPub})
+	if err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	return &SaveDataMessage{
+		Curve:        string(curveName),
+		Xi:           save.Xi.Bytes(),
+		ShareId:      save.ShareID.Bytes(),
+		Ks:           common.BigIntsToBytes(save.Ks),
+		BigXj:        common.BigIntsToBytes(bigXj),
+		EddsaPub:     common.BigIntsToBytes(eddsaPub),
+		RefreshEpoch: save.RefreshEpoch,
+		ChainCode:    save.ChainCode,
+	}, nil
+}
+
+func saveDataFromProto(msg *SaveDataMessage) (*LocalPartySaveData, error) {
+	g, ok := tss.GetGroupByName(tss.CurveName(msg.GetCurve()))
+	if !ok {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: unknown curve %q", msg.GetCurve())
+	}
+	n := len(msg.GetKs())
+	if len(msg.GetBigXj()) != 2*n || !common.NonEmptyMultiBytes(msg.GetKs(), n) ||
+		!common.NonEmptyBytes(msg.GetXi()) || !common.NonEmptyBytes(msg.GetShareId()) {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: missing or mismatched party data")
+	}
+	save := NewLocalPartySaveData(n)
+	var err error
+	if save.BigXj, err = crypto.UnFlattenECPoints(g, common.MultiBytesToBigInts(msg.GetBigXj())); err != nil {
+		return nil, tss.WithKind(tss.ErrInvalidSaveData, err)
+	}
+	eddsaPub, err := crypto.UnFlattenECPoints(g, common.MultiBytesToBigInts(msg.GetEddsaPub()))
+	if err != nil || len(eddsaPub) != 1 {
+		return nil, tss.Errorf(tss.ErrInvalidSaveData, "save data: invalid EDDSAPub")
+	}
+	save.EDDSAPub = eddsaPub[0]
+	save.Xi = new(big.Int).SetBytes(msg.GetXi())
+	save.ShareID = new(big.Int).SetBytes(msg.GetShareId())
+	save.Ks = common.MultiBytesToBigInts(msg.GetKs())
+	save.RefreshEpoch = msg.GetRefreshEpoch()
+	if common.NonEmptyBytes(msg.GetChainCode()) {
+		save.ChainCode = msg.GetChainCode()
+	}
+	return &save, nil
+}
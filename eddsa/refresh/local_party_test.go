@@ -0,0 +1,188 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testParticipants, len(keys))
+
+	refreshed := runRefresh(t, keys, pIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData) *LocalParty {
+		return NewLocalParty(params, keys[i], outCh, endCh).(*LocalParty)
+	})
+	assertRefreshed(t, keys, refreshed)
+
+	// a second refresh moves the key to the next epoch
+	refreshedTwice := runRefresh(t, refreshed, pIDs, func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData) *LocalParty {
+		return NewLocalParty(params, refreshed[i], outCh, endCh).(*LocalParty)
+	})
+	assertRefreshed(t, refreshed, refreshedTwice)
+	assert.Equal(t, keys[0].RefreshEpoch+2, refreshedTwice[0].RefreshEpoch)
+}
+
+func runRefresh(
+	t *testing.T,
+	keys []keygen.LocalPartySaveData,
+	pIDs tss.SortedPartyIDs,
+	newParty func(params *tss.Parameters, i int, outCh chan tss.Message, endCh chan *keygen.LocalPartySaveData) *LocalParty,
+) []keygen.LocalPartySaveData {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]*LocalParty, 0, len(pIDs))
+
+	errCh := make(chan *tss.Error, len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	updater := test.SharedPartyUpdater
+
+	// init the parties
+	for i := 0; i < len(pIDs); i++ {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[i], len(pIDs), testThreshold)
+		P := newParty(params, i, outCh, endCh)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// PHASE: refresh
+	var ended int32
+	for {
+		fmt.Printf("ACTIVE GOROUTINES: %d\n", runtime.NumGoroutine())
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			return nil
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil { // broadcast!
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else { // point-to-point!
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+					return nil
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(pIDs)) {
+				t.Logf("Done. Received save data from %d participants", ended)
+				refreshed := make([]keygen.LocalPartySaveData, len(parties))
+				for j, Pj := range parties {
+					refreshed[j] = Pj.data
+				}
+				return refreshed
+			}
+		}
+	}
+}
+
+func assertRefreshed(t *testing.T, keys, refreshed []keygen.LocalPartySaveData) {
+	oldShares, newShares := make(vss.Shares, 0, len(keys)), make(vss.Shares, 0, len(keys))
+	for j, data := range refreshed {
+		assert.NotEqual(t, 0, data.Xi.Cmp(keys[j].Xi), "the key share must change")
+		assert.True(t, data.EDDSAPub.Equals(keys[j].EDDSAPub), "the public key must not change")
+		assert.Equal(t, keys[j].RefreshEpoch+1, data.RefreshEpoch)
+		for k := range refreshed {
+			assert.True(t, data.BigXj[k].Equals(refreshed[k].BigXj[k]), "everyone has the same BigXj")
+		}
+		assert.True(t, data.BigXj[j].Equals(crypto.ScalarBaseMult(group.Ed25519(), data.Xi)), "ensure BigX_j == g^x_j")
+		oldShares = append(oldShares, &vss.Share{Threshold: testThreshold, ID: keys[j].ShareID, Share: keys[j].Xi})
+		newShares = append(newShares, &vss.Share{Threshold: testThreshold, ID: data.ShareID, Share: data.Xi})
+	}
+	oldX, err := oldShares[:testThreshold+1].ReConstruct(group.Ed25519())
+	assert.NoError(t, err)
+	newX, err := newShares[1 : testThreshold+2].ReConstruct(group.Ed25519())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, oldX.Cmp(newX), "the refreshed shares must reconstruct the same secret")
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, pIDs, err := keygen.LoadKeygenTestFixtures(testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, 10*len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
+	newParty := func(key keygen.LocalPartySaveData) *LocalParty {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[0], len(pIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, key, outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(keys[0])
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(keys[0]).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session that refreshes another epoch of the key
+	nextEpoch := keys[0]
+	nextEpoch.RefreshEpoch++
+	assert.NotNil(t, newParty(nextEpoch).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(keys[0]).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
@@ -0,0 +1,112 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	cmts "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of the proactive key refresh of the EDDSA TSS
+func newRound1(params *tss.Parameters, key, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, key, save, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	Pi := round.PartyID()
+	i := Pi.Index
+
+	// 0. the key must belong to this party and to the same set of parties
+	ids := round.Parties().IDs().Keys()
+	if len(round.key.Ks) != len(ids) || round.key.Xi == nil || round.key.ShareID == nil || round.key.EDDSAPub == nil {
+		return round.WrapError(errors.New("the key does not match the set of parties"))
+	}
+	for j, id := range ids {
+		if round.key.Ks[j] == nil || round.key.Ks[j].Cmp(id) != 0 || round.key.BigXj[j] == nil {
+			return round.WrapError(errors.New("the key does not match the set of parties"))
+		}
+	}
+	if round.key.ShareID.Cmp(Pi.KeyInt()) != 0 {
+		return round.WrapError(errors.New("the key does not belong to this party"))
+	}
+
+	// 1. compute a sharing of zero to re-randomize the key shares
+	vs, shares, err := vss.CreateZeroSharing(round.Group(), round.Threshold(), ids, round.Rand())
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+
+	// make commitment -> (C, D)
+	pGFlat, err := crypto.FlattenECPoints(vs)
+	if err != nil {
+		return round.WrapError(err, Pi)
+	}
+	cmt := cmts.NewHashCommitment(round.Rand(), pGFlat...)
+
+	round.temp.ssidNonce = new(big.Int).SetUint64(0)
+	ssid, err := round.getSSID()
+	if err != nil {
+		return round.WrapError(errors.New("failed to generate ssid"))
+	}
+	round.temp.ssid = ssid
+	round.temp.vs = vs
+	round.temp.shares = shares
+	round.temp.deCommit = cmt.D
+
+	// BROADCAST commitment and the key's epoch; round 1 message
+	{
+		msg := NewRefreshRound1Message(round.PartyID(), cmt.C, round.key.RefreshEpoch)
+		round.temp.refreshRound1Messages[i] = msg
+		if err := round.send(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.refreshRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		// epoch check is in round 2
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,93 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package refresh
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+
+	// 1. every party must refresh the same epoch of the key
+	for j, msg := range round.temp.refreshRound1Messages {
+		r1msg := msg.Content().(*RefreshRound1Message)
+		if r1msg.GetEpoch() != round.key.RefreshEpoch {
+			return round.WrapError(errors.New("the refresh epoch of this party does not match"), msg.GetFrom())
+		}
+		if j != i {
+			round.temp.KGCs[j] = r1msg.UnmarshalCommitment()
+		}
+	}
+
+	// 2. p2p send share ij to Pj
+	for j, Pj := range Ps {
+		if j == i {
+			continue
+		}
+		r2msg1 := NewRefreshRound2Message1(Pj, round.PartyID(), round.temp.shares[j])
+		if err := round.send(r2msg1); err != nil {
+			return err
+		}
+	}
+
+	// 3. BROADCAST de-commitment
+	r2msg2 := NewRefreshRound2Message2(round.PartyID(), round.temp.deCommit)
+	round.temp.refreshRound2Message2s[i] = r2msg2
+	if err := round.send(r2msg2); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*RefreshRound2Message1); ok {
+		return !msg.IsBroadcast()
+	}
+	if _, ok := msg.Content().(*RefreshRound2Message2); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.refreshRound2Message2s {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		if j != round.PartyID().Index {
+			msg1 := round.temp.refreshRound2Message1s[j]
+			if msg1 == nil || !round.CanAccept(msg1) {
+				ret = false
+				continue
+			}
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &round3{round}
+}
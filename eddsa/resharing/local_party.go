@@ -7,6 +7,7 @@
 package resharing
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
@@ -16,6 +17,12 @@ import (
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a resharing session in a keystore container
+	SessionSnapshotKind = "eddsa/resharing.Session"
 )
 
 // Implements Party
@@ -30,6 +37,7 @@ type (
 
 		temp        localTempData
 		input, save keygen.LocalPartySaveData
+		journal     *tss.SessionJournal
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -75,14 +83,14 @@ func NewLocalParty(
 		subset = keygen.BuildLocalSaveDataSubset(key, params.OldParties().IDs())
 	}
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		temp:      localTempData{},
-		input:     subset,
-		save:      keygen.NewLocalPartySaveData(params.NewPartyCount()),
-		out:       out,
-		end:       end,
+		params: params,
+		temp:   localTempData{},
+		input:  subset,
+		save:   keygen.NewLocalPartySaveData(params.NewPartyCount()),
+		out:    out,
+		end:    end,
 	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
 	// msgs init
 	p.temp.dgRound1Messages = make([]tss.ParsedMessage, oldPartyCount)          // from t+1 of Old Committee
 	p.temp.dgRound2Messages = make([]tss.ParsedMessage, params.NewPartyCount()) // from n of New Committee
@@ -94,13 +102,43 @@ func NewLocalParty(
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end)
+	return newRound1(p.params, &p.input, &p.save, &p.temp, p.out, p.end, p.journal)
 }
 
 func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
 	return tss.BaseStart(p, TaskName)
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The key of an old committee member belongs to the
+// caller; its share is destroyed by the last round. The save data of a finished new committee member is the result of
+// the resharing; otherwise the new share is wiped.
+func (p *LocalParty) wipe(finished bool) {
+	for _, share := range p.temp.NewShares {
+		if share != nil {
+			common.WipeBigInt(share.Share)
+		}
+	}
+	cmt.WipeDeCommitment(p.temp.VD)
+	common.WipeBigInt(p.temp.newXi)
+	p.temp.NewShares, p.temp.VD, p.temp.newXi = nil, nil, nil
+	if !finished {
+		common.WipeBigInt(p.save.Xi)
+		p.save.Xi = nil
+	}
+	p.journal.Wipe()
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -126,7 +164,7 @@ func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		maxFromIdx = len(p.params.OldParties().IDs()) - 1
 	}
 	if maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with a sender index too great (%d <= %d)",
 			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
 	}
 	return true, nil
@@ -156,9 +194,61 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
 	return true, nil
 }
 
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *DGRound1Message:
+		return 1
+	case *DGRound2Message:
+		return 2
+	case *DGRound3Message1, *DGRound3Message2:
+		return 3
+	case *DGRound4Message:
+		return 4
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot, such as
+// a different round 1 commitment.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenReSharingSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params, key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), big.NewInt(int64(p.params.Threshold())).Bytes(), big.NewInt(int64(p.params.NewThreshold())).Bytes()}
+	if p.input.EDDSAPub != nil {
+		in = append(in, p.input.EDDSAPub.X().Bytes(), p.input.EDDSAPub.Y().Bytes())
+	}
+	for _, id := range append(p.params.OldParties().IDs(), p.params.NewParties().IDs()...) {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
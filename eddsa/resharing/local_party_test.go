@@ -17,11 +17,13 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	. "github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/signing"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
 )
 
 const (
@@ -68,14 +70,14 @@ func TestE2EConcurrent(t *testing.T) {
 
 	// init the old parties first
 	for j, pID := range oldPIDs {
-		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params := tss.NewReSharingParameters(group.Ed25519(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
 		P := NewLocalParty(params, oldKeys[j], outCh, endCh).(*LocalParty) // discard old key data
 		oldCommittee = append(oldCommittee, P)
 	}
 
 	// init the new parties
 	for _, pID := range newPIDs {
-		params := tss.NewReSharingParameters(tss.Edwards(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
+		params := tss.NewReSharingParameters(group.Ed25519(), oldP2PCtx, newP2PCtx, pID, testParticipants, threshold, newPCount, newThreshold)
 		save := keygen.NewLocalPartySaveData(newPCount)
 		P := NewLocalParty(params, save, outCh, endCh).(*LocalParty)
 		newCommittee = append(newCommittee, P)
@@ -142,7 +144,7 @@ func TestE2EConcurrent(t *testing.T) {
 				for j, key := range newKeys {
 					// xj test: BigXj == xj*G
 					xj := key.Xi
-					gXj := crypto.ScalarBaseMult(tss.Edwards(), xj)
+					gXj := crypto.ScalarBaseMult(group.Ed25519(), xj)
 					BigXj := key.BigXj[j]
 					assert.True(t, BigXj.Equals(gXj), "ensure BigX_j == g^x_j")
 				}
@@ -164,7 +166,7 @@ signing:
 	signEndCh := make(chan *common.SignatureData, len(signPIDs))
 
 	for j, signPID := range signPIDs {
-		params := tss.NewParameters(tss.Edwards(), signP2pCtx, signPID, len(signPIDs), newThreshold)
+		params := tss.NewParameters(group.Ed25519(), signP2pCtx, signPID, len(signPIDs), newThreshold)
 		P := signing.NewLocalParty(big.NewInt(42), params, signKeys[j], signOutCh, signEndCh).(*signing.LocalParty)
 		signParties = append(signParties, P)
 		go func(P *signing.LocalParty) {
@@ -228,3 +230,72 @@ signing:
 		}
 	}
 }
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(testThreshold + 1)
+	assert.NoError(t, err, "should load keygen fixtures")
+	oldP2PCtx := tss.NewPeerContext(oldPIDs)
+	newPIDs := tss.GenerateTestPartyIDs(testParticipants)
+	newP2PCtx := tss.NewPeerContext(newPIDs)
+	outCh := make(chan tss.Message, 10*len(newPIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
+	newParty := func(pID *tss.PartyID, key keygen.LocalPartySaveData, newThreshold int) *LocalParty {
+		params := tss.NewReSharingParameters(group.Ed25519(), oldP2PCtx, newP2PCtx, pID, testParticipants, testThreshold, len(newPIDs), newThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, key, outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(oldPIDs[0], oldKeys[0], testThreshold)
+	assert.Nil(t, P.Start())
+	r1msg := <-outCh
+	sent, _, err := r1msg.WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(oldPIDs[0], oldKeys[0], testThreshold).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session with another threshold
+	assert.NotNil(t, newParty(oldPIDs[0], oldKeys[0], testThreshold+1).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(oldPIDs[0], oldKeys[0], testThreshold).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+
+	// a member of the new committee is restored with the messages of the old committee
+	Q := newParty(newPIDs[0], keygen.NewLocalPartySaveData(len(newPIDs)), testThreshold)
+	assert.Nil(t, Q.Start())
+	for j := 1; j < len(oldPIDs); j++ {
+		assert.Nil(t, newParty(oldPIDs[j], oldKeys[j], testThreshold).Start())
+	}
+	errCh := make(chan *tss.Error, len(oldPIDs))
+	for _, msg := range append([]tss.Message{r1msg}, drain(outCh)...) {
+		test.SharedPartyUpdater(Q, msg, errCh)
+	}
+	assert.Zero(t, len(errCh))
+	ack := drain(outCh)
+	assert.Len(t, ack, 1)
+	snapshot, err = Q.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+	restored := newParty(newPIDs[0], keygen.NewLocalPartySaveData(len(newPIDs)), testThreshold)
+	assert.Nil(t, restored.Restore(snapshot, keystore.NoEncryption()))
+	assert.Equal(t, ack, drain(outCh))
+	assert.Equal(t, Q.String(), restored.String())
+}
+
+func drain(ch chan tss.Message) []tss.Message {
+	msgs := make([]tss.Message, 0, len(ch))
+	for len(ch) > 0 {
+		msgs = append(msgs, <-ch)
+	}
+	return msgs
+}
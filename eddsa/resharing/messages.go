@@ -7,12 +7,12 @@
 package resharing
 
 import (
-	"crypto/elliptic"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
@@ -60,9 +60,9 @@ func (m *DGRound1Message) ValidateBasic() bool {
 		common.NonEmptyBytes(m.VCommitment)
 }
 
-func (m *DGRound1Message) UnmarshalEDDSAPub(ec elliptic.Curve) (*crypto.ECPoint, error) {
+func (m *DGRound1Message) UnmarshalEDDSAPub(g group.AffineGroup) (*crypto.ECPoint, error) {
 	return crypto.NewECPoint(
-		ec,
+		g,
 		new(big.Int).SetBytes(m.EddsaPubX),
 		new(big.Int).SetBytes(m.EddsaPubY))
 }
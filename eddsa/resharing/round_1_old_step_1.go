@@ -7,9 +7,7 @@
 package resharing
 
 import (
-	"errors"
-	"fmt"
-
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
@@ -19,15 +17,15 @@ import (
 )
 
 // round 1 represents round 1 of the keygen part of the EDDSA TSS spec
-func newRound1(params *tss.ReSharingParameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData) tss.Round {
+func newRound1(params *tss.ReSharingParameters, input, save *keygen.LocalPartySaveData, temp *localTempData, out chan<- tss.Message, end chan<- *keygen.LocalPartySaveData, journal *tss.SessionJournal) tss.Round {
 	return &round1{
-		&base{params, temp, input, save, out, end, make([]bool, len(params.OldParties().IDs())), make([]bool, len(params.NewParties().IDs())), false, 1},
+		&base{params, temp, input, save, out, end, make([]bool, len(params.OldParties().IDs())), make([]bool, len(params.NewParties().IDs())), false, 1, journal},
 	}
 }
 
 func (round *round1) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 1
 	round.started = true
@@ -45,21 +43,23 @@ func (round *round1) Start() *tss.Error {
 	// 1. PrepareForSigning() -> w_i
 	xi, ks := round.input.Xi, round.input.Ks
 	if round.Threshold()+1 > len(ks) {
-		return round.WrapError(fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
+		return round.WrapError(tss.Errorf(tss.ErrInvalidParameters, "t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks)), round.PartyID())
 	}
 	newKs := round.NewParties().IDs().Keys()
-	wi := signing.PrepareForSigning(round.Params().EC(), i, len(round.OldParties().IDs()), xi, ks)
+	wi := signing.PrepareForSigning(round.Params().Group(), i, len(round.OldParties().IDs()), xi, ks)
 
 	// 2.
-	vi, shares, err := vss.Create(round.Params().EC(), round.NewThreshold(), wi, newKs, round.Rand())
+	vi, shares, err := vss.Create(round.Params().Group(), round.NewThreshold(), wi, newKs, round.Rand())
+	// security: w_i may be discarded once it is shared
+	common.WipeBigInt(wi)
 	if err != nil {
-		return round.WrapError(err, round.PartyID())
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), round.PartyID())
 	}
 
 	// 3.
 	flatVis, err := crypto.FlattenECPoints(vi)
 	if err != nil {
-		return round.WrapError(err, round.PartyID())
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err), round.PartyID())
 	}
 	vCmt := commitments.NewHashCommitment(round.Rand(), flatVis...)
 
@@ -72,7 +72,9 @@ func (round *round1) Start() *tss.Error {
 		round.NewParties().IDs().Exclude(round.PartyID()), round.PartyID(),
 		round.input.EDDSAPub, vCmt.C)
 	round.temp.dgRound1Messages[i] = r1msg
-	round.out <- r1msg
+	if err := round.send(r1msg); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -108,14 +110,14 @@ func (round *round1) Update() (bool, *tss.Error) {
 		}
 		// save the eddsa pub received from the old committee
 		r1msg := round.temp.dgRound1Messages[0].Content().(*DGRound1Message)
-		candidate, err := r1msg.UnmarshalEDDSAPub(round.Params().EC())
+		candidate, err := r1msg.UnmarshalEDDSAPub(round.Params().Group())
 		if err != nil {
-			return false, round.WrapError(errors.New("unable to unmarshal the eddsa pub key"), msg.GetFrom())
+			return false, round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "unable to unmarshal the eddsa pub key"), msg.GetFrom())
 		}
 		if round.save.EDDSAPub != nil &&
 			!candidate.Equals(round.save.EDDSAPub) {
 			// uh oh - anomaly!
-			return false, round.WrapError(errors.New("eddsa pub key did not match what we received previously"), msg.GetFrom())
+			return false, round.WrapError(tss.Errorf(tss.ErrInvalidMessage, "eddsa pub key did not match what we received previously"), msg.GetFrom())
 		}
 		round.save.EDDSAPub = candidate
 	}
@@ -7,14 +7,12 @@
 package resharing
 
 import (
-	"errors"
-
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *round2) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 2
 	round.started = true
@@ -32,7 +30,9 @@ func (round *round2) Start() *tss.Error {
 	// 1. "broadcast" "ACK" members of the OLD committee
 	r2msg := NewDGRound2Message(round.OldParties().IDs(), Pi)
 	round.temp.dgRound2Messages[i] = r2msg
-	round.out <- r2msg
+	if err := round.send(r2msg); err != nil {
+		return err
+	}
 
 	return nil
 }
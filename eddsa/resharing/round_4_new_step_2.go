@@ -20,7 +20,7 @@ import (
 
 func (round *round4) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 4
 	round.started = true
@@ -40,7 +40,7 @@ func (round *round4) Start() *tss.Error {
 	newXi := big.NewInt(0)
 
 	// 2-8.
-	modQ := common.ModInt(round.Params().EC().Params().N)
+	modQ := common.ModInt(round.Params().Group().Order())
 	vjc := make([][]*crypto.ECPoint, len(round.OldParties().IDs()))
 	for j := 0; j <= len(vjc)-1; j++ { // P1..P_t+1. Ps are indexed from 0 here
 		r1msg := round.temp.dgRound1Messages[j].Content().(*DGRound1Message)
@@ -53,15 +53,11 @@ func (round *round4) Start() *tss.Error {
 		ok, flatVs := vCmtDeCmt.DeCommit()
 		if !ok || len(flatVs) != (round.NewThreshold()+1)*2 { // they're points so * 2
 			// TODO collect culprits and return a list of them as per convention
-			return round.WrapError(errors.New("de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
+			return round.WrapError(tss.Errorf(tss.ErrBadCommitment, "de-commitment of v_j0..v_jt failed"), round.Parties().IDs()[j])
 		}
-		vj, err := crypto.UnFlattenECPoints(round.Params().EC(), flatVs)
+		vj, err := crypto.UnFlattenECPoints(round.Params().Group(), flatVs)
 		if err != nil {
-			return round.WrapError(err, round.Parties().IDs()[j])
-		}
-
-		for i, v := range vj {
-			vj[i] = v.EightInvEight()
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, err), round.Parties().IDs()[j])
 		}
 
 		vjc[j] = vj
@@ -72,8 +68,8 @@ func (round *round4) Start() *tss.Error {
 			ID:        round.PartyID().KeyInt(),
 			Share:     new(big.Int).SetBytes(r3msg1.Share),
 		}
-		if ok := sharej.Verify(round.Params().EC(), round.NewThreshold(), vj); !ok {
-			return round.WrapError(errors.New("share from old committee did not pass Verify()"), round.Parties().IDs()[j])
+		if ok := sharej.Verify(round.Params().Group(), round.NewThreshold(), vj); !ok {
+			return round.WrapError(tss.Errorf(tss.ErrBadShare, "share from old committee did not pass Verify()"), round.Parties().IDs()[j])
 		}
 
 		newXi = new(big.Int).Add(newXi, sharej.Share)
@@ -87,14 +83,14 @@ func (round *round4) Start() *tss.Error {
 		for j := 1; j <= len(vjc)-1; j++ {
 			Vc[c], err = Vc[c].Add(vjc[j][c])
 			if err != nil {
-				return round.WrapError(errors.Wrapf(err, "Vc[c].Add(vjc[j][c])"))
+				return round.WrapError(tss.WithKind(tss.ErrBadShare, errors.Wrapf(err, "Vc[c].Add(vjc[j][c])")))
 			}
 		}
 	}
 
 	// 13-15.
 	if !Vc[0].Equals(round.save.EDDSAPub) {
-		return round.WrapError(errors.New("assertion failed: V_0 != y"), round.PartyID())
+		return round.WrapError(tss.Errorf(tss.ErrBadShare, "assertion failed: V_0 != y"), round.PartyID())
 	}
 
 	// 16-20.
@@ -117,7 +113,7 @@ func (round *round4) Start() *tss.Error {
 		newBigXjs[j] = newBigXj
 	}
 	if len(culprits) > 0 {
-		return round.WrapError(errors.Wrapf(err, "newBigXj.Add(Vc[c].ScalarMult(z))"), culprits...)
+		return round.WrapError(tss.WithKind(tss.ErrBadShare, errors.Wrapf(err, "newBigXj.Add(Vc[c].ScalarMult(z))")), culprits...)
 	}
 
 	round.temp.newXi = newXi
@@ -127,7 +123,9 @@ func (round *round4) Start() *tss.Error {
 	// 21. Send an "ACK" message to both committees to signal that we're ready to save our data
 	r4msg := NewDGRound4Message(round.OldAndNewParties(), Pi)
 	round.temp.dgRound4Messages[i] = r4msg
-	round.out <- r4msg
+	if err := round.send(r4msg); err != nil {
+		return err
+	}
 
 	return nil
 }
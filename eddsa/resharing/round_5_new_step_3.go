@@ -7,14 +7,13 @@
 package resharing
 
 import (
-	"errors"
-
+	"github.com/bnb-chain/tss-lib/v2/common"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *round5) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 5
 	round.started = true
@@ -27,10 +26,12 @@ func (round *round5) Start() *tss.Error {
 		round.save.BigXj = round.temp.newBigXjs
 		round.save.ShareID = round.PartyID().KeyInt()
 		round.save.Xi = round.temp.newXi
+		round.temp.newXi = nil // the save data is the result of the party and must not be wiped with the temp data
 		round.save.Ks = round.temp.newKs
 
 	} else if round.IsOldCommittee() {
-		round.input.Xi.SetInt64(0)
+		// the old share of the caller's key is destroyed
+		common.WipeBigInt(round.input.Xi)
 	}
 
 	round.end <- round.save
@@ -7,36 +7,31 @@
 package signing
 
 import (
-	"errors"
-	"fmt"
-	"math/big"
-
-	"github.com/agl/ed25519/edwards25519"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
 )
 
 func (round *finalization) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 4
 	round.started = true
 	round.resetOK()
 
-	sumS := round.temp.si
+	ed := group.Ed25519()
+	sum := ed.NewScalar().SetBigInt(encodedBytesToBigInt(round.temp.si))
 	for j := range round.Parties().IDs() {
 		round.ok[j] = true
 		if j == round.PartyID().Index {
 			continue
 		}
 		r3msg := round.temp.signRound3Messages[j].Content().(*SignRound3Message)
-		sjBytes := bigIntToEncodedBytes(r3msg.UnmarshalS())
-		var tmpSumS [32]byte
-		edwards25519.ScMulAdd(&tmpSumS, sumS, bigIntToEncodedBytes(big.NewInt(1)), sjBytes)
-		sumS = &tmpSumS
+		sum.Add(sum, ed.NewScalar().SetBigInt(r3msg.UnmarshalS()))
 	}
-	s := encodedBytesToBigInt(sumS)
+	s := sum.BigInt()
+	sumS := bigIntToEncodedBytes(s)
 
 	// save the signature for final output
 	round.data.Signature = append(bigIntToEncodedBytes(round.temp.r)[:], sumS[:]...)
@@ -51,14 +46,14 @@ func (round *finalization) Start() *tss.Error {
 	}
 
 	pk := edwards.PublicKey{
-		Curve: round.Params().EC(),
+		Curve: round.Params().Group().Curve(),
 		X:     round.key.EDDSAPub.X(),
 		Y:     round.key.EDDSAPub.Y(),
 	}
 
 	ok := edwards.Verify(&pk, round.data.M, round.temp.r, s)
 	if !ok {
-		return round.WrapError(fmt.Errorf("signature verification failed"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidSignature, "signature verification failed"))
 	}
 	round.end <- round.data
 
@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// DeriveChildKeyFromPath derives the child of masterPub and chainCode along a path of non-hardened indices, in the
+// style of BIP-32 with an additive tweak. Each step computes I = HMAC-SHA512(chainCode, enc(A) || ser_32(i)), where
+// enc(A) is the 32-byte Ed25519 encoding of the parent key A. The child key is A + (IL mod ℓ) * G and its chain code
+// is IR. It returns the sum of the tweaks, which is the key derivation delta of NewLocalPartyWithKDD.
+func DeriveChildKeyFromPath(masterPub *crypto.ECPoint, chainCode []byte, path []uint32) (*big.Int, *crypto.ECPoint, []byte, error) {
+	if masterPub == nil || !masterPub.ValidateBasic() {
+		return nil, nil, nil, tss.Errorf(tss.ErrInvalidParameters, "invalid master public key")
+	}
+	if len(chainCode) != 32 {
+		return nil, nil, nil, tss.Errorf(tss.ErrInvalidParameters, "the chain code must be 32 bytes")
+	}
+	g := group.Ed25519()
+	modQ := common.ModInt(g.Order())
+	delta := big.NewInt(0)
+	pub, cc := masterPub, chainCode
+	for _, index := range path {
+		if index >= ckd.HardenedKeyStart {
+			return nil, nil, nil, tss.Errorf(tss.ErrInvalidParameters, "the index must be non-hardened")
+		}
+		data := make([]byte, 36)
+		copy(data, ecPointToEncodedBytes(pub.X(), pub.Y())[:])
+		binary.BigEndian.PutUint32(data[32:], index)
+
+		hmac512 := hmac.New(sha512.New, cc)
+		hmac512.Write(data)
+		ilr := hmac512.Sum(nil)
+		il := new(big.Int).Mod(new(big.Int).SetBytes(ilr[:32]), g.Order())
+		if il.Sign() == 0 {
+			return nil, nil, nil, tss.Errorf(tss.ErrInvalidParameters, "invalid derived key")
+		}
+		child, err := pub.Add(crypto.ScalarBaseMult(g, il))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		pub, cc = child, ilr[32:]
+		delta = modQ.Add(delta, il)
+	}
+	return delta, pub, cc, nil
+}
+
+// UpdatePublicKeyAndAdjustBigXj sets the public key of keys to childPub and shifts each BigXj by
+// keyDerivationDelta * G, so that the keys sign for the child key with NewLocalPartyWithKDD
+func UpdatePublicKeyAndAdjustBigXj(keyDerivationDelta *big.Int, keys []keygen.LocalPartySaveData, childPub *crypto.ECPoint) error {
+	var err error
+	gDelta := crypto.ScalarBaseMult(group.Ed25519(), keyDerivationDelta)
+	for k := range keys {
+		keys[k].EDDSAPub = childPub
+		// Suppose X_j has shamir shares X_j0,     X_j1,     ..., X_jn
+		// So X_j + D has shamir shares  X_j0 + D, X_j1 + D, ..., X_jn + D
+		for j := range keys[k].BigXj {
+			if keys[k].BigXj[j], err = keys[k].BigXj[j].Add(gDelta); err != nil {
+				common.Logger.Errorf("error in delta operation")
+				return err
+			}
+		}
+	}
+	return nil
+}
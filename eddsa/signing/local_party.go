@@ -7,7 +7,7 @@
 package signing
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"math/big"
 
@@ -16,6 +16,12 @@ import (
 	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a signing session in a keystore container
+	SessionSnapshotKind = "eddsa/signing.Session"
 )
 
 // Implements Party
@@ -28,9 +34,10 @@ type (
 		*tss.BaseParty
 		params *tss.Parameters
 
-		keys keygen.LocalPartySaveData
-		temp localTempData
-		data *common.SignatureData
+		keys    keygen.LocalPartySaveData
+		temp    localTempData
+		data    *common.SignatureData
+		journal *tss.SessionJournal
 
 		// outbound messaging
 		out chan<- tss.Message
@@ -50,9 +57,10 @@ type (
 		wi,
 		m,
 		ri *big.Int
-		fullBytesLen int
-		pointRi      *crypto.ECPoint
-		deCommit     cmt.HashDeCommitment
+		keyDerivationDelta *big.Int
+		fullBytesLen       int
+		pointRi            *crypto.ECPoint
+		deCommit           cmt.HashDeCommitment
 
 		// round 2
 		cjs []*big.Int
@@ -73,23 +81,38 @@ func NewLocalParty(
 	out chan<- tss.Message,
 	end chan<- *common.SignatureData,
 	fullBytesLen ...int,
+) tss.Party {
+	return NewLocalPartyWithKDD(msg, params, key, nil, out, end, fullBytesLen...)
+}
+
+// NewLocalPartyWithKDD returns a party with key derivation delta for HD support. The keys must have been adjusted to
+// the child key with UpdatePublicKeyAndAdjustBigXj.
+func NewLocalPartyWithKDD(
+	msg *big.Int,
+	params *tss.Parameters,
+	key keygen.LocalPartySaveData,
+	keyDerivationDelta *big.Int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+	fullBytesLen ...int,
 ) tss.Party {
 	partyCount := len(params.Parties().IDs())
 	p := &LocalParty{
-		BaseParty: new(tss.BaseParty),
-		params:    params,
-		keys:      keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
-		temp:      localTempData{},
-		data:      &common.SignatureData{},
-		out:       out,
-		end:       end,
+		params: params,
+		keys:   keygen.BuildLocalSaveDataSubset(key, params.Parties().IDs()),
+		temp:   localTempData{},
+		data:   &common.SignatureData{},
+		out:    out,
+		end:    end,
 	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
 	// msgs init
 	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound2Messages = make([]tss.ParsedMessage, partyCount)
 	p.temp.signRound3Messages = make([]tss.ParsedMessage, partyCount)
 
 	// temp data init
+	p.temp.keyDerivationDelta = keyDerivationDelta
 	p.temp.m = msg
 	if len(fullBytesLen) > 0 {
 		p.temp.fullBytesLen = fullBytesLen[0]
@@ -101,14 +124,21 @@ func NewLocalParty(
 }
 
 func (p *LocalParty) FirstRound() tss.Round {
-	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end)
+	return newRound1(p.params, &p.keys, p.data, &p.temp, p.out, p.end, p.journal)
 }
 
 func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
 	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
 		round1, ok := round.(*round1)
 		if !ok {
-			return round.WrapError(errors.New("unable to Start(). party is in an unexpected round"))
+			return round.WrapError(tss.Errorf(tss.ErrInvalidState, "unable to Start(). party is in an unexpected round"))
 		}
 		if err := round1.prepare(); err != nil {
 			return round.WrapError(err)
@@ -117,6 +147,23 @@ func (p *LocalParty) Start() *tss.Error {
 	})
 }
 
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the secrets of the session, see tss.NewBaseParty. The key belongs to the caller and is left alone; s_i
+// is revealed in the last round, so it is only wiped if the party did not finish.
+func (p *LocalParty) wipe(finished bool) {
+	common.WipeBigInts(p.temp.wi, p.temp.ri)
+	cmt.WipeDeCommitment(p.temp.deCommit)
+	p.temp.wi, p.temp.ri, p.temp.deCommit = nil, nil, nil
+	if !finished && p.temp.si != nil {
+		common.WipeBytes(p.temp.si[:])
+		p.temp.si = nil
+	}
+	p.journal.Wipe()
+}
+
 func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
 	return tss.BaseUpdate(p, msg, TaskName)
 }
@@ -131,11 +178,11 @@ func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroa
 
 func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
-		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with an invalid sender: %s", msg))
 	}
 	// check that the message's "from index" will fit into the array
 	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
-		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "received msg with a sender index too great (%d <= %d)",
 			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
 	}
 	return p.BaseParty.ValidateMessage(msg)
@@ -164,9 +211,64 @@ func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
 		common.Logger.Warningf("unrecognised message ignored: %v", msg)
 		return false, nil
 	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
 	return true, nil
 }
 
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		return 1
+	case *SignRound2Message:
+		return 2
+	case *SignRound3Message:
+		return 3
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The rounds are re-run with the randomness and the messages of the
+// snapshot, and the party fails rather than send a message that differs from one sent before the snapshot, such as
+// a different round 1 commitment.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), bigIntBytes(p.temp.m), bigIntBytes(p.temp.keyDerivationDelta),
+		big.NewInt(int64(p.temp.fullBytesLen)).Bytes()}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
+func bigIntBytes(i *big.Int) []byte {
+	if i == nil {
+		return nil
+	}
+	return i.Bytes()
+}
+
 func (p *LocalParty) PartyID() *tss.PartyID {
 	return p.params.PartyID()
 }
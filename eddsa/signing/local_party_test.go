@@ -7,11 +7,13 @@
 package signing
 
 import (
+	"crypto/rand"
 	"encoding/hex"
 	"fmt"
 	"math/big"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/agl/ed25519/edwards25519"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
@@ -19,9 +21,12 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/ckd"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
 	"github.com/bnb-chain/tss-lib/v2/test"
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
 )
 
 const (
@@ -63,7 +68,7 @@ func TestE2EConcurrent(t *testing.T) {
 	msg := big.NewInt(200)
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
 
 		P := NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
 		parties = append(parties, P)
@@ -135,6 +140,12 @@ signing:
 
 				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
 				assert.True(t, ok, "eddsa verify must pass")
+
+				// the RFC 8032 encoding of the output is the signature
+				rfc8032, err := parties[0].data.ToEd25519()
+				assert.NoError(t, err)
+				assert.Equal(t, parties[0].data.Signature, rfc8032)
+				assert.True(t, parties[0].data.Verify(&pk))
 				t.Log("EDDSA signing test done.")
 				// END EDDSA verify
 
@@ -169,7 +180,7 @@ func TestE2EConcurrentWithLeadingZeroInMSG(t *testing.T) {
 	msg, _ := hex.DecodeString("00f163ee51bcaeff9cdff5e0e3c1a646abd19885fffbab0b3b4236e0cf95c9f5")
 	// init the parties
 	for i := 0; i < len(signPIDs); i++ {
-		params := tss.NewParameters(tss.Edwards(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
 		P := NewLocalParty(new(big.Int).SetBytes(msg), params, keys[i], outCh, endCh, len(msg)).(*LocalParty)
 		parties = append(parties, P)
 		go func(P *LocalParty) {
@@ -248,3 +259,242 @@ signing:
 		}
 	}
 }
+
+func TestE2EWithHDKeyDerivation(t *testing.T) {
+	setUp("info")
+
+	threshold := testThreshold
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	assert.Equal(t, testThreshold+1, len(keys))
+	assert.Equal(t, testThreshold+1, len(signPIDs))
+
+	chainCode := make([]byte, 32)
+	_, err = rand.Read(chainCode)
+	assert.NoError(t, err)
+
+	il, childPub, _, err := DeriveChildKeyFromPath(keys[0].EDDSAPub, chainCode, []uint32{12, 209, 3})
+	assert.NoError(t, err)
+	assert.False(t, childPub.Equals(keys[0].EDDSAPub))
+	err = UpdatePublicKeyAndAdjustBigXj(il, keys, childPub)
+	assert.NoError(t, err)
+
+	_, _, _, err = DeriveChildKeyFromPath(keys[0].EDDSAPub, chainCode, []uint32{ckd.HardenedKeyStart})
+	assert.Error(t, err, "hardened indices are not supported")
+
+	// PHASE: signing
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]*LocalParty, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+
+	msg := big.NewInt(200)
+	// init the parties
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[i], len(signPIDs), threshold)
+
+		P := NewLocalPartyWithKDD(msg, params, keys[i], il, outCh, endCh).(*LocalParty)
+		parties = append(parties, P)
+		go func(P *LocalParty) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+signing:
+	for {
+		select {
+		case err := <-errCh:
+			common.Logger.Errorf("Error: %s", err)
+			assert.FailNow(t, err.Error())
+			break signing
+
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			if dest == nil {
+				for _, P := range parties {
+					if P.PartyID().Index == msg.GetFrom().Index {
+						continue
+					}
+					go updater(P, msg, errCh)
+				}
+			} else {
+				if dest[0].Index == msg.GetFrom().Index {
+					t.Fatalf("party %d tried to send a message to itself (%d)", dest[0].Index, msg.GetFrom().Index)
+				}
+				go updater(parties[dest[0].Index], msg, errCh)
+			}
+
+		case <-endCh:
+			atomic.AddInt32(&ended, 1)
+			if atomic.LoadInt32(&ended) == int32(len(signPIDs)) {
+				t.Logf("Done. Received signature data from %d participants", ended)
+
+				// BEGIN EDDSA verify against the child key
+				pk := edwards.PublicKey{
+					Curve: tss.Edwards(),
+					X:     childPub.X(),
+					Y:     childPub.Y(),
+				}
+				newSig, err := edwards.ParseSignature(parties[0].data.Signature)
+				if !assert.NoError(t, err) {
+					break signing
+				}
+				ok := edwards.Verify(&pk, msg.Bytes(), newSig.R, newSig.S)
+				assert.True(t, ok, "eddsa verify must pass with the child key")
+				t.Log("EDDSA signing test with HD key derivation done.")
+				// END EDDSA verify
+
+				break signing
+			}
+		}
+	}
+}
+
+func TestCloseWipesSecrets(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, len(signPIDs))
+	params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[1], len(signPIDs), testThreshold)
+	P := NewLocalParty(big.NewInt(200), params, keys[1], outCh, nil).(*LocalParty)
+	assert.Nil(t, P.Start())
+	secrets := test.SecretWords(P.temp.wi, P.temp.ri, P.temp.deCommit[0])
+	assert.False(t, test.Wiped(secrets))
+
+	P.Close()
+	assert.True(t, test.Wiped(secrets), "w, r and the decommitment randomness must be wiped")
+	assert.Nil(t, P.temp.wi)
+	assert.Nil(t, P.temp.ri)
+	assert.Equal(t, 1, keys[1].Xi.Sign(), "the key of the caller is left alone")
+
+	_, uerr := P.Update(NewSignRound1Message(signPIDs[0], big.NewInt(1)))
+	if assert.NotNil(t, uerr) {
+		assert.Equal(t, tss.ErrAborted, uerr.Kind())
+	}
+}
+
+func TestE2ESnapshotAndRestore(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	// the messages are delivered one at a time, so the channels must hold every message of a round
+	errCh := make(chan *tss.Error, 10*len(signPIDs))
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	msg := big.NewInt(42)
+	newParty := func(i int) *LocalParty {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(msg, params, keys[i], outCh, endCh).(*LocalParty)
+	}
+	for i := range signPIDs {
+		P := newParty(i)
+		parties = append(parties, P)
+		if err := P.Start(); err != nil {
+			assert.FailNow(t, err.Error())
+		}
+	}
+
+	// party 0 crashes once it has received its round 2 messages
+	key := keystore.KEK(make([]byte, keystore.KEKSize))
+	received, crashed := 0, false
+	signatures := make([]*common.SignatureData, 0, len(signPIDs))
+	for len(signatures) < len(signPIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			dest := msg.GetTo()
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index || (dest != nil && dest[0].Index != P.PartyID().Index) {
+					continue
+				}
+				test.SharedPartyUpdater(P, msg, errCh)
+				if P.PartyID().Index == 0 {
+					received++
+				}
+			}
+			if received < 2*(len(signPIDs)-1) || crashed {
+				continue
+			}
+			crashed = true
+			snapshot, err := parties[0].(*LocalParty).Snapshot(key)
+			assert.NoError(t, err)
+
+			restored := newParty(0)
+			if err := restored.Restore(snapshot, key); err != nil {
+				assert.FailNow(t, err.Error())
+			}
+			assert.Equal(t, parties[0].(*LocalParty).temp.si, restored.temp.si)
+			assert.Contains(t, restored.String(), "round: 3")
+			parties[0] = restored
+		case data := <-endCh:
+			signatures = append(signatures, data)
+		case <-time.After(time.Minute):
+			t.Fatal("timed out waiting for the signatures")
+		}
+	}
+	assert.True(t, crashed)
+	pk := edwards.PublicKey{
+		Curve: tss.Edwards(),
+		X:     keys[0].EDDSAPub.X(),
+		Y:     keys[0].EDDSAPub.Y(),
+	}
+	for _, data := range signatures {
+		assert.Equal(t, signatures[0].GetSignature(), data.GetSignature())
+		assert.True(t, data.Verify(&pk), "eddsa verify must pass")
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *common.SignatureData, 1)
+	newParty := func(msg int64) *LocalParty {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(big.NewInt(msg), params, keys[0], outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(42)
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same round 1 commitment
+	assert.Nil(t, newParty(42).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session that signs another message
+	assert.NotNil(t, newParty(43).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the round 1 commitment is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(42).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
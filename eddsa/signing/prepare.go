@@ -7,25 +7,25 @@
 package signing
 
 import (
-	"crypto/elliptic"
-	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 // PrepareForSigning(), Fig. 7
-func PrepareForSigning(ec elliptic.Curve, i, pax int, xi *big.Int, ks []*big.Int) (wi *big.Int) {
-	modQ := common.ModInt(ec.Params().N)
+func PrepareForSigning(g group.AffineGroup, i, pax int, xi *big.Int, ks []*big.Int) (wi *big.Int) {
+	modQ := common.ModInt(g.Order())
 	if len(ks) != pax {
-		panic(fmt.Errorf("PrepareForSigning: len(ks) != pax (%d != %d)", len(ks), pax))
+		panic(tss.Errorf(tss.ErrInvalidParameters, "PrepareForSigning: len(ks) != pax (%d != %d)", len(ks), pax))
 	}
 	if len(ks) <= i {
-		panic(fmt.Errorf("PrepareForSigning: len(ks) <= i (%d <= %d)", len(ks), i))
+		panic(tss.Errorf(tss.ErrInvalidParameters, "PrepareForSigning: len(ks) <= i (%d <= %d)", len(ks), i))
 	}
 
 	// 1-4.
-	wi = xi
+	wi = new(big.Int).Set(xi) // wi is wiped once it is used, so it must not share xi
 	for j := 0; j < pax; j++ {
 		if j == i {
 			continue
@@ -33,7 +33,7 @@ func PrepareForSigning(ec elliptic.Curve, i, pax int, xi *big.Int, ks []*big.Int
 		ksj := ks[j]
 		ksi := ks[i]
 		if ksj.Cmp(ksi) == 0 {
-			panic(fmt.Errorf("index of two parties are equal"))
+			panic(tss.Errorf(tss.ErrInvalidParameters, "index of two parties are equal"))
 		}
 		// big.Int Div is calculated as: a/b = a * modInv(b,q)
 		coef := modQ.Mul(ks[j], modQ.ModInverse(new(big.Int).Sub(ksj, ksi)))
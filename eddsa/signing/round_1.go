@@ -7,8 +7,6 @@
 package signing
 
 import (
-	"errors"
-	"fmt"
 	"math/big"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
@@ -19,15 +17,15 @@ import (
 )
 
 // round 1 represents round 1 of the signing part of the EDDSA TSS spec
-func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Round {
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, journal *tss.SessionJournal) tss.Round {
 	return &round1{
-		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1},
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
 	}
 }
 
 func (round *round1) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 
 	round.number = 1
@@ -38,13 +36,13 @@ func (round *round1) Start() *tss.Error {
 	var err error
 	round.temp.ssid, err = round.getSSID()
 	if err != nil {
-		return round.WrapError(err)
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err))
 	}
 	// 1. select ri
-	ri := common.GetRandomPositiveInt(round.Rand(), round.Params().EC().Params().N)
+	ri := common.GetRandomPositiveInt(round.Rand(), round.Params().Group().Order())
 
 	// 2. make commitment
-	pointRi := crypto.ScalarBaseMult(round.Params().EC(), ri)
+	pointRi := crypto.ScalarBaseMult(round.Params().Group(), ri)
 	cmt := commitments.NewHashCommitment(round.Rand(), pointRi.X(), pointRi.Y())
 
 	// 3. store r1 message pieces
@@ -58,7 +56,9 @@ func (round *round1) Start() *tss.Error {
 	// 4. broadcast commitment
 	r1msg2 := NewSignRound1Message(round.PartyID(), cmt.C)
 	round.temp.signRound1Messages[i] = r1msg2
-	round.out <- r1msg2
+	if err := round.send(r1msg2); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -99,10 +99,19 @@ func (round *round1) prepare() error {
 	xi := round.key.Xi
 	ks := round.key.Ks
 
+	if round.temp.keyDerivationDelta != nil {
+		// adding the key derivation delta to the xi's
+		// Suppose x has shamir shares x_0,     x_1,     ..., x_n
+		// So x + D has shamir shares  x_0 + D, x_1 + D, ..., x_n + D
+		mod := common.ModInt(round.Params().Group().Order())
+		xi = mod.Add(round.temp.keyDerivationDelta, xi)
+		round.key.Xi = xi
+	}
+
 	if round.Threshold()+1 > len(ks) {
-		return fmt.Errorf("t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
+		return tss.Errorf(tss.ErrInvalidParameters, "t+1=%d is not satisfied by the key count of %d", round.Threshold()+1, len(ks))
 	}
-	wi := PrepareForSigning(round.Params().EC(), i, len(ks), xi, ks)
+	wi := PrepareForSigning(round.Params().Group(), i, len(ks), xi, ks)
 
 	round.temp.wi = wi
 	return nil
@@ -7,7 +7,6 @@
 package signing
 
 import (
-	"errors"
 	"math/big"
 
 	errors2 "github.com/pkg/errors"
@@ -18,7 +17,7 @@ import (
 
 func (round *round2) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 	round.number = 2
 	round.started = true
@@ -36,13 +35,15 @@ func (round *round2) Start() *tss.Error {
 	ContextI := append(round.temp.ssid, new(big.Int).SetUint64(uint64(i)).Bytes()...)
 	pir, err := schnorr.NewZKProof(ContextI, round.temp.ri, round.temp.pointRi, round.Rand())
 	if err != nil {
-		return round.WrapError(errors2.Wrapf(err, "NewZKProof(ri, pointRi)"))
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors2.Wrapf(err, "NewZKProof(ri, pointRi)")))
 	}
 
 	// 3. BROADCAST de-commitments of Shamir poly*G and Schnorr prove
 	r2msg2 := NewSignRound2Message(round.PartyID(), round.temp.deCommit, pir)
 	round.temp.signRound2Messages[i] = r2msg2
-	round.out <- r2msg2
+	if err := round.send(r2msg2); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -16,12 +16,13 @@ import (
 
 	"github.com/bnb-chain/tss-lib/v2/crypto"
 	"github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 	"github.com/bnb-chain/tss-lib/v2/tss"
 )
 
 func (round *round3) Start() *tss.Error {
 	if round.started {
-		return round.WrapError(errors.New("round already started"))
+		return round.WrapError(tss.Errorf(tss.ErrInvalidState, "round already started"))
 	}
 
 	round.number = 3
@@ -29,9 +30,9 @@ func (round *round3) Start() *tss.Error {
 	round.resetOK()
 
 	// 1. init R
-	var R edwards25519.ExtendedGroupElement
-	riBytes := bigIntToEncodedBytes(round.temp.ri)
-	edwards25519.GeScalarMultBase(&R, riBytes)
+	ed := group.Ed25519()
+	ri := ed.NewScalar().SetBigInt(round.temp.ri)
+	R := ed.NewPoint().ScalarBaseMult(ri)
 
 	// 2-6. compute R
 	i := round.PartyID().Index
@@ -46,33 +47,35 @@ func (round *round3) Start() *tss.Error {
 		cmtDeCmt := commitments.HashCommitDecommit{C: round.temp.cjs[j], D: r2msg.UnmarshalDeCommitment()}
 		ok, coordinates := cmtDeCmt.DeCommit()
 		if !ok {
-			return round.WrapError(errors.New("de-commitment verify failed"))
+			return round.WrapError(tss.Errorf(tss.ErrBadCommitment, "de-commitment verify failed"))
 		}
 		if len(coordinates) != 2 {
-			return round.WrapError(errors.New("length of de-commitment should be 2"))
+			return round.WrapError(tss.Errorf(tss.ErrBadCommitment, "length of de-commitment should be 2"))
 		}
 
-		Rj, err := crypto.NewECPoint(round.Params().EC(), coordinates[0], coordinates[1])
-		Rj = Rj.EightInvEight()
+		Rj, err := crypto.NewECPoint(round.Params().Group(), coordinates[0], coordinates[1])
 		if err != nil {
-			return round.WrapError(errors.Wrapf(err, "NewECPoint(Rj)"), Pj)
+			return round.WrapError(tss.WithKind(tss.ErrInvalidMessage, errors.Wrapf(err, "NewECPoint(Rj)")), Pj)
 		}
-		proof, err := r2msg.UnmarshalZKProof(round.Params().EC())
+		proof, err := r2msg.UnmarshalZKProof(round.Params().Group())
 		if err != nil {
-			return round.WrapError(errors.New("failed to unmarshal Rj proof"), Pj)
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to unmarshal Rj proof"), Pj)
 		}
 		ok = proof.Verify(ContextJ, Rj)
 		if !ok {
-			return round.WrapError(errors.New("failed to prove Rj"), Pj)
+			return round.WrapError(tss.Errorf(tss.ErrInvalidProof, "failed to prove Rj"), Pj)
 		}
 
-		extendedRj := ecPointToExtendedElement(round.Params().EC(), Rj.X(), Rj.Y(), round.Rand())
-		R = addExtendedElements(R, extendedRj)
+		R.Add(R, Rj.Point())
 	}
 
 	// 7. compute lambda
 	var encodedR [32]byte
-	R.ToBytes(&encodedR)
+	encodedRBz, err := R.MarshalBinary()
+	if err != nil {
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors.Wrapf(err, "R.MarshalBinary()")))
+	}
+	copy(encodedR[:], encodedRBz)
 	encodedPubKey := ecPointToEncodedBytes(round.key.EDDSAPub.X(), round.key.EDDSAPub.Y())
 
 	// h = hash512(k || A || M)
@@ -92,10 +95,25 @@ func (round *round3) Start() *tss.Error {
 	h.Sum(lambda[:0])
 	var lambdaReduced [32]byte
 	edwards25519.ScReduce(&lambdaReduced, &lambda)
+	lambdaScalar := ed.NewScalar()
+	if err := lambdaScalar.UnmarshalBinary(lambdaReduced[:]); err != nil {
+		return round.WrapError(tss.WithKind(tss.ErrInternal, err))
+	}
 
-	// 8. compute si
+	// 8. compute si = lambda * wi + ri
+	si := ed.NewScalar().Mul(lambdaScalar, ed.NewScalar().SetBigInt(round.temp.wi))
+	si.Add(si, ri)
 	var localS [32]byte
-	edwards25519.ScMulAdd(&localS, &lambdaReduced, bigIntToEncodedBytes(round.temp.wi), riBytes)
+	localSBz, err := si.MarshalBinary()
+	if err != nil {
+		return round.WrapError(tss.WithKind(tss.ErrInternal, errors.Wrapf(err, "si.MarshalBinary()")))
+	}
+	copy(localS[:], localSBz)
+
+	// security: w_i and r_i are no longer needed
+	common.WipeBigInts(round.temp.wi, round.temp.ri)
+	common.WipeBytes(localSBz)
+	round.temp.wi, round.temp.ri = nil, nil
 
 	// 9. store r3 message pieces
 	round.temp.si = &localS
@@ -104,7 +122,9 @@ func (round *round3) Start() *tss.Error {
 	// 10. broadcast si to other parties
 	r3msg := NewSignRound3Message(round.PartyID(), encodedBytesToBigInt(&localS))
 	round.temp.signRound3Messages[round.PartyID().Index] = r3msg
-	round.out <- r3msg
+	if err := round.send(r3msg); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -0,0 +1,284 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+)
+
+type (
+	// Ciphersuite is a FROST ciphersuite (RFC 9591, Section 6): a prime-order group, its encodings and the hash
+	// functions H1-H5 derived from one hash function.
+	Ciphersuite interface {
+		// ContextString is the domain separation prefix of the ciphersuite
+		ContextString() string
+		Group() group.AffineGroup
+
+		SerializeElement(p *crypto.ECPoint) ([]byte, error)
+		DeserializeElement(b []byte) (*crypto.ECPoint, error)
+		SerializeScalar(s *big.Int) []byte
+		DeserializeScalar(b []byte) (*big.Int, error)
+
+		// H1 derives binding factors, H2 the challenge and H3 nonces, all as scalars
+		H1(m []byte) *big.Int
+		H2(m []byte) *big.Int
+		H3(m []byte) *big.Int
+		// H4 hashes the message and H5 the commitment list
+		H4(m []byte) []byte
+		H5(m []byte) []byte
+	}
+
+	ed25519Suite   struct{}
+	secp256k1Suite struct{}
+)
+
+const (
+	ed25519ContextString   = "FROST-ED25519-SHA512-v1"
+	secp256k1ContextString = "FROST-secp256k1-SHA256-v1"
+
+	scalarLen = 32
+)
+
+var (
+	_ Ciphersuite = ed25519Suite{}
+	_ Ciphersuite = secp256k1Suite{}
+
+	ErrInvalidElement = errors.New("frost: invalid group element")
+	ErrInvalidScalar  = errors.New("frost: invalid scalar")
+)
+
+// Ed25519 returns the FROST(Ed25519, SHA-512) ciphersuite. Its signatures verify as plain Ed25519 signatures.
+func Ed25519() Ciphersuite {
+	return ed25519Suite{}
+}
+
+// Secp256k1 returns the FROST(secp256k1, SHA-256) ciphersuite.
+func Secp256k1() Ciphersuite {
+	return secp256k1Suite{}
+}
+
+// CiphersuiteFor returns the ciphersuite of the given group, which must be group.Ed25519() or group.Secp256k1().
+func CiphersuiteFor(g group.AffineGroup) (Ciphersuite, error) {
+	switch g {
+	case group.Ed25519():
+		return Ed25519(), nil
+	case group.Secp256k1():
+		return Secp256k1(), nil
+	case nil:
+		return nil, errors.New("frost: unknown curve")
+	default:
+		return nil, fmt.Errorf("frost: no ciphersuite for curve %s", g.Name())
+	}
+}
+
+// ----- //
+
+func (ed25519Suite) ContextString() string {
+	return ed25519ContextString
+}
+
+func (ed25519Suite) Group() group.AffineGroup {
+	return group.Ed25519()
+}
+
+func (ed25519Suite) SerializeElement(p *crypto.ECPoint) ([]byte, error) {
+	return serializeElement(p)
+}
+
+func (s ed25519Suite) DeserializeElement(b []byte) (*crypto.ECPoint, error) {
+	return deserializeElement(s.Group(), b)
+}
+
+// SerializeScalar encodes s as 32 little-endian bytes
+func (s ed25519Suite) SerializeScalar(x *big.Int) []byte {
+	return reverse(fixedBytes(new(big.Int).Mod(x, s.Group().Order()), scalarLen))
+}
+
+func (s ed25519Suite) DeserializeScalar(b []byte) (*big.Int, error) {
+	if len(b) != scalarLen {
+		return nil, ErrInvalidScalar
+	}
+	x := new(big.Int).SetBytes(reverse(append([]byte{}, b...)))
+	if x.Cmp(s.Group().Order()) >= 0 {
+		return nil, ErrInvalidScalar
+	}
+	return x, nil
+}
+
+func (s ed25519Suite) H1(m []byte) *big.Int {
+	return s.hashToScalar([]byte(ed25519ContextString+"rho"), m)
+}
+
+// H2 has no context string so that signatures are compatible with Ed25519
+func (s ed25519Suite) H2(m []byte) *big.Int {
+	return s.hashToScalar(nil, m)
+}
+
+func (s ed25519Suite) H3(m []byte) *big.Int {
+	return s.hashToScalar([]byte(ed25519ContextString+"nonce"), m)
+}
+
+func (ed25519Suite) H4(m []byte) []byte {
+	h := sha512.Sum512(append([]byte(ed25519ContextString+"msg"), m...))
+	return h[:]
+}
+
+func (ed25519Suite) H5(m []byte) []byte {
+	h := sha512.Sum512(append([]byte(ed25519ContextString+"com"), m...))
+	return h[:]
+}
+
+// hashToScalar interprets SHA-512(prefix || m) as a little-endian integer mod L
+func (s ed25519Suite) hashToScalar(prefix, m []byte) *big.Int {
+	h := sha512.Sum512(append(append([]byte{}, prefix...), m...))
+	x := new(big.Int).SetBytes(reverse(h[:]))
+	return x.Mod(x, s.Group().Order())
+}
+
+// ----- //
+
+func (secp256k1Suite) ContextString() string {
+	return secp256k1ContextString
+}
+
+func (secp256k1Suite) Group() group.AffineGroup {
+	return group.Secp256k1()
+}
+
+// SerializeElement encodes p in the 33-byte compressed SEC1 format
+func (secp256k1Suite) SerializeElement(p *crypto.ECPoint) ([]byte, error) {
+	return serializeElement(p)
+}
+
+func (s secp256k1Suite) DeserializeElement(b []byte) (*crypto.ECPoint, error) {
+	return deserializeElement(s.Group(), b)
+}
+
+// SerializeScalar encodes s as 32 big-endian bytes
+func (s secp256k1Suite) SerializeScalar(x *big.Int) []byte {
+	return fixedBytes(new(big.Int).Mod(x, s.Group().Order()), scalarLen)
+}
+
+func (s secp256k1Suite) DeserializeScalar(b []byte) (*big.Int, error) {
+	if len(b) != scalarLen {
+		return nil, ErrInvalidScalar
+	}
+	x := new(big.Int).SetBytes(b)
+	if x.Cmp(s.Group().Order()) >= 0 {
+		return nil, ErrInvalidScalar
+	}
+	return x, nil
+}
+
+func (s secp256k1Suite) H1(m []byte) *big.Int {
+	return s.hashToField(m, []byte(secp256k1ContextString+"rho"))
+}
+
+func (s secp256k1Suite) H2(m []byte) *big.Int {
+	return s.hashToField(m, []byte(secp256k1ContextString+"chal"))
+}
+
+func (s secp256k1Suite) H3(m []byte) *big.Int {
+	return s.hashToField(m, []byte(secp256k1ContextString+"nonce"))
+}
+
+func (secp256k1Suite) H4(m []byte) []byte {
+	h := sha256.Sum256(append([]byte(secp256k1ContextString+"msg"), m...))
+	return h[:]
+}
+
+func (secp256k1Suite) H5(m []byte) []byte {
+	h := sha256.Sum256(append([]byte(secp256k1ContextString+"com"), m...))
+	return h[:]
+}
+
+// hashToField is hash_to_field(m, 1) of RFC 9380 with expand_message_xmd(SHA-256) and L = 48, over the scalar field
+func (s secp256k1Suite) hashToField(m, dst []byte) *big.Int {
+	x := new(big.Int).SetBytes(expandMessageXMD(m, dst, 48))
+	return x.Mod(x, s.Group().Order())
+}
+
+// ----- //
+
+// serializeElement encodes p as its group does, which is the encoding of RFC 9591 for both ciphersuites
+func serializeElement(p *crypto.ECPoint) ([]byte, error) {
+	if p == nil || !p.ValidateBasic() {
+		return nil, ErrInvalidElement
+	}
+	return p.Point().MarshalBinary()
+}
+
+// deserializeElement rejects non-canonical encodings, the identity and points outside the prime-order subgroup
+func deserializeElement(g group.AffineGroup, b []byte) (*crypto.ECPoint, error) {
+	point := g.NewPoint()
+	if err := point.UnmarshalBinary(b); err != nil {
+		return nil, ErrInvalidElement
+	}
+	p, err := crypto.NewECPointFromGroupPoint(point)
+	if err != nil {
+		return nil, ErrInvalidElement
+	}
+	return p, nil
+}
+
+// expandMessageXMD is expand_message_xmd of RFC 9380, Section 5.3.1, with SHA-256
+func expandMessageXMD(msg, dst []byte, lenInBytes int) []byte {
+	const bInBytes, sInBytes = sha256.Size, sha256.BlockSize
+	ell := (lenInBytes + bInBytes - 1) / bInBytes
+	if ell > 255 || lenInBytes > 65535 || len(dst) > 255 {
+		panic("expandMessageXMD: invalid parameters")
+	}
+	dstPrime := append(append([]byte{}, dst...), byte(len(dst)))
+
+	h := sha256.New()
+	h.Write(make([]byte, sInBytes))
+	h.Write(msg)
+	h.Write([]byte{byte(lenInBytes >> 8), byte(lenInBytes), 0})
+	h.Write(dstPrime)
+	b0 := h.Sum(nil)
+
+	h.Reset()
+	h.Write(b0)
+	h.Write([]byte{1})
+	h.Write(dstPrime)
+	bi := h.Sum(nil)
+
+	uniform := append(make([]byte, 0, ell*bInBytes), bi...)
+	for i := 2; i <= ell; i++ {
+		xored := make([]byte, bInBytes)
+		for j := range b0 {
+			xored[j] = b0[j] ^ bi[j]
+		}
+		h.Reset()
+		h.Write(xored)
+		h.Write([]byte{byte(i)})
+		h.Write(dstPrime)
+		bi = h.Sum(nil)
+		uniform = append(uniform, bi...)
+	}
+	return uniform[:lenInBytes]
+}
+
+// fixedBytes returns x as big-endian bytes left-padded to size
+func fixedBytes(x *big.Int, size int) []byte {
+	b := make([]byte, size)
+	return x.FillBytes(b)
+}
+
+func reverse(b []byte) []byte {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return b
+}
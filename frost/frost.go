@@ -0,0 +1,203 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package frost holds the ciphersuites and the group operations of FROST (RFC 9591), shared by the
+// frost/preprocess and frost/signing protocols.
+package frost
+
+import (
+	"errors"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+type (
+	// SigningCommitment is a participant's public commitment to a hiding and a binding nonce
+	SigningCommitment struct {
+		// Identifier is the participant's share index, the Ks value of its key
+		Identifier      *big.Int
+		Hiding, Binding *crypto.ECPoint
+	}
+)
+
+// Identifier returns the FROST identifier of a party with share index k
+func Identifier(cs Ciphersuite, k *big.Int) *big.Int {
+	return new(big.Int).Mod(k, cs.Group().Order())
+}
+
+// NonceGenerate returns a fresh nonce mixed with the secret share, so that a weak random source alone does not
+// reveal it (RFC 9591, Section 4.1)
+func NonceGenerate(cs Ciphersuite, secret *big.Int, rand io.Reader) (*big.Int, error) {
+	randomBytes := make([]byte, 32)
+	if _, err := io.ReadFull(rand, randomBytes); err != nil {
+		return nil, err
+	}
+	return cs.H3(append(randomBytes, cs.SerializeScalar(secret)...)), nil
+}
+
+// DeriveInterpolatingValue returns the Lagrange coefficient at 0 of the participant with the given identifier
+func DeriveInterpolatingValue(cs Ciphersuite, identifiers []*big.Int, identifier *big.Int) (*big.Int, error) {
+	modQ := common.ModInt(cs.Group().Order())
+	num, den := big.NewInt(1), big.NewInt(1)
+	found := false
+	for _, xj := range identifiers {
+		if xj.Cmp(identifier) == 0 {
+			if found {
+				return nil, errors.New("frost: duplicate identifier")
+			}
+			found = true
+			continue
+		}
+		num = modQ.Mul(num, xj)
+		den = modQ.Mul(den, modQ.Sub(xj, identifier))
+	}
+	if !found {
+		return nil, errors.New("frost: identifier is not a participant")
+	}
+	if den.Sign() == 0 {
+		return nil, errors.New("frost: duplicate identifier")
+	}
+	return modQ.Mul(num, modQ.ModInverse(den)), nil
+}
+
+// ValidateElement checks that p is an element of the ciphersuite's group that DeserializeElement would accept
+func ValidateElement(cs Ciphersuite, p *crypto.ECPoint) error {
+	b, err := cs.SerializeElement(p)
+	if err != nil {
+		return err
+	}
+	_, err = cs.DeserializeElement(b)
+	return err
+}
+
+// EncodeGroupCommitmentList encodes the commitments sorted by identifier
+func EncodeGroupCommitmentList(cs Ciphersuite, commitments []*SigningCommitment) ([]byte, error) {
+	sorted := make([]*SigningCommitment, len(commitments))
+	copy(sorted, commitments)
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Identifier.Cmp(sorted[b].Identifier) < 0
+	})
+	encoded := make([]byte, 0, len(sorted)*(scalarLen+2*(scalarLen+1)))
+	for _, c := range sorted {
+		hiding, err := cs.SerializeElement(c.Hiding)
+		if err != nil {
+			return nil, err
+		}
+		binding, err := cs.SerializeElement(c.Binding)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, cs.SerializeScalar(c.Identifier)...)
+		encoded = append(encoded, hiding...)
+		encoded = append(encoded, binding...)
+	}
+	return encoded, nil
+}
+
+// ComputeBindingFactors returns the binding factor of each commitment, in the order they are given
+func ComputeBindingFactors(cs Ciphersuite, groupPublicKey *crypto.ECPoint, commitments []*SigningCommitment, msg []byte) ([]*big.Int, error) {
+	groupPublicKeyEnc, err := cs.SerializeElement(groupPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	encodedCommitments, err := EncodeGroupCommitmentList(cs, commitments)
+	if err != nil {
+		return nil, err
+	}
+	prefix := append(groupPublicKeyEnc, cs.H4(msg)...)
+	prefix = append(prefix, cs.H5(encodedCommitments)...)
+
+	bindingFactors := make([]*big.Int, len(commitments))
+	for i, c := range commitments {
+		rhoInput := append(append([]byte{}, prefix...), cs.SerializeScalar(c.Identifier)...)
+		bindingFactors[i] = cs.H1(rhoInput)
+	}
+	return bindingFactors, nil
+}
+
+// ComputeGroupCommitment returns R = Σ (Di + ρi * Ei)
+func ComputeGroupCommitment(cs Ciphersuite, commitments []*SigningCommitment, bindingFactors []*big.Int) (*crypto.ECPoint, error) {
+	var R *crypto.ECPoint
+	for i, c := range commitments {
+		share, err := c.Hiding.Add(c.Binding.ScalarMult(bindingFactors[i]))
+		if err != nil {
+			return nil, err
+		}
+		if R == nil {
+			R = share
+		} else if R, err = R.Add(share); err != nil {
+			return nil, err
+		}
+	}
+	if R == nil || !R.ValidateBasic() {
+		return nil, ErrInvalidElement
+	}
+	return R, nil
+}
+
+// ComputeChallenge returns c = H2(R || PK || msg)
+func ComputeChallenge(cs Ciphersuite, groupCommitment, groupPublicKey *crypto.ECPoint, msg []byte) (*big.Int, error) {
+	rEnc, err := cs.SerializeElement(groupCommitment)
+	if err != nil {
+		return nil, err
+	}
+	pkEnc, err := cs.SerializeElement(groupPublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return cs.H2(append(append(rEnc, pkEnc...), msg...)), nil
+}
+
+// VerifySignatureShare checks z_i * G = Di + ρi * Ei + (c * λi) * PKi
+func VerifySignatureShare(cs Ciphersuite, zi *big.Int, commitment *SigningCommitment, bindingFactor, lambda, challenge *big.Int, publicKeyShare *crypto.ECPoint) bool {
+	modQ := common.ModInt(cs.Group().Order())
+	commShare, err := commitment.Hiding.Add(commitment.Binding.ScalarMult(bindingFactor))
+	if err != nil {
+		return false
+	}
+	r, err := commShare.Add(publicKeyShare.ScalarMult(modQ.Mul(challenge, lambda)))
+	if err != nil {
+		return false
+	}
+	return crypto.ScalarBaseMult(cs.Group(), zi).Equals(r)
+}
+
+// EncodeSignature returns SerializeElement(R) || SerializeScalar(z)
+func EncodeSignature(cs Ciphersuite, R *crypto.ECPoint, z *big.Int) ([]byte, error) {
+	rEnc, err := cs.SerializeElement(R)
+	if err != nil {
+		return nil, err
+	}
+	return append(rEnc, cs.SerializeScalar(z)...), nil
+}
+
+// Verify checks a signature produced by frost/signing (RFC 9591, Appendix B) against the group public key
+func Verify(cs Ciphersuite, groupPublicKey *crypto.ECPoint, msg, sig []byte) bool {
+	if len(sig) <= scalarLen {
+		return false
+	}
+	R, err := cs.DeserializeElement(sig[:len(sig)-scalarLen])
+	if err != nil {
+		return false
+	}
+	z, err := cs.DeserializeScalar(sig[len(sig)-scalarLen:])
+	if err != nil {
+		return false
+	}
+	c, err := ComputeChallenge(cs, R, groupPublicKey, msg)
+	if err != nil {
+		return false
+	}
+	right, err := R.Add(groupPublicKey.ScalarMult(c))
+	if err != nil {
+		return false
+	}
+	return crypto.ScalarBaseMult(cs.Group(), z).Equals(right)
+}
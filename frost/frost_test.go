@@ -0,0 +1,71 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+// test vectors of RFC 9380, Appendix K.1
+func TestExpandMessageXMD(t *testing.T) {
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	tests := []struct {
+		msg, expected string
+	}{
+		{"", "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235"},
+		{"abc", "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, hex.EncodeToString(expandMessageXMD([]byte(tt.msg), dst, 0x20)))
+	}
+}
+
+func TestSerializeRoundTrip(t *testing.T) {
+	for _, cs := range []Ciphersuite{Ed25519(), Secp256k1()} {
+		s := common.GetRandomPositiveInt(rand.Reader, cs.Group().Order())
+		sBz := cs.SerializeScalar(s)
+		assert.Len(t, sBz, scalarLen)
+		s2, err := cs.DeserializeScalar(sBz)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, s.Cmp(s2))
+		_, err = cs.DeserializeScalar(fixedBytes(cs.Group().Order(), scalarLen))
+		assert.Error(t, err, "scalars must be reduced")
+
+		p := crypto.ScalarBaseMult(cs.Group(), s)
+		pBz, err := cs.SerializeElement(p)
+		assert.NoError(t, err)
+		p2, err := cs.DeserializeElement(pBz)
+		assert.NoError(t, err)
+		assert.True(t, p.Equals(p2))
+	}
+}
+
+func TestDeriveInterpolatingValue(t *testing.T) {
+	cs := Secp256k1()
+	modQ := common.ModInt(cs.Group().Order())
+	// f(x) = secret + slope * x, interpolated at 0 from the shares at 1 and 2
+	secret, slope := common.GetRandomPositiveInt(rand.Reader, cs.Group().Order()), common.GetRandomPositiveInt(rand.Reader, cs.Group().Order())
+	ids := []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(5)}
+	sum := big.NewInt(0)
+	for _, id := range ids[:2] {
+		lambda, err := DeriveInterpolatingValue(cs, ids[:2], id)
+		assert.NoError(t, err)
+		sum = modQ.Add(sum, modQ.Mul(lambda, modQ.Add(secret, modQ.Mul(slope, id))))
+	}
+	assert.Equal(t, 0, secret.Cmp(sum))
+
+	_, err := DeriveInterpolatingValue(cs, ids[:2], ids[2])
+	assert.Error(t, err, "the identifier must be a participant")
+}
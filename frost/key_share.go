@@ -0,0 +1,96 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package frost
+
+import (
+	"encoding/hex"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	ecdsaKeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsaKeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// KeyShare is the part of a keygen output used by FROST. It is taken from the save data of eddsa/keygen for
+	// Ed25519 or of ecdsa/keygen for secp256k1, so existing keys can sign without a new keygen.
+	KeyShare struct {
+		// secret fields (not shared, but stored locally)
+		Xi, ShareID *big.Int // xi, kj
+
+		// original indexes (ki in signing preparation phase)
+		Ks []*big.Int
+
+		// public keys (Xj = uj*G for each Pj)
+		BigXj []*crypto.ECPoint // Xj
+
+		// the group public key
+		PubKey *crypto.ECPoint // y
+	}
+)
+
+// NewKeyShareFromEdDSA returns the FROST(Ed25519, SHA-512) key share of an eddsa/keygen party
+func NewKeyShareFromEdDSA(key eddsaKeygen.LocalPartySaveData) KeyShare {
+	return KeyShare{
+		Xi:      key.Xi,
+		ShareID: key.ShareID,
+		Ks:      key.Ks,
+		BigXj:   key.BigXj,
+		PubKey:  key.EDDSAPub,
+	}
+}
+
+// NewKeyShareFromECDSA returns the FROST(secp256k1, SHA-256) key share of an ecdsa/keygen party
+func NewKeyShareFromECDSA(key ecdsaKeygen.LocalPartySaveData) KeyShare {
+	return KeyShare{
+		Xi:      key.Xi,
+		ShareID: key.ShareID,
+		Ks:      key.Ks,
+		BigXj:   key.BigXj,
+		PubKey:  key.ECDSAPub,
+	}
+}
+
+func (key KeyShare) ValidateBasic() bool {
+	if key.Xi == nil || key.ShareID == nil || key.PubKey == nil || !key.PubKey.ValidateBasic() {
+		return false
+	}
+	if len(key.Ks) == 0 || len(key.Ks) != len(key.BigXj) {
+		return false
+	}
+	for j := range key.Ks {
+		if key.Ks[j] == nil || key.BigXj[j] == nil || !key.BigXj[j].ValidateBasic() {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildKeyShareSubset re-creates the KeyShare to contain data for only the list of signing parties.
+func BuildKeyShareSubset(sourceData KeyShare, sortedIDs tss.SortedPartyIDs) KeyShare {
+	keysToIndices := make(map[string]int, len(sourceData.Ks))
+	for j, kj := range sourceData.Ks {
+		keysToIndices[hex.EncodeToString(kj.Bytes())] = j
+	}
+	newData := KeyShare{
+		Xi:      sourceData.Xi,
+		ShareID: sourceData.ShareID,
+		Ks:      make([]*big.Int, sortedIDs.Len()),
+		BigXj:   make([]*crypto.ECPoint, sortedIDs.Len()),
+		PubKey:  sourceData.PubKey,
+	}
+	for j, id := range sortedIDs {
+		savedIdx, ok := keysToIndices[hex.EncodeToString(id.Key)]
+		if !ok {
+			panic("BuildKeyShareSubset: unable to find a signer party in the key share")
+		}
+		newData.Ks[j] = sourceData.Ks[savedIdx]
+		newData.BigXj[j] = sourceData.BigXj[savedIdx]
+	}
+	return newData
+}
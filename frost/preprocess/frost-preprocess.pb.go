@@ -0,0 +1,162 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/frost-preprocess.proto
+
+package preprocess
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a BROADCAST message sent to all parties during Round 1 of the FROST nonce preprocessing protocol.
+type PreprocessRound1Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hiding  [][]byte `protobuf:"bytes,1,rep,name=hiding,proto3" json:"hiding,omitempty"`
+	Binding [][]byte `protobuf:"bytes,2,rep,name=binding,proto3" json:"binding,omitempty"`
+}
+
+func (x *PreprocessRound1Message) Reset() {
+	*x = PreprocessRound1Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_frost_preprocess_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PreprocessRound1Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreprocessRound1Message) ProtoMessage() {}
+
+func (x *PreprocessRound1Message) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_frost_preprocess_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreprocessRound1Message.ProtoReflect.Descriptor instead.
+func (*PreprocessRound1Message) Descriptor() ([]byte, []int) {
+	return file_protob_frost_preprocess_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PreprocessRound1Message) GetHiding() [][]byte {
+	if x != nil {
+		return x.Hiding
+	}
+	return nil
+}
+
+func (x *PreprocessRound1Message) GetBinding() [][]byte {
+	if x != nil {
+		return x.Binding
+	}
+	return nil
+}
+
+var File_protob_frost_preprocess_proto protoreflect.FileDescriptor
+
+var file_protob_frost_preprocess_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x2d, 0x70,
+	0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x1f, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e,
+	0x66, 0x72, 0x6f, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x22, 0x4b, 0x0a, 0x17, 0x50, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x6f,
+	0x75, 0x6e, 0x64, 0x31, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68,
+	0x69, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x06, 0x68, 0x69, 0x64,
+	0x69, 0x6e, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x62, 0x69, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x12, 0x5a,
+	0x10, 0x66, 0x72, 0x6f, 0x73, 0x74, 0x2f, 0x70, 0x72, 0x65, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_protob_frost_preprocess_proto_rawDescOnce sync.Once
+	file_protob_frost_preprocess_proto_rawDescData = file_protob_frost_preprocess_proto_rawDesc
+)
+
+func file_protob_frost_preprocess_proto_rawDescGZIP() []byte {
+	file_protob_frost_preprocess_proto_rawDescOnce.Do(func() {
+		file_protob_frost_preprocess_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_frost_preprocess_proto_rawDescData)
+	})
+	return file_protob_frost_preprocess_proto_rawDescData
+}
+
+var file_protob_frost_preprocess_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_frost_preprocess_proto_goTypes = []interface{}{
+	(*PreprocessRound1Message)(nil), // 0: binance.tsslib.frost.preprocess.PreprocessRound1Message
+}
+var file_protob_frost_preprocess_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_protob_frost_preprocess_proto_init() }
+func file_protob_frost_preprocess_proto_init() {
+	if File_protob_frost_preprocess_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_frost_preprocess_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PreprocessRound1Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_frost_preprocess_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_frost_preprocess_proto_goTypes,
+		DependencyIndexes: file_protob_frost_preprocess_proto_depIdxs,
+		MessageInfos:      file_protob_frost_preprocess_proto_msgTypes,
+	}.Build()
+	File_protob_frost_preprocess_proto = out.File
+	file_protob_frost_preprocess_proto_rawDesc = nil
+	file_protob_frost_preprocess_proto_goTypes = nil
+	file_protob_frost_preprocess_proto_depIdxs = nil
+}
@@ -0,0 +1,182 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package preprocess
+
+import (
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+	const count = 3
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	// PHASE: preprocessing
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *NonceData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty(params, frost.NewKeyShareFromEdDSA(keys[i]), count, outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	nonces := make([]*NonceData, len(signPIDs))
+	var ended int
+preprocessing:
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+			break preprocessing
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			nonces[signPIDs.FindByKey(data.ShareID).Index] = data
+			if ended++; ended == len(signPIDs) {
+				break preprocessing
+			}
+		}
+	}
+
+	// every party holds the same commitments, and its own nonces open them
+	for i, data := range nonces {
+		assert.True(t, data.ValidateBasic())
+		assert.True(t, data.IsBoundTo(signPIDs, signPIDs[i]))
+		assert.Equal(t, count, data.Len())
+		for s := 0; s < count; s++ {
+			for j := range signPIDs {
+				assert.True(t, data.Commitments[s][j].Hiding.Equals(nonces[0].Commitments[s][j].Hiding))
+				assert.True(t, data.Commitments[s][j].Binding.Equals(nonces[0].Commitments[s][j].Binding))
+			}
+			own := data.Commitments[s][i]
+			assert.True(t, crypto.ScalarBaseMult(group.Ed25519(), data.HidingNonces[s]).Equals(own.Hiding))
+			assert.True(t, crypto.ScalarBaseMult(group.Ed25519(), data.BindingNonces[s]).Equals(own.Binding))
+		}
+	}
+
+	// a slot can be consumed once
+	_, _, _, err = nonces[0].Consume(1)
+	assert.NoError(t, err)
+	assert.True(t, nonces[0].IsConsumed(1))
+	assert.False(t, nonces[0].IsConsumed(0))
+	_, _, _, err = nonces[0].Consume(1)
+	assert.Equal(t, ErrNoncesConsumed, err)
+	_, _, _, err = nonces[0].Consume(count)
+	assert.Equal(t, ErrNoncesConsumed, err)
+}
+
+func TestMessagesBeforeStart(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *NonceData, len(signPIDs))
+	parties := make([]tss.Party, 0, len(signPIDs))
+	for i := range signPIDs {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		parties = append(parties, NewLocalParty(params, frost.NewKeyShareFromEdDSA(keys[i]), 1, outCh, endCh))
+	}
+
+	// the first party receives the messages of all of the others before it is started
+	errCh := make(chan *tss.Error, len(signPIDs))
+	for _, P := range parties[1:] {
+		assert.Nil(t, P.Start())
+		test.SharedPartyUpdater(parties[0], <-outCh, errCh)
+	}
+	assert.Empty(t, errCh)
+	assert.Nil(t, parties[0].Start())
+	<-outCh
+	select {
+	case data := <-endCh:
+		assert.Equal(t, signPIDs[0].KeyInt(), data.ShareID)
+	default:
+		assert.Fail(t, "the first party should finish once it is started")
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *NonceData, 1)
+	newParty := func(count int) *LocalParty {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(params, frost.NewKeyShareFromEdDSA(keys[0]), count, outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(2)
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same commitments to its nonces
+	restored := newParty(2)
+	assert.Nil(t, restored.Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+	assert.Equal(t, P.temp.hidingNonces, restored.temp.hidingNonces)
+
+	// the snapshot cannot be restored into a session with another number of slots
+	assert.NotNil(t, newParty(3).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the commitments is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(2).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
@@ -0,0 +1,80 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package preprocess
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into frost-preprocess.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that preprocessing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*PreprocessRound1Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewPreprocessRound1Message(
+	from *tss.PartyID,
+	hiding, binding []*crypto.ECPoint,
+) (tss.ParsedMessage, error) {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	hidingFlat, err := crypto.FlattenECPoints(hiding)
+	if err != nil {
+		return nil, err
+	}
+	bindingFlat, err := crypto.FlattenECPoints(binding)
+	if err != nil {
+		return nil, err
+	}
+	content := &PreprocessRound1Message{
+		Hiding:  common.BigIntsToBytes(hidingFlat),
+		Binding: common.BigIntsToBytes(bindingFlat),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg), nil
+}
+
+func (m *PreprocessRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		len(m.GetHiding()) > 0 &&
+		len(m.GetHiding())%2 == 0 &&
+		len(m.GetHiding()) == len(m.GetBinding()) &&
+		common.NonEmptyMultiBytes(m.GetHiding()) &&
+		common.NonEmptyMultiBytes(m.GetBinding())
+}
+
+func (m *PreprocessRound1Message) UnmarshalHiding(g group.AffineGroup) ([]*crypto.ECPoint, error) {
+	return unmarshalPoints(g, m.GetHiding())
+}
+
+func (m *PreprocessRound1Message) UnmarshalBinding(g group.AffineGroup) ([]*crypto.ECPoint, error) {
+	return unmarshalPoints(g, m.GetBinding())
+}
+
+func unmarshalPoints(g group.AffineGroup, bzs [][]byte) ([]*crypto.ECPoint, error) {
+	points, err := crypto.UnFlattenECPoints(g, common.MultiBytesToBigInts(bzs))
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, errors.New("no commitments")
+	}
+	return points, nil
+}
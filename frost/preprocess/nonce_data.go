@@ -0,0 +1,97 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package preprocess
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var (
+	ErrNoncesConsumed = errors.New("the nonces of this slot have already been consumed")
+)
+
+type (
+	// NonceData is one party's output of the preprocessing protocol, consumed by frost/signing. It holds a number of
+	// slots, each with this party's nonce pair and the commitments of all parties. It is bound to the signer set it
+	// was produced for, and each slot MUST be used to sign one message only: signing two messages with the same
+	// nonces reveals the key share.
+	NonceData struct {
+		// the signing parties' original indexes, in the order of the sorted party IDs
+		Ks      []*big.Int
+		ShareID *big.Int
+
+		// HidingNonces[s] and BindingNonces[s] are this party's nonces (d, e) of slot s, nil once consumed
+		HidingNonces, BindingNonces []*big.Int
+		// Commitments[s][j] is the commitment of Pj to its nonces of slot s
+		Commitments [][]*frost.SigningCommitment
+
+		mtx sync.Mutex
+	}
+)
+
+func (d *NonceData) ValidateBasic() bool {
+	if d == nil || d.ShareID == nil || len(d.Ks) == 0 {
+		return false
+	}
+	if len(d.HidingNonces) == 0 || len(d.HidingNonces) != len(d.BindingNonces) || len(d.HidingNonces) != len(d.Commitments) {
+		return false
+	}
+	for _, commitments := range d.Commitments {
+		if len(commitments) != len(d.Ks) {
+			return false
+		}
+		for _, c := range commitments {
+			if c == nil || c.Identifier == nil || !c.Hiding.ValidateBasic() || !c.Binding.ValidateBasic() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Len returns the number of slots
+func (d *NonceData) Len() int {
+	return len(d.Commitments)
+}
+
+// IsConsumed reports whether the nonces of the slot have been consumed, or the slot does not exist
+func (d *NonceData) IsConsumed(slot int) bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	return slot < 0 || slot >= len(d.HidingNonces) || d.HidingNonces[slot] == nil || d.BindingNonces[slot] == nil
+}
+
+// Consume returns this party's nonces and the commitments of the slot, and removes the nonces so that they can
+// never be used again. Persist the NonceData after this call if it is kept in storage.
+func (d *NonceData) Consume(slot int) (hiding, binding *big.Int, commitments []*frost.SigningCommitment, err error) {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if slot < 0 || slot >= len(d.HidingNonces) || d.HidingNonces[slot] == nil || d.BindingNonces[slot] == nil {
+		return nil, nil, nil, ErrNoncesConsumed
+	}
+	hiding, binding = d.HidingNonces[slot], d.BindingNonces[slot]
+	d.HidingNonces[slot], d.BindingNonces[slot] = nil, nil
+	return hiding, binding, d.Commitments[slot], nil
+}
+
+// IsBoundTo reports whether the nonces were produced by self for the signing parties ids
+func (d *NonceData) IsBoundTo(ids tss.SortedPartyIDs, self *tss.PartyID) bool {
+	if len(d.Ks) != len(ids) || d.ShareID.Cmp(self.KeyInt()) != 0 {
+		return false
+	}
+	for j, id := range ids {
+		if d.Ks[j].Cmp(id.KeyInt()) != 0 {
+			return false
+		}
+	}
+	return true
+}
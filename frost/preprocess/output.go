@@ -0,0 +1,89 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package preprocess
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *output) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	cs, err := frost.CiphersuiteFor(round.Group())
+	if err != nil {
+		return round.WrapError(err)
+	}
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	count := round.temp.count
+
+	// 1. collect the commitments of every party for each slot
+	commitments := make([][]*frost.SigningCommitment, count)
+	for s := range commitments {
+		commitments[s] = make([]*frost.SigningCommitment, len(Ps))
+	}
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		hiding, binding := round.temp.hiding, round.temp.binding
+		if j != i {
+			r1msg := round.temp.preprocessRound1Messages[j].Content().(*PreprocessRound1Message)
+			var err1, err2 error
+			hiding, err1 = r1msg.UnmarshalHiding(round.Group())
+			binding, err2 = r1msg.UnmarshalBinding(round.Group())
+			if err1 != nil || err2 != nil || len(hiding) != count || len(binding) != count {
+				culprits = append(culprits, Pj)
+				continue
+			}
+		}
+		identifier := frost.Identifier(cs, round.key.Ks[j])
+		for s := 0; s < count; s++ {
+			if frost.ValidateElement(cs, hiding[s]) != nil || frost.ValidateElement(cs, binding[s]) != nil {
+				culprits = append(culprits, Pj)
+				break
+			}
+			commitments[s][j] = &frost.SigningCommitment{Identifier: identifier, Hiding: hiding[s], Binding: binding[s]}
+		}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("received invalid nonce commitments"), culprits...)
+	}
+
+	// 2. output this party's nonces with everyone's commitments
+	nonces := &NonceData{
+		Ks:            round.key.Ks,
+		ShareID:       round.key.ShareID,
+		HidingNonces:  round.temp.hidingNonces,
+		BindingNonces: round.temp.bindingNonces,
+		Commitments:   commitments,
+	}
+	round.temp.hidingNonces, round.temp.bindingNonces = nil, nil
+	round.end <- nonces
+	return nil
+}
+
+func (round *output) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *output) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *output) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,101 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package preprocess
+
+import (
+	"errors"
+	"math/big"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 1 of FROST (RFC 9591, Section 5.1), run for a batch of nonce slots
+func newRound1(params *tss.Parameters, key *frost.KeyShare, temp *localTempData, out chan<- tss.Message, end chan<- *NonceData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, key, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if round.temp.count < 1 {
+		return round.WrapError(errors.New("the number of nonce slots must be positive"))
+	}
+	if !round.key.ValidateBasic() || round.key.ShareID.Cmp(round.PartyID().KeyInt()) != 0 {
+		return round.WrapError(errors.New("the key share does not belong to this party"))
+	}
+	cs, err := frost.CiphersuiteFor(round.Group())
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	// 1. for each slot, (d, e) <- nonce_generate(xi) and (D, E) = (g^d, g^e)
+	count := round.temp.count
+	round.temp.hidingNonces, round.temp.bindingNonces = make([]*big.Int, count), make([]*big.Int, count)
+	round.temp.hiding, round.temp.binding = make([]*crypto.ECPoint, count), make([]*crypto.ECPoint, count)
+	for s := 0; s < count; s++ {
+		d, err := frost.NonceGenerate(cs, round.key.Xi, round.Rand())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NonceGenerate(hiding)"))
+		}
+		e, err := frost.NonceGenerate(cs, round.key.Xi, round.Rand())
+		if err != nil {
+			return round.WrapError(errors2.Wrapf(err, "NonceGenerate(binding)"))
+		}
+		round.temp.hidingNonces[s], round.temp.bindingNonces[s] = d, e
+		round.temp.hiding[s], round.temp.binding[s] = crypto.ScalarBaseMult(round.Group(), d), crypto.ScalarBaseMult(round.Group(), e)
+	}
+
+	// 2. BROADCAST the commitments
+	i := round.PartyID().Index
+	r1msg, err := NewPreprocessRound1Message(round.PartyID(), round.temp.hiding, round.temp.binding)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.preprocessRound1Messages[i] = r1msg
+	if err := round.send(r1msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.preprocessRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*PreprocessRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &output{round}
+}
@@ -0,0 +1,92 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	cs, err := frost.CiphersuiteFor(round.Group())
+	if err != nil {
+		return round.WrapError(err)
+	}
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	commitments := round.temp.commitments
+	ids := identifiers(commitments)
+	modQ := common.ModInt(round.Group().Order())
+
+	// 1. verify each signature share against the commitments and public key share of its sender, and sum them
+	z := round.temp.zi
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		zj := r1msg.UnmarshalZ()
+		lambdaJ, err := frost.DeriveInterpolatingValue(cs, ids, commitments[j].Identifier)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		if zj.Cmp(round.Group().Order()) >= 0 ||
+			!frost.VerifySignatureShare(cs, zj, commitments[j], round.temp.bindingFactors[j], lambdaJ, round.temp.challenge, round.key.BigXj[j]) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		z = modQ.Add(z, zj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("signature share verification failed"), culprits...)
+	}
+
+	// 2. the signature is (R, z)
+	rEnc, err := cs.SerializeElement(round.temp.bigR)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	zEnc := cs.SerializeScalar(z)
+	sig := append(append([]byte{}, rEnc...), zEnc...)
+	if !frost.Verify(cs, round.key.PubKey, round.temp.m, sig) {
+		return round.WrapError(errors.New("signature verification failed"))
+	}
+
+	// save the signature for final output
+	round.data.Signature = sig
+	round.data.R = rEnc
+	round.data.S = zEnc
+	round.data.M = round.temp.m
+	round.end <- round.data
+	return nil
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
@@ -0,0 +1,249 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/frost/preprocess"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	// SessionSnapshotKind identifies the snapshot of a signing session in a keystore container
+	SessionSnapshotKind = "frost/signing.Session"
+)
+
+// Implements Party
+// Implements Stringer
+var (
+	_ tss.Party    = (*LocalParty)(nil)
+	_ fmt.Stringer = (*LocalParty)(nil)
+)
+
+type (
+	// LocalParty runs the FROST signing round (RFC 9591, Section 5.2) with one slot of the nonces produced by
+	// frost/preprocess for the same set of parties.
+	LocalParty struct {
+		*tss.BaseParty
+		params *tss.Parameters
+
+		key     frost.KeyShare
+		nonces  *preprocess.NonceData
+		slot    int
+		temp    localTempData
+		data    *common.SignatureData
+		journal *tss.SessionJournal
+
+		// outbound messaging
+		out chan<- tss.Message
+		end chan<- *common.SignatureData
+	}
+
+	localMessageStore struct {
+		signRound1Messages []tss.ParsedMessage
+	}
+
+	localTempData struct {
+		localMessageStore
+
+		// temp data (thrown away after sign)
+		m []byte
+		hidingNonce,
+		bindingNonce *big.Int
+		commitments []*frost.SigningCommitment
+
+		// round 1
+		bindingFactors []*big.Int
+		challenge      *big.Int
+		bigR           *crypto.ECPoint
+		zi             *big.Int
+	}
+)
+
+// NewLocalParty returns a party that signs msg with the given slot of nonces. The slot is consumed when the party
+// is started, and Start() fails if the nonces were produced for another set of parties or have already been consumed.
+// All parties must agree on the slot before they start.
+func NewLocalParty(
+	msg []byte,
+	params *tss.Parameters,
+	key frost.KeyShare,
+	nonces *preprocess.NonceData,
+	slot int,
+	out chan<- tss.Message,
+	end chan<- *common.SignatureData,
+) tss.Party {
+	partyCount := len(params.Parties().IDs())
+	p := &LocalParty{
+		params: params,
+		key:    frost.BuildKeyShareSubset(key, params.Parties().IDs()),
+		nonces: nonces,
+		slot:   slot,
+		temp:   localTempData{},
+		data:   &common.SignatureData{},
+		out:    out,
+		end:    end,
+	}
+	p.BaseParty = tss.NewBaseParty(p.wipe)
+	// msgs init
+	p.temp.signRound1Messages = make([]tss.ParsedMessage, partyCount)
+	// temp data init
+	p.temp.m = msg
+	return p
+}
+
+func (p *LocalParty) FirstRound() tss.Round {
+	return newRound1(p.params, &p.key, p.data, &p.temp, p.out, p.end, p.journal)
+}
+
+func (p *LocalParty) Start() *tss.Error {
+	if p.params.SessionSnapshots() && p.journal == nil {
+		journal, err := tss.NewSessionJournal(p.params.Rand())
+		if err != nil {
+			return p.WrapError(tss.WithKind(tss.ErrInternal, err))
+		}
+		p.journal = journal
+	}
+	return tss.BaseStart(p, TaskName, func(round tss.Round) *tss.Error {
+		if !p.nonces.ValidateBasic() {
+			return round.WrapError(errors.New("the nonces are invalid"))
+		}
+		if !p.nonces.IsBoundTo(p.params.Parties().IDs(), p.PartyID()) {
+			return round.WrapError(errors.New("the nonces do not match the set of signing parties"))
+		}
+		// the nonces of a restored party were consumed before its snapshot, which holds the zi it sent
+		if kept := p.journal.Kept(); kept != nil {
+			if p.slot < 0 || p.nonces.Len() <= p.slot {
+				return round.WrapError(preprocess.ErrNoncesConsumed)
+			}
+			p.temp.zi, p.temp.commitments = new(big.Int).SetBytes(kept), p.nonces.Commitments[p.slot]
+			return nil
+		}
+		hiding, binding, commitments, err := p.nonces.Consume(p.slot)
+		if err != nil {
+			return round.WrapError(err)
+		}
+		p.temp.hidingNonce, p.temp.bindingNonce, p.temp.commitments = hiding, binding, commitments
+		return nil
+	})
+}
+
+func (p *LocalParty) StartWithContext(ctx context.Context) *tss.Error {
+	return tss.BaseStartWithContext(ctx, p, func() { p.temp = localTempData{} })
+}
+
+// wipe zeroes the nonces of the slot once they have been consumed, see tss.NewBaseParty
+func (p *LocalParty) wipe(bool) {
+	common.WipeBigInts(p.temp.hidingNonce, p.temp.bindingNonce)
+	p.temp.hidingNonce, p.temp.bindingNonce = nil, nil
+	p.journal.Wipe()
+}
+
+func (p *LocalParty) Update(msg tss.ParsedMessage) (ok bool, err *tss.Error) {
+	return tss.BaseUpdate(p, msg, TaskName)
+}
+
+func (p *LocalParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *LocalParty) ValidateMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if ok, err := p.BaseParty.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	// check that the message's "from index" will fit into the array
+	if maxFromIdx := len(p.params.Parties().IDs()) - 1; maxFromIdx < msg.GetFrom().Index {
+		return false, p.WrapError(fmt.Errorf("received msg with a sender index too great (%d <= %d)",
+			maxFromIdx, msg.GetFrom().Index), msg.GetFrom())
+	}
+	return true, nil
+}
+
+func (p *LocalParty) StoreMessage(msg tss.ParsedMessage) (bool, *tss.Error) {
+	// ValidateBasic is cheap; double-check the message here in case the public StoreMessage was called externally
+	if ok, err := p.ValidateMessage(msg); !ok || err != nil {
+		return ok, err
+	}
+	fromPIdx := msg.GetFrom().Index
+
+	// switch/case is necessary to store any messages beyond current round
+	// this does not handle message replays. we expect the caller to apply replay and spoofing protection.
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		p.temp.signRound1Messages[fromPIdx] = msg
+	default: // unrecognised message, just ignore!
+		common.Logger.Warningf("unrecognised message ignored: %v", msg)
+		return false, nil
+	}
+	if p.journal != nil {
+		if err := p.journal.Receive(msg); err != nil {
+			return false, p.WrapError(err)
+		}
+	}
+	return true, nil
+}
+
+// MessageRound returns the number of the round that accepts msg, or 0 if none does
+func (p *LocalParty) MessageRound(msg tss.ParsedMessage) int {
+	switch msg.Content().(type) {
+	case *SignRound1Message:
+		return 1
+	}
+	return 0
+}
+
+// Snapshot returns the state of the session sealed with key, from which Restore resumes it after a crash. The
+// parameters of the party must have session snapshots enabled. The snapshot holds the secrets of the session; it
+// should be taken after each message the party receives and must be deleted once the session has finished.
+func (p *LocalParty) Snapshot(key keystore.Key) ([]byte, error) {
+	return tss.BaseSnapshot(p, p.journal, SessionSnapshotKind, p.sessionBinding(), p.params.Rand(), key)
+}
+
+// Restore resumes the session of a snapshot in a party constructed with the same arguments as the party of the
+// snapshot; it is called instead of Start. The slot of nonces was consumed before the snapshot and is not consumed
+// again: the party sends the zi of the snapshot once more, and fails rather than send a zi that differs from it.
+func (p *LocalParty) Restore(snapshot []byte, key keystore.Key) *tss.Error {
+	journal, msgs, round, err := tss.OpenSessionSnapshot(snapshot, SessionSnapshotKind, p.sessionBinding(), p.params.Parties(), key)
+	if err != nil {
+		return p.WrapError(err)
+	}
+	p.journal = journal
+	return tss.BaseRestore(p, journal, msgs, round)
+}
+
+// sessionBinding identifies the inputs of the session, so that a snapshot is not restored into another session
+func (p *LocalParty) sessionBinding() []byte {
+	in := [][]byte{p.PartyID().GetKey(), p.temp.m, big.NewInt(int64(p.slot)).Bytes()}
+	if p.nonces.ValidateBasic() && 0 <= p.slot && p.slot < p.nonces.Len() {
+		for _, c := range p.nonces.Commitments[p.slot] {
+			in = append(in, c.Identifier.Bytes(), c.Hiding.X().Bytes(), c.Hiding.Y().Bytes(), c.Binding.X().Bytes(), c.Binding.Y().Bytes())
+		}
+	}
+	for _, id := range p.params.Parties().IDs() {
+		in = append(in, id.GetKey())
+	}
+	return common.SHA512_256(in...)
+}
+
+func (p *LocalParty) PartyID() *tss.PartyID {
+	return p.params.PartyID()
+}
+
+func (p *LocalParty) String() string {
+	return fmt.Sprintf("id: %s, %s", p.PartyID(), p.BaseParty.String())
+}
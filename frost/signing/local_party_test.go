@@ -0,0 +1,266 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	ecdsaKeygen "github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	eddsaKeygen "github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/frost/preprocess"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EEd25519(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := eddsaKeygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	shares := make([]frost.KeyShare, len(keys))
+	for i, key := range keys {
+		shares[i] = frost.NewKeyShareFromEdDSA(key)
+	}
+
+	msgs := [][]byte{[]byte("hello, frost"), {}}
+	sigs := runPreprocessAndSign(t, group.Ed25519(), shares, signPIDs, msgs)
+
+	// FROST(Ed25519, SHA-512) signatures are Ed25519 signatures
+	pub, err := frost.Ed25519().SerializeElement(keys[0].EDDSAPub)
+	assert.NoError(t, err)
+	for s, msg := range msgs {
+		for _, sig := range sigs[s] {
+			assert.True(t, ed25519.Verify(pub, msg, sig.Signature), "ed25519 verify must pass")
+		}
+	}
+}
+
+func TestE2ESecp256k1(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := ecdsaKeygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	shares := make([]frost.KeyShare, len(keys))
+	for i, key := range keys {
+		shares[i] = frost.NewKeyShareFromECDSA(key)
+	}
+
+	msgs := [][]byte{[]byte("hello, frost"), []byte("hello again")}
+	sigs := runPreprocessAndSign(t, group.Secp256k1(), shares, signPIDs, msgs)
+
+	for s, msg := range msgs {
+		for _, sig := range sigs[s] {
+			assert.True(t, frost.Verify(frost.Secp256k1(), keys[0].ECDSAPub, msg, sig.Signature), "frost verify must pass")
+			assert.False(t, frost.Verify(frost.Secp256k1(), keys[0].ECDSAPub, []byte("another message"), sig.Signature))
+		}
+	}
+}
+
+// runPreprocessAndSign preprocesses one nonce slot per message, signs each message with its slot and returns the
+// signatures output by every party
+func runPreprocessAndSign(t *testing.T, ec group.AffineGroup, shares []frost.KeyShare, signPIDs tss.SortedPartyIDs, msgs [][]byte) [][]*common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	updater := test.SharedPartyUpdater
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	nonces := runPreprocess(t, ec, shares, signPIDs, len(msgs))
+
+	// PHASE: signing, one message per slot
+	sigs := make([][]*common.SignatureData, len(msgs))
+	for slot, msg := range msgs {
+		parties := make([]tss.Party, 0, len(signPIDs))
+		endCh := make(chan *common.SignatureData, len(signPIDs))
+		for i := 0; i < len(signPIDs); i++ {
+			params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+			P := NewLocalParty(msg, params, shares[i], nonces[i], slot, outCh, endCh)
+			parties = append(parties, P)
+			go func(P tss.Party) {
+				if err := P.Start(); err != nil {
+					errCh <- err
+				}
+			}(P)
+		}
+
+		var ended int32
+	signing:
+		for {
+			select {
+			case err := <-errCh:
+				assert.FailNow(t, err.Error())
+
+			case msg := <-outCh:
+				routeTestMessage(parties, msg, errCh, updater)
+
+			case sig := <-endCh:
+				sigs[slot] = append(sigs[slot], sig)
+				if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+					break signing
+				}
+			}
+		}
+		for _, sig := range sigs[slot] {
+			assert.Equal(t, sigs[slot][0].Signature, sig.Signature, "all parties must output the same signature")
+		}
+	}
+
+	// PHASE: a slot is single-use
+	for i, data := range nonces {
+		for slot := range msgs {
+			assert.True(t, data.IsConsumed(slot))
+		}
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := NewLocalParty([]byte("another message"), params, shares[i], data, 0, outCh, nil)
+		assert.NotNil(t, P.Start(), "consumed nonces must not sign again")
+	}
+	return sigs
+}
+
+// runPreprocess returns the nonces of each party for the given number of slots
+func runPreprocess(t *testing.T, ec group.AffineGroup, shares []frost.KeyShare, signPIDs tss.SortedPartyIDs, slots int) []*preprocess.NonceData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	updater := test.SharedPartyUpdater
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+
+	preParties := make([]tss.Party, 0, len(signPIDs))
+	noncesCh := make(chan *preprocess.NonceData, len(signPIDs))
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(ec, p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := preprocess.NewLocalParty(params, shares[i], slots, outCh, noncesCh)
+		preParties = append(preParties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	nonces := make([]*preprocess.NonceData, len(signPIDs))
+	for received := 0; received < len(signPIDs); {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			routeTestMessage(preParties, msg, errCh, updater)
+
+		case data := <-noncesCh:
+			// nonces survive a round trip through JSON
+			bz, err := json.Marshal(data)
+			assert.NoError(t, err)
+			var loaded preprocess.NonceData
+			assert.NoError(t, json.Unmarshal(bz, &loaded))
+			assert.True(t, loaded.ValidateBasic())
+			assert.Equal(t, slots, loaded.Len())
+			nonces[signPIDs.FindByKey(loaded.ShareID).Index] = &loaded
+			received++
+		}
+	}
+	return nonces
+}
+
+func routeTestMessage(parties []tss.Party, msg tss.Message, errCh chan *tss.Error, updater func(tss.Party, tss.Message, chan<- *tss.Error)) {
+	for _, P := range parties {
+		if P.PartyID().Index == msg.GetFrom().Index {
+			continue
+		}
+		go updater(P, msg, errCh)
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	setUp("info")
+
+	keys, signPIDs, err := eddsaKeygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	shares := make([]frost.KeyShare, len(keys))
+	for i, key := range keys {
+		shares[i] = frost.NewKeyShareFromEdDSA(key)
+	}
+	nonces := runPreprocess(t, group.Ed25519(), shares, signPIDs, 1)
+
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	msg := []byte("hello, frost")
+	newParty := func(i int, msg []byte) *LocalParty {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(msg, params, shares[i], nonces[i], 0, outCh, endCh).(*LocalParty)
+	}
+	parties := make([]tss.Party, 0, len(signPIDs))
+	r1msgs := make([]tss.Message, 0, len(signPIDs))
+	for i := range signPIDs {
+		P := newParty(i, msg)
+		assert.Nil(t, P.Start())
+		parties = append(parties, P)
+		r1msgs = append(r1msgs, <-outCh)
+	}
+	sent, _, err := r1msgs[0].WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := parties[0].(*LocalParty).Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party does not consume the nonces again and re-emits the same zi
+	restored := newParty(0, msg)
+	assert.Nil(t, restored.Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session that signs another message
+	assert.NotNil(t, newParty(0, []byte("another message")).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot that no longer holds the zi that was sent is refused and nothing is sent
+	tampered, err := test.SetSnapshotField(snapshot, SessionSnapshotKind, "kept", big.NewInt(1).Bytes())
+	assert.NoError(t, err)
+	err = newParty(0, msg).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+
+	// the restored party completes the signature
+	parties[0] = restored
+	for _, msg := range r1msgs {
+		for _, P := range parties {
+			test.SharedPartyUpdater(P, msg, errCh)
+		}
+	}
+	assert.Zero(t, len(errCh))
+	pub, err := frost.Ed25519().SerializeElement(keys[0].EDDSAPub)
+	assert.NoError(t, err)
+	for range signPIDs {
+		assert.True(t, ed25519.Verify(pub, msg, (<-endCh).Signature), "ed25519 verify must pass")
+	}
+}
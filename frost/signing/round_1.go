@@ -0,0 +1,126 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 represents round 2 of FROST (RFC 9591, Section 5.2); round 1 of FROST is frost/preprocess
+func newRound1(params *tss.Parameters, key *frost.KeyShare, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if !round.key.ValidateBasic() || round.key.ShareID.Cmp(round.PartyID().KeyInt()) != 0 {
+		return round.WrapError(errors.New("the key share does not belong to this party"))
+	}
+	cs, err := frost.CiphersuiteFor(round.Group())
+	if err != nil {
+		return round.WrapError(err)
+	}
+	i := round.PartyID().Index
+	commitments := round.temp.commitments
+
+	// 1. the binding factors ρj, the group commitment R and the challenge c
+	bindingFactors, err := frost.ComputeBindingFactors(cs, round.key.PubKey, commitments, round.temp.m)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	bigR, err := frost.ComputeGroupCommitment(cs, commitments, bindingFactors)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	challenge, err := frost.ComputeChallenge(cs, bigR, round.key.PubKey, round.temp.m)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	lambdaI, err := frost.DeriveInterpolatingValue(cs, identifiers(commitments), commitments[i].Identifier)
+	if err != nil {
+		return round.WrapError(err)
+	}
+
+	// 2. zi = di + ei * ρi + λi * xi * c; a restored party has no nonces and sends the zi of its snapshot again
+	zi := round.temp.zi
+	if zi == nil {
+		modQ := common.ModInt(round.Group().Order())
+		zi = modQ.Add(
+			modQ.Add(round.temp.hidingNonce, modQ.Mul(round.temp.bindingNonce, bindingFactors[i])),
+			modQ.Mul(modQ.Mul(lambdaI, round.key.Xi), challenge))
+
+		// security: the nonces were consumed and must never be used again
+		common.WipeBigInts(round.temp.hidingNonce, round.temp.bindingNonce)
+		round.temp.hidingNonce, round.temp.bindingNonce = nil, nil
+		if round.journal != nil {
+			round.journal.Keep(zi.Bytes())
+		}
+	}
+
+	round.temp.bindingFactors = bindingFactors
+	round.temp.challenge = challenge
+	round.temp.bigR = bigR
+	round.temp.zi = zi
+
+	// 3. BROADCAST zi
+	r1msg := NewSignRound1Message(round.PartyID(), zi)
+	round.temp.signRound1Messages[i] = r1msg
+	if err := round.send(r1msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+func identifiers(commitments []*frost.SigningCommitment) []*big.Int {
+	ids := make([]*big.Int, len(commitments))
+	for j, c := range commitments {
+		ids[j] = c.Identifier
+	}
+	return ids
+}
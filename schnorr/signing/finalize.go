@@ -0,0 +1,112 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func (round *finalization) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 3
+	round.started = true
+	round.resetOK()
+
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	modQ := common.ModInt(round.Group().Order())
+	key := round.temp.signingKey
+
+	// 1. verify zj * G = gR * (Dj + ρj * Ej) + (e * λj * gQ * gP) * Xj for each Pj, and sum the shares
+	z := round.temp.zi
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		round.ok[j] = true
+		if j == i {
+			continue
+		}
+		r2msg := round.temp.signRound2Messages[j].Content().(*SignRound2Message)
+		zj := r2msg.UnmarshalZ()
+		if zj.Cmp(round.Group().Order()) >= 0 || !round.verifyShare(j, zj) {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		z = modQ.Add(z, zj)
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("signature share verification failed"), culprits...)
+	}
+
+	// 2. the secret key of Q is gQ * gP * x + gQ * t, so z gets e * gQ * t
+	z = modQ.Add(z, modQ.Mul(round.temp.challenge, key.tweakTerm))
+
+	// 3. the signature is x(R) || z
+	rBz, zBz := xOnly(round.temp.bigR), z.FillBytes(make([]byte, 32))
+	sigBz := append(append([]byte{}, rBz...), zBz...)
+	sig, err := schnorr.ParseSignature(sigBz)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	pub, err := schnorr.ParsePubKey(xOnly(key.Q))
+	if err != nil {
+		return round.WrapError(err)
+	}
+	if !sig.Verify(round.temp.m, pub) {
+		return round.WrapError(errors.New("signature verification failed"))
+	}
+
+	// save the signature for final output
+	round.data.Signature = sigBz
+	round.data.R = rBz
+	round.data.S = zBz
+	round.data.M = round.temp.m
+	round.end <- round.data
+	return nil
+}
+
+func (round *finalization) verifyShare(j int, zj *big.Int) bool {
+	modQ := common.ModInt(round.Group().Order())
+	c := round.temp.commitments[j]
+	lambdaJ, err := frost.DeriveInterpolatingValue(frost.Secp256k1(), identifiers(round.temp.commitments), c.Identifier)
+	if err != nil {
+		return false
+	}
+	nonceCommitment, err := c.Hiding.Add(c.Binding.ScalarMult(round.temp.bindingFactors[j]))
+	if err != nil {
+		return false
+	}
+	keyCoef := modQ.Mul(modQ.Mul(round.temp.challenge, lambdaJ), round.temp.signingKey.keyFactor)
+	right, err := nonceCommitment.ScalarMult(round.temp.nonceFactor).Add(round.key.BigXj[j].ScalarMult(keyCoef))
+	if err != nil {
+		return false
+	}
+	return crypto.ScalarBaseMult(round.Group(), zj).Equals(right)
+}
+
+func (round *finalization) CanAccept(msg tss.ParsedMessage) bool {
+	// not expecting any incoming messages in this round
+	return false
+}
+
+func (round *finalization) Update() (bool, *tss.Error) {
+	// not expecting any incoming messages in this round
+	return false, nil
+}
+
+func (round *finalization) NextRound() tss.Round {
+	return nil // finished!
+}
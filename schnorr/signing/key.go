@@ -0,0 +1,94 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+)
+
+type (
+	// signingKey is the BIP-340 key that the parties sign for, derived from the keygen public key P:
+	// Q = gQ * (gP * P + t * G), where gP and gQ in {1, -1} make P and Q have an even Y and t is the BIP-341 tweak.
+	// the secret key of Q is keyFactor * x + tweakTerm, where x is the key shared by keygen.
+	signingKey struct {
+		Q         *crypto.ECPoint
+		keyFactor *big.Int
+		tweakTerm *big.Int
+	}
+)
+
+// TaprootOutputKey returns the 32-byte x-only BIP-341 output key of a keygen public key for the given script tree
+// merkle root; an empty merkleRoot gives a key-path only output (BIP-86). Pay to this key to spend with
+// NewLocalPartyWithTapTweak.
+func TaprootOutputKey(internalKey *crypto.ECPoint, merkleRoot []byte) ([]byte, error) {
+	key, err := newSigningKey(internalKey, true, merkleRoot)
+	if err != nil {
+		return nil, err
+	}
+	return xOnly(key.Q), nil
+}
+
+// XOnlyPubKey returns the 32-byte x-only BIP-340 public key of a keygen public key, as signed for by NewLocalParty
+func XOnlyPubKey(pubKey *crypto.ECPoint) []byte {
+	return xOnly(pubKey)
+}
+
+func newSigningKey(P *crypto.ECPoint, tweaked bool, merkleRoot []byte) (*signingKey, error) {
+	if P == nil || !P.ValidateBasic() {
+		return nil, errors.New("invalid public key")
+	}
+	g := P.Group()
+	modQ := common.ModInt(g.Order())
+	one, minusOne := big.NewInt(1), new(big.Int).Sub(g.Order(), big.NewInt(1))
+
+	// 1. lift P to the point with an even Y
+	gP := one
+	if !hasEvenY(P) {
+		P, gP = negate(P), minusOne
+	}
+	if !tweaked {
+		return &signingKey{Q: P, keyFactor: gP, tweakTerm: big.NewInt(0)}, nil
+	}
+
+	// 2. Q = P + t * G, t = hashTapTweak(x(P) || merkleRoot)
+	tBz := chainhash.TaggedHash(chainhash.TagTapTweak, xOnly(P), merkleRoot)
+	t := new(big.Int).SetBytes(tBz[:])
+	if t.Cmp(g.Order()) >= 0 {
+		return nil, errors.New("the taproot tweak is out of range")
+	}
+	Q, err := P.Add(crypto.ScalarBaseMult(g, t))
+	if err != nil {
+		return nil, errors.New("the tweaked key is the point at infinity")
+	}
+
+	// 3. lift Q to the point with an even Y
+	gQ := one
+	if !hasEvenY(Q) {
+		Q, gQ = negate(Q), minusOne
+	}
+	return &signingKey{Q: Q, keyFactor: modQ.Mul(gQ, gP), tweakTerm: modQ.Mul(gQ, t)}, nil
+}
+
+func hasEvenY(p *crypto.ECPoint) bool {
+	return p.Y().Bit(0) == 0
+}
+
+func negate(p *crypto.ECPoint) *crypto.ECPoint {
+	// the negation of a point other than the identity is not the identity either
+	neg, _ := crypto.NewECPointFromGroupPoint(p.Group().NewPoint().Negate(p.Point()))
+	return neg
+}
+
+func xOnly(p *crypto.ECPoint) []byte {
+	return p.X().FillBytes(make([]byte, 32))
+}
@@ -0,0 +1,177 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"sync/atomic"
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+const (
+	testParticipants = test.TestParticipants
+	testThreshold    = test.TestThreshold
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+}
+
+func TestE2EConcurrent(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+
+	msg := sha256.Sum256([]byte("hello, taproot"))
+	sig := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+		return NewLocalParty(msg[:], params, key, out, end)
+	})
+
+	pub, err := schnorr.ParsePubKey(XOnlyPubKey(keys[0].ECDSAPub))
+	assert.NoError(t, err)
+	assertValidSignature(t, sig, msg[:], pub)
+}
+
+func TestE2EWithTapTweak(t *testing.T) {
+	setUp("info")
+
+	// PHASE: load keygen fixtures
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	internalKey, err := btcec.ParsePubKey(append([]byte{0x02 | byte(keys[0].ECDSAPub.Y().Bit(0))}, XOnlyPubKey(keys[0].ECDSAPub)...))
+	assert.NoError(t, err)
+
+	merkleRoot := make([]byte, 32)
+	_, _ = rand.Read(merkleRoot)
+	for _, root := range [][]byte{nil, merkleRoot} {
+		// the output key matches the one computed by btcd
+		outputKey, err := TaprootOutputKey(keys[0].ECDSAPub, root)
+		assert.NoError(t, err)
+		expected := txscript.ComputeTaprootOutputKey(internalKey, root)
+		assert.Equal(t, schnorr.SerializePubKey(expected), outputKey)
+
+		msg := sha256.Sum256(append([]byte("hello, taproot"), root...))
+		sig := runSigning(t, keys, signPIDs, func(params *tss.Parameters, key keygen.LocalPartySaveData, out chan<- tss.Message, end chan<- *common.SignatureData) tss.Party {
+			return NewLocalPartyWithTapTweak(msg[:], params, key, root, out, end)
+		})
+		assertValidSignature(t, sig, msg[:], expected)
+	}
+}
+
+func TestE2ERestoreRefusesDifferentRound1Commitment(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(testThreshold+1, testParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	outCh := make(chan tss.Message, 10*len(signPIDs))
+	endCh := make(chan *common.SignatureData, 1)
+	msg := sha256.Sum256([]byte("hello, taproot"))
+	newParty := func(msg []byte) *LocalParty {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[0], len(signPIDs), testThreshold)
+		params.SetSessionSnapshots(true)
+		return NewLocalParty(msg, params, keys[0], outCh, endCh).(*LocalParty)
+	}
+
+	P := newParty(msg[:])
+	assert.Nil(t, P.Start())
+	sent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	snapshot, err := P.Snapshot(keystore.NoEncryption())
+	assert.NoError(t, err)
+
+	// a restored party re-emits the same commitments to its nonces
+	assert.Nil(t, newParty(msg[:]).Restore(snapshot, keystore.NoEncryption()))
+	reSent, _, err := (<-outCh).WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, sent, reSent)
+
+	// the snapshot cannot be restored into a session that signs another message
+	other := sha256.Sum256([]byte("hello, other taproot"))
+	assert.NotNil(t, newParty(other[:]).Restore(snapshot, keystore.NoEncryption()))
+
+	// a snapshot whose randomness no longer reproduces the commitments is refused and nothing is sent
+	tampered, err := test.ReseedSnapshot(snapshot, SessionSnapshotKind)
+	assert.NoError(t, err)
+	err = newParty(msg[:]).Restore(tampered, keystore.NoEncryption())
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "refusing to send it")
+	}
+	assert.Zero(t, len(outCh))
+}
+
+func runSigning(t *testing.T, keys []keygen.LocalPartySaveData, signPIDs tss.SortedPartyIDs,
+	newParty func(*tss.Parameters, keygen.LocalPartySaveData, chan<- tss.Message, chan<- *common.SignatureData) tss.Party) *common.SignatureData {
+	p2pCtx := tss.NewPeerContext(signPIDs)
+	parties := make([]tss.Party, 0, len(signPIDs))
+
+	errCh := make(chan *tss.Error, len(signPIDs))
+	outCh := make(chan tss.Message, len(signPIDs))
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+
+	updater := test.SharedPartyUpdater
+	for i := 0; i < len(signPIDs); i++ {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, signPIDs[i], len(signPIDs), testThreshold)
+		P := newParty(params, keys[i], outCh, endCh)
+		parties = append(parties, P)
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var ended int32
+	var sig *common.SignatureData
+	for {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+
+		case msg := <-outCh:
+			for _, P := range parties {
+				if P.PartyID().Index == msg.GetFrom().Index {
+					continue
+				}
+				go updater(P, msg, errCh)
+			}
+
+		case data := <-endCh:
+			if sig != nil {
+				assert.Equal(t, sig.Signature, data.Signature, "all parties must output the same signature")
+			}
+			sig = data
+			if atomic.AddInt32(&ended, 1) == int32(len(signPIDs)) {
+				return sig
+			}
+		}
+	}
+}
+
+func assertValidSignature(t *testing.T, data *common.SignatureData, msg []byte, pub *btcec.PublicKey) {
+	assert.Len(t, data.Signature, 64)
+	sig, err := schnorr.ParseSignature(data.Signature)
+	if assert.NoError(t, err) {
+		assert.True(t, sig.Verify(msg, pub), "schnorr verify must pass")
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"math/big"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into schnorr-signing.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that signing messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*SignRound1Message)(nil),
+		(*SignRound2Message)(nil),
+	}
+)
+
+// ----- //
+
+func NewSignRound1Message(
+	from *tss.PartyID,
+	hiding, binding *crypto.ECPoint,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound1Message{
+		HidingX:  hiding.X().Bytes(),
+		HidingY:  hiding.Y().Bytes(),
+		BindingX: binding.X().Bytes(),
+		BindingY: binding.Y().Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound1Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetHidingX()) &&
+		common.NonEmptyBytes(m.GetHidingY()) &&
+		common.NonEmptyBytes(m.GetBindingX()) &&
+		common.NonEmptyBytes(m.GetBindingY())
+}
+
+func (m *SignRound1Message) UnmarshalHiding(g group.AffineGroup) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		g,
+		new(big.Int).SetBytes(m.GetHidingX()),
+		new(big.Int).SetBytes(m.GetHidingY()))
+}
+
+func (m *SignRound1Message) UnmarshalBinding(g group.AffineGroup) (*crypto.ECPoint, error) {
+	return crypto.NewECPoint(
+		g,
+		new(big.Int).SetBytes(m.GetBindingX()),
+		new(big.Int).SetBytes(m.GetBindingY()))
+}
+
+// ----- //
+
+func NewSignRound2Message(
+	from *tss.PartyID,
+	zi *big.Int,
+) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: true,
+	}
+	content := &SignRound2Message{
+		Z: zi.Bytes(),
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *SignRound2Message) ValidateBasic() bool {
+	return m != nil &&
+		common.NonEmptyBytes(m.GetZ())
+}
+
+func (m *SignRound2Message) UnmarshalZ() *big.Int {
+	return new(big.Int).SetBytes(m.GetZ())
+}
@@ -0,0 +1,98 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+
+	errors2 "github.com/pkg/errors"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// round 1 commits to a hiding and a binding nonce, as in round 1 of FROST (RFC 9591, Section 5.1)
+func newRound1(params *tss.Parameters, key *keygen.LocalPartySaveData, data *common.SignatureData, temp *localTempData, out chan<- tss.Message, end chan<- *common.SignatureData, journal *tss.SessionJournal) tss.Round {
+	return &round1{
+		&base{params, key, data, temp, out, end, make([]bool, len(params.Parties().IDs())), false, 1, journal},
+	}
+}
+
+func (round *round1) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	// BIP-340 signs the 32-byte hash computed by the caller, e.g. a Taproot signature hash
+	if len(round.temp.m) != 32 {
+		return round.WrapError(errors.New("the message must be a 32-byte hash"))
+	}
+
+	round.number = 1
+	round.started = true
+	round.resetOK()
+
+	if round.Group() != group.Secp256k1() {
+		return round.WrapError(errors.New("BIP-340 signatures require the secp256k1 curve"))
+	}
+	signingKey, err := newSigningKey(round.key.ECDSAPub, round.temp.tweaked, round.temp.merkleRoot)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	round.temp.signingKey = signingKey
+
+	// 1. (d, e) <- nonce_generate(xi) and (D, E) = (g^d, g^e)
+	cs := frost.Secp256k1()
+	d, err := frost.NonceGenerate(cs, round.key.Xi, round.Rand())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NonceGenerate(hiding)"))
+	}
+	e, err := frost.NonceGenerate(cs, round.key.Xi, round.Rand())
+	if err != nil {
+		return round.WrapError(errors2.Wrapf(err, "NonceGenerate(binding)"))
+	}
+	round.temp.hidingNonce, round.temp.bindingNonce = d, e
+
+	// 2. BROADCAST (D, E)
+	i := round.PartyID().Index
+	r1msg := NewSignRound1Message(round.PartyID(), crypto.ScalarBaseMult(round.Group(), d), crypto.ScalarBaseMult(round.Group(), e))
+	round.temp.signRound1Messages[i] = r1msg
+	if err := round.send(r1msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round1) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound1Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round1) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound1Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round1) NextRound() tss.Round {
+	round.started = false
+	return &round2{round}
+}
@@ -0,0 +1,134 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package signing
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/frost"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+var one = big.NewInt(1)
+
+func (round *round2) Start() *tss.Error {
+	if round.started {
+		return round.WrapError(errors.New("round already started"))
+	}
+	round.number = 2
+	round.started = true
+	round.resetOK()
+
+	cs := frost.Secp256k1()
+	Ps := round.Parties().IDs()
+	i := round.PartyID().Index
+	modQ := common.ModInt(round.Group().Order())
+	Q := round.temp.signingKey.Q
+
+	// 1. collect the nonce commitments of all parties
+	commitments := make([]*frost.SigningCommitment, len(Ps))
+	culprits := make([]*tss.PartyID, 0, len(Ps))
+	for j, Pj := range Ps {
+		r1msg := round.temp.signRound1Messages[j].Content().(*SignRound1Message)
+		hiding, err1 := r1msg.UnmarshalHiding(round.Group())
+		binding, err2 := r1msg.UnmarshalBinding(round.Group())
+		if err1 != nil || err2 != nil {
+			culprits = append(culprits, Pj)
+			continue
+		}
+		commitments[j] = &frost.SigningCommitment{Identifier: frost.Identifier(cs, round.key.Ks[j]), Hiding: hiding, Binding: binding}
+	}
+	if len(culprits) > 0 {
+		return round.WrapError(errors.New("received invalid nonce commitments"), culprits...)
+	}
+
+	// 2. the binding factors ρj and R = Σ (Dj + ρj * Ej); the nonces are negated if R has an odd Y
+	bindingFactors, err := frost.ComputeBindingFactors(cs, Q, commitments, round.temp.m)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	bigR, err := frost.ComputeGroupCommitment(cs, commitments, bindingFactors)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	nonceFactor := one
+	if !hasEvenY(bigR) {
+		nonceFactor = new(big.Int).Sub(round.Group().Order(), one)
+	}
+
+	// 3. e = hashBIP0340/challenge(x(R) || x(Q) || m)
+	eBz := chainhash.TaggedHash(chainhash.TagBIP0340Challenge, xOnly(bigR), xOnly(Q), round.temp.m)
+	challenge := new(big.Int).Mod(new(big.Int).SetBytes(eBz[:]), round.Group().Order())
+
+	// 4. zi = gR * (di + ei * ρi) + λi * gQ * gP * xi * e
+	lambdaI, err := frost.DeriveInterpolatingValue(cs, identifiers(commitments), commitments[i].Identifier)
+	if err != nil {
+		return round.WrapError(err)
+	}
+	nonce := modQ.Mul(nonceFactor, modQ.Add(round.temp.hidingNonce, modQ.Mul(round.temp.bindingNonce, bindingFactors[i])))
+	zi := modQ.Add(nonce, modQ.Mul(modQ.Mul(lambdaI, round.temp.signingKey.keyFactor), modQ.Mul(round.key.Xi, challenge)))
+
+	// security: the nonces must never be used again
+	common.WipeBigInts(round.temp.hidingNonce, round.temp.bindingNonce, nonce)
+	round.temp.hidingNonce, round.temp.bindingNonce = nil, nil
+
+	round.temp.commitments = commitments
+	round.temp.bindingFactors = bindingFactors
+	round.temp.nonceFactor = nonceFactor
+	round.temp.challenge = challenge
+	round.temp.bigR = bigR
+	round.temp.zi = zi
+
+	// 5. BROADCAST zi
+	r2msg := NewSignRound2Message(round.PartyID(), zi)
+	round.temp.signRound2Messages[i] = r2msg
+	if err := round.send(r2msg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (round *round2) Update() (bool, *tss.Error) {
+	ret := true
+	for j, msg := range round.temp.signRound2Messages {
+		if round.ok[j] {
+			continue
+		}
+		if msg == nil || !round.CanAccept(msg) {
+			ret = false
+			continue
+		}
+		round.ok[j] = true
+	}
+	return ret, nil
+}
+
+func (round *round2) CanAccept(msg tss.ParsedMessage) bool {
+	if _, ok := msg.Content().(*SignRound2Message); ok {
+		return msg.IsBroadcast()
+	}
+	return false
+}
+
+func (round *round2) NextRound() tss.Round {
+	round.started = false
+	return &finalization{round}
+}
+
+// ----- //
+
+func identifiers(commitments []*frost.SigningCommitment) []*big.Int {
+	ids := make([]*big.Int, len(commitments))
+	for j, c := range commitments {
+		ids[j] = c.Identifier
+	}
+	return ids
+}
@@ -7,7 +7,12 @@
 package test
 
 import (
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+
 	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
 )
 
 func SharedPartyUpdater(party tss.Party, msg tss.Message, errCh chan<- *tss.Error) {
@@ -29,3 +34,53 @@ func SharedPartyUpdater(party tss.Party, msg tss.Message, errCh chan<- *tss.Erro
 		errCh <- err
 	}
 }
+
+// SecretWords returns the words backing each of xs. They stay reachable when a variable is rebound to another value,
+// so a test takes them before the secrets are wiped and checks them with Wiped afterwards.
+func SecretWords(xs ...*big.Int) [][]big.Word {
+	words := make([][]big.Word, 0, len(xs))
+	for _, x := range xs {
+		w := x.Bits()
+		words = append(words, w[:cap(w)])
+	}
+	return words
+}
+
+// Wiped reports whether every word returned by SecretWords is zero
+func Wiped(words [][]big.Word) bool {
+	for _, ws := range words {
+		for _, w := range ws {
+			if w != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ReseedSnapshot returns a copy of an unencrypted session snapshot of the given kind with another seed, so that a party
+// restored from it draws other randomness than the party that took the snapshot
+func ReseedSnapshot(snapshot []byte, kind string) ([]byte, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, err
+	}
+	return SetSnapshotField(snapshot, kind, "seed", seed)
+}
+
+// SetSnapshotField returns a copy of an unencrypted session snapshot of the given kind in which field is set to value
+func SetSnapshotField(snapshot []byte, kind, field string, value interface{}) ([]byte, error) {
+	version, payload, err := keystore.Open(snapshot, kind, keystore.NoEncryption())
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]interface{}
+	if err = json.Unmarshal(payload, &fields); err != nil {
+		return nil, err
+	}
+	fields[field] = value
+	if payload, err = json.Marshal(fields); err != nil {
+		return nil, err
+	}
+	return keystore.Seal(rand.Reader, kind, version, payload, keystore.NoEncryption())
+}
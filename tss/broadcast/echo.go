@@ -0,0 +1,256 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package broadcast turns the broadcast messages of a party into a reliable broadcast with an echo round, so that a
+// sender cannot show different messages to different parties.
+package broadcast
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const digestLen = sha512.Size256
+
+type (
+	// EchoParty wraps a party so that the broadcast messages it receives are only passed on once every party has
+	// echoed the same digests for them. Once it has received the broadcast messages of a type from all the other
+	// parties, it sends the digest of each to every party. A digest that differs from its own names the sender
+	// of the message as an equivocating party, so the inner round never advances on a split view.
+	//
+	// Broadcast messages must be sent by every party to all the parties of the parameters, as in keygen, signing
+	// and refresh; resharing is not supported. Without authenticated channels a party that echoes a false digest
+	// cannot be told apart from an equivocating sender.
+	EchoParty struct {
+		tss.Party
+		params *tss.Parameters
+		out    chan<- tss.Message
+
+		mtx    sync.Mutex
+		rounds map[string]*echoRound // by message type
+	}
+
+	// echoRound holds the broadcast messages of one type and their echoes, by party index
+	echoRound struct {
+		held     []tss.ParsedMessage
+		digests  [][]byte
+		echoes   [][][]byte
+		echoed   bool
+		released bool
+	}
+)
+
+// ErrEquivocation is the cause of the errors of the senders that sent different broadcast messages; it is of the kind
+// tss.ErrEquivocation
+var ErrEquivocation = tss.WithKind(tss.ErrEquivocation, errors.New("echo broadcast: equivocation"))
+
+// NewEchoParty wraps party, which must have been constructed with params. out is the channel the echo messages are
+// sent to, usually the one party was constructed with.
+func NewEchoParty(party tss.Party, params *tss.Parameters, out chan<- tss.Message) tss.Party {
+	return &EchoParty{
+		Party:  party,
+		params: params,
+		out:    out,
+		rounds: make(map[string]*echoRound),
+	}
+}
+
+func (p *EchoParty) UpdateFromBytes(wireBytes []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	msg, err := tss.ParseWireMessage(wireBytes, from, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Update(msg)
+}
+
+func (p *EchoParty) Update(msg tss.ParsedMessage) (bool, *tss.Error) {
+	if echo, ok := msg.Content().(*EchoMessage); ok {
+		return p.updateEcho(msg, echo)
+	}
+	if !msg.IsBroadcast() {
+		return p.Party.Update(msg)
+	}
+	return p.updateBroadcast(msg)
+}
+
+func (p *EchoParty) String() string {
+	return fmt.Sprintf("echo broadcast, %s", p.Party.String())
+}
+
+// ----- //
+
+func (p *EchoParty) updateBroadcast(msg tss.ParsedMessage) (bool, *tss.Error) {
+	j, ok := p.senderIndex(msg)
+	if !ok || j == p.params.PartyID().Index {
+		// leave it to the inner party to reject
+		return p.Party.Update(msg)
+	}
+	bz, _, err := msg.WireBytes()
+	if err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	}
+	digest := common.SHA512_256(bz)
+
+	p.mtx.Lock()
+	rnd := p.round(msg.Type())
+	if rnd.digests[j] != nil {
+		p.mtx.Unlock()
+		if bytes.Equal(rnd.digests[j], digest) {
+			return true, nil // a duplicate
+		}
+		return false, p.WrapError(fmt.Errorf("%w: received two different %s messages", ErrEquivocation, msg.Type()), msg.GetFrom())
+	}
+	rnd.held[j], rnd.digests[j] = msg, digest
+	var echo tss.ParsedMessage
+	if !rnd.echoed && rnd.receivedAll(p.params.PartyID().Index) {
+		rnd.echoed = true
+		echo = NewEchoMessage(p.params.PartyID(), msg.Type(), rnd.digests)
+	}
+	release, culprits := p.tryRelease(rnd)
+	p.mtx.Unlock()
+
+	if echo != nil {
+		p.out <- echo
+	}
+	return p.deliver(msg.Type(), release, culprits)
+}
+
+func (p *EchoParty) updateEcho(msg tss.ParsedMessage, echo *EchoMessage) (bool, *tss.Error) {
+	k, ok := p.senderIndex(msg)
+	if !ok {
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "echo broadcast: echo from an unknown party"), msg.GetFrom())
+	}
+	if !msg.ValidateBasic() || len(echo.GetDigests()) != p.params.PartyCount() {
+		return false, p.WrapError(tss.Errorf(tss.ErrInvalidMessage, "echo broadcast: malformed echo of %s messages", echo.GetMessageType()), msg.GetFrom())
+	}
+	if k == p.params.PartyID().Index {
+		return true, nil
+	}
+
+	p.mtx.Lock()
+	rnd := p.round(echo.GetMessageType())
+	if rnd.echoes[k] != nil {
+		same := digestsEqual(rnd.echoes[k], echo.GetDigests())
+		p.mtx.Unlock()
+		if same {
+			return true, nil // a duplicate
+		}
+		return false, p.WrapError(fmt.Errorf("%w: received two different echoes of %s messages", ErrEquivocation, echo.GetMessageType()), msg.GetFrom())
+	}
+	rnd.echoes[k] = echo.GetDigests()
+	release, culprits := p.tryRelease(rnd)
+	p.mtx.Unlock()
+
+	return p.deliver(echo.GetMessageType(), release, culprits)
+}
+
+// tryRelease returns the held messages once the broadcast messages and echoes of every other party are in and
+// agree, or the senders of the messages they disagree on. it must be called with the mutex held.
+func (p *EchoParty) tryRelease(rnd *echoRound) ([]tss.ParsedMessage, []*tss.PartyID) {
+	i := p.params.PartyID().Index
+	if rnd.released || !rnd.receivedAll(i) || !rnd.echoedByAll(i) {
+		return nil, nil
+	}
+	Ps := p.params.Parties().IDs()
+	culprits := make([]*tss.PartyID, 0)
+	for j := range Ps {
+		if j == i {
+			continue
+		}
+		for k, digests := range rnd.echoes {
+			if k == i || k == j {
+				continue
+			}
+			if !bytes.Equal(digests[j], rnd.digests[j]) {
+				culprits = append(culprits, Ps[j])
+				break
+			}
+		}
+	}
+	if len(culprits) > 0 {
+		return nil, culprits
+	}
+	rnd.released = true
+	held := rnd.held
+	rnd.held = nil
+	return held, nil
+}
+
+// deliver passes the released messages to the inner party
+func (p *EchoParty) deliver(msgType string, release []tss.ParsedMessage, culprits []*tss.PartyID) (bool, *tss.Error) {
+	if len(culprits) > 0 {
+		return false, p.WrapError(fmt.Errorf("%w: the parties were sent different %s messages", ErrEquivocation, msgType), culprits...)
+	}
+	for _, msg := range release {
+		if msg == nil {
+			continue
+		}
+		if _, err := p.Party.Update(msg); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (p *EchoParty) round(msgType string) *echoRound {
+	rnd, ok := p.rounds[msgType]
+	if !ok {
+		n := p.params.PartyCount()
+		rnd = &echoRound{
+			held:    make([]tss.ParsedMessage, n),
+			digests: make([][]byte, n),
+			echoes:  make([][][]byte, n),
+		}
+		p.rounds[msgType] = rnd
+	}
+	return rnd
+}
+
+// senderIndex returns the index of the sender of msg, checking that it is one of the parties
+func (p *EchoParty) senderIndex(msg tss.ParsedMessage) (int, bool) {
+	from := msg.GetFrom()
+	Ps := p.params.Parties().IDs()
+	if from == nil || from.Index < 0 || len(Ps) <= from.Index || !bytes.Equal(Ps[from.Index].Key, from.Key) {
+		return 0, false
+	}
+	return from.Index, true
+}
+
+func (rnd *echoRound) receivedAll(self int) bool {
+	for j, d := range rnd.digests {
+		if j != self && d == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (rnd *echoRound) echoedByAll(self int) bool {
+	for k, e := range rnd.echoes {
+		if k != self && e == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func digestsEqual(a, b [][]byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for j := range a {
+		if !bytes.Equal(a[j], b[j]) {
+			return false
+		}
+	}
+	return true
+}
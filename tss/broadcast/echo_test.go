@@ -0,0 +1,129 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package broadcast
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	cmt "github.com/bnb-chain/tss-lib/v2/crypto/commitments"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestE2EEchoBroadcastKeygen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(test.TestParticipants)
+	parties, errCh, outCh, endCh := newEchoKeygenParties(pIDs)
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	var first *keygen.LocalPartySaveData
+	ended := 0
+	for ended < len(pIDs) {
+		select {
+		case err := <-errCh:
+			assert.FailNow(t, err.Error())
+		case msg := <-outCh:
+			route(parties, msg, errCh)
+		case save := <-endCh:
+			ended++
+			if first == nil {
+				first = save
+				continue
+			}
+			assert.True(t, save.EDDSAPub.Equals(first.EDDSAPub), "all parties should agree on the public key")
+		}
+	}
+}
+
+func TestE2EEchoBroadcastEquivocation(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(test.TestParticipants)
+	parties, errCh, outCh, _ := newEchoKeygenParties(pIDs)
+	for _, P := range parties {
+		go func(P tss.Party) {
+			if err := P.Start(); err != nil {
+				errCh <- err
+			}
+		}(P)
+	}
+
+	// P[0] shows P[1] another commitment than the one it sends to the others
+	equivocator, victim := pIDs[0], parties[1]
+	forged := keygen.NewKGRound1Message(equivocator, cmt.NewHashCommitment(rand.Reader, common.GetRandomPositiveInt(rand.Reader, group.Ed25519().Order())).C)
+	for {
+		select {
+		case err := <-errCh:
+			assert.True(t, errors.Is(err.Cause(), ErrEquivocation), "should be an equivocation: %v", err)
+			if assert.Equal(t, 1, len(err.Culprits())) {
+				assert.Equal(t, equivocator, err.Culprits()[0])
+			}
+			return
+		case msg := <-outCh:
+			if _, ok := msg.(tss.ParsedMessage).Content().(*keygen.KGRound1Message); ok && msg.GetFrom() == equivocator {
+				for _, P := range parties[2:] {
+					go test.SharedPartyUpdater(P, msg, errCh)
+				}
+				go test.SharedPartyUpdater(victim, forged, errCh)
+				continue
+			}
+			route(parties, msg, errCh)
+		}
+	}
+}
+
+func newEchoKeygenParties(pIDs tss.SortedPartyIDs) ([]tss.Party, chan *tss.Error, chan tss.Message, chan *keygen.LocalPartySaveData) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	parties := make([]tss.Party, 0, len(pIDs))
+	// each party echoes every broadcast to every other party
+	errCh := make(chan *tss.Error, len(pIDs)*len(pIDs))
+	outCh := make(chan tss.Message, len(pIDs)*len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+	for _, pID := range pIDs {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pID, len(pIDs), keygen.TestThreshold)
+		parties = append(parties, NewEchoParty(keygen.NewLocalParty(params, outCh, endCh), params, outCh))
+	}
+	return parties, errCh, outCh, endCh
+}
+
+func route(parties []tss.Party, msg tss.Message, errCh chan *tss.Error) {
+	dest := msg.GetTo()
+	if dest == nil {
+		for _, P := range parties {
+			if P.PartyID().Index == msg.GetFrom().Index {
+				continue
+			}
+			go test.SharedPartyUpdater(P, msg, errCh)
+		}
+		return
+	}
+	go test.SharedPartyUpdater(parties[dest[0].Index], msg, errCh)
+}
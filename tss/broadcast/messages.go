@@ -0,0 +1,48 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package broadcast
+
+import (
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+// These messages were generated from Protocol Buffers definitions into echo-broadcast.pb.go
+// The following messages are registered on the Protocol Buffers "wire"
+
+var (
+	// Ensure that echo broadcast messages implement ValidateBasic
+	_ = []tss.MessageContent{
+		(*EchoMessage)(nil),
+	}
+)
+
+// ----- //
+
+func NewEchoMessage(from *tss.PartyID, msgType string, digests [][]byte) tss.ParsedMessage {
+	meta := tss.MessageRouting{
+		From:        from,
+		IsBroadcast: false,
+	}
+	content := &EchoMessage{
+		MessageType: msgType,
+		Digests:     digests,
+	}
+	msg := tss.NewMessageWrapper(meta, content)
+	return tss.NewMessage(meta, content, msg)
+}
+
+func (m *EchoMessage) ValidateBasic() bool {
+	if m == nil || m.GetMessageType() == "" || len(m.GetDigests()) == 0 {
+		return false
+	}
+	for _, d := range m.GetDigests() {
+		if len(d) != 0 && len(d) != digestLen {
+			return false
+		}
+	}
+	return true
+}
@@ -13,6 +13,8 @@ import (
 
 	s256k1 "github.com/btcsuite/btcd/btcec/v2"
 	"github.com/decred/dcrd/dcrec/edwards/v2"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 )
 
 type CurveName string
@@ -20,6 +22,7 @@ type CurveName string
 const (
 	Secp256k1 CurveName = "secp256k1"
 	Ed25519   CurveName = "ed25519"
+	Secp256r1 CurveName = "secp256r1"
 )
 
 var (
@@ -34,6 +37,7 @@ func init() {
 	registry = make(map[CurveName]elliptic.Curve)
 	registry[Secp256k1] = s256k1.S256()
 	registry[Ed25519] = edwards.Edwards()
+	registry[Secp256r1] = elliptic.P256()
 }
 
 func RegisterCurve(name CurveName, curve elliptic.Curve) {
@@ -60,6 +64,23 @@ func GetCurveName(curve elliptic.Curve) (CurveName, bool) {
 	return "", false
 }
 
+// GetGroupByName returns the group of the points of the curve registered as name
+func GetGroupByName(name CurveName) (group.AffineGroup, bool) {
+	curve, ok := GetCurveByName(name)
+	if !ok {
+		return nil, false
+	}
+	return group.FromCurve(curve)
+}
+
+// GetGroupName returns the name of the curve of g in the curve registry
+func GetGroupName(g group.AffineGroup) (CurveName, bool) {
+	if g == nil {
+		return "", false
+	}
+	return GetCurveName(g.Curve())
+}
+
 // SameCurve returns true if both lhs and rhs are the same known curve
 func SameCurve(lhs, rhs elliptic.Curve) bool {
 	lName, lOk := GetCurveName(lhs)
@@ -93,3 +114,8 @@ func S256() elliptic.Curve {
 func Edwards() elliptic.Curve {
 	return edwards.Edwards()
 }
+
+// NIST P-256, also known as secp256r1 and prime256v1
+func P256() elliptic.Curve {
+	return elliptic.P256()
+}
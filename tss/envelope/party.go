@@ -0,0 +1,67 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/transport"
+)
+
+type (
+	// Party wraps a party so that UpdateFromBytes only accepts sealed messages, and passes them on with the sender
+	// and broadcast flag that were signed rather than those given by the relay. The messages passed on implement
+	// Received, so that the party can keep their receipts as evidence.
+	Party struct {
+		tss.Party
+		sealer *Sealer
+	}
+
+	sealingTransport struct {
+		transport.Transport
+		sealer *Sealer
+	}
+)
+
+// NewParty wraps party to open the messages it receives with sealer
+func NewParty(party tss.Party, sealer *Sealer) tss.Party {
+	return &Party{Party: party, sealer: sealer}
+}
+
+func (p *Party) UpdateFromBytes(sealed []byte, from *tss.PartyID, isBroadcast bool) (bool, *tss.Error) {
+	wireBytes, sender, isBroadcast, err := p.sealer.Open(sealed, from)
+	if err != nil {
+		// the relay cannot be trusted to name the culprit
+		return false, p.WrapError(err)
+	}
+	msg, err := tss.ParseWireMessage(wireBytes, sender, isBroadcast)
+	if err != nil {
+		return false, p.WrapError(err)
+	}
+	return p.Party.Update(&receivedMessage{ParsedMessage: msg, receipt: p.sealer.receipt(sealed, sender, isBroadcast)})
+}
+
+// NewTransport wraps t to seal the messages it sends with sealer. The messages it receives are still sealed and
+// must be passed to a party wrapped with NewParty.
+func NewTransport(t transport.Transport, sealer *Sealer) transport.Transport {
+	return &sealingTransport{Transport: t, sealer: sealer}
+}
+
+func (t *sealingTransport) Send(ctx context.Context, msg tss.Message) error {
+	sealedMsgs, err := t.sealer.Seal(rand.Reader, msg)
+	if err != nil {
+		return err
+	}
+	for _, sealed := range sealedMsgs {
+		if err := t.Transport.Send(ctx, sealed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
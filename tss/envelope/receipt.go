@@ -0,0 +1,86 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package envelope
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// Receipt holds a message as sealed and signed by its sender, so that its recipient can show a third party what
+	// the sender sent. A point-to-point message also comes with the key of the direction from the sender to the
+	// recipient, without which it cannot be read; handing such a receipt over discloses every message sent in that
+	// direction in the session.
+	Receipt struct {
+		Sealed     []byte
+		SenderKey  ed25519.PublicKey
+		OpeningKey []byte
+	}
+
+	// Received is implemented by the messages that a party wrapped with NewParty passes on
+	Received interface {
+		tss.ParsedMessage
+		Receipt() *Receipt
+	}
+
+	receivedMessage struct {
+		tss.ParsedMessage
+		receipt *Receipt
+	}
+)
+
+var _ Received = (*receivedMessage)(nil)
+
+// ReceiptOf returns the receipt of msg, or nil if it did not reach the party through NewParty
+func ReceiptOf(msg tss.ParsedMessage) *Receipt {
+	if received, ok := msg.(Received); ok {
+		return received.Receipt()
+	}
+	return nil
+}
+
+// Open checks that the sealed message was signed with SenderKey by sender and decrypts it if it was sent
+// point-to-point. It returns the message and the session it was sealed for. It is up to the caller to check that
+// SenderKey is the signing key of sender's identity.
+func (r *Receipt) Open(sender *tss.PartyID) (msg tss.ParsedMessage, sessionID string, err error) {
+	if r == nil || sender == nil {
+		return nil, "", fmt.Errorf("%w: no receipt", ErrUnauthenticated)
+	}
+	if len(r.SenderKey) != ed25519.PublicKeySize {
+		return nil, "", fmt.Errorf("%w: invalid signing key", ErrUnauthenticated)
+	}
+	m := new(SealedMessage)
+	if err = proto.Unmarshal(r.Sealed, m); err != nil {
+		return nil, "", fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if !bytes.Equal(m.GetFromKey(), sender.GetKey()) {
+		return nil, "", fmt.Errorf("%w: not sealed by %s", ErrUnauthenticated, sender)
+	}
+	aead, err := chacha20poly1305.NewX(r.OpeningKey)
+	if err != nil && !m.GetIsBroadcast() {
+		return nil, "", fmt.Errorf("%w: invalid opening key", ErrUnauthenticated)
+	}
+	wireBytes, err := openSealed(m, sender, r.SenderKey, aead)
+	if err != nil {
+		return nil, "", err
+	}
+	if msg, err = tss.ParseWireMessage(wireBytes, sender, m.GetIsBroadcast()); err != nil {
+		return nil, "", err
+	}
+	return msg, m.GetSessionId(), nil
+}
+
+func (m *receivedMessage) Receipt() *Receipt {
+	return m.receipt
+}
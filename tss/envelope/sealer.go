@@ -0,0 +1,328 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package envelope signs the messages of a party with its static identity and encrypts those sent point-to-point,
+// so that a relay can neither read the secret shares in them nor spoof their sender.
+package envelope
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	signatureDomain = "tss-lib envelope signature v1"
+	keyDomain       = "tss-lib envelope key v1"
+)
+
+type (
+	// Identity holds the static keys of a party: an Ed25519 key to sign its messages and an X25519 key to receive
+	// encrypted ones. It must be kept as secret as the key shares.
+	Identity struct {
+		SigningKey    ed25519.PrivateKey
+		EncryptionKey []byte
+	}
+
+	// PublicIdentity holds the public keys of a party's Identity, to be distributed to its peers ahead of time
+	PublicIdentity struct {
+		SigningKey    ed25519.PublicKey
+		EncryptionKey []byte
+	}
+
+	// Sealer seals the messages of one party in one session and opens those sent to it
+	Sealer struct {
+		sessionID  string
+		self       *tss.PartyID
+		identity   *Identity
+		peers      []*tss.PartyID
+		peersByKey map[string]*peer
+	}
+
+	peer struct {
+		id       *tss.PartyID
+		public   PublicIdentity
+		sendAEAD cipher.AEAD
+		recvAEAD cipher.AEAD
+		recvKey  []byte
+	}
+
+	// sealedMessage is a tss.Message whose WireBytes are sealed for the parties in GetTo().
+	// WireMsg returns the message before it was sealed and must not be sent.
+	sealedMessage struct {
+		tss.Message
+		to     []*tss.PartyID
+		sealed []byte
+	}
+)
+
+var (
+	ErrUnauthenticated = tss.WithKind(tss.ErrInvalidMessage, errors.New("envelope: message could not be authenticated"))
+
+	_ tss.Message = (*sealedMessage)(nil)
+)
+
+// GenerateIdentity returns a new identity with keys from rand
+func GenerateIdentity(rand io.Reader) (*Identity, error) {
+	_, signingKey, err := ed25519.GenerateKey(rand)
+	if err != nil {
+		return nil, err
+	}
+	encryptionKey := make([]byte, curve25519.ScalarSize)
+	if _, err := io.ReadFull(rand, encryptionKey); err != nil {
+		return nil, err
+	}
+	return &Identity{SigningKey: signingKey, EncryptionKey: encryptionKey}, nil
+}
+
+// Public returns the public keys of the identity
+func (id *Identity) Public() (PublicIdentity, error) {
+	encryptionKey, err := curve25519.X25519(id.EncryptionKey, curve25519.Basepoint)
+	if err != nil {
+		return PublicIdentity{}, err
+	}
+	return PublicIdentity{
+		SigningKey:    id.SigningKey.Public().(ed25519.PublicKey),
+		EncryptionKey: encryptionKey,
+	}, nil
+}
+
+// NewSealer returns the sealer of self in the given session. peers lists every party of the session, including
+// self and, when resharing, the members of both committees, and identities maps the Id of each to its public keys.
+func NewSealer(sessionID string, self *tss.PartyID, identity *Identity, peers []*tss.PartyID, identities map[string]PublicIdentity) (*Sealer, error) {
+	if identity == nil || len(identity.SigningKey) != ed25519.PrivateKeySize || len(identity.EncryptionKey) != curve25519.ScalarSize {
+		return nil, errors.New("envelope: invalid identity")
+	}
+	selfPublic, err := identity.Public()
+	if err != nil {
+		return nil, err
+	}
+	s := &Sealer{
+		sessionID:  sessionID,
+		self:       self,
+		identity:   identity,
+		peers:      peers,
+		peersByKey: make(map[string]*peer, len(peers)),
+	}
+	for _, Pj := range peers {
+		public, ok := identities[Pj.Id]
+		if !ok {
+			return nil, fmt.Errorf("envelope: no identity for party %s", Pj)
+		}
+		if len(public.SigningKey) != ed25519.PublicKeySize || len(public.EncryptionKey) != curve25519.PointSize {
+			return nil, fmt.Errorf("envelope: invalid identity for party %s", Pj)
+		}
+		p := &peer{id: Pj, public: public}
+		if partyKey(Pj) != partyKey(self) {
+			shared, err := curve25519.X25519(identity.EncryptionKey, public.EncryptionKey)
+			if err != nil {
+				return nil, fmt.Errorf("envelope: invalid encryption key for party %s: %w", Pj, err)
+			}
+			// each direction has its own key, bound to both parties' keys and the session
+			sendKey, err := deriveKey(shared, sessionID, selfPublic.EncryptionKey, public.EncryptionKey)
+			if err != nil {
+				return nil, err
+			}
+			if p.recvKey, err = deriveKey(shared, sessionID, public.EncryptionKey, selfPublic.EncryptionKey); err != nil {
+				return nil, err
+			}
+			if p.sendAEAD, err = chacha20poly1305.NewX(sendKey); err != nil {
+				return nil, err
+			}
+			if p.recvAEAD, err = chacha20poly1305.NewX(p.recvKey); err != nil {
+				return nil, err
+			}
+		}
+		s.peersByKey[partyKey(Pj)] = p
+	}
+	return s, nil
+}
+
+// Seal signs msg and, unless it is a broadcast, encrypts it to each of its recipients. The returned messages are
+// sent in its place, and their WireBytes passed to the UpdateFromBytes of a party wrapped with NewParty.
+func (s *Sealer) Seal(rand io.Reader, msg tss.Message) ([]tss.Message, error) {
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return nil, err
+	}
+	if partyKey(routing.From) != partyKey(s.self) {
+		return nil, errors.New("envelope: cannot seal a message of another party")
+	}
+	if routing.IsBroadcast {
+		sealed, err := s.seal(rand, nil, true, wireBytes)
+		if err != nil {
+			return nil, err
+		}
+		return []tss.Message{&sealedMessage{Message: msg, to: msg.GetTo(), sealed: sealed}}, nil
+	}
+	to := routing.To
+	if to == nil {
+		to = s.peers
+	}
+	sealedMsgs := make([]tss.Message, 0, len(to))
+	for _, Pj := range to {
+		if partyKey(Pj) == partyKey(s.self) {
+			continue
+		}
+		p, ok := s.peersByKey[partyKey(Pj)]
+		if !ok {
+			return nil, fmt.Errorf("envelope: unknown recipient %s", Pj)
+		}
+		sealed, err := s.seal(rand, p, false, wireBytes)
+		if err != nil {
+			return nil, err
+		}
+		sealedMsgs = append(sealedMsgs, &sealedMessage{Message: msg, to: []*tss.PartyID{Pj}, sealed: sealed})
+	}
+	return sealedMsgs, nil
+}
+
+// Open checks the signature of a sealed message and decrypts it if it was sent point-to-point. It returns the wire
+// bytes of the message and its authenticated sender, which must match from when it is not nil.
+func (s *Sealer) Open(sealed []byte, from *tss.PartyID) (wireBytes []byte, sender *tss.PartyID, isBroadcast bool, err error) {
+	m := new(SealedMessage)
+	if err = proto.Unmarshal(sealed, m); err != nil {
+		return nil, nil, false, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	if m.GetSessionId() != s.sessionID {
+		return nil, nil, false, fmt.Errorf("%w: sealed for session %q", ErrUnauthenticated, m.GetSessionId())
+	}
+	p, ok := s.peersByKey[hex.EncodeToString(m.GetFromKey())]
+	if !ok || p.recvAEAD == nil {
+		return nil, nil, false, fmt.Errorf("%w: unknown sender", ErrUnauthenticated)
+	}
+	if from != nil && partyKey(from) != partyKey(p.id) {
+		return nil, nil, false, fmt.Errorf("%w: sealed by %s, not %s", ErrUnauthenticated, p.id, from)
+	}
+	if !m.GetIsBroadcast() && !bytes.Equal(m.GetToKey(), s.self.GetKey()) {
+		return nil, nil, false, fmt.Errorf("%w: sealed for another party", ErrUnauthenticated)
+	}
+	if wireBytes, err = openSealed(m, p.id, p.public.SigningKey, p.recvAEAD); err != nil {
+		return nil, nil, false, err
+	}
+	return wireBytes, p.id, m.GetIsBroadcast(), nil
+}
+
+// ----- //
+
+func (s *Sealer) seal(rand io.Reader, to *peer, isBroadcast bool, wireBytes []byte) ([]byte, error) {
+	m := &SealedMessage{
+		SessionId:   s.sessionID,
+		FromKey:     s.self.GetKey(),
+		IsBroadcast: isBroadcast,
+		Payload:     wireBytes,
+	}
+	if to != nil {
+		m.ToKey = to.id.GetKey()
+		m.Nonce = make([]byte, to.sendAEAD.NonceSize())
+		if _, err := io.ReadFull(rand, m.Nonce); err != nil {
+			return nil, err
+		}
+		m.Payload = to.sendAEAD.Seal(nil, m.Nonce, wireBytes, additionalData(m))
+	}
+	m.Signature = ed25519.Sign(s.identity.SigningKey, signatureInput(m))
+	return proto.Marshal(m)
+}
+
+// receipt returns the receipt of a message from sender that Open accepted
+func (s *Sealer) receipt(sealed []byte, sender *tss.PartyID, isBroadcast bool) *Receipt {
+	p := s.peersByKey[partyKey(sender)]
+	r := &Receipt{Sealed: append([]byte(nil), sealed...), SenderKey: p.public.SigningKey}
+	if !isBroadcast {
+		r.OpeningKey = p.recvKey
+	}
+	return r
+}
+
+// openSealed checks that m was signed by the signing key of sender and decrypts it with aead unless it is a broadcast
+func openSealed(m *SealedMessage, sender *tss.PartyID, signingKey ed25519.PublicKey, aead cipher.AEAD) ([]byte, error) {
+	if !ed25519.Verify(signingKey, signatureInput(m), m.GetSignature()) {
+		return nil, fmt.Errorf("%w: invalid signature of %s", ErrUnauthenticated, sender)
+	}
+	if m.GetIsBroadcast() {
+		if len(m.GetToKey()) != 0 {
+			return nil, fmt.Errorf("%w: broadcast message with a recipient", ErrUnauthenticated)
+		}
+		return m.GetPayload(), nil
+	}
+	if len(m.GetNonce()) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: invalid nonce", ErrUnauthenticated)
+	}
+	wireBytes, err := aead.Open(nil, m.GetNonce(), m.GetPayload(), additionalData(m))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	}
+	return wireBytes, nil
+}
+
+func deriveKey(shared []byte, sessionID string, fromKey, toKey []byte) ([]byte, error) {
+	info := appendLengthPrefixed([]byte(keyDomain), []byte(sessionID), fromKey, toKey)
+	key := make([]byte, chacha20poly1305.KeySize)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, nil, info), key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// additionalData binds a ciphertext to its session, sender and recipient
+func additionalData(m *SealedMessage) []byte {
+	return appendLengthPrefixed(nil, []byte(m.GetSessionId()), m.GetFromKey(), m.GetToKey())
+}
+
+func signatureInput(m *SealedMessage) []byte {
+	isBroadcast := []byte{0}
+	if m.GetIsBroadcast() {
+		isBroadcast[0] = 1
+	}
+	return appendLengthPrefixed([]byte(signatureDomain),
+		[]byte(m.GetSessionId()), m.GetFromKey(), m.GetToKey(), isBroadcast, m.GetNonce(), m.GetPayload())
+}
+
+func appendLengthPrefixed(dst []byte, fields ...[]byte) []byte {
+	var length [4]byte
+	for _, f := range fields {
+		binary.BigEndian.PutUint32(length[:], uint32(len(f)))
+		dst = append(append(dst, length[:]...), f...)
+	}
+	return dst
+}
+
+func partyKey(id *tss.PartyID) string {
+	return hex.EncodeToString(id.GetKey())
+}
+
+// ----- //
+
+func (m *sealedMessage) GetTo() []*tss.PartyID {
+	return m.to
+}
+
+func (m *sealedMessage) WireBytes() ([]byte, *tss.MessageRouting, error) {
+	_, routing, err := m.Message.WireBytes()
+	if err != nil {
+		return nil, nil, err
+	}
+	sealedRouting := *routing
+	sealedRouting.To = m.to
+	return m.sealed, &sealedRouting, nil
+}
+
+func (m *sealedMessage) String() string {
+	return fmt.Sprintf("sealed %s", m.Message.String())
+}
@@ -0,0 +1,237 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/crypto/vss"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/transport"
+)
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestSealOpen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(3)
+	sealers := newSealers(t, "session", pIDs)
+
+	share := &vss.Share{Threshold: 1, ID: pIDs[1].KeyInt(), Share: common.GetRandomPositiveInt(rand.Reader, group.Ed25519().Order())}
+	p2p := keygen.NewKGRound2Message1(pIDs[1], pIDs[0], share)
+	wireBytes, _, _ := p2p.WireBytes()
+	sealed, err := sealers[0].Seal(rand.Reader, p2p)
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(sealed)) {
+		return
+	}
+	sealedBytes, routing, err := sealed[0].WireBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, pIDs[1], routing.To[0])
+	assert.False(t, bytes.Contains(sealedBytes, share.Share.Bytes()), "the share should be encrypted")
+
+	opened, sender, isBroadcast, err := sealers[1].Open(sealedBytes, pIDs[0])
+	assert.NoError(t, err)
+	assert.Equal(t, wireBytes, opened)
+	assert.Equal(t, pIDs[0], sender)
+	assert.False(t, isBroadcast)
+
+	// another party can neither open it nor have it attributed to someone else
+	_, _, _, err = sealers[2].Open(sealedBytes, pIDs[0])
+	assert.True(t, errors.Is(err, ErrUnauthenticated))
+	_, _, _, err = sealers[1].Open(sealedBytes, pIDs[2])
+	assert.True(t, errors.Is(err, ErrUnauthenticated))
+
+	// a broadcast message is signed for everyone
+	bcast := keygen.NewKGRound1Message(pIDs[0], common.GetRandomPositiveInt(rand.Reader, group.Ed25519().Order()))
+	sealed, err = sealers[0].Seal(rand.Reader, bcast)
+	if !assert.NoError(t, err) || !assert.Equal(t, 1, len(sealed)) {
+		return
+	}
+	sealedBytes, _, _ = sealed[0].WireBytes()
+	for _, s := range sealers[1:] {
+		_, sender, isBroadcast, err = s.Open(sealedBytes, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, pIDs[0], sender)
+		assert.True(t, isBroadcast)
+	}
+}
+
+func TestOpenRejectsTampering(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(2)
+	sealers := newSealers(t, "session", pIDs)
+	share := &vss.Share{Threshold: 1, ID: pIDs[1].KeyInt(), Share: common.GetRandomPositiveInt(rand.Reader, group.Ed25519().Order())}
+	sealed, err := sealers[0].Seal(rand.Reader, keygen.NewKGRound2Message1(pIDs[1], pIDs[0], share))
+	if !assert.NoError(t, err) {
+		return
+	}
+	sealedBytes, _, _ := sealed[0].WireBytes()
+
+	tamper := func(f func(m *SealedMessage)) []byte {
+		m := new(SealedMessage)
+		if err := proto.Unmarshal(sealedBytes, m); err != nil {
+			t.Fatal(err)
+		}
+		f(m)
+		bz, err := proto.Marshal(m)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bz
+	}
+	for name, bz := range map[string][]byte{
+		"payload":   tamper(func(m *SealedMessage) { m.Payload[0] ^= 1 }),
+		"sender":    tamper(func(m *SealedMessage) { m.FromKey = pIDs[1].GetKey() }),
+		"broadcast": tamper(func(m *SealedMessage) { m.IsBroadcast = true }),
+		"session":   tamper(func(m *SealedMessage) { m.SessionId = "another" }),
+		"signature": tamper(func(m *SealedMessage) { m.Signature = m.Signature[1:] }),
+	} {
+		_, _, _, err := sealers[1].Open(bz, nil)
+		assert.True(t, errors.Is(err, ErrUnauthenticated), "tampered %s should be rejected", name)
+	}
+
+	// nor can a party of another session
+	other := newSealers(t, "another", pIDs)
+	_, _, _, err = other[1].Open(sealedBytes, nil)
+	assert.True(t, errors.Is(err, ErrUnauthenticated))
+}
+
+func TestReceiptOpen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(3)
+	sealers := newSealers(t, "session", pIDs)
+	share := &vss.Share{Threshold: 1, ID: pIDs[1].KeyInt(), Share: common.GetRandomPositiveInt(rand.Reader, group.Ed25519().Order())}
+	p2p := keygen.NewKGRound2Message1(pIDs[1], pIDs[0], share)
+	wireBytes, _, _ := p2p.WireBytes()
+	sealed, err := sealers[0].Seal(rand.Reader, p2p)
+	if !assert.NoError(t, err) {
+		return
+	}
+	sealedBytes, _, _ := sealed[0].WireBytes()
+	_, sender, isBroadcast, err := sealers[1].Open(sealedBytes, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	receipt := sealers[1].receipt(sealedBytes, sender, isBroadcast)
+
+	// anyone holding the receipt can read the message and check who signed it
+	msg, sessionID, err := receipt.Open(pIDs[0])
+	if assert.NoError(t, err) {
+		assert.Equal(t, "session", sessionID)
+		opened, _, _ := msg.WireBytes()
+		assert.Equal(t, wireBytes, opened)
+		assert.False(t, msg.IsBroadcast())
+	}
+	_, _, err = receipt.Open(pIDs[2])
+	assert.True(t, errors.Is(err, ErrUnauthenticated), "the receipt should not open as another party's message")
+	forged := *receipt
+	forged.SenderKey = sealers[2].identity.SigningKey.Public().(ed25519.PublicKey)
+	_, _, err = forged.Open(pIDs[0])
+	assert.True(t, errors.Is(err, ErrUnauthenticated), "the receipt should not open under another signing key")
+	forged = *receipt
+	forged.OpeningKey = nil
+	_, _, err = forged.Open(pIDs[0])
+	assert.True(t, errors.Is(err, ErrUnauthenticated), "a point-to-point receipt should not open without its key")
+
+	// a broadcast receipt needs no key
+	sealed, err = sealers[0].Seal(rand.Reader, keygen.NewKGRound1Message(pIDs[0], common.GetRandomPositiveInt(rand.Reader, group.Ed25519().Order())))
+	if !assert.NoError(t, err) {
+		return
+	}
+	sealedBytes, _, _ = sealed[0].WireBytes()
+	receipt = sealers[2].receipt(sealedBytes, pIDs[0], true)
+	assert.Nil(t, receipt.OpeningKey)
+	msg, _, err = receipt.Open(pIDs[0])
+	if assert.NoError(t, err) {
+		assert.True(t, msg.IsBroadcast())
+	}
+}
+
+func TestE2ESealedKeygen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(test.TestParticipants)
+	sealers := newSealers(t, "keygen", pIDs)
+	p2pCtx := tss.NewPeerContext(pIDs)
+	router := transport.NewMemoryRouter()
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	errCh := make(chan error, len(pIDs))
+	for i, pID := range pIDs {
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pID, len(pIDs), keygen.TestThreshold)
+		outCh := make(chan tss.Message, len(pIDs))
+		P := NewParty(keygen.NewLocalParty(params, outCh, endCh), sealers[i])
+		tr := NewTransport(router.Transport("keygen", pID, pIDs), sealers[i])
+		defer tr.Close()
+		go func(r *transport.Runner) {
+			errCh <- r.Run(ctx)
+		}(transport.NewRunner(P, tr, outCh))
+	}
+	for range pIDs {
+		assert.NoError(t, <-errCh)
+	}
+
+	close(endCh)
+	var first *keygen.LocalPartySaveData
+	for save := range endCh {
+		if first == nil {
+			first = save
+			continue
+		}
+		assert.True(t, save.EDDSAPub.Equals(first.EDDSAPub), "all parties should agree on the public key")
+	}
+}
+
+func newSealers(t *testing.T, sessionID string, pIDs tss.SortedPartyIDs) []*Sealer {
+	identities := make([]*Identity, len(pIDs))
+	publics := make(map[string]PublicIdentity, len(pIDs))
+	for i, pID := range pIDs {
+		id, err := GenerateIdentity(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		identities[i] = id
+		if publics[pID.Id], err = id.Public(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sealers := make([]*Sealer, len(pIDs))
+	for i, pID := range pIDs {
+		s, err := NewSealer(sessionID, pID, identities[i], pIDs, publics)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sealers[i] = s
+	}
+	return sealers
+}
@@ -7,7 +7,10 @@
 package tss
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 )
 
 // fundamental is an error that has a message and a stack, but no caller.
@@ -19,6 +22,74 @@ type Error struct {
 	culprits []*PartyID
 }
 
+type (
+	// the type of the error kinds; the code identifies a kind in the JSON encoding of an Error
+	errorKind struct {
+		code, msg string
+	}
+
+	// an error with a kind attached, which keeps the message of the error
+	kindError struct {
+		kind error
+		err  error
+	}
+
+	errorJSON struct {
+		Kind     string      `json:"kind"`
+		Message  string      `json:"message"`
+		Task     string      `json:"task"`
+		Round    int         `json:"round"`
+		Victim   *partyJSON  `json:"victim,omitempty"`
+		Culprits []partyJSON `json:"culprits,omitempty"`
+	}
+
+	partyJSON struct {
+		ID      string `json:"id"`
+		Moniker string `json:"moniker"`
+		Key     []byte `json:"key"`
+		Index   int    `json:"index"`
+	}
+)
+
+// The kinds of the errors of the protocols. The cause of an Error matches at most one of them with errors.Is; the
+// message of the cause gives the details.
+var (
+	// ErrInvalidParameters: the arguments of a constructor or of Start are invalid, e.g. the threshold
+	ErrInvalidParameters error = &errorKind{"invalid_parameters", "invalid parameters"}
+	// ErrInvalidSaveData: the save data given to a party is incomplete or inconsistent
+	ErrInvalidSaveData error = &errorKind{"invalid_save_data", "invalid save data"}
+	// ErrInvalidState: the party was used out of order, e.g. started twice
+	ErrInvalidState error = &errorKind{"invalid_state", "invalid state"}
+	// ErrPreParamsGeneration: the safe primes or the Paillier key could not be generated in time
+	ErrPreParamsGeneration error = &errorKind{"pre_params_generation", "pre-params generation failed"}
+	// ErrInvalidMessage: a message is malformed or its content is invalid; the culprit is its sender
+	ErrInvalidMessage error = &errorKind{"invalid_message", "invalid message"}
+	// ErrDuplicateMessage: a message repeats values that another party sent before
+	ErrDuplicateMessage error = &errorKind{"duplicate_message", "duplicate message"}
+	// ErrEquivocation: a sender sent two different messages of the same type
+	ErrEquivocation error = &errorKind{"equivocation", "equivocation"}
+	// ErrInvalidProof: a zero-knowledge proof of the culprits failed to verify
+	ErrInvalidProof error = &errorKind{"invalid_proof", "invalid proof"}
+	// ErrBadCommitment: the culprits opened a commitment to a different value
+	ErrBadCommitment error = &errorKind{"bad_commitment", "bad commitment"}
+	// ErrBadShare: a secret share of the culprits does not match their commitments
+	ErrBadShare error = &errorKind{"bad_share", "bad share"}
+	// ErrInvalidSignature: the signature produced by the parties does not verify
+	ErrInvalidSignature error = &errorKind{"invalid_signature", "invalid signature"}
+	// ErrTimeout: a round did not receive its messages in time; the culprits are the parties it waited for
+	ErrTimeout error = &errorKind{"timeout", "timeout"}
+	// ErrAborted: the context of the party was cancelled; the culprits are the parties it waited for
+	ErrAborted error = &errorKind{"aborted", "aborted"}
+	// ErrInternal: a local computation failed
+	ErrInternal error = &errorKind{"internal", "internal error"}
+
+	errorKinds = []error{
+		ErrInvalidParameters, ErrInvalidSaveData, ErrInvalidState, ErrPreParamsGeneration, ErrInvalidMessage,
+		ErrDuplicateMessage, ErrEquivocation, ErrInvalidProof, ErrBadCommitment, ErrBadShare, ErrInvalidSignature,
+		ErrTimeout, ErrAborted, ErrInternal,
+	}
+)
+
 func NewError(err error, task string, round int, victim *PartyID, culprits ...*PartyID) *Error {
 	return &Error{cause: err, task: task, round: round, victim: victim, culprits: culprits}
 }
@@ -35,6 +106,11 @@ func (err *Error) Victim() *PartyID { return err.victim }
 
 func (err *Error) Culprits() []*PartyID { return err.culprits }
 
+// Kind returns the kind of the cause, one of the Err variables of this package, or nil if it has none
+func (err *Error) Kind() error {
+	return ErrorKind(err.cause)
+}
+
 func (err *Error) Error() string {
 	if err == nil || err.cause == nil {
 		return "Error is nil"
@@ -46,3 +122,98 @@ func (err *Error) Error() string {
 	return fmt.Sprintf("task %s, party %v, round %d: %s",
 		err.task, err.victim, err.round, err.cause.Error())
 }
+
+// MarshalJSON encodes the error for reporting to other services. The kind is the code of Kind(), e.g. "invalid_proof",
+// or "unknown"; the message is the message of the cause.
+func (err *Error) MarshalJSON() ([]byte, error) {
+	out := errorJSON{Kind: "unknown", Task: err.task, Round: err.round, Victim: newPartyJSON(err.victim)}
+	if kind, ok := err.Kind().(*errorKind); ok {
+		out.Kind = kind.code
+	}
+	if err.cause != nil {
+		out.Message = err.cause.Error()
+	}
+	for _, culprit := range err.culprits {
+		if pj := newPartyJSON(culprit); pj != nil {
+			out.Culprits = append(out.Culprits, *pj)
+		}
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes an error encoded by MarshalJSON. The cause has the message and the kind of the original cause.
+func (err *Error) UnmarshalJSON(data []byte) error {
+	in := new(errorJSON)
+	if e := json.Unmarshal(data, in); e != nil {
+		return e
+	}
+	*err = Error{cause: errors.New(in.Message), task: in.Task, round: in.Round, victim: in.Victim.partyID()}
+	for _, kind := range errorKinds {
+		if kind.(*errorKind).code == in.Kind {
+			err.cause = WithKind(kind, err.cause)
+		}
+	}
+	for i := range in.Culprits {
+		err.culprits = append(err.culprits, in.Culprits[i].partyID())
+	}
+	return nil
+}
+
+// ----- //
+
+func (k *errorKind) Error() string { return k.msg }
+
+// WithKind attaches a kind to err, so that errors.Is(err, kind) holds. The message is still the one of err.
+func WithKind(kind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// Errorf formats an error of the given kind like fmt.Errorf
+func Errorf(kind error, format string, a ...interface{}) error {
+	return WithKind(kind, fmt.Errorf(format, a...))
+}
+
+// ErrorKind returns the kind of err, one of the Err variables of this package, or nil if it has none. If kinds are
+// attached at several levels of the chain of err, the outermost one is returned.
+func ErrorKind(err error) error {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		switch e := e.(type) {
+		case *kindError:
+			return e.kind
+		case *errorKind:
+			return e
+		}
+	}
+	// errors that combine the errors of several culprits may only match their kinds with errors.Is
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+func (e *kindError) Error() string { return e.err.Error() }
+
+func (e *kindError) Unwrap() error { return e.err }
+
+func (e *kindError) Is(target error) bool { return target == e.kind }
+
+func newPartyJSON(pid *PartyID) *partyJSON {
+	if pid == nil || pid.MessageWrapper_PartyID == nil {
+		return nil
+	}
+	return &partyJSON{ID: pid.Id, Moniker: pid.Moniker, Key: pid.Key, Index: pid.Index}
+}
+
+func (pj *partyJSON) partyID() *PartyID {
+	if pj == nil {
+		return nil
+	}
+	pid := NewPartyID(pj.ID, pj.Moniker, new(big.Int).SetBytes(pj.Key))
+	pid.Index = pj.Index
+	return pid
+}
@@ -0,0 +1,72 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorKind(t *testing.T) {
+	cause := errors.New("vss verify failed")
+	err := WithKind(ErrBadShare, cause)
+	assert.Equal(t, "vss verify failed", err.Error(), "the message is the one of the cause")
+	assert.True(t, errors.Is(err, ErrBadShare))
+	assert.False(t, errors.Is(err, ErrInvalidProof))
+	assert.True(t, errors.Is(err, cause))
+	assert.Nil(t, WithKind(ErrBadShare, nil))
+
+	pIDs := GenerateTestPartyIDs(3)
+	tssErr := NewError(fmt.Errorf("round 3: %w", err), "test", 3, pIDs[0], pIDs[1])
+	assert.True(t, errors.Is(tssErr, ErrBadShare))
+	assert.Equal(t, ErrBadShare, tssErr.Kind())
+	assert.Nil(t, NewError(errors.New("no kind"), "test", 1, pIDs[0]).Kind())
+
+	// the outermost kind wins
+	assert.Equal(t, ErrTimeout, ErrorKind(Errorf(ErrTimeout, "round timed out: %w", Errorf(ErrAborted, "aborted"))))
+	// the errors of several culprits
+	var multiErr error
+	multiErr = multierror.Append(multiErr, errors.New("no kind"), Errorf(ErrInvalidProof, "modProof verify failed"))
+	assert.Equal(t, ErrInvalidProof, ErrorKind(multiErr))
+}
+
+func TestErrorJSON(t *testing.T) {
+	pIDs := GenerateTestPartyIDs(3)
+	tssErr := NewError(Errorf(ErrInvalidProof, "facProof verify failed"), "ecdsa-keygen", 3, pIDs[0], pIDs[1], pIDs[2])
+	bz, err := json.Marshal(tssErr)
+	assert.NoError(t, err)
+
+	fields := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(bz, &fields))
+	assert.Equal(t, "invalid_proof", fields["kind"])
+	assert.Equal(t, "facProof verify failed", fields["message"])
+	assert.Equal(t, "ecdsa-keygen", fields["task"])
+	assert.Len(t, fields["culprits"], 2)
+
+	decoded := new(Error)
+	assert.NoError(t, json.Unmarshal(bz, decoded))
+	assert.Equal(t, tssErr.Error(), decoded.Error())
+	assert.True(t, errors.Is(decoded, ErrInvalidProof))
+	assert.Equal(t, pIDs[0].KeyInt(), decoded.Victim().KeyInt())
+	if assert.Len(t, decoded.Culprits(), 2) {
+		assert.Equal(t, pIDs[2].Index, decoded.Culprits()[1].Index)
+		assert.Equal(t, pIDs[2].Id, decoded.Culprits()[1].Id)
+	}
+
+	// an error without a kind
+	bz, err = json.Marshal(NewError(errors.New("oops"), "test", 1, nil))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(bz, decoded))
+	assert.Nil(t, decoded.Kind())
+	assert.Nil(t, decoded.Victim())
+	assert.Contains(t, string(bz), `"kind":"unknown"`)
+}
@@ -0,0 +1,234 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package keystore wraps payloads such as the save data of a party in a versioned container for storage at rest.
+// The payload may be encrypted with AES-256-GCM under a key derived from a passphrase or under a key-encryption
+// key (KEK) held by the application, e.g. in an HSM or a cloud KMS.
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// FormatVersion is the version of the container format written by Seal
+	FormatVersion uint32 = 1
+
+	// KEKSize is the size of a key-encryption key, which is used as an AES-256 key
+	KEKSize = 32
+
+	saltSize = 16
+
+	// Argon2id parameters, following the second recommendation of RFC 9106
+	argon2Time    = 3
+	argon2Memory  = 64 * 1024 // KiB
+	argon2Threads = 4
+
+	// scrypt parameters
+	scryptLogN = 15
+	scryptR    = 8
+	scryptP    = 1
+
+	// upper bounds on the parameters accepted by Open, so that a crafted container cannot exhaust the host
+	maxArgon2Time   = 64
+	maxArgon2Memory = 4 * 1024 * 1024 // KiB
+	maxScryptLogN   = 22
+	maxScryptRP     = 1 << 16
+)
+
+type (
+	// Key holds the secret that protects a container: nothing, a passphrase or a KEK.
+	// A passphrase Key opens containers sealed with either passphrase KDF.
+	Key struct {
+		kdf    KDF
+		secret []byte
+	}
+
+	// Migration upgrades a payload encoded with one version to the next version
+	Migration func(payload []byte) ([]byte, error)
+
+	// Migrations maps a payload version to the Migration that upgrades it to the next version
+	Migrations map[uint32]Migration
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("keystore: unsupported container format")
+	ErrKindMismatch      = errors.New("keystore: the container holds a different kind of payload")
+	ErrWrongKey          = errors.New("keystore: the key does not match the protection of the container")
+	ErrDecryption        = errors.New("keystore: wrong key or corrupted container")
+)
+
+// NoEncryption returns a Key that leaves the payload in the clear. Only use it if the storage is protected otherwise.
+func NoEncryption() Key {
+	return Key{kdf: KDF_NONE}
+}
+
+// Passphrase returns a Key that encrypts the payload under a key derived from passphrase with Argon2id
+func Passphrase(passphrase []byte) Key {
+	return Key{kdf: KDF_ARGON2ID, secret: passphrase}
+}
+
+// ScryptPassphrase returns a Key that encrypts the payload under a key derived from passphrase with scrypt
+func ScryptPassphrase(passphrase []byte) Key {
+	return Key{kdf: KDF_SCRYPT, secret: passphrase}
+}
+
+// KEK returns a Key that encrypts the payload directly under the KEKSize-byte key-encryption key kek
+func KEK(kek []byte) Key {
+	return Key{kdf: KDF_KEK, secret: kek}
+}
+
+func (k Key) isPassphrase() bool {
+	return k.kdf == KDF_ARGON2ID || k.kdf == KDF_SCRYPT
+}
+
+// Seal wraps payload, encoded with the given version of kind, in a container protected by key
+func Seal(rand io.Reader, kind string, version uint32, payload []byte, key Key) ([]byte, error) {
+	c := &Container{
+		FormatVersion: FormatVersion,
+		Kind:          kind,
+		Version:       version,
+		Kdf:           key.kdf,
+	}
+	if key.kdf == KDF_NONE {
+		c.Payload = payload
+		return proto.Marshal(c)
+	}
+	switch key.kdf {
+	case KDF_ARGON2ID:
+		c.KdfParams = []uint32{argon2Time, argon2Memory, argon2Threads}
+	case KDF_SCRYPT:
+		c.KdfParams = []uint32{scryptLogN, scryptR, scryptP}
+	}
+	if key.isPassphrase() {
+		c.Salt = make([]byte, saltSize)
+		if _, err := io.ReadFull(rand, c.Salt); err != nil {
+			return nil, err
+		}
+	}
+	aead, err := newAEAD(c, key)
+	if err != nil {
+		return nil, err
+	}
+	c.Nonce = make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand, c.Nonce); err != nil {
+		return nil, err
+	}
+	ad, err := additionalData(c)
+	if err != nil {
+		return nil, err
+	}
+	c.Payload = aead.Seal(nil, c.Nonce, payload, ad)
+	return proto.Marshal(c)
+}
+
+// Open unwraps the payload of a container of the given kind sealed with key, along with the version of its encoding
+func Open(data []byte, kind string, key Key) (uint32, []byte, error) {
+	c := new(Container)
+	if err := proto.Unmarshal(data, c); err != nil {
+		return 0, nil, fmt.Errorf("keystore: %w", err)
+	}
+	if c.GetFormatVersion() != FormatVersion {
+		return 0, nil, ErrUnsupportedFormat
+	}
+	if c.GetKind() != kind {
+		return 0, nil, ErrKindMismatch
+	}
+	switch {
+	case c.GetKdf() == KDF_NONE && key.kdf == KDF_NONE:
+		return c.GetVersion(), c.GetPayload(), nil
+	case c.GetKdf() == KDF_KEK && key.kdf == KDF_KEK:
+	case (c.GetKdf() == KDF_ARGON2ID || c.GetKdf() == KDF_SCRYPT) && key.isPassphrase():
+	default:
+		return 0, nil, ErrWrongKey
+	}
+	aead, err := newAEAD(c, key)
+	if err != nil {
+		return 0, nil, err
+	}
+	if len(c.GetNonce()) != aead.NonceSize() {
+		return 0, nil, ErrDecryption
+	}
+	ad, err := additionalData(c)
+	if err != nil {
+		return 0, nil, err
+	}
+	payload, err := aead.Open(nil, c.GetNonce(), c.GetPayload(), ad)
+	if err != nil {
+		return 0, nil, ErrDecryption
+	}
+	return c.GetVersion(), payload, nil
+}
+
+// Apply upgrades payload from version to current by applying each migration in turn
+func (m Migrations) Apply(version, current uint32, payload []byte) ([]byte, error) {
+	if current < version {
+		return nil, fmt.Errorf("keystore: payload version %d is newer than the supported version %d", version, current)
+	}
+	for ; version < current; version++ {
+		migrate, ok := m[version]
+		if !ok {
+			return nil, fmt.Errorf("keystore: no migration from payload version %d", version)
+		}
+		var err error
+		if payload, err = migrate(payload); err != nil {
+			return nil, fmt.Errorf("keystore: migration from payload version %d failed: %w", version, err)
+		}
+	}
+	return payload, nil
+}
+
+// ----- //
+
+// newAEAD derives the encryption key of c from key, which must match the protection of c
+func newAEAD(c *Container, key Key) (cipher.AEAD, error) {
+	var aesKey []byte
+	params := c.GetKdfParams()
+	switch c.GetKdf() {
+	case KDF_ARGON2ID:
+		if len(params) != 3 || params[0] == 0 || maxArgon2Time < params[0] ||
+			params[1] == 0 || maxArgon2Memory < params[1] || params[2] == 0 || 255 < params[2] {
+			return nil, errors.New("keystore: invalid argon2id parameters")
+		}
+		aesKey = argon2.IDKey(key.secret, c.GetSalt(), params[0], params[1], uint8(params[2]), 32)
+	case KDF_SCRYPT:
+		if len(params) != 3 || params[0] == 0 || maxScryptLogN < params[0] ||
+			params[1] == 0 || params[2] == 0 || maxScryptRP < params[1]*params[2] {
+			return nil, errors.New("keystore: invalid scrypt parameters")
+		}
+		var err error
+		if aesKey, err = scrypt.Key(key.secret, c.GetSalt(), 1<<params[0], int(params[1]), int(params[2]), 32); err != nil {
+			return nil, err
+		}
+	case KDF_KEK:
+		if len(key.secret) != KEKSize {
+			return nil, fmt.Errorf("keystore: the KEK must be %d bytes", KEKSize)
+		}
+		aesKey = key.secret
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// additionalData binds every field of the container but the payload to its ciphertext
+func additionalData(c *Container) ([]byte, error) {
+	header := proto.Clone(c).(*Container)
+	header.Payload = nil
+	return proto.MarshalOptions{Deterministic: true}.Marshal(header)
+}
@@ -0,0 +1,287 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        v3.20.3
+// source: protob/keystore.proto
+
+package keystore
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// The key derivation used to encrypt the payload of a Container.
+type KDF int32
+
+const (
+	// the payload is not encrypted
+	KDF_NONE     KDF = 0
+	KDF_ARGON2ID KDF = 1
+	KDF_SCRYPT   KDF = 2
+	// the payload is encrypted directly with a key-encryption key supplied by the application
+	KDF_KEK KDF = 3
+)
+
+// Enum value maps for KDF.
+var (
+	KDF_name = map[int32]string{
+		0: "NONE",
+		1: "ARGON2ID",
+		2: "SCRYPT",
+		3: "KEK",
+	}
+	KDF_value = map[string]int32{
+		"NONE":     0,
+		"ARGON2ID": 1,
+		"SCRYPT":   2,
+		"KEK":      3,
+	}
+)
+
+func (x KDF) Enum() *KDF {
+	p := new(KDF)
+	*p = x
+	return p
+}
+
+func (x KDF) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (KDF) Descriptor() protoreflect.EnumDescriptor {
+	return file_protob_keystore_proto_enumTypes[0].Descriptor()
+}
+
+func (KDF) Type() protoreflect.EnumType {
+	return &file_protob_keystore_proto_enumTypes[0]
+}
+
+func (x KDF) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use KDF.Descriptor instead.
+func (KDF) EnumDescriptor() ([]byte, []int) {
+	return file_protob_keystore_proto_rawDescGZIP(), []int{0}
+}
+
+// Wraps a versioned payload, such as the save data of a party, for storage at rest.
+type Container struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the version of the container format itself
+	FormatVersion uint32 `protobuf:"varint,1,opt,name=format_version,json=formatVersion,proto3" json:"format_version,omitempty"`
+	// the type of the payload, e.g. "ecdsa/keygen.LocalPartySaveData"
+	Kind string `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	// the version of the payload encoding
+	Version uint32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	Kdf     KDF    `protobuf:"varint,4,opt,name=kdf,proto3,enum=binance.tsslib.keystore.KDF" json:"kdf,omitempty"`
+	Salt    []byte `protobuf:"bytes,5,opt,name=salt,proto3" json:"salt,omitempty"`
+	// argon2id: time, memory (KiB), threads; scrypt: log2(N), r, p
+	KdfParams []uint32 `protobuf:"varint,6,rep,packed,name=kdf_params,json=kdfParams,proto3" json:"kdf_params,omitempty"`
+	Nonce     []byte   `protobuf:"bytes,7,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// the payload, or its AES-256-GCM ciphertext when kdf is not NONE
+	Payload []byte `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *Container) Reset() {
+	*x = Container{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protob_keystore_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Container) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container) ProtoMessage() {}
+
+func (x *Container) ProtoReflect() protoreflect.Message {
+	mi := &file_protob_keystore_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container.ProtoReflect.Descriptor instead.
+func (*Container) Descriptor() ([]byte, []int) {
+	return file_protob_keystore_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Container) GetFormatVersion() uint32 {
+	if x != nil {
+		return x.FormatVersion
+	}
+	return 0
+}
+
+func (x *Container) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Container) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Container) GetKdf() KDF {
+	if x != nil {
+		return x.Kdf
+	}
+	return KDF_NONE
+}
+
+func (x *Container) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *Container) GetKdfParams() []uint32 {
+	if x != nil {
+		return x.KdfParams
+	}
+	return nil
+}
+
+func (x *Container) GetNonce() []byte {
+	if x != nil {
+		return x.Nonce
+	}
+	return nil
+}
+
+func (x *Container) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_protob_keystore_proto protoreflect.FileDescriptor
+
+var file_protob_keystore_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x2f, 0x6b, 0x65, 0x79, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65,
+	0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69, 0x62, 0x2e, 0x6b, 0x65, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x22, 0xf3, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x12, 0x25,
+	0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x03, 0x6b, 0x64, 0x66, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1c, 0x2e, 0x62, 0x69, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x74, 0x73, 0x73, 0x6c, 0x69,
+	0x62, 0x2e, 0x6b, 0x65, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x4b, 0x44, 0x46, 0x52, 0x03,
+	0x6b, 0x64, 0x66, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x73, 0x61, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6b, 0x64, 0x66, 0x5f, 0x70,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x09, 0x6b, 0x64, 0x66,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x2a, 0x32, 0x0a, 0x03, 0x4b, 0x44, 0x46, 0x12, 0x08, 0x0a,
+	0x04, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x52, 0x47, 0x4f, 0x4e,
+	0x32, 0x49, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x53, 0x43, 0x52, 0x59, 0x50, 0x54, 0x10,
+	0x02, 0x12, 0x07, 0x0a, 0x03, 0x4b, 0x45, 0x4b, 0x10, 0x03, 0x42, 0x0e, 0x5a, 0x0c, 0x74, 0x73,
+	0x73, 0x2f, 0x6b, 0x65, 0x79, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_protob_keystore_proto_rawDescOnce sync.Once
+	file_protob_keystore_proto_rawDescData = file_protob_keystore_proto_rawDesc
+)
+
+func file_protob_keystore_proto_rawDescGZIP() []byte {
+	file_protob_keystore_proto_rawDescOnce.Do(func() {
+		file_protob_keystore_proto_rawDescData = protoimpl.X.CompressGZIP(file_protob_keystore_proto_rawDescData)
+	})
+	return file_protob_keystore_proto_rawDescData
+}
+
+var file_protob_keystore_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_protob_keystore_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_protob_keystore_proto_goTypes = []interface{}{
+	(KDF)(0),          // 0: binance.tsslib.keystore.KDF
+	(*Container)(nil), // 1: binance.tsslib.keystore.Container
+}
+var file_protob_keystore_proto_depIdxs = []int32{
+	0, // 0: binance.tsslib.keystore.Container.kdf:type_name -> binance.tsslib.keystore.KDF
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_protob_keystore_proto_init() }
+func file_protob_keystore_proto_init() {
+	if File_protob_keystore_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_protob_keystore_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Container); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_protob_keystore_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_protob_keystore_proto_goTypes,
+		DependencyIndexes: file_protob_keystore_proto_depIdxs,
+		EnumInfos:         file_protob_keystore_proto_enumTypes,
+		MessageInfos:      file_protob_keystore_proto_msgTypes,
+	}.Build()
+	File_protob_keystore_proto = out.File
+	file_protob_keystore_proto_rawDesc = nil
+	file_protob_keystore_proto_goTypes = nil
+	file_protob_keystore_proto_depIdxs = nil
+}
@@ -0,0 +1,95 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package keystore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+const testKind = "test.Payload"
+
+func TestSealOpen(t *testing.T) {
+	payload := []byte("the secret share")
+	kek := make([]byte, KEKSize)
+	_, _ = rand.Read(kek)
+
+	keys := []Key{NoEncryption(), Passphrase([]byte("correct horse")), ScryptPassphrase([]byte("battery staple")), KEK(kek)}
+	for _, key := range keys {
+		sealed, err := Seal(rand.Reader, testKind, 7, payload, key)
+		if !assert.NoError(t, err, key.kdf.String()) {
+			continue
+		}
+		if key.kdf != KDF_NONE {
+			assert.False(t, bytes.Contains(sealed, payload), "%s: the payload should be encrypted", key.kdf)
+		}
+		version, opened, err := Open(sealed, testKind, key)
+		assert.NoError(t, err, key.kdf.String())
+		assert.Equal(t, uint32(7), version)
+		assert.Equal(t, payload, opened)
+
+		_, _, err = Open(sealed, "other.Payload", key)
+		assert.Equal(t, ErrKindMismatch, err)
+	}
+}
+
+func TestOpenRejects(t *testing.T) {
+	payload := []byte("the secret share")
+	key := ScryptPassphrase([]byte("battery staple"))
+	sealed, err := Seal(rand.Reader, testKind, 1, payload, key)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	_, _, err = Open(sealed, testKind, Passphrase([]byte("battery stapler")))
+	assert.Equal(t, ErrDecryption, err)
+	_, _, err = Open(sealed, testKind, NoEncryption())
+	assert.Equal(t, ErrWrongKey, err)
+	_, _, err = Open(sealed, testKind, KEK(make([]byte, KEKSize)))
+	assert.Equal(t, ErrWrongKey, err)
+
+	// the version is authenticated along with the payload
+	c := new(Container)
+	assert.NoError(t, proto.Unmarshal(sealed, c))
+	c.Version = 2
+	tampered, _ := proto.Marshal(c)
+	_, _, err = Open(tampered, testKind, key)
+	assert.Equal(t, ErrDecryption, err)
+
+	c.Version, c.FormatVersion = 1, FormatVersion+1
+	tampered, _ = proto.Marshal(c)
+	_, _, err = Open(tampered, testKind, key)
+	assert.Equal(t, ErrUnsupportedFormat, err)
+}
+
+func TestMigrationsApply(t *testing.T) {
+	migrations := Migrations{
+		1: func(payload []byte) ([]byte, error) { return append(payload, '2'), nil },
+		2: func(payload []byte) ([]byte, error) { return append(payload, '3'), nil },
+	}
+	out, err := migrations.Apply(1, 3, []byte("v1"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v123"), out)
+
+	out, err = migrations.Apply(3, 3, []byte("v3"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("v3"), out)
+
+	_, err = migrations.Apply(4, 3, nil)
+	assert.Error(t, err)
+	_, err = migrations.Apply(0, 3, nil)
+	assert.Error(t, err)
+
+	failing := Migrations{1: func([]byte) ([]byte, error) { return nil, errors.New("bad payload") }}
+	_, err = failing.Apply(1, 2, nil)
+	assert.Error(t, err)
+}
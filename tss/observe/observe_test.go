@@ -0,0 +1,171 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package observe
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/ecdsa/signing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+	"github.com/bnb-chain/tss-lib/v2/tss/transport"
+)
+
+// the events of two rounds of party 0, in which the proof of party 2 fails
+func testEvents() ([]tss.Event, tss.SortedPartyIDs) {
+	pIDs := tss.GenerateTestPartyIDs(3)
+	start := time.Now()
+	return []tss.Event{
+		tss.RoundStarted{Party: pIDs[0], Task: "test", Round: 1, Time: start},
+		tss.WaitingForChanged{Party: pIDs[0], Task: "test", Round: 1, WaitingFor: pIDs[1:]},
+		tss.MessageStored{Party: pIDs[0], Task: "test", Round: 1, From: pIDs[1], Type: "Round1", IsBroadcast: true},
+		tss.WaitingForChanged{Party: pIDs[0], Task: "test", Round: 1, WaitingFor: pIDs[2:]},
+		tss.MessageStored{Party: pIDs[0], Task: "test", Round: 1, From: pIDs[2], Type: "Round1", IsBroadcast: true},
+		tss.WaitingForChanged{Party: pIDs[0], Task: "test", Round: 1, WaitingFor: []*tss.PartyID{}},
+		tss.RoundFinished{Party: pIDs[0], Task: "test", Round: 1, Duration: time.Second},
+		// the proofs of round 2 are verified in its Start
+		tss.ProofVerified{Party: pIDs[0], Task: "test", Round: 2, Prover: pIDs[1], Proof: "ZKProof", Valid: true, Duration: time.Millisecond},
+		tss.ProofVerified{Party: pIDs[0], Task: "test", Round: 2, Prover: pIDs[2], Proof: "ZKProof", Valid: false, Duration: time.Millisecond},
+		tss.RoundStarted{Party: pIDs[0], Task: "test", Round: 2, Time: start.Add(time.Second)},
+		tss.MessageStored{Party: pIDs[0], Task: "test", Round: 2, From: pIDs[1], Type: "Round2", Queued: true},
+		tss.RoundFinished{Party: pIDs[0], Task: "test", Round: 2, Duration: time.Second},
+	}, pIDs
+}
+
+func TestPrometheus(t *testing.T) {
+	reg := prometheus.NewPedanticRegistry()
+	o, err := NewPrometheus(reg)
+	assert.NoError(t, err)
+	_, err = NewPrometheus(reg)
+	assert.Error(t, err, "the metrics are already registered")
+
+	events, pIDs := testEvents()
+	for _, event := range events {
+		o.Observe(event)
+	}
+	assert.Equal(t, 2.0, testutil.ToFloat64(o.messages.WithLabelValues("test", "Round1", "false")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.messages.WithLabelValues("test", "Round2", "true")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.proofs.WithLabelValues("test", "ZKProof", "true")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(o.proofs.WithLabelValues("test", "ZKProof", "false")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(o.waitingFor.WithLabelValues("test", pIDs[0].Id)))
+	assert.Equal(t, 2, testutil.CollectAndCount(o.roundDuration))
+	assert.Equal(t, 3, testutil.CollectAndCount(o.messageWait))
+	assert.Empty(t, o.started, "the finished rounds are forgotten")
+	problems, err := testutil.GatherAndLint(reg)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestOpenTelemetry(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("tss")
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("tss")
+	o, err := NewOpenTelemetry(context.Background(), tracer, meter)
+	assert.NoError(t, err)
+
+	events, _ := testEvents()
+	for _, event := range events {
+		o.Observe(event)
+	}
+
+	ended := spans.Ended()
+	if assert.Len(t, ended, 2) {
+		assert.Equal(t, "test round 1", ended[0].Name())
+		assert.Equal(t, codes.Unset, ended[0].Status().Code)
+		// 3 changes of the parties it waits for and 2 messages
+		assert.Len(t, ended[0].Events(), 5)
+		assert.Equal(t, "test round 2", ended[1].Name())
+		assert.Equal(t, codes.Error, ended[1].Status().Code)
+		// the proofs verified before the span started are added to it
+		assert.Len(t, ended[1].Events(), 3)
+		assert.Equal(t, "proof verified", ended[1].Events()[0].Name)
+	}
+	assert.Empty(t, o.spans)
+	assert.Empty(t, o.pending)
+
+	var rm metricdata.ResourceMetrics
+	assert.NoError(t, reader.Collect(context.Background(), &rm))
+	sums := make(map[string]int64)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				for _, dp := range sum.DataPoints {
+					sums[m.Name] += dp.Value
+					if valid, ok := dp.Attributes.Value(attribute.Key("tss.valid")); ok && !valid.AsBool() {
+						assert.Equal(t, int64(1), dp.Value)
+					}
+				}
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{"tss.messages.stored": 3, "tss.proofs.verified": 2}, sums)
+}
+
+func TestE2ESigningWithObservers(t *testing.T) {
+	keys, signPIDs, err := keygen.LoadKeygenTestFixturesRandomSet(test.TestThreshold+1, test.TestParticipants)
+	assert.NoError(t, err, "should load keygen fixtures")
+	p2pCtx := tss.NewPeerContext(signPIDs)
+
+	reg := prometheus.NewRegistry()
+	prom, err := NewPrometheus(reg)
+	assert.NoError(t, err)
+	spans := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)).Tracer("tss")
+	otel, err := NewOpenTelemetry(context.Background(), tracer, sdkmetric.NewMeterProvider().Meter("tss"))
+	assert.NoError(t, err)
+	observer := tss.MultiObserver(prom, otel)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	router := transport.NewMemoryRouter()
+	var wg sync.WaitGroup
+	endCh := make(chan *common.SignatureData, len(signPIDs))
+	for i, pID := range signPIDs {
+		params := tss.NewParameters(group.Secp256k1(), p2pCtx, pID, len(signPIDs), test.TestThreshold)
+		params.SetObserver(observer)
+		outCh := make(chan tss.Message, len(signPIDs))
+		P := signing.NewLocalParty(big.NewInt(42), params, keys[i], outCh, endCh)
+		runner := transport.NewRunner(P, router.Transport("signing", pID, signPIDs), outCh)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, runner.Run(ctx))
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, endCh, len(signPIDs))
+
+	// every party ends the span of each of the 10 rounds
+	assert.Len(t, spans.Ended(), 10*len(signPIDs))
+	for _, span := range spans.Ended() {
+		assert.Equal(t, codes.Unset, span.Status().Code)
+	}
+	others := float64(len(signPIDs) * (len(signPIDs) - 1))
+	assert.Equal(t, 5*others, testutil.ToFloat64(prom.proofs.WithLabelValues(signing.TaskName, "ZKProof", "true"))+
+		testutil.ToFloat64(prom.proofs.WithLabelValues(signing.TaskName, "ZKVProof", "true"))+
+		testutil.ToFloat64(prom.proofs.WithLabelValues(signing.TaskName, "ProofBob", "true"))+
+		testutil.ToFloat64(prom.proofs.WithLabelValues(signing.TaskName, "ProofBobWC", "true")))
+	assert.Equal(t, 10, testutil.CollectAndCount(prom.roundDuration))
+}
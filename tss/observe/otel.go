@@ -0,0 +1,189 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package observe
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// OpenTelemetry is a tss.Observer that records each round of a party as a span, with the messages, the proofs
+	// and the changes of the parties it waits for as span events, and records the metrics of NewPrometheus as
+	// OpenTelemetry instruments. A span is ended when its round finishes, so the span of the round in which a party
+	// fails is not ended.
+	OpenTelemetry struct {
+		ctx           context.Context
+		tracer        trace.Tracer
+		roundDuration metric.Float64Histogram
+		messages      metric.Int64Counter
+		messageWait   metric.Float64Histogram
+		proofs        metric.Int64Counter
+		proofDuration metric.Float64Histogram
+
+		mtx   sync.Mutex
+		spans map[roundKey]*roundSpan
+		// the proofs verified while a round was starting, before its span existed
+		pending map[roundKey][]tss.ProofVerified
+	}
+
+	roundSpan struct {
+		span    trace.Span
+		round   int
+		started time.Time
+	}
+)
+
+var _ tss.Observer = (*OpenTelemetry)(nil)
+
+// NewOpenTelemetry returns an Observer whose round spans are children of the span in ctx
+func NewOpenTelemetry(ctx context.Context, tracer trace.Tracer, meter metric.Meter) (*OpenTelemetry, error) {
+	o := &OpenTelemetry{
+		ctx:     ctx,
+		tracer:  tracer,
+		spans:   make(map[roundKey]*roundSpan),
+		pending: make(map[roundKey][]tss.ProofVerified),
+	}
+	var err error
+	if o.roundDuration, err = meter.Float64Histogram("tss.round.duration",
+		metric.WithUnit("s"), metric.WithDescription("The time of a round from its start to its last message.")); err != nil {
+		return nil, err
+	}
+	if o.messages, err = meter.Int64Counter("tss.messages.stored",
+		metric.WithDescription("The messages stored by the parties.")); err != nil {
+		return nil, err
+	}
+	if o.messageWait, err = meter.Float64Histogram("tss.message.wait",
+		metric.WithUnit("s"), metric.WithDescription("The time from the start of a round to the message of a sender.")); err != nil {
+		return nil, err
+	}
+	if o.proofs, err = meter.Int64Counter("tss.proofs.verified",
+		metric.WithDescription("The proofs verified by the parties.")); err != nil {
+		return nil, err
+	}
+	if o.proofDuration, err = meter.Float64Histogram("tss.proof.duration",
+		metric.WithUnit("s"), metric.WithDescription("The time spent verifying a proof.")); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func (o *OpenTelemetry) Observe(event tss.Event) {
+	switch e := event.(type) {
+	case tss.RoundStarted:
+		key := roundKey{e.Party, e.Task}
+		_, span := o.tracer.Start(o.ctx, fmt.Sprintf("%s round %d", e.Task, e.Round),
+			trace.WithTimestamp(e.Time),
+			trace.WithAttributes(
+				attribute.String("tss.task", e.Task),
+				attribute.Int("tss.round", e.Round),
+				attribute.String("tss.party", e.Party.Id),
+			))
+		o.mtx.Lock()
+		o.spans[key] = &roundSpan{span: span, round: e.Round, started: e.Time}
+		pending := o.pending[key]
+		delete(o.pending, key)
+		o.mtx.Unlock()
+		for _, proof := range pending {
+			o.addProof(span, proof)
+		}
+
+	case tss.RoundFinished:
+		key := roundKey{e.Party, e.Task}
+		o.mtx.Lock()
+		rs := o.spans[key]
+		delete(o.spans, key)
+		o.mtx.Unlock()
+		o.roundDuration.Record(o.ctx, e.Duration.Seconds(), metric.WithAttributes(
+			attribute.String("tss.task", e.Task),
+			attribute.Int("tss.round", e.Round),
+		))
+		if rs != nil {
+			rs.span.End()
+		}
+
+	case tss.MessageStored:
+		o.messages.Add(o.ctx, 1, metric.WithAttributes(
+			attribute.String("tss.task", e.Task),
+			attribute.String("tss.message_type", e.Type),
+			attribute.Bool("tss.queued", e.Queued),
+		))
+		rs := o.span(roundKey{e.Party, e.Task}, e.Round)
+		if rs == nil {
+			return
+		}
+		o.messageWait.Record(o.ctx, time.Since(rs.started).Seconds(), metric.WithAttributes(
+			attribute.String("tss.task", e.Task),
+			attribute.Int("tss.round", e.Round),
+			attribute.String("tss.from", e.From.Id),
+		))
+		rs.span.AddEvent("message stored", trace.WithAttributes(
+			attribute.String("tss.from", e.From.Id),
+			attribute.String("tss.message_type", e.Type),
+			attribute.Bool("tss.broadcast", e.IsBroadcast),
+			attribute.Bool("tss.queued", e.Queued),
+		))
+
+	case tss.ProofVerified:
+		task, proof := attribute.String("tss.task", e.Task), attribute.String("tss.proof", e.Proof)
+		o.proofs.Add(o.ctx, 1, metric.WithAttributes(task, proof, attribute.Bool("tss.valid", e.Valid)))
+		o.proofDuration.Record(o.ctx, e.Duration.Seconds(), metric.WithAttributes(task, proof))
+		key := roundKey{e.Party, e.Task}
+		o.mtx.Lock()
+		rs, ok := o.spans[key]
+		if !ok || rs.round != e.Round {
+			// the proofs of a round may be verified in its Start, which is reported once it returns
+			o.pending[key] = append(o.pending[key], e)
+			o.mtx.Unlock()
+			return
+		}
+		o.mtx.Unlock()
+		o.addProof(rs.span, e)
+
+	case tss.WaitingForChanged:
+		rs := o.span(roundKey{e.Party, e.Task}, e.Round)
+		if rs == nil {
+			return
+		}
+		ids := make([]string, 0, len(e.WaitingFor))
+		for _, id := range e.WaitingFor {
+			ids = append(ids, id.Id)
+		}
+		rs.span.AddEvent("waiting for", trace.WithAttributes(attribute.StringSlice("tss.waiting_for", ids)))
+	}
+}
+
+// span returns the span of the given round of a party, if it is the current one
+func (o *OpenTelemetry) span(key roundKey, round int) *roundSpan {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+	if rs, ok := o.spans[key]; ok && rs.round == round {
+		return rs
+	}
+	return nil
+}
+
+func (o *OpenTelemetry) addProof(span trace.Span, e tss.ProofVerified) {
+	span.AddEvent("proof verified", trace.WithAttributes(
+		attribute.String("tss.proof", e.Proof),
+		attribute.String("tss.prover", e.Prover.Id),
+		attribute.Bool("tss.valid", e.Valid),
+		attribute.Float64("tss.duration", e.Duration.Seconds()),
+	))
+	if !e.Valid {
+		span.SetStatus(codes.Error, fmt.Sprintf("the %s of %s failed", e.Proof, e.Prover.Id))
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package observe adapts the events of tss parties to Prometheus metrics and to OpenTelemetry traces and metrics.
+// It is a separate module so that the tss-lib module does not depend on either library.
+package observe
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// Prometheus is a tss.Observer that records the events of parties as Prometheus metrics
+	Prometheus struct {
+		roundDuration *prometheus.HistogramVec
+		messages      *prometheus.CounterVec
+		messageWait   *prometheus.HistogramVec
+		proofs        *prometheus.CounterVec
+		proofDuration *prometheus.HistogramVec
+		waitingFor    *prometheus.GaugeVec
+
+		mtx     sync.Mutex
+		started map[roundKey]time.Time
+	}
+
+	// identifies the current round of a party, which is reported with its task
+	roundKey struct {
+		party *tss.PartyID
+		task  string
+	}
+)
+
+var _ tss.Observer = (*Prometheus)(nil)
+
+// NewPrometheus registers the metrics of the parties with reg in the "tss" namespace and returns an Observer that
+// updates them:
+//   - tss_round_duration_seconds{task, round}: the time of a round from its start to its last message
+//   - tss_messages_stored_total{task, type, queued}: the messages stored by the parties
+//   - tss_message_wait_seconds{task, round, from}: the time from the start of a round to the message of each sender,
+//     which shows the slow parties
+//   - tss_proofs_verified_total{task, proof, valid}: the proofs verified by the parties
+//   - tss_proof_verification_seconds{task, proof}: the time spent verifying each proof
+//   - tss_waiting_for_parties{task, party}: the number of parties the current round of each party is waiting for
+func NewPrometheus(reg prometheus.Registerer) (*Prometheus, error) {
+	o := &Prometheus{
+		roundDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tss",
+			Name:      "round_duration_seconds",
+			Help:      "The time of a round from its start to its last message.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"task", "round"}),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "tss",
+			Name:      "messages_stored_total",
+			Help:      "The messages stored by the parties.",
+		}, []string{"task", "type", "queued"}),
+		messageWait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tss",
+			Name:      "message_wait_seconds",
+			Help:      "The time from the start of a round to the message of a sender.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 10),
+		}, []string{"task", "round", "from"}),
+		proofs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "tss",
+			Name:      "proofs_verified_total",
+			Help:      "The proofs verified by the parties.",
+		}, []string{"task", "proof", "valid"}),
+		proofDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tss",
+			Name:      "proof_verification_seconds",
+			Help:      "The time spent verifying a proof.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+		}, []string{"task", "proof"}),
+		waitingFor: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "tss",
+			Name:      "waiting_for_parties",
+			Help:      "The number of parties the current round of a party is waiting for.",
+		}, []string{"task", "party"}),
+		started: make(map[roundKey]time.Time),
+	}
+	for _, c := range []prometheus.Collector{o.roundDuration, o.messages, o.messageWait, o.proofs, o.proofDuration, o.waitingFor} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+func (o *Prometheus) Observe(event tss.Event) {
+	switch e := event.(type) {
+	case tss.RoundStarted:
+		o.mtx.Lock()
+		o.started[roundKey{e.Party, e.Task}] = e.Time
+		o.mtx.Unlock()
+	case tss.RoundFinished:
+		o.mtx.Lock()
+		delete(o.started, roundKey{e.Party, e.Task})
+		o.mtx.Unlock()
+		o.roundDuration.WithLabelValues(e.Task, strconv.Itoa(e.Round)).Observe(e.Duration.Seconds())
+	case tss.MessageStored:
+		o.messages.WithLabelValues(e.Task, e.Type, strconv.FormatBool(e.Queued)).Inc()
+		o.mtx.Lock()
+		started, ok := o.started[roundKey{e.Party, e.Task}]
+		o.mtx.Unlock()
+		if ok {
+			o.messageWait.WithLabelValues(e.Task, strconv.Itoa(e.Round), e.From.Id).Observe(time.Since(started).Seconds())
+		}
+	case tss.ProofVerified:
+		o.proofs.WithLabelValues(e.Task, e.Proof, strconv.FormatBool(e.Valid)).Inc()
+		o.proofDuration.WithLabelValues(e.Task, e.Proof).Observe(e.Duration.Seconds())
+	case tss.WaitingForChanged:
+		o.waitingFor.WithLabelValues(e.Task, e.Party.Id).Set(float64(len(e.WaitingFor)))
+	}
+}
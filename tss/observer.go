@@ -0,0 +1,187 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"time"
+)
+
+type (
+	// Observer receives the events of the parties whose Parameters it is set on. A party reports its events from the
+	// time it is started, synchronously and mostly while it is locked, so Observe must be quick and must not call
+	// the party. Proofs may be verified concurrently, so Observe must be safe for concurrent use.
+	Observer interface {
+		Observe(event Event)
+	}
+
+	// ObserverFunc adapts a function to an Observer
+	ObserverFunc func(event Event)
+
+	// Event is one of RoundStarted, RoundFinished, MessageStored, ProofVerified and WaitingForChanged
+	Event interface {
+		event()
+	}
+
+	// RoundStarted is reported when a party has started a round and sent its first messages in it. Time is when the
+	// party began the computation of the round.
+	RoundStarted struct {
+		Party *PartyID
+		Task  string
+		Round int
+		Time  time.Time
+	}
+
+	// RoundFinished is reported when a party has all the messages of a round and leaves it. Duration is the time from
+	// the start of the round, including its computation and the time spent waiting for the messages.
+	RoundFinished struct {
+		Party    *PartyID
+		Task     string
+		Round    int
+		Duration time.Duration
+	}
+
+	// MessageStored is reported when a party stores a message from another party. Queued is set if the message
+	// arrived before the party reached a round that could accept it. Round is the round of the party.
+	MessageStored struct {
+		Party       *PartyID
+		Task        string
+		Round       int
+		From        *PartyID
+		Type        string
+		IsBroadcast bool
+		Queued      bool
+	}
+
+	// ProofVerified is reported when a party has verified a proof of another party; Valid is false if the proof failed
+	ProofVerified struct {
+		Party    *PartyID
+		Task     string
+		Round    int
+		Prover   *PartyID
+		Proof    string
+		Valid    bool
+		Duration time.Duration
+	}
+
+	// WaitingForChanged is reported when the set of parties that the current round of a party is waiting for changes,
+	// including when a round starts
+	WaitingForChanged struct {
+		Party      *PartyID
+		Task       string
+		Round      int
+		WaitingFor []*PartyID
+	}
+
+	multiObserver []Observer
+
+	// the state of a started party that reports its events
+	observing struct {
+		observer   Observer
+		task       string
+		round      int
+		started    time.Time
+		waitingFor []*PartyID
+	}
+)
+
+func (RoundStarted) event()      {}
+func (RoundFinished) event()     {}
+func (MessageStored) event()     {}
+func (ProofVerified) event()     {}
+func (WaitingForChanged) event() {}
+
+func (f ObserverFunc) Observe(event Event) {
+	f(event)
+}
+
+// MultiObserver returns an Observer that reports each event to all of the observers, in order
+func MultiObserver(observers ...Observer) Observer {
+	return multiObserver(observers)
+}
+
+func (m multiObserver) Observe(event Event) {
+	for _, o := range m {
+		o.Observe(event)
+	}
+}
+
+// ----- //
+
+// Observe reports an event to the Observer of the parameters, if one is set
+func (params *Parameters) Observe(event Event) {
+	if params.observer != nil {
+		params.observer.Observe(event)
+	}
+}
+
+// observeStart is called once the first round of a party was started at started
+func observeStart(p Party, task string, started time.Time) {
+	o := p.observing()
+	o.observer, o.task = p.round().Params().Observer(), task
+	observeRoundStarted(p, started)
+}
+
+// observeRoundStarted is called once the current round was started at started; the round sets its number in Start
+func observeRoundStarted(p Party, started time.Time) {
+	o := p.observing()
+	if o.observer == nil {
+		return
+	}
+	o.round, o.started, o.waitingFor = p.round().RoundNumber(), started, nil
+	o.observer.Observe(RoundStarted{Party: p.PartyID(), Task: o.task, Round: o.round, Time: started})
+}
+
+// observeRoundFinished is called before the party advances from the current round
+func observeRoundFinished(p Party) {
+	o := p.observing()
+	if o.observer == nil {
+		return
+	}
+	o.observer.Observe(RoundFinished{Party: p.PartyID(), Task: o.task, Round: o.round, Duration: time.Since(o.started)})
+}
+
+func observeMessage(p Party, msg ParsedMessage, queued bool) {
+	o := p.observing()
+	if o.observer == nil {
+		return
+	}
+	o.observer.Observe(MessageStored{
+		Party:       p.PartyID(),
+		Task:        o.task,
+		Round:       o.round,
+		From:        msg.GetFrom(),
+		Type:        msg.Type(),
+		IsBroadcast: msg.IsBroadcast(),
+		Queued:      queued,
+	})
+}
+
+// observeWaitingFor is called after the current round was started or updated
+func observeWaitingFor(p Party) {
+	o := p.observing()
+	if o.observer == nil || p.round() == nil {
+		return
+	}
+	waitingFor := p.round().WaitingFor()
+	if o.waitingFor != nil && samePartyIDs(o.waitingFor, waitingFor) {
+		return
+	}
+	o.waitingFor = append([]*PartyID{}, waitingFor...)
+	o.observer.Observe(WaitingForChanged{Party: p.PartyID(), Task: o.task, Round: o.round, WaitingFor: waitingFor})
+}
+
+func samePartyIDs(a, b []*PartyID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].KeyInt().Cmp(b[i].KeyInt()) != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -7,27 +7,35 @@
 package tss
 
 import (
-	"crypto/elliptic"
 	"crypto/rand"
 	"io"
 	"runtime"
 	"time"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
 )
 
 type (
 	Parameters struct {
-		ec                  elliptic.Curve
+		group               group.AffineGroup
 		partyID             *PartyID
 		parties             *PeerContext
 		partyCount          int
 		threshold           int
 		concurrency         int
 		safePrimeGenTimeout time.Duration
+		roundTimeout        time.Duration
 		// proof session info
 		nonce int
 		// for keygen
 		noProofMod bool
 		noProofFac bool
+		// for ecdsa signing; nil selects the default of the curve
+		lowS *bool
+		// whether parties keep a SessionJournal so that they can be snapshotted and restored
+		sessionSnapshots bool
+		// receives the events of the parties
+		observer Observer
 		// random sources
 		partialKeyRand, rand io.Reader
 	}
@@ -45,9 +53,9 @@ const (
 )
 
 // Exported, used in `tss` client
-func NewParameters(ec elliptic.Curve, ctx *PeerContext, partyID *PartyID, partyCount, threshold int) *Parameters {
+func NewParameters(g group.AffineGroup, ctx *PeerContext, partyID *PartyID, partyCount, threshold int) *Parameters {
 	return &Parameters{
-		ec:                  ec,
+		group:               g,
 		parties:             ctx,
 		partyID:             partyID,
 		partyCount:          partyCount,
@@ -59,8 +67,9 @@ func NewParameters(ec elliptic.Curve, ctx *PeerContext, partyID *PartyID, partyC
 	}
 }
 
-func (params *Parameters) EC() elliptic.Curve {
-	return params.ec
+// Group is the group of curve points the protocols run in
+func (params *Parameters) Group() group.AffineGroup {
+	return params.group
 }
 
 func (params *Parameters) Parties() *PeerContext {
@@ -96,6 +105,15 @@ func (params *Parameters) SetSafePrimeGenTimeout(timeout time.Duration) {
 	params.safePrimeGenTimeout = timeout
 }
 
+// RoundTimeout is the time a party started with StartWithContext waits for the messages of a round; 0 means no limit.
+func (params *Parameters) RoundTimeout() time.Duration {
+	return params.roundTimeout
+}
+
+func (params *Parameters) SetRoundTimeout(timeout time.Duration) {
+	params.roundTimeout = timeout
+}
+
 func (params *Parameters) NoProofMod() bool {
 	return params.noProofMod
 }
@@ -112,6 +130,38 @@ func (params *Parameters) SetNoProofFac() {
 	params.noProofFac = true
 }
 
+// LowS returns true if ecdsa signing normalizes s to the lower half of the order, as the consensus rules of
+// secp256k1 chains require. It defaults to true on secp256k1 and to false on the other curves.
+func (params *Parameters) LowS() bool {
+	if params.lowS != nil {
+		return *params.lowS
+	}
+	return params.group.Name() == group.Secp256k1().Name()
+}
+
+func (params *Parameters) SetLowS(lowS bool) {
+	params.lowS = &lowS
+}
+
+// SessionSnapshots returns true if the parties that support it draw their randomness from a SessionJournal, so
+// that their state can be saved with Snapshot() and resumed with Restore() after a crash
+func (params *Parameters) SessionSnapshots() bool {
+	return params.sessionSnapshots
+}
+
+func (params *Parameters) SetSessionSnapshots(enabled bool) {
+	params.sessionSnapshots = enabled
+}
+
+// Observer receives the events of the parties created with these parameters; nil disables the events
+func (params *Parameters) Observer() Observer {
+	return params.observer
+}
+
+func (params *Parameters) SetObserver(observer Observer) {
+	params.observer = observer
+}
+
 func (params *Parameters) PartialKeyRand() io.Reader {
 	return params.partialKeyRand
 }
@@ -131,8 +181,8 @@ func (params *Parameters) SetRand(rand io.Reader) {
 // ----- //
 
 // Exported, used in `tss` client
-func NewReSharingParameters(ec elliptic.Curve, ctx, newCtx *PeerContext, partyID *PartyID, partyCount, threshold, newPartyCount, newThreshold int) *ReSharingParameters {
-	params := NewParameters(ec, ctx, partyID, partyCount, threshold)
+func NewReSharingParameters(g group.AffineGroup, ctx, newCtx *PeerContext, partyID *PartyID, partyCount, threshold, newPartyCount, newThreshold int) *ReSharingParameters {
+	params := NewParameters(g, ctx, partyID, partyCount, threshold)
 	return &ReSharingParameters{
 		Parameters:    params,
 		newParties:    newCtx,
@@ -7,15 +7,23 @@
 package tss
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/bnb-chain/tss-lib/v2/common"
 )
 
 type Party interface {
 	Start() *Error
+	// StartWithContext starts the party and blocks until it finishes or is aborted by the cancellation of ctx or
+	// the expiry of a round timeout. An aborted party discards its temporary secrets and returns an *Error naming the
+	// parties it was waiting for as culprits. Unlike Start, it also processes the messages that were passed to Update
+	// before the party was started, so messages may be delivered to it as soon as it is constructed.
+	StartWithContext(ctx context.Context) *Error
 	// The main entry point when updating a party's state from the wire.
 	// isBroadcast should represent whether the message was received via a reliable broadcast
 	UpdateFromBytes(wireBytes []byte, from *PartyID, isBroadcast bool) (ok bool, err *Error)
@@ -25,10 +33,15 @@ type Party interface {
 	WaitingFor() []*PartyID
 	ValidateMessage(msg ParsedMessage) (bool, *Error)
 	StoreMessage(msg ParsedMessage) (bool, *Error)
+	// MessageRound returns the number of the round of the party that accepts msg, or 0 if none of its rounds do
+	MessageRound(msg ParsedMessage) int
 	FirstRound() Round
 	WrapError(err error, culprits ...*PartyID) *Error
 	PartyID() *PartyID
 	String() string
+	// Close stops the party and wipes its secrets from memory. A running party fails with ErrAborted; a finished
+	// party keeps the data it sent to its end channel. Close may be called at any time and more than once.
+	Close()
 
 	// Private lifecycle methods
 	setRound(Round) *Error
@@ -36,12 +49,51 @@ type Party interface {
 	advance()
 	lock()
 	unlock()
+	watch() <-chan struct{}
+	abort(cause error, onAbort func()) *Error
+	abortError() *Error
+	fail(err *Error) *Error
+	finish()
+	dedupe(msg ParsedMessage) (bool, error)
+	hasReceived() bool
+	enqueue(msg ParsedMessage)
+	queued() []ParsedMessage
+	queuedFrom(sender *PartyID) int
+	dequeue(accept func(ParsedMessage) bool) []ParsedMessage
+	observing() *observing
 }
 
+// maxQueuedPerSender bounds the messages of a sender that a party keeps for its later rounds. A sender cannot get more
+// than a round ahead of the party, as each of its rounds waits for the messages of the party, so this leaves room for
+// the two messages of a round twice over.
+const maxQueuedPerSender = 4
+
 type BaseParty struct {
 	mtx        sync.Mutex
 	rnd        Round
 	FirstRound Round
+
+	// set when the party is started with a context
+	progress chan struct{} // signalled when the party moves to the next round
+	aborted  *Error
+
+	// messages that the current round cannot accept yet; they are stored once the party reaches a round that can
+	queue []ParsedMessage
+	// the digest of the message received from each sender for each message type
+	received map[string][]byte
+
+	obs observing
+
+	// wipes the secrets of the party, see NewBaseParty
+	wipe     func(finished bool)
+	finished bool
+}
+
+// NewBaseParty returns a BaseParty that calls wipe, with the party locked, to zero the secrets of the party from
+// memory once it finishes, fails, is aborted or is closed. finished is true if the party has ended with its result;
+// wipe must then leave alone the values that were sent to the end channel.
+func NewBaseParty(wipe func(finished bool)) *BaseParty {
+	return &BaseParty{wipe: wipe}
 }
 
 func (p *BaseParty) Running() bool {
@@ -67,17 +119,29 @@ func (p *BaseParty) WrapError(err error, culprits ...*PartyID) *Error {
 // an implementation of ValidateMessage that is shared across the different types of parties (keygen, signing, dynamic groups)
 func (p *BaseParty) ValidateMessage(msg ParsedMessage) (bool, *Error) {
 	if msg == nil || msg.Content() == nil {
-		return false, p.WrapError(fmt.Errorf("received nil msg: %s", msg))
+		return false, p.WrapError(Errorf(ErrInvalidMessage, "received nil msg: %s", msg))
 	}
 	if msg.GetFrom() == nil || !msg.GetFrom().ValidateBasic() {
-		return false, p.WrapError(fmt.Errorf("received msg with an invalid sender: %s", msg))
+		return false, p.WrapError(Errorf(ErrInvalidMessage, "received msg with an invalid sender: %s", msg))
 	}
 	if !msg.ValidateBasic() {
-		return false, p.WrapError(fmt.Errorf("message failed ValidateBasic: %s", msg), msg.GetFrom())
+		return false, p.WrapError(Errorf(ErrInvalidMessage, "message failed ValidateBasic: %s", msg), msg.GetFrom())
 	}
 	return true, nil
 }
 
+func (p *BaseParty) Close() {
+	p.lock()
+	defer p.unlock()
+	if p.aborted == nil {
+		p.aborted = p.WrapError(Errorf(ErrAborted, "the party was closed"))
+	}
+	p.rnd = nil
+	p.queue = nil
+	p.zeroize()
+	p.signal()
+}
+
 func (p *BaseParty) String() string {
 	if rnd := p.round(); rnd != nil {
 		return fmt.Sprintf("round: %d", rnd.RoundNumber())
@@ -91,7 +155,7 @@ func (p *BaseParty) String() string {
 
 func (p *BaseParty) setRound(round Round) *Error {
 	if p.rnd != nil {
-		return p.WrapError(errors.New("a round is already set on this party"))
+		return p.WrapError(Errorf(ErrInvalidState, "a round is already set on this party"))
 	}
 	p.rnd = round
 	return nil
@@ -103,6 +167,17 @@ func (p *BaseParty) round() Round {
 
 func (p *BaseParty) advance() {
 	p.rnd = p.rnd.NextRound()
+	p.signal()
+}
+
+// signal wakes StartWithContext up to check whether the party has finished or failed
+func (p *BaseParty) signal() {
+	if p.progress != nil {
+		select {
+		case p.progress <- struct{}{}:
+		default: // the watcher has not consumed the previous signal yet
+		}
+	}
 }
 
 func (p *BaseParty) lock() {
@@ -113,75 +188,323 @@ func (p *BaseParty) unlock() {
 	p.mtx.Unlock()
 }
 
+func (p *BaseParty) watch() <-chan struct{} {
+	p.lock()
+	defer p.unlock()
+	if p.progress == nil {
+		p.progress = make(chan struct{}, 1)
+	}
+	return p.progress
+}
+
+func (p *BaseParty) abortError() *Error {
+	return p.aborted
+}
+
+// fail stops the party after a round failed with err and wipes its secrets; later updates return err
+func (p *BaseParty) fail(err *Error) *Error {
+	if p.aborted == nil {
+		p.aborted = err
+	}
+	p.rnd = nil
+	p.zeroize()
+	p.signal()
+	return err
+}
+
+// finish wipes the secrets of a party that has moved past its last round
+func (p *BaseParty) finish() {
+	p.finished = true
+	p.zeroize()
+}
+
+func (p *BaseParty) zeroize() {
+	if p.wipe != nil {
+		p.wipe(p.finished)
+	}
+}
+
+// dedupe returns false if msg is a retransmission of a message received before, and ErrEquivocation if the sender
+// sent another message of the same type before
+func (p *BaseParty) dedupe(msg ParsedMessage) (bool, error) {
+	wire, _, err := msg.WireBytes()
+	if err != nil {
+		return false, err
+	}
+	broadcast := []byte{0}
+	if msg.IsBroadcast() {
+		broadcast[0] = 1
+	}
+	key := string(msg.GetFrom().GetKey()) + "/" + msg.Type()
+	digest := common.SHA512_256(wire, broadcast)
+	if prev, ok := p.received[key]; ok {
+		if !bytes.Equal(prev, digest) {
+			return false, fmt.Errorf("%w: received two different %s messages from %s", ErrEquivocation, msg.Type(), msg.GetFrom())
+		}
+		return false, nil
+	}
+	if p.received == nil {
+		p.received = make(map[string][]byte)
+	}
+	p.received[key] = digest
+	return true, nil
+}
+
+// hasReceived returns true if the party has received a message
+func (p *BaseParty) hasReceived() bool {
+	return len(p.received) > 0
+}
+
+func (p *BaseParty) enqueue(msg ParsedMessage) {
+	p.queue = append(p.queue, msg)
+}
+
+func (p *BaseParty) queued() []ParsedMessage {
+	return p.queue
+}
+
+// queuedFrom returns the number of queued messages of sender
+func (p *BaseParty) queuedFrom(sender *PartyID) int {
+	n := 0
+	for _, msg := range p.queue {
+		if bytes.Equal(msg.GetFrom().GetKey(), sender.GetKey()) {
+			n++
+		}
+	}
+	return n
+}
+
+// dequeue removes the queued messages that accept returns true for and returns them in the order they arrived
+func (p *BaseParty) dequeue(accept func(ParsedMessage) bool) []ParsedMessage {
+	var msgs []ParsedMessage
+	rest := p.queue[:0]
+	for _, msg := range p.queue {
+		if accept(msg) {
+			msgs = append(msgs, msg)
+		} else {
+			rest = append(rest, msg)
+		}
+	}
+	p.queue = rest
+	return msgs
+}
+
+func (p *BaseParty) observing() *observing {
+	return &p.obs
+}
+
+// abort stops a running party with an error naming the parties the current round is waiting for; it returns nil if
+// the party has already finished.
+func (p *BaseParty) abort(cause error, onAbort func()) *Error {
+	p.lock()
+	defer p.unlock()
+	if p.aborted != nil {
+		return p.aborted
+	}
+	if p.rnd == nil {
+		return nil
+	}
+	p.aborted = p.rnd.WrapError(cause, p.rnd.WaitingFor()...)
+	p.rnd = nil
+	p.zeroize()
+	if onAbort != nil {
+		onAbort()
+	}
+	return p.aborted
+}
+
 // ----- //
 
 func BaseStart(p Party, task string, prepare ...func(Round) *Error) *Error {
 	p.lock()
 	defer p.unlock()
 	if p.PartyID() == nil || !p.PartyID().ValidateBasic() {
-		return p.WrapError(fmt.Errorf("could not start. this party has an invalid PartyID: %+v", p.PartyID()))
+		return p.WrapError(Errorf(ErrInvalidParameters, "could not start. this party has an invalid PartyID: %+v", p.PartyID()))
 	}
-	if p.round() != nil {
-		return p.WrapError(errors.New("could not start. this party is in an unexpected state. use the constructor and Start()"))
+	if p.round() != nil || p.abortError() != nil {
+		return p.WrapError(Errorf(ErrInvalidState, "could not start. this party is in an unexpected state. use the constructor and Start()"))
 	}
 	round := p.FirstRound()
 	if err := p.setRound(round); err != nil {
 		return err
 	}
 	if 1 < len(prepare) {
-		return p.WrapError(errors.New("too many prepare functions given to Start(); 1 allowed"))
+		return p.WrapError(Errorf(ErrInvalidParameters, "too many prepare functions given to Start(); 1 allowed"))
 	}
 	if len(prepare) == 1 {
 		if err := prepare[0](round); err != nil {
-			return err
+			return p.fail(err)
 		}
 	}
 	common.Logger.Infof("party %s: %s round %d starting", p.round().Params().PartyID(), task, 1)
 	defer func() {
-		common.Logger.Debugf("party %s: %s round %d finished", p.round().Params().PartyID(), task, 1)
+		common.Logger.Debugf("party %s: %s round %d finished", p.PartyID(), task, 1)
 	}()
-	return p.round().Start()
+	started := time.Now()
+	if err := p.round().Start(); err != nil {
+		return p.fail(err)
+	}
+	observeStart(p, task, started)
+	if !p.hasReceived() {
+		observeWaitingFor(p)
+		return nil
+	}
+	// the messages that arrived before the party was started may already complete the first round
+	return baseProceed(p)
 }
 
-// an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups)
+// an implementation of Update that is shared across the different types of parties (keygen, signing, dynamic groups).
+// Retransmissions are dropped and a second, different message of the same type from the same sender fails with
+// ErrEquivocation. A message that the current round cannot accept is queued and stored once the party reaches a round
+// that can.
 func BaseUpdate(p Party, msg ParsedMessage, task string) (ok bool, err *Error) {
 	// fast-fail on an invalid message; do not lock the mutex yet
 	if _, err := p.ValidateMessage(msg); err != nil {
 		return false, err
 	}
-	// lock the mutex. need this mtx unlock hook; L108 is recursive so cannot use defer
-	r := func(ok bool, err *Error) (bool, *Error) {
-		p.unlock()
-		return ok, err
-	}
 	p.lock() // data is written to P state below
+	defer p.unlock()
 	common.Logger.Debugf("party %s received message: %s", p.PartyID(), msg.String())
-	if p.round() != nil {
-		common.Logger.Debugf("party %s round %d update: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
+	if err := p.abortError(); err != nil {
+		return false, err
+	}
+	if fresh, err := p.dedupe(msg); err != nil {
+		return false, p.WrapError(err, msg.GetFrom())
+	} else if !fresh {
+		common.Logger.Debugf("party %s dropped a retransmitted message: %s", p.PartyID(), msg.String())
+		return true, nil
+	}
+	if p.round() != nil && !p.round().CanAccept(msg) {
+		switch number := p.MessageRound(msg); {
+		case number == 0:
+			return false, p.WrapError(Errorf(ErrInvalidMessage, "no round accepts a %s message", msg.Type()), msg.GetFrom())
+		case number <= p.round().RoundNumber():
+			common.Logger.Warningf("party %s round %d dropped a message for an earlier round: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
+			return false, nil
+		}
+		if p.queuedFrom(msg.GetFrom()) >= maxQueuedPerSender {
+			return false, p.WrapError(Errorf(ErrInvalidMessage, "too many messages queued from %s", msg.GetFrom()), msg.GetFrom())
+		}
+		common.Logger.Debugf("party %s round %d queued a message for a later round: %s", p.PartyID(), p.round().RoundNumber(), msg.String())
+		// the current round may still proceed without it, e.g. when only another committee receives in it
+		p.enqueue(msg)
+	} else {
+		if ok, err := p.StoreMessage(msg); err != nil || !ok {
+			return false, err
+		}
+		observeMessage(p, msg, false)
+		if p.round() != nil {
+			common.Logger.Debugf("party %s: %s round %d update: %s", p.PartyID(), task, p.round().RoundNumber(), msg.String())
+		}
+	}
+	if err := baseProceed(p); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// BaseStartWithContext is an implementation of StartWithContext that is shared across the different types of parties.
+// It returns once the party finishes or fails, or aborts it when the context is done or a round times out. The
+// secrets of an aborted party are wiped first, see NewBaseParty; onAbort is then called with the party locked to
+// drop the rest of its temporary data.
+func BaseStartWithContext(ctx context.Context, p Party, onAbort func()) *Error {
+	if err := ctx.Err(); err != nil {
+		return p.WrapError(ContextError(err))
+	}
+	progress := p.watch()
+	if err := p.Start(); err != nil {
+		return err
+	}
+	p.lock()
+	if err := baseProceed(p); err != nil {
+		p.unlock()
+		return err
 	}
-	if ok, err := p.StoreMessage(msg); err != nil || !ok {
-		return r(false, err)
+	if p.round() == nil {
+		p.unlock()
+		return nil
 	}
-	if p.round() != nil {
-		common.Logger.Debugf("party %s: %s round %d update", p.round().Params().PartyID(), task, p.round().RoundNumber())
+	timeout := p.round().Params().RoundTimeout()
+	p.unlock()
+
+	for {
+		var deadline <-chan time.Time
+		var timer *time.Timer
+		if 0 < timeout {
+			timer = time.NewTimer(timeout)
+			deadline = timer.C
+		}
+		select {
+		case <-progress:
+			if timer != nil {
+				timer.Stop()
+			}
+			p.lock()
+			finished, err := p.round() == nil, p.abortError()
+			p.unlock()
+			if err != nil {
+				// the party failed or was closed
+				return err
+			}
+			if finished {
+				return nil
+			}
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return p.abort(ContextError(ctx.Err()), onAbort)
+		case <-deadline:
+			return p.abort(Errorf(ErrTimeout, "round timed out after %s: %w", timeout, context.DeadlineExceeded), onAbort)
+		}
+	}
+}
+
+// ContextError attaches ErrTimeout or ErrAborted to the error of a done context, for parties that watch a context
+func ContextError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return WithKind(ErrTimeout, err)
+	}
+	return WithKind(ErrAborted, err)
+}
+
+// baseProceed updates the current round with the messages stored so far and moves the party through every round
+// that has all of its messages. The queued messages that a new round can accept are stored when it starts. A round
+// that fails to update or to start fails the party; its secrets are wiped and later updates return the same error.
+// The party must be locked.
+func baseProceed(p Party) *Error {
+	for p.round() != nil {
 		if _, err := p.round().Update(); err != nil {
-			return r(false, err)
-		}
-		if p.round().CanProceed() {
-			if p.advance(); p.round() != nil {
-				if err := p.round().Start(); err != nil {
-					return r(false, err)
-				}
-				rndNum := p.round().RoundNumber()
-				common.Logger.Infof("party %s: %s round %d started", p.round().Params().PartyID(), task, rndNum)
-			} else {
-				// finished! the round implementation will have sent the data through the `end` channel.
-				common.Logger.Infof("party %s: %s finished!", p.PartyID(), task)
+			return p.fail(err)
+		}
+		observeWaitingFor(p)
+		if !p.round().CanProceed() {
+			return nil
+		}
+		observeRoundFinished(p)
+		if p.advance(); p.round() == nil {
+			// finished! the round implementation will have sent the data through the `end` channel.
+			common.Logger.Infof("party %s: finished!", p.PartyID())
+			p.finish()
+			return nil
+		}
+		started := time.Now()
+		if err := p.round().Start(); err != nil {
+			return p.fail(err)
+		}
+		observeRoundStarted(p, started)
+		common.Logger.Infof("party %s: round %d started", p.PartyID(), p.round().RoundNumber())
+		for _, msg := range p.dequeue(p.round().CanAccept) {
+			if _, err := p.StoreMessage(msg); err != nil {
+				return p.fail(err)
 			}
-			p.unlock()                      // recursive so can't defer after return
-			return BaseUpdate(p, msg, task) // re-run round update or finish)
+			observeMessage(p, msg, true)
+		}
+		// the messages of the rounds that have gone by, e.g. those for another committee, would never be stored
+		number := p.round().RoundNumber()
+		for _, msg := range p.dequeue(func(msg ParsedMessage) bool { return p.MessageRound(msg) <= number }) {
+			common.Logger.Debugf("party %s round %d dropped a message for an earlier round: %s", p.PartyID(), number, msg.String())
 		}
-		return r(true, nil)
 	}
-	return r(true, nil)
+	return nil
 }
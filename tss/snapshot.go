@@ -0,0 +1,355 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package tss
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"sync"
+
+	"golang.org/x/crypto/chacha20"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss/keystore"
+)
+
+// A session snapshot lets a party resume a session after its process crashed. Rather than serializing the secrets
+// of the rounds, the party draws all of its randomness from a stream seeded by the journal, which records the seed
+// and the messages the party received; a restored party re-runs the rounds from the seed and the received messages
+// and so re-derives the same secrets. The journal also records the digests of the messages the party sent, and a
+// restored party fails if a re-run round produces a message that differs from one that was already sent, e.g. a
+// different round 1 commitment in signing, which would reuse a nonce.
+//
+// The secrets are therefore never written to a snapshot, but the seed is as secret as all of them together. The
+// costs of the design are that a restore repeats the work of every round up to the snapshot, that a party can only
+// be restored while its inputs, e.g. the pre-params of an ecdsa keygen, are given again unchanged, and that no snapshot
+// can be taken once the party has finished or failed, because the seed is then wiped. Every source of randomness of a
+// round must come from the journal for a re-run to be faithful. A value that the rounds cannot derive again, e.g. a
+// share computed from single-use nonces that were consumed when the party started, is recorded with Keep instead.
+
+const (
+	// SessionSnapshotVersion is the version of the snapshot encoding written by BaseSnapshot
+	SessionSnapshotVersion uint32 = 1
+
+	sessionSeedSize = chacha20.KeySize
+)
+
+type (
+	// SessionJournal is the random source of a party that supports snapshots and the record of the messages it
+	// received and sent. It is safe for concurrent use.
+	SessionJournal struct {
+		mtx      sync.Mutex
+		seed     []byte
+		stream   *chacha20.Cipher
+		received []receivedMessage
+		sent     [][]byte
+		// the digests of the messages sent before the snapshot the journal was restored from
+		replay   [][]byte
+		replayed int
+		kept     []byte
+	}
+
+	receivedMessage struct {
+		From        int    `json:"from"`
+		FromKey     []byte `json:"from_key,omitempty"`
+		IsBroadcast bool   `json:"is_broadcast"`
+		WireBytes   []byte `json:"wire_bytes"`
+	}
+
+	sessionSnapshot struct {
+		Binding  []byte            `json:"binding"`
+		Round    int               `json:"round"`
+		Seed     []byte            `json:"seed"`
+		Received []receivedMessage `json:"received"`
+		Sent     [][]byte          `json:"sent"`
+		Kept     []byte            `json:"kept"`
+	}
+)
+
+// NewSessionJournal returns a journal with a seed drawn from rand
+func NewSessionJournal(rand io.Reader) (*SessionJournal, error) {
+	seed, err := common.GetRandomBytes(rand, sessionSeedSize)
+	if err != nil {
+		return nil, WithKind(ErrInternal, err)
+	}
+	return newSessionJournal(seed)
+}
+
+func newSessionJournal(seed []byte) (*SessionJournal, error) {
+	stream, err := chacha20.NewUnauthenticatedCipher(seed, make([]byte, chacha20.NonceSize))
+	if err != nil {
+		return nil, WithKind(ErrInternal, err)
+	}
+	return &SessionJournal{seed: seed, stream: stream}, nil
+}
+
+// Read fills b with the next bytes of the seeded stream
+func (j *SessionJournal) Read(b []byte) (int, error) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if j.stream == nil {
+		return 0, Errorf(ErrInvalidState, "the session journal was wiped")
+	}
+	for i := range b {
+		b[i] = 0
+	}
+	j.stream.XORKeyStream(b, b)
+	return len(b), nil
+}
+
+// Wipe zeroes the seed of the journal, from which every secret of the session can be recomputed. It is called when
+// the party finishes or fails; the journal can then neither be read nor be snapshotted.
+func (j *SessionJournal) Wipe() {
+	if j == nil {
+		return
+	}
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	common.WipeBytes(j.seed)
+	j.seed, j.stream = nil, nil
+}
+
+// Fork returns a new stream seeded from the journal's stream. Rounds that draw randomness from several goroutines
+// must fork a stream for each goroutine before starting them, so that a re-run draws the same bytes in each.
+func (j *SessionJournal) Fork() io.Reader {
+	seed := make([]byte, sessionSeedSize)
+	_, _ = j.Read(seed)
+	fork, _ := newSessionJournal(seed)
+	return fork
+}
+
+// Keep records a value of the session that the rounds cannot derive again from the seed, e.g. a share computed from
+// nonces that were consumed when the party started. It is written to the snapshots in the clear, so it must be
+// public once it has been sent, e.g. because it is broadcast.
+func (j *SessionJournal) Keep(value []byte) {
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	j.kept = value
+}
+
+// Kept returns the value recorded with Keep, which a restored journal takes from its snapshot, or nil
+func (j *SessionJournal) Kept() []byte {
+	if j == nil {
+		return nil
+	}
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	return j.kept
+}
+
+// Receive records a message that the party stored
+func (j *SessionJournal) Receive(msg ParsedMessage) error {
+	wire, _, err := msg.WireBytes()
+	if err != nil {
+		return WithKind(ErrInvalidMessage, err)
+	}
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	j.received = append(j.received, receivedMessage{
+		From:        msg.GetFrom().Index,
+		FromKey:     msg.GetFrom().GetKey(),
+		IsBroadcast: msg.IsBroadcast(),
+		WireBytes:   wire,
+	})
+	return nil
+}
+
+// Send records a message that the party is about to send. While a restored party re-runs its rounds, it returns an
+// error if the message differs from the one that was sent in its place before the snapshot; the message must then
+// not be sent.
+func (j *SessionJournal) Send(msg Message) error {
+	digest, err := messageDigest(msg)
+	if err != nil {
+		return WithKind(ErrInternal, err)
+	}
+	j.mtx.Lock()
+	defer j.mtx.Unlock()
+	if j.replayed < len(j.replay) {
+		if !bytes.Equal(j.replay[j.replayed], digest) {
+			return Errorf(ErrInvalidState, "the restored session produced a message that differs from message %d sent before the snapshot (%s); refusing to send it",
+				j.replayed, msg.Type())
+		}
+		j.replayed++
+	}
+	j.sent = append(j.sent, digest)
+	return nil
+}
+
+// ----- //
+
+// BaseSnapshot returns the state of a party: its current round, the messages in journal and the binding of the
+// session, sealed with key in a keystore container of the given kind. binding identifies the inputs of the session,
+// e.g. the message being signed, and must be given again to BaseRestore.
+func BaseSnapshot(p Party, journal *SessionJournal, kind string, binding []byte, rand io.Reader, key keystore.Key) ([]byte, error) {
+	if journal == nil {
+		return nil, Errorf(ErrInvalidState, "snapshot: the party was not started with session snapshots enabled")
+	}
+	p.lock()
+	defer p.unlock()
+	if p.abortError() != nil {
+		return nil, Errorf(ErrInvalidState, "snapshot: the party was aborted")
+	}
+	snapshot := sessionSnapshot{Binding: binding}
+	if rnd := p.round(); rnd != nil {
+		snapshot.Round = rnd.RoundNumber()
+	}
+	journal.mtx.Lock()
+	if journal.seed == nil {
+		journal.mtx.Unlock()
+		return nil, Errorf(ErrInvalidState, "snapshot: the session has ended")
+	}
+	snapshot.Seed = journal.seed
+	snapshot.Received = append(snapshot.Received, journal.received...)
+	snapshot.Sent = append(snapshot.Sent, journal.sent...)
+	snapshot.Kept = journal.kept
+	journal.mtx.Unlock()
+	// the messages queued for later rounds have not been stored yet
+	for _, msg := range p.queued() {
+		wire, _, err := msg.WireBytes()
+		if err != nil {
+			return nil, WithKind(ErrInternal, err)
+		}
+		snapshot.Received = append(snapshot.Received, receivedMessage{
+			From:        msg.GetFrom().Index,
+			FromKey:     msg.GetFrom().GetKey(),
+			IsBroadcast: msg.IsBroadcast(),
+			WireBytes:   wire,
+		})
+	}
+	payload, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, WithKind(ErrInternal, err)
+	}
+	return keystore.Seal(rand, kind, SessionSnapshotVersion, payload, key)
+}
+
+// OpenSessionSnapshot opens a snapshot written by BaseSnapshot for a session with the same binding. It returns the
+// journal of the snapshot, which the party must use as its random source, with the messages it had received.
+func OpenSessionSnapshot(data []byte, kind string, binding []byte, parties *PeerContext, key keystore.Key) (*SessionJournal, []ParsedMessage, int, error) {
+	return openSessionSnapshot(data, kind, binding, key, parties.IDs())
+}
+
+// OpenReSharingSessionSnapshot is OpenSessionSnapshot for a resharing session, whose messages come from the parties of
+// the old and of the new committee
+func OpenReSharingSessionSnapshot(data []byte, kind string, binding []byte, params *ReSharingParameters, key keystore.Key) (*SessionJournal, []ParsedMessage, int, error) {
+	return openSessionSnapshot(data, kind, binding, key, params.OldParties().IDs(), params.NewParties().IDs())
+}
+
+func openSessionSnapshot(data []byte, kind string, binding []byte, key keystore.Key, committees ...SortedPartyIDs) (*SessionJournal, []ParsedMessage, int, error) {
+	version, payload, err := keystore.Open(data, kind, key)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	if version != SessionSnapshotVersion {
+		return nil, nil, 0, Errorf(ErrInvalidParameters, "snapshot: unsupported version %d", version)
+	}
+	snapshot := new(sessionSnapshot)
+	if err = json.Unmarshal(payload, snapshot); err != nil {
+		return nil, nil, 0, WithKind(ErrInvalidParameters, err)
+	}
+	if !bytes.Equal(snapshot.Binding, binding) {
+		return nil, nil, 0, Errorf(ErrInvalidParameters, "snapshot: the snapshot belongs to another session")
+	}
+	if len(snapshot.Seed) != sessionSeedSize {
+		return nil, nil, 0, Errorf(ErrInvalidParameters, "snapshot: invalid seed")
+	}
+	journal, err := newSessionJournal(snapshot.Seed)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	journal.replay, journal.kept = snapshot.Sent, snapshot.Kept
+	msgs := make([]ParsedMessage, 0, len(snapshot.Received))
+	for _, rm := range snapshot.Received {
+		from := rm.sender(committees)
+		if from == nil {
+			return nil, nil, 0, Errorf(ErrInvalidParameters, "snapshot: invalid sender index %d", rm.From)
+		}
+		msg, err := ParseWireMessage(rm.WireBytes, from, rm.IsBroadcast)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return journal, msgs, snapshot.Round, nil
+}
+
+// sender returns the party of the committees that sent the message, or nil if there is none. The key of the sender
+// tells the committees apart; the snapshots that do not record it have a single committee.
+func (rm receivedMessage) sender(committees []SortedPartyIDs) *PartyID {
+	for _, ids := range committees {
+		if rm.From < 0 || len(ids) <= rm.From {
+			continue
+		}
+		if rm.FromKey == nil || bytes.Equal(ids[rm.From].GetKey(), rm.FromKey) {
+			return ids[rm.From]
+		}
+	}
+	return nil
+}
+
+// BaseRestore resumes the session of a snapshot opened with OpenSessionSnapshot in a party constructed with the same
+// arguments as the party of the snapshot, instead of starting it. The party must use journal as its random source.
+// The party is started, the messages are replayed into it and it then continues in the round of the snapshot.
+func BaseRestore(p Party, journal *SessionJournal, msgs []ParsedMessage, round int) *Error {
+	p.lock()
+	started := p.round() != nil || p.abortError() != nil
+	p.unlock()
+	if started {
+		return p.WrapError(Errorf(ErrInvalidState, "restore: the party has already been started"))
+	}
+	for _, msg := range msgs {
+		if _, err := p.ValidateMessage(msg); err != nil {
+			return err
+		}
+		p.lock()
+		_, err := p.dedupe(msg)
+		var storeErr *Error
+		if err == nil {
+			_, storeErr = p.StoreMessage(msg)
+		}
+		p.unlock()
+		if err != nil {
+			return p.WrapError(err, msg.GetFrom())
+		}
+		if storeErr != nil {
+			return storeErr
+		}
+	}
+	if err := p.Start(); err != nil {
+		return err
+	}
+	p.lock()
+	defer p.unlock()
+	if err := baseProceed(p); err != nil {
+		return err
+	}
+	journal.mtx.Lock()
+	replayed := journal.replayed == len(journal.replay)
+	journal.mtx.Unlock()
+	if rnd := p.round(); !replayed || (rnd != nil && rnd.RoundNumber() < round) {
+		return p.WrapError(Errorf(ErrInvalidState, "restore: the session did not reach round %d of the snapshot", round))
+	}
+	return nil
+}
+
+// messageDigest identifies the content and the recipients of a message
+func messageDigest(msg Message) ([]byte, error) {
+	wire, routing, err := msg.WireBytes()
+	if err != nil {
+		return nil, err
+	}
+	broadcast := []byte{0}
+	if routing.IsBroadcast {
+		broadcast[0] = 1
+	}
+	in := [][]byte{[]byte(msg.Type()), wire, broadcast}
+	for _, to := range routing.To {
+		in = append(in, to.GetKey())
+	}
+	return common.SHA512_256(in...), nil
+}
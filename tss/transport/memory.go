@@ -0,0 +1,86 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// MemoryRouter connects the parties of any number of sessions within one process. It is meant for tests and
+	// as a reference for network transports.
+	MemoryRouter struct {
+		mtx       sync.Mutex
+		mailboxes map[string]*mailbox // by session ID and party key
+	}
+
+	memoryTransport struct {
+		router    *MemoryRouter
+		sessionID string
+		peers     []*tss.PartyID
+		inbox     *mailbox
+	}
+)
+
+var _ Transport = (*memoryTransport)(nil)
+
+func NewMemoryRouter() *MemoryRouter {
+	return &MemoryRouter{mailboxes: make(map[string]*mailbox)}
+}
+
+// Transport returns the transport of self in the given session. peers lists every party of the session, including
+// self and, when resharing, the members of both committees. Messages sent to a party before it joins are queued.
+func (r *MemoryRouter) Transport(sessionID string, self *tss.PartyID, peers []*tss.PartyID) Transport {
+	return &memoryTransport{
+		router:    r,
+		sessionID: sessionID,
+		peers:     peers,
+		inbox:     r.mailbox(sessionID, self),
+	}
+}
+
+func (r *MemoryRouter) mailbox(sessionID string, id *tss.PartyID) *mailbox {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	k := sessionID + "/" + partyKey(id)
+	mb, ok := r.mailboxes[k]
+	if !ok {
+		mb = newMailbox()
+		r.mailboxes[k] = mb
+	}
+	return mb
+}
+
+// ----- //
+
+func (t *memoryTransport) Send(ctx context.Context, msg tss.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	for _, Pj := range recipients(msg, t.peers) {
+		in := &Message{WireBytes: wireBytes, From: routing.From, IsBroadcast: routing.IsBroadcast}
+		// a party that has closed its transport has finished and no longer needs the message
+		_ = t.router.mailbox(t.sessionID, Pj).put(in)
+	}
+	return nil
+}
+
+func (t *memoryTransport) Receive(ctx context.Context) (*Message, error) {
+	return t.inbox.take(ctx)
+}
+
+func (t *memoryTransport) Close() error {
+	t.inbox.close()
+	return nil
+}
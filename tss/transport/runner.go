@@ -0,0 +1,106 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"context"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// Runner drives a party over a transport: it sends what the party writes to its out channel and updates the
+	// party with what the transport receives.
+	Runner struct {
+		party     tss.Party
+		transport Transport
+		out       <-chan tss.Message
+	}
+)
+
+// NewRunner returns a Runner of party. out must be the channel the party was constructed with, and must not be
+// shared with other parties.
+func NewRunner(party tss.Party, transport Transport, out <-chan tss.Message) *Runner {
+	return &Runner{
+		party:     party,
+		transport: transport,
+		out:       out,
+	}
+}
+
+// Run starts the party and relays its messages until it finishes, fails or ctx is done, honouring the round timeout
+// of its parameters. A failure of the party is returned as a *tss.Error, and one of the transport as is.
+// The output of the party is still sent to its end channel, which must be buffered or read by the caller.
+// The transport is not closed.
+func (r *Runner) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// only the first error is kept; it is the cause of the others
+	errCh := make(chan error, 1)
+	fail := func(err error) {
+		select {
+		case errCh <- err:
+		default:
+		}
+		cancel()
+	}
+
+	stop, relayed := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(relayed)
+		for {
+			select {
+			case msg := <-r.out:
+				if err := r.transport.Send(ctx, msg); err != nil {
+					fail(err)
+					return
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+	go func() {
+		for {
+			in, err := r.transport.Receive(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					fail(err)
+				}
+				return
+			}
+			if _, err := r.party.UpdateFromBytes(in.WireBytes, in.From, in.IsBroadcast); err != nil {
+				fail(err)
+				return
+			}
+		}
+	}()
+
+	err := r.party.StartWithContext(ctx)
+	close(stop)
+	<-relayed
+	if err != nil {
+		select {
+		case cause := <-errCh:
+			return cause
+		default:
+			return err
+		}
+	}
+	// the last round may have written messages the relay did not get to
+	for {
+		select {
+		case msg := <-r.out:
+			if err := r.transport.Send(ctx, msg); err != nil {
+				return err
+			}
+		default:
+			return nil
+		}
+	}
+}
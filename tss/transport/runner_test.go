@@ -0,0 +1,313 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"io"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-log"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/crypto/group"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/keygen"
+	"github.com/bnb-chain/tss-lib/v2/eddsa/resharing"
+	"github.com/bnb-chain/tss-lib/v2/test"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const testTimeout = 30 * time.Second
+
+func setUp(level string) {
+	if err := log.SetLogLevel("tss-lib", level); err != nil {
+		panic(err)
+	}
+
+	// only for test
+	tss.SetCurve(tss.Edwards())
+}
+
+func TestMemoryRouterKeygen(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(test.TestParticipants)
+	router := NewMemoryRouter()
+	transports := make([]Transport, len(pIDs))
+	for i, pID := range pIDs {
+		transports[i] = router.Transport("keygen", pID, pIDs)
+	}
+	runKeygen(t, pIDs, transports)
+}
+
+func TestTCPTransportKeygenWithTLS(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(test.TestParticipants)
+	tlsConfigs := testTLSConfigs(t, pIDs)
+	listeners := make([]net.Listener, len(pIDs))
+	addrs := make(map[string]string, len(pIDs))
+	for i, pID := range pIDs {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if !assert.NoError(t, err) {
+			return
+		}
+		listeners[i] = l
+		addrs[pID.Id] = l.Addr().String()
+	}
+	transports := make([]Transport, len(pIDs))
+	for i, pID := range pIDs {
+		transports[i] = NewTCPTransport("keygen", pID, pIDs, listeners[i], addrs, tlsConfigs[i])
+	}
+	runKeygen(t, pIDs, transports)
+}
+
+func TestTCPTransportDropsSpoofedSender(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(3)
+	tlsConfigs := testTLSConfigs(t, pIDs)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	receiver := NewTCPTransport("session", pIDs[0], pIDs, l, nil, tlsConfigs[0])
+	defer receiver.Close()
+
+	// party 1 holds a valid certificate, but only for itself
+	conn, err := tls.Dial("tcp", l.Addr().String(), tlsConfigs[1])
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	for _, from := range []*tss.PartyID{pIDs[2], pIDs[1]} {
+		bz, err := proto.Marshal(&Envelope{SessionId: "session", FromKey: from.GetKey(), IsBroadcast: true, WireBytes: from.GetKey()})
+		if !assert.NoError(t, err) {
+			return
+		}
+		frame := make([]byte, frameHeaderLen+len(bz))
+		binary.BigEndian.PutUint32(frame, uint32(len(bz)))
+		copy(frame[frameHeaderLen:], bz)
+		_, err = conn.Write(frame)
+		assert.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	msg, err := receiver.Receive(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, pIDs[1], msg.From, "the message that claims to be from party 2 must be dropped")
+		assert.Equal(t, pIDs[1].GetKey(), msg.WireBytes)
+	}
+}
+
+func TestTCPTransportDropsSilentClient(t *testing.T) {
+	setUp("info")
+
+	defer func(timeout time.Duration) { handshakeTimeout = timeout }(handshakeTimeout)
+	handshakeTimeout = 100 * time.Millisecond
+	pIDs := tss.GenerateTestPartyIDs(2)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	receiver := NewTCPTransport("session", pIDs[0], pIDs, l, nil, testTLSConfigs(t, pIDs)[0])
+	defer receiver.Close()
+
+	// the client connects but never starts the handshake
+	conn, err := net.Dial("tcp", l.Addr().String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer conn.Close()
+	assert.NoError(t, conn.SetReadDeadline(time.Now().Add(testTimeout)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Equal(t, io.EOF, err, "the connection must be closed once the handshake times out")
+}
+
+func TestMemoryRouterResharing(t *testing.T) {
+	setUp("info")
+
+	threshold := keygen.TestThreshold
+	oldKeys, oldPIDs, err := keygen.LoadKeygenTestFixtures(threshold + 1)
+	if !assert.NoError(t, err, "should load keygen fixtures") {
+		return
+	}
+	newPIDs := tss.GenerateTestPartyIDs(test.TestParticipants)
+	oldP2PCtx, newP2PCtx := tss.NewPeerContext(oldPIDs), tss.NewPeerContext(newPIDs)
+	allPIDs := append(append([]*tss.PartyID{}, oldPIDs...), newPIDs...)
+
+	router := NewMemoryRouter()
+	runners := make([]*Runner, 0, len(allPIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, len(allPIDs))
+	for i, pID := range allPIDs {
+		params := tss.NewReSharingParameters(group.Ed25519(), oldP2PCtx, newP2PCtx, pID, keygen.TestParticipants, threshold, len(newPIDs), threshold)
+		key := keygen.NewLocalPartySaveData(len(newPIDs))
+		if i < len(oldPIDs) {
+			key = oldKeys[i]
+		}
+		outCh := make(chan tss.Message, len(allPIDs))
+		P := resharing.NewLocalParty(params, key, outCh, endCh)
+		transport := router.Transport("resharing", pID, allPIDs)
+		defer transport.Close()
+		runners = append(runners, NewRunner(P, transport, outCh))
+	}
+	assert.NoError(t, runAll(runners))
+
+	close(endCh)
+	pubKey := oldKeys[0].EDDSAPub
+	newKeys := 0
+	for save := range endCh {
+		if save.Xi == nil {
+			continue
+		}
+		newKeys++
+		assert.True(t, save.EDDSAPub.Equals(pubKey), "the public key should not change")
+	}
+	assert.Equal(t, len(newPIDs), newKeys)
+}
+
+func TestRunnerCancel(t *testing.T) {
+	setUp("info")
+
+	pIDs := tss.GenerateTestPartyIDs(test.TestParticipants)
+	router := NewMemoryRouter()
+	p2pCtx := tss.NewPeerContext(pIDs)
+	outCh := make(chan tss.Message, len(pIDs))
+	endCh := make(chan *keygen.LocalPartySaveData, 1)
+	params := tss.NewParameters(group.Ed25519(), p2pCtx, pIDs[0], len(pIDs), keygen.TestThreshold)
+	P := keygen.NewLocalParty(params, outCh, endCh)
+
+	// the other parties never join, so the party waits until the context is done
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err := NewRunner(P, router.Transport("keygen", pIDs[0], pIDs), outCh).Run(ctx)
+	if assert.Error(t, err) {
+		tssErr, ok := err.(*tss.Error)
+		if assert.True(t, ok, "should be a *tss.Error") {
+			assert.ErrorIs(t, tssErr.Cause(), context.DeadlineExceeded)
+			assert.Equal(t, len(pIDs)-1, len(tssErr.Culprits()))
+		}
+	}
+}
+
+func runKeygen(t *testing.T, pIDs tss.SortedPartyIDs, transports []Transport) {
+	p2pCtx := tss.NewPeerContext(pIDs)
+	endCh := make(chan *keygen.LocalPartySaveData, len(pIDs))
+	runners := make([]*Runner, len(pIDs))
+	for i, pID := range pIDs {
+		defer transports[i].Close()
+		params := tss.NewParameters(group.Ed25519(), p2pCtx, pID, len(pIDs), keygen.TestThreshold)
+		outCh := make(chan tss.Message, len(pIDs))
+		runners[i] = NewRunner(keygen.NewLocalParty(params, outCh, endCh), transports[i], outCh)
+	}
+	if !assert.NoError(t, runAll(runners)) {
+		return
+	}
+
+	close(endCh)
+	var first *keygen.LocalPartySaveData
+	for save := range endCh {
+		if first == nil {
+			first = save
+			continue
+		}
+		assert.True(t, save.EDDSAPub.Equals(first.EDDSAPub), "all parties should agree on the public key")
+	}
+	assert.NotNil(t, first)
+}
+
+// runAll runs the runners concurrently and returns the first error
+func runAll(runners []*Runner) error {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	errCh := make(chan error, len(runners))
+	for _, r := range runners {
+		go func(r *Runner) {
+			errCh <- r.Run(ctx)
+		}(r)
+	}
+	var first error
+	for range runners {
+		if err := <-errCh; err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// testTLSConfigs returns the mutual TLS config of each party, with a certificate for 127.0.0.1 that names its Id and
+// is signed by a test CA shared by all parties
+func testTLSConfigs(t *testing.T, pIDs []*tss.PartyID) []*tls.Config {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "tss-lib test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca)
+
+	configs := make([]*tls.Config, len(pIDs))
+	for i, pID := range pIDs {
+		sk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(int64(i + 2)),
+			Subject:      pkix.Name{CommonName: pID.Id},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &sk.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		configs[i] = &tls.Config{
+			Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: sk, Leaf: cert}},
+			RootCAs:      pool,
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+	return configs
+}
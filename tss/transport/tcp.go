@@ -0,0 +1,349 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+package transport
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bnb-chain/tss-lib/v2/common"
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+const (
+	frameHeaderLen = 4
+	maxFrameLen    = 64 << 20
+)
+
+// handshakeTimeout bounds the TLS handshake of an accepted connection, so that a silent client does not hold it open
+var handshakeTimeout = 10 * time.Second
+
+type (
+	tcpTransport struct {
+		sessionID  string
+		self       *tss.PartyID
+		peers      []*tss.PartyID
+		peersByKey map[string]*tss.PartyID
+		peersByID  map[string]*tss.PartyID
+		addrs      map[string]string
+		tlsConfig  *tls.Config
+		listener   net.Listener
+		inbox      *mailbox
+
+		mtx     sync.Mutex
+		closed  bool
+		conns   map[string]*tcpConn // dialed, by party key
+		inConns map[net.Conn]struct{}
+		wg      sync.WaitGroup
+	}
+
+	tcpConn struct {
+		mtx  sync.Mutex
+		conn net.Conn
+	}
+)
+
+var _ Transport = (*tcpTransport)(nil)
+
+// NewTCPTransport returns the transport of self in the given session, accepting messages on listener and sending
+// them over connections dialed to the addresses in addrs, which maps the Id of each peer to its listening address.
+// Each frame carries an Envelope naming its sender, which is only trusted as far as the connection is. When
+// tlsConfig is not nil both the accepted and the dialed connections use TLS, and it must require and verify client
+// certificates. The certificate of each party must then name its PartyID.Id as its subject common name or as one of
+// its DNS names: a connection is bound to the party named by the certificate of its peer, and the frames that claim
+// another sender are dropped.
+func NewTCPTransport(sessionID string, self *tss.PartyID, peers []*tss.PartyID, listener net.Listener, addrs map[string]string, tlsConfig *tls.Config) Transport {
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+	t := &tcpTransport{
+		sessionID:  sessionID,
+		self:       self,
+		peers:      peers,
+		peersByKey: make(map[string]*tss.PartyID, len(peers)),
+		peersByID:  make(map[string]*tss.PartyID, len(peers)),
+		addrs:      addrs,
+		tlsConfig:  tlsConfig,
+		listener:   listener,
+		inbox:      newMailbox(),
+		conns:      make(map[string]*tcpConn),
+		inConns:    make(map[net.Conn]struct{}),
+	}
+	for _, Pj := range peers {
+		t.peersByKey[partyKey(Pj)] = Pj
+		t.peersByID[Pj.Id] = Pj
+	}
+	t.wg.Add(1)
+	go t.accept()
+	return t
+}
+
+func (t *tcpTransport) Send(ctx context.Context, msg tss.Message) error {
+	wireBytes, routing, err := msg.WireBytes()
+	if err != nil {
+		return err
+	}
+	env := &Envelope{
+		SessionId:   t.sessionID,
+		FromKey:     routing.From.GetKey(),
+		IsBroadcast: routing.IsBroadcast,
+		WireBytes:   wireBytes,
+	}
+	bz, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+	if len(bz) > maxFrameLen {
+		return fmt.Errorf("transport: message of %d bytes is too large", len(bz))
+	}
+	frame := make([]byte, frameHeaderLen+len(bz))
+	binary.BigEndian.PutUint32(frame, uint32(len(bz)))
+	copy(frame[frameHeaderLen:], bz)
+	for _, Pj := range recipients(msg, t.peers) {
+		if err := t.sendTo(ctx, Pj, frame); err != nil {
+			return fmt.Errorf("transport: send to %s: %w", Pj, err)
+		}
+	}
+	return nil
+}
+
+func (t *tcpTransport) Receive(ctx context.Context) (*Message, error) {
+	return t.inbox.take(ctx)
+}
+
+func (t *tcpTransport) Close() error {
+	t.mtx.Lock()
+	if t.closed {
+		t.mtx.Unlock()
+		return nil
+	}
+	t.closed = true
+	err := t.listener.Close()
+	for _, c := range t.conns {
+		_ = c.conn.Close()
+	}
+	for conn := range t.inConns {
+		_ = conn.Close()
+	}
+	t.mtx.Unlock()
+	t.inbox.close()
+	t.wg.Wait()
+	return err
+}
+
+// ----- //
+
+// sendTo writes frame to Pj, dialing it again once if the cached connection has failed
+func (t *tcpTransport) sendTo(ctx context.Context, Pj *tss.PartyID, frame []byte) error {
+	for attempt := 0; ; attempt++ {
+		c, err := t.conn(ctx, Pj)
+		if err != nil {
+			return err
+		}
+		if err = c.write(ctx, frame); err == nil {
+			return nil
+		}
+		t.dropConn(Pj, c)
+		if attempt > 0 || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+func (t *tcpTransport) conn(ctx context.Context, Pj *tss.PartyID) (*tcpConn, error) {
+	key := partyKey(Pj)
+	t.mtx.Lock()
+	if t.closed {
+		t.mtx.Unlock()
+		return nil, ErrClosed
+	}
+	if c, ok := t.conns[key]; ok {
+		t.mtx.Unlock()
+		return c, nil
+	}
+	t.mtx.Unlock()
+
+	addr, ok := t.addrs[Pj.Id]
+	if !ok {
+		return nil, fmt.Errorf("no address for party %s", Pj)
+	}
+	var conn net.Conn
+	var err error
+	if t.tlsConfig != nil {
+		conn, err = (&tls.Dialer{Config: t.tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		// the dialed party must be the one that holds the certificate, or the frame would reveal its secrets to another
+		if peer := t.certParty(tlsConn.ConnectionState().PeerCertificates); peer == nil || partyKey(peer) != key {
+			_ = conn.Close()
+			return nil, fmt.Errorf("the certificate of %s does not name the party", addr)
+		}
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.closed {
+		_ = conn.Close()
+		return nil, ErrClosed
+	}
+	// another Send may have dialed the party in the meantime
+	if c, ok := t.conns[key]; ok {
+		_ = conn.Close()
+		return c, nil
+	}
+	c := &tcpConn{conn: conn}
+	t.conns[key] = c
+	return c, nil
+}
+
+func (t *tcpTransport) dropConn(Pj *tss.PartyID, c *tcpConn) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.conns[partyKey(Pj)] == c {
+		delete(t.conns, partyKey(Pj))
+	}
+	_ = c.conn.Close()
+}
+
+func (c *tcpConn) write(ctx context.Context, frame []byte) error {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := c.conn.SetWriteDeadline(deadline); err != nil {
+			return err
+		}
+		defer func() { _ = c.conn.SetWriteDeadline(time.Time{}) }()
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
+
+func (t *tcpTransport) accept() {
+	defer t.wg.Done()
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			t.mtx.Lock()
+			closed := t.closed
+			t.mtx.Unlock()
+			if !closed {
+				common.Logger.Errorf("transport: accept failed: %v", err)
+			}
+			return
+		}
+		t.mtx.Lock()
+		if t.closed {
+			t.mtx.Unlock()
+			_ = conn.Close()
+			return
+		}
+		t.inConns[conn] = struct{}{}
+		t.wg.Add(1)
+		t.mtx.Unlock()
+		go t.serve(conn)
+	}
+}
+
+// serve reads the frames of an accepted connection into the inbox until it is closed
+func (t *tcpTransport) serve(conn net.Conn) {
+	defer t.wg.Done()
+	defer func() {
+		t.mtx.Lock()
+		delete(t.inConns, conn)
+		t.mtx.Unlock()
+		_ = conn.Close()
+	}()
+	// the party that holds the certificate of a TLS peer; nil without TLS, when the envelope is trusted as it is
+	var peer *tss.PartyID
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		if err := conn.SetDeadline(time.Now().Add(handshakeTimeout)); err != nil {
+			return
+		}
+		if err := tlsConn.Handshake(); err != nil {
+			common.Logger.Warnf("transport: dropping connection from %s: %v", conn.RemoteAddr(), err)
+			return
+		}
+		if err := conn.SetDeadline(time.Time{}); err != nil {
+			return
+		}
+		if peer = t.certParty(tlsConn.ConnectionState().PeerCertificates); peer == nil {
+			common.Logger.Warnf("transport: dropping connection from %s: %v", conn.RemoteAddr(), errUnknownCertificate)
+			return
+		}
+	}
+	r := bufio.NewReader(conn)
+	header := make([]byte, frameHeaderLen)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			return
+		}
+		n := binary.BigEndian.Uint32(header)
+		if n > maxFrameLen {
+			common.Logger.Warnf("transport: dropping connection from %s: frame of %d bytes is too large", conn.RemoteAddr(), n)
+			return
+		}
+		bz := make([]byte, n)
+		if _, err := io.ReadFull(r, bz); err != nil {
+			return
+		}
+		env := new(Envelope)
+		if err := proto.Unmarshal(bz, env); err != nil {
+			common.Logger.Warnf("transport: dropping connection from %s: %v", conn.RemoteAddr(), err)
+			return
+		}
+		if env.GetSessionId() != t.sessionID {
+			common.Logger.Warnf("transport: ignoring a message for session %q", env.GetSessionId())
+			continue
+		}
+		from, ok := t.peersByKey[hex.EncodeToString(env.GetFromKey())]
+		if !ok || partyKey(from) == partyKey(t.self) {
+			common.Logger.Warnf("transport: ignoring a message from an unknown party")
+			continue
+		}
+		if peer != nil && partyKey(from) != partyKey(peer) {
+			common.Logger.Warnf("transport: ignoring a message from %s that claims to be from %s", peer, from)
+			continue
+		}
+		in := &Message{WireBytes: env.GetWireBytes(), From: from, IsBroadcast: env.GetIsBroadcast()}
+		if err := t.inbox.put(in); err != nil {
+			return
+		}
+	}
+}
+
+var errUnknownCertificate = errors.New("the certificate does not name a party of the session")
+
+// certParty returns the peer named by the subject common name or a DNS name of the leaf certificate, or nil
+func (t *tcpTransport) certParty(certs []*x509.Certificate) *tss.PartyID {
+	if len(certs) == 0 {
+		return nil
+	}
+	leaf := certs[0]
+	for _, name := range append([]string{leaf.Subject.CommonName}, leaf.DNSNames...) {
+		if Pj, ok := t.peersByID[name]; ok && partyKey(Pj) != partyKey(t.self) {
+			return Pj
+		}
+	}
+	return nil
+}
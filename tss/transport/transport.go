@@ -0,0 +1,120 @@
+// Copyright © 2019 Binance
+//
+// This file is part of Binance. The full Binance copyright notice, including
+// terms governing use, modification, and redistribution, is contained in the
+// file LICENSE at the root of the source code distribution tree.
+
+// Package transport moves the messages of a tss.Party between the parties of a session. A Transport carries the
+// wire bytes of messages for one party in one session, and a Runner drives a party to completion over it.
+package transport
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+
+	"github.com/bnb-chain/tss-lib/v2/tss"
+)
+
+type (
+	// Transport sends and receives the messages of one party in one session.
+	// Messages are routed by the Key of their recipients, so a party may only appear once in a session.
+	Transport interface {
+		// Send delivers msg to the parties in msg.GetTo(), or to every other party of the session if it is nil
+		Send(ctx context.Context, msg tss.Message) error
+		// Receive blocks until a message for this party arrives, ctx is done or the transport is closed
+		Receive(ctx context.Context) (*Message, error)
+		Close() error
+	}
+
+	// Message is a received message, ready to be passed to tss.Party.UpdateFromBytes
+	Message struct {
+		WireBytes   []byte
+		From        *tss.PartyID
+		IsBroadcast bool
+	}
+)
+
+var ErrClosed = errors.New("transport: closed")
+
+// recipients returns the parties msg is routed to, leaving out its sender
+func recipients(msg tss.Message, peers []*tss.PartyID) []*tss.PartyID {
+	to := msg.GetTo()
+	if to == nil {
+		to = peers
+	}
+	fromKey := partyKey(msg.GetFrom())
+	dest := make([]*tss.PartyID, 0, len(to))
+	for _, Pj := range to {
+		if partyKey(Pj) != fromKey {
+			dest = append(dest, Pj)
+		}
+	}
+	return dest
+}
+
+func partyKey(id *tss.PartyID) string {
+	return hex.EncodeToString(id.GetKey())
+}
+
+// ----- //
+
+// mailbox is an unbounded queue of received messages, so that a slow party never blocks its senders
+type mailbox struct {
+	mtx    sync.Mutex
+	queue  []*Message
+	notify chan struct{}
+	closed bool
+}
+
+func newMailbox() *mailbox {
+	return &mailbox{notify: make(chan struct{}, 1)}
+}
+
+func (mb *mailbox) put(msg *Message) error {
+	mb.mtx.Lock()
+	defer mb.mtx.Unlock()
+	if mb.closed {
+		return ErrClosed
+	}
+	mb.queue = append(mb.queue, msg)
+	select {
+	case mb.notify <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (mb *mailbox) take(ctx context.Context) (*Message, error) {
+	for {
+		mb.mtx.Lock()
+		if len(mb.queue) > 0 {
+			msg := mb.queue[0]
+			mb.queue[0] = nil
+			mb.queue = mb.queue[1:]
+			mb.mtx.Unlock()
+			return msg, nil
+		}
+		closed := mb.closed
+		mb.mtx.Unlock()
+		if closed {
+			return nil, ErrClosed
+		}
+		select {
+		case <-mb.notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (mb *mailbox) close() {
+	mb.mtx.Lock()
+	defer mb.mtx.Unlock()
+	mb.closed = true
+	select {
+	case mb.notify <- struct{}{}:
+	default:
+	}
+}
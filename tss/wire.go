@@ -7,7 +7,6 @@
 package tss
 
 import (
-	"errors"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 )
@@ -19,7 +18,7 @@ func ParseWireMessage(wireBytes []byte, from *PartyID, isBroadcast bool) (Parsed
 	wire.From = from.MessageWrapper_PartyID
 	wire.IsBroadcast = isBroadcast
 	if err := proto.Unmarshal(wireBytes, wire.Message); err != nil {
-		return nil, err
+		return nil, WithKind(ErrInvalidMessage, err)
 	}
 	return parseWrappedMessage(wire, from)
 }
@@ -27,7 +26,7 @@ func ParseWireMessage(wireBytes []byte, from *PartyID, isBroadcast bool) (Parsed
 func parseWrappedMessage(wire *MessageWrapper, from *PartyID) (ParsedMessage, error) {
 	m, err := wire.Message.UnmarshalNew()
 	if err != nil {
-		return nil, err
+		return nil, WithKind(ErrInvalidMessage, err)
 	}
 	meta := MessageRouting{
 		From:        from,
@@ -36,5 +35,5 @@ func parseWrappedMessage(wire *MessageWrapper, from *PartyID) (ParsedMessage, er
 	if content, ok := m.(MessageContent); ok {
 		return NewMessage(meta, content, wire), nil
 	}
-	return nil, errors.New("ParseWireMessage: the message contained unknown content")
+	return nil, Errorf(ErrInvalidMessage, "ParseWireMessage: the message contained unknown content")
 }